@@ -3,6 +3,8 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
@@ -10,7 +12,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dantezy/polymarket-sniper/internal/chain"
 	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,24 +32,35 @@ const (
  \___/|____/|____/ \____| /_/   \_\_|   |_|   |_| \_\\___/  \_/  |_____|
 
 USDC Approval Tool v%s
-One-time USDC approval for Polymarket CTF Exchange
+One-time USDC approval for the Polymarket CTF exchanges
 `
 )
 
 var (
 	usdcAddress     = common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
-	ctfExchange     = common.HexToAddress("0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
 	maxUint256      = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
 	erc20ApproveABI = `[{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`
 )
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[approve] ")
+	exchange := flag.String("exchange", "both", "exchange to approve: standard, neg-risk or both")
+	minAllowance := flag.Float64("min-allowance", 1_000_000, "top up exchanges whose USDC allowance is below this many dollars")
+	flag.Parse()
+
+	logs, err := logx.Setup("approve", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Printf(banner, version)
 	fmt.Println(strings.Repeat("-", 70))
 
+	approvals, err := selectExchanges(*exchange)
+	if err != nil {
+		log.Fatalf("invalid -exchange: %v", err)
+	}
+
 	cfg, err := config.LoadWithPrivateKey()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
@@ -57,28 +72,43 @@ func main() {
 		log.Fatalf("failed to create wallet: %v", err)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	// Only exchanges short of allowance need a transaction
+	rpc := chain.NewClient(cfg.PolygonRPCURLs)
+	pending, err := rpc.PendingApprovals(ctx, w.AddressHex(), approvals, *minAllowance)
+	if err != nil {
+		log.Fatalf("failed to check allowances: %v", err)
+	}
+
 	log.Printf("wallet address: %s", w.AddressHex())
 	log.Printf("USDC contract:  %s", usdcAddress.Hex())
-	log.Printf("spender (CTF):  %s", ctfExchange.Hex())
 	log.Printf("amount:         MAX (2^256 - 1)")
 	log.Printf("chain ID:       %d", cfg.PolygonChainID)
-	log.Printf("RPC URL:        %s", cfg.PolygonRPCURL)
+	log.Printf("RPC URLs:       %s", strings.Join(cfg.PolygonRPCURLs, ", "))
 	fmt.Println(strings.Repeat("-", 70))
 
-	if !confirmAction() {
+	if len(pending) == 0 {
+		log.Printf("every selected exchange already has at least $%.0f allowance, nothing to do", *minAllowance)
+		os.Exit(0)
+	}
+	for _, a := range pending {
+		log.Printf("spender:        %s %s (allowance $%.2f)", a.Name, a.Spender, a.Amount)
+	}
+
+	if !confirmAction(pending) {
 		log.Println("operation cancelled by user")
 		os.Exit(0)
 	}
 
 	log.Println("connecting to Polygon RPC...")
-	client, err := ethclient.Dial(cfg.PolygonRPCURL)
+	client, rpcURL, err := rpc.Dial(ctx)
 	if err != nil {
 		log.Fatalf("failed to connect to RPC: %v", err)
 	}
 	defer client.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	log.Printf("using RPC: %s", rpcURL)
 
 	log.Println("fetching account nonce...")
 	nonce, err := client.PendingNonceAt(ctx, w.Address())
@@ -86,66 +116,108 @@ func main() {
 		log.Fatalf("failed to get nonce: %v", err)
 	}
 
-	log.Println("fetching gas price...")
-	gasPrice, err := client.SuggestGasPrice(ctx)
-	if err != nil {
-		log.Fatalf("failed to get gas price: %v", err)
+	log.Println("fetching gas fees...")
+	tip, feeCap, err := chain.DynamicFees(ctx, client, chain.GasSettings{
+		MaxPriceGwei: cfg.MaxGasPriceGwei,
+		TipGwei:      cfg.GasTipGwei,
+		FeeCapGwei:   cfg.GasFeeCapGwei,
+	})
+	if errors.Is(err, chain.ErrGasPriceTooHigh) {
+		log.Fatalf("refusing to send during a gas spike: %v (raise MAX_GAS_PRICE_GWEI or try again later)", err)
 	}
-
-	callData, err := buildApproveCallData(ctfExchange, maxUint256)
 	if err != nil {
-		log.Fatalf("failed to build call data: %v", err)
+		log.Fatalf("failed to get gas fees: %v", err)
 	}
-
-	gasLimit := uint64(60000)
-
-	tx := types.NewTransaction(
-		nonce,
-		usdcAddress,
-		big.NewInt(0),
-		gasLimit,
-		gasPrice,
-		callData,
-	)
+	log.Printf("gas: tip %s gwei, max fee %s gwei", chain.FormatGwei(tip), chain.FormatGwei(feeCap))
 
 	chainID := big.NewInt(int64(cfg.PolygonChainID))
-	signedTx, err := signTransaction(tx, w, chainID)
-	if err != nil {
-		log.Fatalf("failed to sign transaction: %v", err)
-	}
+	var sent []*types.Transaction
+	for i, a := range pending {
+		callData, err := buildApproveCallData(common.HexToAddress(a.Spender), maxUint256)
+		if err != nil {
+			log.Fatalf("failed to build call data: %v", err)
+		}
 
-	log.Println("sending transaction...")
-	if err := client.SendTransaction(ctx, signedTx); err != nil {
-		log.Fatalf("failed to send transaction: %v", err)
-	}
+		gasLimit := uint64(60000)
+
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce + uint64(i),
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       gasLimit,
+			To:        &usdcAddress,
+			Value:     big.NewInt(0),
+			Data:      callData,
+		})
+
+		signedTx, err := signTransaction(tx, w, chainID)
+		if err != nil {
+			log.Fatalf("failed to sign transaction: %v", err)
+		}
 
-	txHash := signedTx.Hash().Hex()
-	fmt.Println(strings.Repeat("-", 70))
-	log.Printf("transaction submitted successfully")
-	log.Printf("tx hash: %s", txHash)
-	log.Printf("view on PolygonScan: https://polygonscan.com/tx/%s", txHash)
-	fmt.Println(strings.Repeat("-", 70))
+		log.Printf("sending approval for %s...", a.Name)
+		if err := client.SendTransaction(ctx, signedTx); err != nil {
+			log.Fatalf("failed to send transaction: %v", err)
+		}
+		sent = append(sent, signedTx)
+
+		txHash := signedTx.Hash().Hex()
+		fmt.Println(strings.Repeat("-", 70))
+		log.Printf("transaction submitted successfully")
+		log.Printf("tx hash: %s", txHash)
+		log.Printf("view on PolygonScan: https://polygonscan.com/tx/%s", txHash)
+		fmt.Println(strings.Repeat("-", 70))
+	}
 
 	log.Println("waiting for confirmation (this may take a minute)...")
 
-	receipt, err := waitForReceipt(ctx, client, signedTx.Hash())
-	if err != nil {
-		log.Printf("warning: failed to get receipt: %v", err)
-		log.Println("transaction may still be pending, check PolygonScan for status")
-		os.Exit(0)
+	failed := false
+	for i, signedTx := range sent {
+		receipt, err := waitForReceipt(ctx, client, signedTx.Hash())
+		if err != nil {
+			log.Printf("warning: failed to get receipt for %s: %v", pending[i].Name, err)
+			log.Println("transaction may still be pending, check PolygonScan for status")
+			continue
+		}
+
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			log.Printf("%s approval confirmed in block %d", pending[i].Name, receipt.BlockNumber.Uint64())
+		} else {
+			log.Printf("%s approval failed - check PolygonScan for details", pending[i].Name)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
 	}
+	log.Println("USDC approval done - you can now trade on Polymarket")
+}
 
-	if receipt.Status == types.ReceiptStatusSuccessful {
-		log.Printf("transaction confirmed in block %d", receipt.BlockNumber.Uint64())
-		log.Println("USDC approval successful - you can now trade on Polymarket")
-	} else {
-		log.Fatalf("transaction failed - check PolygonScan for details")
+// selectExchanges returns the exchanges the -exchange flag names.
+func selectExchanges(name string) ([]chain.Approval, error) {
+	standard := chain.Approval{Name: "CTF Exchange", Spender: wallet.ExchangeContract.Hex()}
+	negRisk := chain.Approval{Name: "Neg Risk CTF Exchange", Spender: wallet.NegRiskExchangeContract.Hex()}
+
+	switch strings.ToLower(name) {
+	case "standard":
+		return []chain.Approval{standard}, nil
+	case "neg-risk", "negrisk":
+		return []chain.Approval{negRisk}, nil
+	case "both", "":
+		return []chain.Approval{standard, negRisk}, nil
 	}
+	return nil, fmt.Errorf("unknown exchange %q, want standard, neg-risk or both", name)
 }
 
-func confirmAction() bool {
+func confirmAction(pending []chain.Approval) bool {
+	names := make([]string, len(pending))
+	for i, a := range pending {
+		names[i] = "the Polymarket " + a.Name
+	}
+
 	fmt.Println()
-	fmt.Println("This will approve the Polymarket CTF Exchange to spend your USDC.")
+	fmt.Printf("This will approve %s to spend your USDC.\n", strings.Join(names, " and "))
 	fmt.Println("This is a one-time operation required before trading.")
 	fmt.Println()
 	fmt.Print("Do you want to proceed? (yes/no): ")
@@ -176,7 +248,7 @@ func buildApproveCallData(spender common.Address, amount *big.Int) ([]byte, erro
 }
 
 func signTransaction(tx *types.Transaction, w *wallet.Wallet, chainID *big.Int) (*types.Transaction, error) {
-	signer := types.NewEIP155Signer(chainID)
+	signer := types.NewLondonSigner(chainID)
 	txHash := signer.Hash(tx)
 
 	signature, err := w.Sign(txHash.Bytes())
@@ -184,7 +256,7 @@ func signTransaction(tx *types.Transaction, w *wallet.Wallet, chainID *big.Int)
 		return nil, fmt.Errorf("failed to sign: %w", err)
 	}
 
-	// Note: Do NOT adjust V value here. The EIP-155 signer expects the raw
+	// Note: Do NOT adjust V value here. The London signer expects the raw
 	// recovery ID (0 or 1) and will compute the proper V value internally.
 	// Adding 27 would corrupt the signature.
 
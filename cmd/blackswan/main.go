@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,7 +10,10 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/dantezy/polymarket-sniper/internal/api"
+	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 	"github.com/dantezy/polymarket-sniper/internal/strategy"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
@@ -27,8 +31,11 @@ Power Law Distribution Strategy for Polymarket
 `
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[blackswan] ")
+	logs, err := logx.Setup("blackswan", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Print(banner)
 	fmt.Println(strings.Repeat("-", 60))
@@ -46,8 +53,9 @@ func main() {
 	}
 	log.Printf("mode:             %s", mode)
 	log.Printf("chain ID:         %d", cfg.PolygonChainID)
-	log.Printf("bankroll:         $%.2f", cfg.MaxPositionSize)
+	log.Printf("bankroll:         $%.2f", cfg.BlackSwanBankrollSize())
 	log.Printf("price range:      %.2f¢ - %.1f¢", cfg.BlackSwanMinPrice*100, cfg.BlackSwanMaxPrice*100)
+	log.Printf("opposite side:    >= %.0f%%", cfg.BlackSwanMinOpposite*100)
 	log.Printf("bet size:         %.1f%% of bankroll", cfg.BlackSwanBetPercent*100)
 	log.Printf("max positions:    %d", cfg.BlackSwanMaxPositions)
 	log.Printf("max exposure:     $%.2f", cfg.BlackSwanMaxExposure)
@@ -70,6 +78,7 @@ func main() {
 			log.Printf("telegram init failed (continuing without): %v", err)
 			tg = nil
 		} else {
+			tg.SetRateLimit(cfg.TelegramRateLimit)
 			log.Println("telegram: enabled")
 		}
 	} else {
@@ -96,6 +105,21 @@ func main() {
 		cancel()
 	}()
 
+	// Serve read-only state for dashboards (optional)
+	if cfg.APIPort > 0 {
+		board := api.NewBoard("blackswan")
+		hunter.WithStateBoard(board)
+		go func() {
+			addr := fmt.Sprintf(":%d", cfg.APIPort)
+			if err := api.NewServer(board, cfg.APIToken).ListenAndServe(ctx, addr); err != nil {
+				log.Printf("api error: %v", err)
+			}
+		}()
+		if cfg.APIToken == "" {
+			log.Printf("api: no API_TOKEN set, state is readable by anyone who can reach port %d", cfg.APIPort)
+		}
+	}
+
 	fmt.Println(strings.Repeat("-", 60))
 	log.Println("starting black swan hunter...")
 	log.Println("strategy: buy low-probability events with limit orders")
@@ -108,15 +132,29 @@ func main() {
 			"Bankroll: $%.2f\n"+
 			"Target: %.1f¢ - %.0f¢\n"+
 			"Max Bets: %d",
-			mode, cfg.MaxPositionSize,
+			mode, cfg.BlackSwanBankrollSize(),
 			cfg.BlackSwanMinPrice*100, cfg.BlackSwanMaxPrice*100,
 			cfg.BlackSwanMaxPositions))
 	}
 
 	// Run the hunter
-	if err := hunter.Run(ctx); err != nil && err != context.Canceled {
+	// Rejected API credentials halt cleanly: the alert is delivered before
+	// exiting non-zero
+	err = hunter.Run(ctx)
+	halted := errors.Is(err, clob.ErrAuthExpired)
+	if err != nil && err != context.Canceled && !halted {
 		log.Fatalf("hunter error: %v", err)
 	}
 
+	// Deliver any digest still waiting on the rate limit
+	if tg != nil {
+		tg.Flush()
+	}
+
+	if halted {
+		log.Printf("halted: %v", err)
+		os.Exit(1)
+	}
+
 	log.Println("shutdown complete")
 }
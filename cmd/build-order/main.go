@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// build-order builds and signs an order exactly as the bots would, then prints
+// the request body, domain separator and order hash without submitting it.
+// Useful for cross-checking signatures against other client implementations.
+func main() {
+	logs, err := logx.Setup("build-order", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
+
+	tokenID := flag.String("token", "", "token ID (decimal string)")
+	side := flag.String("side", "buy", "order side: buy or sell")
+	price := flag.Float64("price", 0, "limit price in (0, 1)")
+	size := flag.Float64("size", 0, "order size in shares")
+	orderType := flag.String("type", "GTC", "order type: GTC, FOK or GTD")
+	negRisk := flag.Bool("neg-risk", false, "sign for the Neg Risk CTF Exchange")
+	feeRate := flag.Int("fee-bps", 0, "fee rate in basis points")
+	tickSize := flag.Float64("tick", 0, "market tick size (default 0.01)")
+	rounding := flag.String("rounding", "", "size rounding: floor or nearest (default ORDER_SIZE_ROUNDING)")
+	flag.Parse()
+
+	if *tokenID == "" || *price <= 0 || *size <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var orderSide clob.OrderSide
+	switch strings.ToLower(*side) {
+	case "buy":
+		orderSide = clob.OrderSideBuy
+	case "sell":
+		orderSide = clob.OrderSideSell
+	default:
+		log.Fatalf("invalid side %q: must be buy or sell", *side)
+	}
+
+	ot := clob.OrderType(strings.ToUpper(*orderType))
+	switch ot {
+	case clob.OrderTypeGTC, clob.OrderTypeFOK, clob.OrderTypeGTD:
+	default:
+		log.Fatalf("invalid order type %q: must be GTC, FOK or GTD", *orderType)
+	}
+
+	cfg, err := config.LoadWithPrivateKey()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	w, err := wallet.NewWalletFromHex(cfg.PrivateKey)
+	if err != nil {
+		log.Fatalf("failed to create wallet: %v", err)
+	}
+
+	var builder *clob.OrderBuilder
+	if cfg.UseProxyWallet() {
+		builder = clob.NewOrderBuilderWithProxy(w, cfg.CLOBApiKey, common.HexToAddress(cfg.ProxyWalletAddress), cfg.SignatureType)
+	} else {
+		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
+	}
+
+	if *rounding == "" {
+		*rounding = cfg.OrderSizeRounding
+	}
+	mode, err := clob.ParseRoundingMode(*rounding)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	builder.WithRoundingMode(mode)
+
+	req, err := builder.BuildOrder(clob.BuildParams{
+		TokenID:    *tokenID,
+		Side:       orderSide,
+		Price:      *price,
+		Size:       *size,
+		OrderType:  ot,
+		FeeRateBps: *feeRate,
+		NegRisk:    *negRisk,
+		TickSize:   *tickSize,
+	})
+	if err != nil {
+		log.Fatalf("failed to build order: %v", err)
+	}
+
+	orderHash, err := builder.OrderHash(&req.Order, *negRisk)
+	if err != nil {
+		log.Fatalf("failed to hash order: %v", err)
+	}
+
+	exchange := wallet.ExchangeContract
+	if *negRisk {
+		exchange = wallet.NegRiskExchangeContract
+	}
+
+	body, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		log.Fatalf("failed to marshal order: %v", err)
+	}
+
+	fmt.Printf("Signer (EOA):      %s\n", w.AddressHex())
+	fmt.Printf("Maker:             %s\n", builder.Address().Hex())
+	fmt.Printf("Exchange:          %s\n", exchange.Hex())
+	fmt.Printf("Chain ID:          %d\n", wallet.ChainID)
+	fmt.Printf("Domain separator:  %s\n", builder.DomainSeparator(*negRisk).Hex())
+	fmt.Printf("Order hash:        %s\n", orderHash.Hex())
+	fmt.Println(strings.Repeat("-", 60))
+	fmt.Println(string(body))
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+)
+
+const version = "0.1.0"
+
+// secretFields are printed masked.
+var secretFields = map[string]bool{
+	"PrivateKey":       true,
+	"PrivateKeys":      true,
+	"CLOBApiKey":       true,
+	"CLOBSecret":       true,
+	"CLOBPassphrase":   true,
+	"TelegramBotToken": true,
+	"APIToken":         true,
+}
+
+// proxyFields hold proxy URLs whose passwords are masked.
+var proxyFields = map[string]bool{
+	"ProxyURL":  true,
+	"ProxyURLs": true,
+}
+
+// rpcFields hold RPC URLs whose path and query, where hosted providers put
+// the API key, are masked.
+var rpcFields = map[string]bool{
+	"PolygonRPCURL":  true,
+	"PolygonRPCURLs": true,
+}
+
+// sniperFields are the 15M sniper's own settings.
+var sniperFields = map[string]bool{
+	"SnipePrice":         true,
+	"TriggerSeconds":     true,
+	"MinLiquidityShares": true,
+	"MinLiquidityUSD":    true,
+	"MinConfidence":      true,
+	"MaxUncertainty":     true,
+}
+
+func main() {
+	strategy := flag.String("strategy", "", "only show fields for this strategy: weather, blackswan or sniper (default: all)")
+	flag.Parse()
+
+	switch *strategy {
+	case "", "weather", "blackswan", "sniper":
+	default:
+		log.Fatalf("unknown strategy %q: must be weather, blackswan or sniper", *strategy)
+	}
+
+	fmt.Printf("Config Check v%s\n", version)
+	fmt.Println("Effective configuration after .env, environment and defaults (no trading)")
+	fmt.Println(strings.Repeat("-", 70))
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("FAIL: %v\n", err)
+		os.Exit(1)
+	}
+
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		if !showField(name, *strategy) {
+			continue
+		}
+		fmt.Printf("%-22s %s\n", name, formatField(name, v.Field(i)))
+	}
+	fmt.Println(strings.Repeat("-", 70))
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Printf("FAIL: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("PASS: config is valid")
+}
+
+// fieldStrategy returns the strategy a field belongs to, or "" for settings
+// every strategy shares.
+func fieldStrategy(name string) string {
+	switch {
+	case strings.HasPrefix(name, "Weather"):
+		return "weather"
+	case strings.HasPrefix(name, "BlackSwan"):
+		return "blackswan"
+	case strings.HasPrefix(name, "Snipe"), sniperFields[name]:
+		return "sniper"
+	}
+	return ""
+}
+
+// showField reports whether a field is relevant to the selected strategy.
+func showField(name, strategy string) bool {
+	if strategy == "" {
+		return true
+	}
+	owner := fieldStrategy(name)
+	return owner == "" || owner == strategy
+}
+
+// formatField renders a field's value with secrets masked.
+func formatField(name string, value reflect.Value) string {
+	switch {
+	case secretFields[name] && value.Kind() == reflect.Slice:
+		secrets := make([]string, value.Len())
+		for i := range secrets {
+			secrets[i] = maskSecret(value.Index(i).String())
+		}
+		return fmt.Sprintf("%v", secrets)
+	case secretFields[name]:
+		return maskSecret(value.String())
+	case proxyFields[name] && value.Kind() == reflect.Slice:
+		proxies := make([]string, value.Len())
+		for i := range proxies {
+			proxies[i] = maskProxy(value.Index(i).String())
+		}
+		return fmt.Sprintf("%v", proxies)
+	case proxyFields[name]:
+		return maskProxy(value.String())
+	case rpcFields[name] && value.Kind() == reflect.Slice:
+		urls := make([]string, value.Len())
+		for i := range urls {
+			urls[i] = maskRPCURL(value.Index(i).String())
+		}
+		return fmt.Sprintf("%v", urls)
+	case rpcFields[name]:
+		return maskRPCURL(value.String())
+	case value.Kind() == reflect.String:
+		return fmt.Sprintf("%q", value.String())
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+// maskSecret shows only whether a secret is set.
+func maskSecret(s string) string {
+	if s == "" {
+		return "(not set)"
+	}
+	return "**** (set)"
+}
+
+// maskProxy hides the password in a proxy URL such as user:pass@host:port.
+func maskProxy(proxy string) string {
+	scheme, rest := "", proxy
+	if i := strings.Index(proxy, "://"); i >= 0 {
+		scheme, rest = proxy[:i+3], proxy[i+3:]
+	}
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return proxy
+	}
+	user, _, hasPassword := strings.Cut(rest[:at], ":")
+	if !hasPassword {
+		return proxy
+	}
+	return scheme + user + ":****" + rest[at:]
+}
+
+// maskRPCURL keeps an RPC URL's scheme and host but hides its credentials,
+// path and query, e.g. https://polygon-mainnet.g.alchemy.com/v2/KEY.
+func maskRPCURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return maskSecret(raw)
+	}
+	masked := u.Scheme + "://" + u.Host
+	if u.User != nil {
+		masked = u.Scheme + "://****@" + u.Host
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		masked += "/****"
+	}
+	return masked
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
 	"math/big"
@@ -13,6 +14,9 @@ import (
 )
 
 func main() {
+	domains := flag.Bool("domains", false, "print the EIP-712 domain of both the standard and neg-risk exchanges and exit")
+	flag.Parse()
+
 	cfg, err := config.LoadWithPrivateKey()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
@@ -23,6 +27,11 @@ func main() {
 		log.Fatalf("Failed to create wallet: %v", err)
 	}
 
+	if *domains {
+		printDomains(w, int64(cfg.PolygonChainID))
+		return
+	}
+
 	signer := wallet.NewSigner(w)
 
 	// Create a test order with known values
@@ -97,3 +106,51 @@ func main() {
 	fmt.Printf("Signature length: %d bytes\n", len(sigBytes))
 	fmt.Printf("V value: %d\n", sigBytes[64])
 }
+
+// printDomains prints everything that goes into an order's EIP-712 domain
+// for each exchange, to diff against Polymarket's published values. The
+// hashes are the ones the signer actually uses, each checked against the
+// hash of the string go-order-utils defines it from. Order builders always
+// sign for wallet.ChainID, so configChainID (POLYGON_CHAIN_ID) is only
+// flagged when it differs.
+func printDomains(w *wallet.Wallet, configChainID int64) {
+	domainTypeHash, orderTypeHash := wallet.TypeHashes()
+	nameHash, versionHash := wallet.DomainHashes()
+
+	chainID := int64(wallet.ChainID)
+	fmt.Printf("Chain ID: %d\n", chainID)
+	if configChainID != chainID {
+		fmt.Printf("WARNING: POLYGON_CHAIN_ID is %d, but orders are always signed for chain %d\n", configChainID, chainID)
+	}
+	printSignerHash("EIP712Domain type hash", domainTypeHash,
+		"EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")
+	printSignerHash("Order type hash", orderTypeHash,
+		"Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)")
+	printSignerHash("Name hash", nameHash, wallet.DomainName)
+	printSignerHash("Version hash", versionHash, "1")
+
+	exchanges := []struct {
+		name    string
+		address common.Address
+	}{
+		{"Standard CTF Exchange", wallet.ExchangeContract},
+		{"Neg Risk CTF Exchange", wallet.NegRiskExchangeContract},
+	}
+	for _, ex := range exchanges {
+		signer := wallet.NewSignerWithConfig(w, chainID, ex.address)
+		fmt.Printf("\n%s\n", ex.name)
+		fmt.Printf("  Verifying contract: %s\n", ex.address.Hex())
+		fmt.Printf("  Domain separator: %s\n", signer.DomainSeparator().Hex())
+	}
+}
+
+// printSignerHash prints a hash the signer uses, flagging it when it isn't
+// the hash of reference.
+func printSignerHash(label string, got common.Hash, reference string) {
+	want := crypto.Keccak256Hash([]byte(reference))
+	if got == want {
+		fmt.Printf("%s: %s (matches %q)\n", label, got.Hex(), reference)
+		return
+	}
+	fmt.Printf("%s: %s MISMATCH, want %s for %q\n", label, got.Hex(), want.Hex(), reference)
+}
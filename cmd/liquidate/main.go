@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/dataapi"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const version = "0.1.0"
+
+// dustShares is the smallest holding worth trying to sell.
+const dustShares = 0.01
+
+// sale is one planned fill-or-kill sell.
+type sale struct {
+	pos    dataapi.Position
+	price  float64 // Worst bid the sell sweeps to, its limit price
+	shares float64 // Shares the bids above -min-price can absorb
+}
+
+func main() {
+	minPrice := flag.Float64("min-price", 0.01, "never sell below this price; positions without bids above it are skipped")
+	yes := flag.Bool("yes", false, "skip the confirmation prompt")
+	flag.Parse()
+
+	logs, err := logx.Setup("liquidate", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
+
+	fmt.Printf("Liquidate v%s\n", version)
+	fmt.Println("Sells every held position into the order book at the bids (fill-or-kill)")
+	fmt.Println(strings.Repeat("-", 70))
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	if *minPrice <= 0 || *minPrice >= 1 {
+		log.Fatalf("invalid -min-price %.4f: must be between 0 and 1", *minPrice)
+	}
+
+	w, err := wallet.NewWalletFromHex(cfg.PrivateKey)
+	if err != nil {
+		log.Fatalf("failed to create wallet: %v", err)
+	}
+	walletAddr := w.AddressHex()
+
+	var client *clob.Client
+	if cfg.ProxyURL != "" {
+		client, err = clob.NewClientWithProxy(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, walletAddr, cfg.ProxyURL)
+		if err != nil {
+			log.Fatalf("failed to create CLOB client: %v", err)
+		}
+	} else {
+		client = clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, walletAddr)
+	}
+	client.WithUTLS(cfg.CLOBUTLS).WithOrderRetries(cfg.CLOBOrderRetries)
+
+	var builder *clob.OrderBuilder
+	if cfg.UseProxyWallet() {
+		builder = clob.NewOrderBuilderWithProxy(w, cfg.CLOBApiKey, common.HexToAddress(cfg.ProxyWalletAddress), cfg.SignatureType)
+	} else {
+		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
+	}
+	// Floor rounding, so a sell never exceeds the shares held
+	builder.WithTickSizes(client).WithMinOrderSizes(client).WithRoundingMode(clob.RoundFloor)
+	if cfg.ValidateSignatures {
+		client.WithSignatureCheck(builder)
+	}
+
+	holder := walletAddr
+	if cfg.ProxyWalletAddress != "" {
+		holder = cfg.ProxyWalletAddress
+	}
+	// Only the primary wallet is liquidated; the extra PRIVATE_KEYS wallets
+	// blackswan spreads orders over keep their positions
+	if n := len(cfg.PrivateKeys) - 1; n > 0 {
+		log.Printf("warning: not liquidating the %d other PRIVATE_KEYS wallets; sell their positions from each wallet's own PRIVATE_KEY", n)
+	}
+	log.Printf("fetching positions for %s...", holder)
+	positions, err := dataapi.NewClient().GetPositions(holder)
+	if err != nil {
+		log.Fatalf("failed to fetch positions: %v", err)
+	}
+
+	sales := planSales(client, positions, *minPrice)
+	if len(sales) == 0 {
+		log.Println("nothing to sell")
+		return
+	}
+
+	var expected float64
+	fmt.Printf("%-40s %-6s %10s %10s %8s %10s\n", "MARKET", "SIDE", "HELD", "SELL", "LIMIT", "PROCEEDS")
+	for _, s := range sales {
+		proceeds := s.shares * s.price
+		expected += proceeds
+		fmt.Printf("%-40s %-6s %10.2f %10.2f %8.4f %10.2f\n",
+			truncate(s.pos.Title, 40), s.pos.Outcome, s.pos.Size, s.shares, s.price, proceeds)
+	}
+	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("at least $%.2f from %d positions (fills may be better than the limit)\n", expected, len(sales))
+
+	if !*yes && !confirm() {
+		log.Println("liquidation cancelled")
+		return
+	}
+
+	var proceeds float64
+	var sold, failed int
+	for _, s := range sales {
+		label := fmt.Sprintf("%s [%s]", truncate(s.pos.Title, 40), s.pos.Outcome)
+		if err := sell(client, builder, s); err != nil {
+			log.Printf("FAILED %s: %v", label, err)
+			failed++
+			continue
+		}
+		log.Printf("SOLD %s: %.2f shares at >= $%.4f", label, s.shares, s.price)
+		proceeds += s.shares * s.price
+		sold++
+	}
+
+	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("sold %d, failed %d, proceeds at least $%.2f\n", sold, failed, proceeds)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// planSales prices a sell for every position with bids above minPrice.
+// Resolved positions are left for redemption, since their books are gone.
+func planSales(client *clob.Client, positions []dataapi.Position, minPrice float64) []sale {
+	var sales []sale
+	for _, pos := range positions {
+		label := fmt.Sprintf("%s [%s]", truncate(pos.Title, 40), pos.Outcome)
+		switch {
+		case pos.Size < dustShares:
+			continue
+		case pos.Redeemable:
+			log.Printf("skip %s: resolved, redeem instead", label)
+			continue
+		}
+
+		book, err := client.GetOrderBook(pos.Asset)
+		if err != nil {
+			log.Printf("skip %s: failed to fetch order book: %v", label, err)
+			continue
+		}
+		price, shares := book.SweepBids(pos.Size, minPrice)
+		if shares < dustShares {
+			log.Printf("skip %s: no bids at or above $%.4f", label, minPrice)
+			continue
+		}
+		if shares < pos.Size {
+			log.Printf("partial %s: bids above $%.4f take %.2f of %.2f shares", label, minPrice, shares, pos.Size)
+		}
+		sales = append(sales, sale{pos: pos, price: price, shares: shares})
+	}
+	return sales
+}
+
+// sell submits a fill-or-kill sell for s.
+func sell(client *clob.Client, builder *clob.OrderBuilder, s sale) error {
+	negRisk, err := client.GetNegRisk(s.pos.Asset)
+	if err != nil {
+		return fmt.Errorf("failed to check neg risk: %w", err)
+	}
+	order, err := builder.BuildOrder(clob.BuildParams{
+		TokenID:   s.pos.Asset,
+		Side:      clob.OrderSideSell,
+		Price:     s.price,
+		Size:      s.shares,
+		OrderType: clob.OrderTypeFOK,
+		NegRisk:   negRisk,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build order: %w", err)
+	}
+	resp, err := client.CreateOrder(order)
+	if err != nil {
+		return fmt.Errorf("failed to submit order: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("order rejected: %s", resp.Error)
+	}
+	return nil
+}
+
+// confirm asks before selling anything.
+func confirm() bool {
+	fmt.Println()
+	fmt.Println("This will sell the positions above at market. It cannot be undone.")
+	fmt.Print("Type 'yes' to proceed: ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		log.Printf("failed to read input: %v", err)
+		return false
+	}
+	return strings.TrimSpace(strings.ToLower(input)) == "yes"
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
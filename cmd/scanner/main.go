@@ -9,6 +9,7 @@ import (
 
 	"github.com/dantezy/polymarket-sniper/internal/config"
 	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 )
 
 const (
@@ -26,8 +27,11 @@ Finds active 15-minute BTC/ETH markets on Polymarket
 )
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[scanner] ")
+	logs, err := logx.Setup("scanner", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Printf(banner, version)
 	fmt.Println(strings.Repeat("-", 70))
@@ -78,11 +82,11 @@ func printMarket(market gamma.Market) {
 	noPrice := "N/A"
 
 	if yesToken := market.GetYesToken(); yesToken != nil {
-		yesPrice = fmt.Sprintf("$%.4f", yesToken.Price)
+		yesPrice = fmt.Sprintf("$%.4f", yesToken.Probability())
 	}
 
 	if noToken := market.GetNoToken(); noToken != nil {
-		noPrice = fmt.Sprintf("$%.4f", noToken.Price)
+		noPrice = fmt.Sprintf("$%.4f", noToken.Probability())
 	}
 
 	timeLeft := "unknown"
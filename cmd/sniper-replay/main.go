@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
+	"github.com/dantezy/polymarket-sniper/internal/strategy"
+)
+
+const version = "0.1.0"
+
+func main() {
+	file := flag.String("file", "", "snapshot recording to replay (default: SNIPE_RECORD_FILE)")
+	minConf := flag.String("min-confidence", "", "comma-separated MIN_CONFIDENCE values to try (default: from config)")
+	maxUncert := flag.String("max-uncertainty", "", "comma-separated MAX_UNCERTAINTY values to try (default: from config)")
+	trigger := flag.String("trigger-seconds", "", "comma-separated TRIGGER_SECONDS values to try (default: from config)")
+	verbose := flag.Bool("v", false, "print each market's decision")
+	flag.Parse()
+
+	logs, err := logx.Setup("sniper-replay", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
+
+	fmt.Printf("Sniper Replay v%s\n", version)
+	fmt.Println("Replays recorded price snapshots through the sniper's analysis (no trading)")
+	fmt.Println(strings.Repeat("-", 70))
+
+	cfg, err := config.LoadMinimal()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	path := *file
+	if path == "" {
+		path = cfg.SnipeRecordFile
+	}
+	if path == "" {
+		log.Fatal("no recording: pass -file or set SNIPE_RECORD_FILE")
+	}
+
+	confs, err := parseFloats(*minConf, cfg.MinConfidence)
+	if err != nil {
+		log.Fatalf("invalid -min-confidence: %v", err)
+	}
+	uncerts, err := parseFloats(*maxUncert, cfg.MaxUncertainty)
+	if err != nil {
+		log.Fatalf("invalid -max-uncertainty: %v", err)
+	}
+	triggers, err := parseFloats(*trigger, float64(cfg.TriggerSeconds))
+	if err != nil {
+		log.Fatalf("invalid -trigger-seconds: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open recording: %v", err)
+	}
+	records, err := strategy.ReadReplayRecords(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("failed to read recording: %v", err)
+	}
+	log.Printf("loaded %d snapshots from %s", len(records), path)
+
+	fmt.Printf("%-8s %-8s %-8s %7s %6s %6s %6s %9s  %s\n",
+		"MIN_CONF", "MAX_UNC", "TRIGGER", "MARKETS", "TRADES", "WINS", "LOSSES", "PNL", "SKIPS")
+	for _, conf := range confs {
+		for _, uncert := range uncerts {
+			for _, trig := range triggers {
+				run := *cfg
+				run.MinConfidence = conf
+				run.MaxUncertainty = uncert
+				run.TriggerSeconds = int(trig)
+
+				report := strategy.Replay(&run, records)
+				fmt.Printf("%-8.2f %-8.2f %-8d %7d %6d %6d %6d %+9.2f  %s\n",
+					conf, uncert, run.TriggerSeconds, report.Markets, report.Trades,
+					report.Wins, report.Losses, report.PnL, formatSkips(report.Skips))
+				if *verbose {
+					printResults(report)
+				}
+			}
+		}
+	}
+}
+
+// parseFloats splits a comma-separated list, or returns def when s is empty.
+func parseFloats(s string, def float64) ([]float64, error) {
+	if s == "" {
+		return []float64{def}, nil
+	}
+	var values []float64
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// formatSkips lists skip reasons by count, most common first.
+func formatSkips(skips map[strategy.SkipReason]int) string {
+	reasons := make([]strategy.SkipReason, 0, len(skips))
+	for reason := range skips {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if skips[reasons[i]] != skips[reasons[j]] {
+			return skips[reasons[i]] > skips[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%s=%d", reason, skips[reason])
+	}
+	return strings.Join(parts, " ")
+}
+
+// printResults prints each market's decision and outcome.
+func printResults(report strategy.ReplayReport) {
+	for _, r := range report.Results {
+		winner := r.Winner
+		if winner == "" {
+			winner = "?"
+		}
+		switch {
+		case !r.Decided:
+			fmt.Printf("    %-40s never reached the trigger window warmed up\n", r.Slug)
+		case r.Traded():
+			fmt.Printf("    %-40s BUY %-4s @ %.4f  winner %s\n", r.Slug, r.Analysis.Side, r.Analysis.EntryPrice, winner)
+		default:
+			fmt.Printf("    %-40s skip: %s  winner %s\n", r.Slug, r.Analysis.SkipDescription, winner)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,7 +10,9 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 	"github.com/dantezy/polymarket-sniper/internal/strategy"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
@@ -30,8 +33,11 @@ Automated trading for 15-minute BTC/ETH markets
 )
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[sniper] ")
+	logs, err := logx.Setup("sniper", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Printf(banner, version)
 	fmt.Println(strings.Repeat("-", 60))
@@ -60,6 +66,7 @@ func main() {
 		log.Fatalf("failed to create telegram bot: %v", err)
 	}
 	bot.SetDryRun(cfg.DryRun)
+	bot.SetRateLimit(cfg.TelegramRateLimit)
 
 	log.Println("initializing sniper strategy...")
 	sniper, err := strategy.NewSniper(cfg, w, bot)
@@ -86,7 +93,9 @@ func main() {
 	log.Println("starting sniper strategy...")
 	fmt.Println(strings.Repeat("-", 60))
 
-	if err := sniper.Run(ctx); err != nil && err != context.Canceled {
+	err = sniper.Run(ctx)
+	halted := errors.Is(err, clob.ErrAuthExpired)
+	if err != nil && err != context.Canceled && !halted {
 		log.Printf("strategy error: %v", err)
 		bot.NotifyError(err)
 	}
@@ -97,6 +106,11 @@ func main() {
 		log.Printf("warning: failed to send shutdown notification: %v", err)
 	}
 
+	if halted {
+		log.Printf("halted: %v", err)
+		os.Exit(1)
+	}
+
 	log.Println("shutdown complete")
 	os.Exit(0)
 }
@@ -114,7 +128,7 @@ func printConfig(cfg *config.Config) {
 
 	log.Printf("mode:             %s", mode)
 	log.Printf("chain ID:         %d", cfg.PolygonChainID)
-	log.Printf("max position:     $%.2f", cfg.MaxPositionSize)
+	log.Printf("max position:     $%.2f", cfg.SnipePositionSize())
 	log.Printf("snipe price:      %.2f", cfg.SnipePrice)
 	log.Printf("trigger seconds:  %d", cfg.TriggerSeconds)
 	log.Printf("telegram:         %s", telegramStatus)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,7 +10,9 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 	"github.com/dantezy/polymarket-sniper/internal/strategy"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
@@ -27,8 +30,11 @@ NFL/NBA sniping for Polymarket
 `
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[sports] ")
+	logs, err := logx.Setup("sports", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Print(banner)
 	fmt.Println(strings.Repeat("-", 60))
@@ -46,7 +52,7 @@ func main() {
 	}
 	log.Printf("mode:             %s", mode)
 	log.Printf("chain ID:         %d", cfg.PolygonChainID)
-	log.Printf("max position:     $%.2f", cfg.MaxPositionSize)
+	log.Printf("shares per trade: %.2f", cfg.SportsShares())
 
 	// Initialize wallet
 	log.Println("initializing wallet...")
@@ -65,6 +71,7 @@ func main() {
 			log.Printf("telegram init failed (continuing without): %v", err)
 			tg = nil
 		} else {
+			tg.SetRateLimit(cfg.TelegramRateLimit)
 			log.Println("telegram: enabled")
 		}
 	} else {
@@ -95,9 +102,23 @@ func main() {
 	log.Println("starting sports sniper strategy...")
 
 	// Run the sniper
-	if err := sniper.Run(ctx); err != nil && err != context.Canceled {
+	// Rejected API credentials halt cleanly: the alert is delivered before
+	// exiting non-zero
+	err = sniper.Run(ctx)
+	halted := errors.Is(err, clob.ErrAuthExpired)
+	if err != nil && err != context.Canceled && !halted {
 		log.Fatalf("sniper error: %v", err)
 	}
 
+	// Deliver any digest still waiting on the rate limit
+	if tg != nil {
+		tg.Flush()
+	}
+
+	if halted {
+		log.Printf("halted: %v", err)
+		os.Exit(1)
+	}
+
 	log.Println("shutdown complete")
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
+	"github.com/dantezy/polymarket-sniper/internal/telegram"
+)
+
+const version = "0.1.0"
+
+func main() {
+	message := flag.String("message", "", "text to send (default: a timestamped test message)")
+	flag.Parse()
+
+	logs, err := logx.Setup("telegram-test", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
+
+	fmt.Printf("Telegram Test v%s\n", version)
+	fmt.Println("Sends a test message with TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID")
+	fmt.Println(strings.Repeat("-", 70))
+
+	cfg := config.LoadTelegramConfig()
+	if cfg.BotToken == "" {
+		fail("TELEGRAM_BOT_TOKEN is not set", "create a bot with @BotFather and add its token to .env")
+	}
+	if cfg.ChatID == "" {
+		fail("TELEGRAM_CHAT_ID is not set", "message your bot, then read your chat ID from https://api.telegram.org/bot<token>/getUpdates")
+	}
+
+	bot, err := telegram.NewBot(cfg.BotToken, cfg.ChatID)
+	if err != nil {
+		fail(err.Error(), hint(err))
+	}
+
+	text := *message
+	if text == "" {
+		host, _ := os.Hostname()
+		text = fmt.Sprintf("Polymarket Sniper test message from %s at %s", host, time.Now().Format(time.RFC3339))
+	}
+	if err := bot.SendCritical(text); err != nil {
+		fail(err.Error(), hint(err))
+	}
+
+	fmt.Println("PASS: test message sent")
+}
+
+// fail prints the error with a hint for fixing it and exits non-zero.
+func fail(msg, fix string) {
+	fmt.Printf("FAIL: %s\n", msg)
+	if fix != "" {
+		fmt.Printf("HINT: %s\n", fix)
+	}
+	os.Exit(1)
+}
+
+// hint suggests a fix for the errors Telegram commonly returns.
+func hint(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "invalid chat id"):
+		return "TELEGRAM_CHAT_ID must be a number (group chat IDs start with -)"
+	case strings.Contains(msg, "unauthorized"), strings.Contains(msg, "not found") && !strings.Contains(msg, "chat"):
+		return "TELEGRAM_BOT_TOKEN is wrong or revoked; copy it again from @BotFather"
+	case strings.Contains(msg, "chat not found"):
+		return "check TELEGRAM_CHAT_ID, and send /start to the bot (or add it to the group) first"
+	case strings.Contains(msg, "blocked"):
+		return "the bot was blocked in this chat; unblock it and send /start"
+	case strings.Contains(msg, "kicked"), strings.Contains(msg, "not a member"):
+		return "add the bot back to the group"
+	}
+	return ""
+}
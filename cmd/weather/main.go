@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,7 +10,10 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/dantezy/polymarket-sniper/internal/api"
+	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
 	"github.com/dantezy/polymarket-sniper/internal/strategy"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
@@ -33,8 +37,11 @@ Exploit mispricings between forecasts and Polymarket odds
 `
 
 func main() {
-	log.SetFlags(log.Ltime | log.Lmsgprefix)
-	log.SetPrefix("[weather] ")
+	logs, err := logx.Setup("weather", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
 
 	fmt.Print(banner)
 	fmt.Println(strings.Repeat("-", 60))
@@ -65,6 +72,9 @@ func main() {
 	log.Printf("min volume:       $%.0f", cfg.WeatherMinVolume)
 	log.Printf("max spread:       %.0f%%", cfg.WeatherMaxSpread*100)
 	log.Printf("bid discount:     %.0f%%", cfg.WeatherBidDiscount*100)
+	if cfg.WeatherMaxDiscountAbs > 0 {
+		log.Printf("max discount:     %.0f¢", cfg.WeatherMaxDiscountAbs*100)
+	}
 
 	// Initialize wallet
 	log.Println("initializing wallet...")
@@ -83,6 +93,7 @@ func main() {
 			log.Printf("telegram init failed (continuing without): %v", err)
 			tg = nil
 		} else {
+			tg.SetRateLimit(cfg.TelegramRateLimit)
 			log.Println("telegram: enabled")
 		}
 	} else {
@@ -109,6 +120,21 @@ func main() {
 		cancel()
 	}()
 
+	// Serve read-only state for dashboards (optional)
+	if cfg.APIPort > 0 {
+		board := api.NewBoard("weather")
+		sniper.WithStateBoard(board)
+		go func() {
+			addr := fmt.Sprintf(":%d", cfg.APIPort)
+			if err := api.NewServer(board, cfg.APIToken).ListenAndServe(ctx, addr); err != nil {
+				log.Printf("api error: %v", err)
+			}
+		}()
+		if cfg.APIToken == "" {
+			log.Printf("api: no API_TOKEN set, state is readable by anyone who can reach port %d", cfg.APIPort)
+		}
+	}
+
 	fmt.Println(strings.Repeat("-", 60))
 	log.Println("starting weather sniper...")
 	log.Println("strategy: exploit forecast/market price divergence")
@@ -130,9 +156,23 @@ func main() {
 	}
 
 	// Run the sniper
-	if err := sniper.Run(ctx); err != nil && err != context.Canceled {
+	// Rejected API credentials halt cleanly: the alert is delivered before
+	// exiting non-zero
+	err = sniper.Run(ctx)
+	halted := errors.Is(err, clob.ErrAuthExpired)
+	if err != nil && err != context.Canceled && !halted {
 		log.Fatalf("sniper error: %v", err)
 	}
 
+	// Deliver any digest still waiting on the rate limit
+	if tg != nil {
+		tg.Flush()
+	}
+
+	if halted {
+		log.Printf("halted: %v", err)
+		os.Exit(1)
+	}
+
 	log.Println("shutdown complete")
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/logx"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+const version = "0.1.0"
+
+func main() {
+	flaggedOnly := flag.Bool("flagged", false, "only print markets with parsing gaps")
+	flag.Parse()
+
+	logs, err := logx.Setup("wx-scan", config.LoadLogConfig())
+	if err != nil {
+		log.Fatalf("failed to set up logging: %v", err)
+	}
+	defer logs.Close()
+
+	fmt.Printf("Weather Market Scanner v%s\n", version)
+	fmt.Println("Shows how the weather strategy classifies live markets (no trading)")
+	fmt.Println(strings.Repeat("-", 70))
+
+	cfg, err := config.LoadMinimal()
+	if err != nil {
+		log.Printf("warning: failed to load config: %v", err)
+		log.Println("continuing with defaults...")
+	}
+
+	client := gamma.NewClient()
+	if cfg != nil && cfg.ProxyURL != "" {
+		client = gamma.NewClientWithProxy(cfg.ProxyURL)
+	}
+
+	log.Println("fetching weather markets...")
+	markets, err := client.GetWeatherMarkets()
+	if err != nil {
+		log.Fatalf("failed to fetch weather markets: %v", err)
+	}
+
+	var parsed []*gamma.WeatherMarket
+	var rejected []gamma.Market
+	for _, market := range markets {
+		if wm := gamma.ParseWeatherMarket(market); wm != nil {
+			parsed = append(parsed, wm)
+		} else {
+			rejected = append(rejected, market)
+		}
+	}
+	sort.Slice(parsed, func(i, j int) bool {
+		if parsed[i].Location != parsed[j].Location {
+			return parsed[i].Location < parsed[j].Location
+		}
+		return parsed[i].ResolutionDate.Before(parsed[j].ResolutionDate)
+	})
+
+	fmt.Println()
+	printHeader()
+
+	flagged := 0
+	for _, wm := range parsed {
+		gaps := parsingGaps(wm)
+		if len(gaps) > 0 {
+			flagged++
+		} else if *flaggedOnly {
+			continue
+		}
+		printMarket(wm, gaps)
+	}
+
+	if len(rejected) > 0 {
+		fmt.Println()
+		fmt.Printf("Not parsed as tradeable weather markets (%d):\n", len(rejected))
+		for _, market := range rejected {
+			fmt.Printf("  %s\n", truncate(market.Question, 96))
+		}
+	}
+
+	fmt.Println()
+	log.Printf("found %d market(s): %d parsed, %d flagged, %d rejected",
+		len(markets), len(parsed), flagged, len(rejected))
+}
+
+// parsingGaps lists what the parser couldn't work out for a market.
+func parsingGaps(wm *gamma.WeatherMarket) []string {
+	var gaps []string
+	if wm.MarketType == gamma.WeatherTypeUnknown {
+		gaps = append(gaps, "unknown type")
+	}
+	if wm.Location == "Unknown" {
+		gaps = append(gaps, "unknown location")
+	}
+	return gaps
+}
+
+func printHeader() {
+	fmt.Printf("%-14s | %-13s | %-17s | %-5s | %-7s | %-7s | %-4s\n",
+		"Location", "Type", "Threshold", "Days", "Yes", "No", "Tier")
+	fmt.Println(strings.Repeat("-", 90))
+}
+
+func printMarket(wm *gamma.WeatherMarket, gaps []string) {
+	tier := "-"
+	if location := weather.FindLocationByName(wm.Location); location != nil {
+		tier = string(location.Tier)
+	}
+
+	fmt.Printf("%-14s | %-13s | %-17s | %5.1f | $%.4f | $%.4f | %-4s\n",
+		truncate(wm.Location, 14), wm.MarketType, formatThreshold(wm),
+		wm.DaysUntilResolution(), wm.YesPrice, wm.NoPrice, tier)
+	fmt.Printf("  %s\n", truncate(wm.Market.Question, 96))
+	if len(gaps) > 0 {
+		fmt.Printf("  !! %s\n", strings.Join(gaps, ", "))
+	}
+}
+
+// formatThreshold shows a threshold in the units it is traded in, with the
+// conversion the strategy uses alongside.
+func formatThreshold(wm *gamma.WeatherMarket) string {
+	if wm.ThresholdUnits == "" {
+		return "-"
+	}
+	switch wm.MarketType {
+	case gamma.WeatherTypeWind:
+		return fmt.Sprintf("%.0f %s (%.0f km/h)", wm.Threshold, wm.ThresholdUnits, wm.GetThresholdKmh())
+	case gamma.WeatherTypeUV:
+		return fmt.Sprintf("UV %.0f", wm.Threshold)
+	default:
+		return fmt.Sprintf("%.1f°C / %.1f°F", wm.GetThresholdCelsius(), wm.GetThresholdFahrenheit())
+	}
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
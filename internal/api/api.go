@@ -0,0 +1,196 @@
+// Package api serves a running strategy's state as read-only JSON for
+// dashboards.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+// shutdownTimeout bounds how long in-flight requests get on shutdown.
+const shutdownTimeout = 5 * time.Second
+
+// Position is an order or holding as the API reports it.
+type Position struct {
+	OrderID  string    `json:"order_id"`
+	TokenID  string    `json:"token_id"`
+	Market   string    `json:"market"`
+	Question string    `json:"question"`
+	Side     string    `json:"side"`
+	Price    float64   `json:"price"`
+	Shares   float64   `json:"shares"`
+	Status   string    `json:"status"`
+	PlacedAt time.Time `json:"placed_at"`
+}
+
+// Opportunity is a candidate from the strategy's last scan.
+type Opportunity struct {
+	Market      string  `json:"market"`
+	Question    string  `json:"question"`
+	Side        string  `json:"side"`
+	TokenID     string  `json:"token_id"`
+	MarketPrice float64 `json:"market_price"`
+	BidPrice    float64 `json:"bid_price"`
+	Probability float64 `json:"probability,omitempty"` // Our probability for Side, when the strategy models one
+	Edge        float64 `json:"edge,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// State is the /state response.
+type State struct {
+	Strategy  string                 `json:"strategy"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Stats     map[string]interface{} `json:"stats"`
+	Positions []Position             `json:"positions"`
+}
+
+// Scan is the /opportunities response.
+type Scan struct {
+	Strategy      string        `json:"strategy"`
+	ScannedAt     time.Time     `json:"scanned_at"`
+	Opportunities []Opportunity `json:"opportunities"`
+}
+
+// Board holds the latest state a strategy published. The strategy publishes
+// from its own loop and the server only reads copies, so requests never
+// touch live strategy data. A nil Board ignores publishes.
+type Board struct {
+	strategy string
+
+	mu    sync.RWMutex
+	state State
+	scan  Scan
+}
+
+// NewBoard creates an empty board for the named strategy.
+func NewBoard(strategy string) *Board {
+	return &Board{
+		strategy: strategy,
+		state:    State{Strategy: strategy, Stats: map[string]interface{}{}, Positions: []Position{}},
+		scan:     Scan{Strategy: strategy, Opportunities: []Opportunity{}},
+	}
+}
+
+// PublishState replaces the stats and positions served on /state.
+func (b *Board) PublishState(stats map[string]interface{}, positions []Position) {
+	if b == nil {
+		return
+	}
+	if positions == nil {
+		positions = []Position{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = State{Strategy: b.strategy, UpdatedAt: time.Now().UTC(), Stats: stats, Positions: positions}
+}
+
+// PublishScan replaces the candidates served on /opportunities.
+func (b *Board) PublishScan(opportunities []Opportunity) {
+	if b == nil {
+		return
+	}
+	if opportunities == nil {
+		opportunities = []Opportunity{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.scan = Scan{Strategy: b.strategy, ScannedAt: time.Now().UTC(), Opportunities: opportunities}
+}
+
+// State returns the last published state.
+func (b *Board) State() State {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.state
+}
+
+// Scan returns the last published scan.
+func (b *Board) Scan() Scan {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.scan
+}
+
+// Server answers GET /state and GET /opportunities from a Board, and GET
+// /upstreams with the process's outgoing HTTP stats per host.
+type Server struct {
+	board *Board
+	token string // Required bearer token, empty = no auth
+}
+
+// NewServer creates a server for board. When token is set, requests must
+// send "Authorization: Bearer <token>".
+func NewServer(board *Board, token string) *Server {
+	return &Server{board: board, token: token}
+}
+
+// Handler returns the API's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/state", s.serve(func() interface{} { return s.board.State() }))
+	mux.HandleFunc("/opportunities", s.serve(func() interface{} { return s.board.Scan() }))
+	mux.HandleFunc("/upstreams", s.serve(func() interface{} { return httpx.Stats() }))
+	return mux
+}
+
+// ListenAndServe serves the API on addr until ctx is canceled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("[api] serving strategy state on %s", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve API: %w", err)
+	}
+	return nil
+}
+
+// serve wraps a read-only JSON endpoint with the method and token checks.
+func (s *Server) serve(body func() interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body()); err != nil {
+			log.Printf("[api] failed to write %s response: %v", r.URL.Path, err)
+		}
+	}
+}
+
+// authorized checks the bearer token in constant time.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	want := "Bearer " + s.token
+	got := r.Header.Get("Authorization")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
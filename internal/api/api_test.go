@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer_Auth(t *testing.T) {
+	board := NewBoard("test")
+	srv := httptest.NewServer(NewServer(board, "secret").Handler())
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		want   int
+	}{
+		{"no token", http.MethodGet, "/state", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodGet, "/state", "Bearer nope", http.StatusUnauthorized},
+		{"state", http.MethodGet, "/state", "Bearer secret", http.StatusOK},
+		{"opportunities", http.MethodGet, "/opportunities", "Bearer secret", http.StatusOK},
+		{"read only", http.MethodPost, "/state", "Bearer secret", http.StatusMethodNotAllowed},
+		{"unknown path", http.MethodGet, "/orders", "Bearer secret", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_EmptyBoard(t *testing.T) {
+	srv := httptest.NewServer(NewServer(NewBoard("test"), "").Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/opportunities")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	// Dashboards get an empty list rather than null before the first scan
+	if string(raw["opportunities"]) != "[]" {
+		t.Errorf("opportunities = %s, want []", raw["opportunities"])
+	}
+}
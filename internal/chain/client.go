@@ -0,0 +1,253 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+const (
+	// DefaultRPCURL is the public Polygon RPC used when none is configured.
+	DefaultRPCURL = "https://polygon-rpc.com"
+
+	// USDCContract is the USDC.e token contract on Polygon.
+	USDCContract = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
+
+	balanceOfSelector = "0x70a08231"
+	allowanceSelector = "0xdd62ed3e"
+	noncesSelector    = "0x7ecebe00" // nonces(address) on the CTF exchanges
+	defaultTimeout    = 10 * time.Second
+)
+
+// Client is a JSON-RPC client that fails over across a list of RPC endpoints.
+// Each call tries the endpoints in order and returns the first success.
+type Client struct {
+	urls       []string
+	httpClient *http.Client
+}
+
+// NewClient creates a failover client. Empty entries are ignored; if no URL
+// remains, DefaultRPCURL is used.
+func NewClient(urls []string) *Client {
+	cleaned := make([]string, 0, len(urls))
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u != "" {
+			cleaned = append(cleaned, u)
+		}
+	}
+	if len(cleaned) == 0 {
+		cleaned = []string{DefaultRPCURL}
+	}
+
+	return &Client{
+		urls: cleaned,
+		httpClient: &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: httpx.Transport(nil),
+		},
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func (c *Client) WithHTTPClient(client *http.Client) *Client {
+	c.httpClient = client
+	return c
+}
+
+// URLs returns the endpoints in failover order.
+func (c *Client) URLs() []string {
+	return c.urls
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Call performs a JSON-RPC call, failing over to the next endpoint on
+// transport errors, non-200 responses, or RPC errors.
+func (c *Client) Call(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var lastErr error
+	for i, url := range c.urls {
+		result, err := c.callOnce(ctx, url, body)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if i < len(c.urls)-1 {
+			log.Printf("[chain] %s failed on %s: %v, trying next RPC", method, url, err)
+		}
+	}
+
+	return nil, fmt.Errorf("all %d RPCs failed: %w", len(c.urls), lastErr)
+}
+
+func (c *Client) callOnce(ctx context.Context, url string, body []byte) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	}
+
+	return rpcResp.Result, nil
+}
+
+// Dial returns an ethclient connected to the first endpoint that answers
+// eth_chainId, along with the URL it picked.
+func (c *Client) Dial(ctx context.Context) (*ethclient.Client, string, error) {
+	var lastErr error
+	for _, url := range c.urls {
+		client, err := ethclient.DialContext(ctx, url)
+		if err != nil {
+			lastErr = err
+			log.Printf("[chain] failed to dial %s: %v", url, err)
+			continue
+		}
+
+		// HTTP dials are lazy, so probe the endpoint before handing it out
+		if _, err := client.ChainID(ctx); err != nil {
+			client.Close()
+			lastErr = err
+			log.Printf("[chain] %s not responding: %v", url, err)
+			continue
+		}
+
+		return client, url, nil
+	}
+
+	return nil, "", fmt.Errorf("all %d RPCs failed: %w", len(c.urls), lastErr)
+}
+
+// USDCBalance reads the USDC.e balance of address in dollars.
+func (c *Client) USDCBalance(ctx context.Context, address string) (float64, error) {
+	return c.callUSDC(ctx, balanceOfSelector+padAddress(address))
+}
+
+// USDCAllowance reads how many USDC.e dollars owner has approved spender to
+// transfer.
+func (c *Client) USDCAllowance(ctx context.Context, owner, spender string) (float64, error) {
+	return c.callUSDC(ctx, allowanceSelector+padAddress(owner)+padAddress(spender))
+}
+
+// Approval is a contract that may move the wallet's USDC.
+type Approval struct {
+	Name    string  // For display, e.g. "CTF Exchange"
+	Spender string  // Contract address
+	Amount  float64 // Current allowance in dollars, set by PendingApprovals
+}
+
+// PendingApprovals reads owner's USDC allowance for each spender and returns
+// those approved for less than min dollars, with Amount set, in order.
+func (c *Client) PendingApprovals(ctx context.Context, owner string, approvals []Approval, min float64) ([]Approval, error) {
+	var pending []Approval
+	for _, a := range approvals {
+		amount, err := c.USDCAllowance(ctx, owner, a.Spender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read allowance for %s: %w", a.Name, err)
+		}
+		if amount < min {
+			a.Amount = amount
+			pending = append(pending, a)
+		}
+	}
+	return pending, nil
+}
+
+// ExchangeNonce reads maker's current order nonce from a CTF exchange
+// contract. The exchange only fills orders signed with this nonce.
+func (c *Client) ExchangeNonce(ctx context.Context, exchange, maker string) (*big.Int, error) {
+	return c.callUint256(ctx, exchange, noncesSelector+padAddress(maker))
+}
+
+// callUSDC runs a read-only call against the USDC contract and decodes the
+// uint256 result as a 6-decimal dollar amount.
+func (c *Client) callUSDC(ctx context.Context, callData string) (float64, error) {
+	balanceWei, err := c.callUint256(ctx, USDCContract, callData)
+	if err != nil {
+		return 0, err
+	}
+
+	balanceFloat := new(big.Float).Quo(
+		new(big.Float).SetInt(balanceWei),
+		new(big.Float).SetInt64(1e6),
+	)
+
+	f, _ := balanceFloat.Float64()
+	return f, nil
+}
+
+// callUint256 runs a read-only call against contract and decodes the result
+// as a uint256. An empty result decodes to zero.
+func (c *Client) callUint256(ctx context.Context, contract, callData string) (*big.Int, error) {
+	call := map[string]string{"to": contract, "data": callData}
+	raw, err := c.Call(ctx, "eth_call", call, "latest")
+	if err != nil {
+		return nil, err
+	}
+
+	var result string
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	hexResult := strings.TrimPrefix(result, "0x")
+	if hexResult == "" || hexResult == "0" {
+		return new(big.Int), nil
+	}
+
+	value, ok := new(big.Int).SetString(hexResult, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid uint256 format: %s", result)
+	}
+	return value, nil
+}
+
+// padAddress left-pads a hex address to a 32-byte ABI word.
+func padAddress(address string) string {
+	return fmt.Sprintf("%064s", strings.TrimPrefix(strings.ToLower(address), "0x"))
+}
@@ -0,0 +1,196 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewClient_DefaultURL(t *testing.T) {
+	tests := []struct {
+		name string
+		urls []string
+		want []string
+	}{
+		{"nil", nil, []string{DefaultRPCURL}},
+		{"blank entries", []string{" ", ""}, []string{DefaultRPCURL}},
+		{"trimmed", []string{" https://a ", "https://b"}, []string{"https://a", "https://b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewClient(tt.urls).URLs()
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("URLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUSDCBalance_FailsOverToSecondRPC(t *testing.T) {
+	var firstHits, secondHits int32
+
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&firstHits, 1)
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer first.Close()
+
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&secondHits, 1)
+		// 12.5 USDC = 12_500_000 = 0xbebc20
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000bebc20"}`)
+	}))
+	defer second.Close()
+
+	c := NewClient([]string{first.URL, second.URL})
+	balance, err := c.USDCBalance(context.Background(), "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if balance != 12.5 {
+		t.Errorf("balance = %v, want 12.5", balance)
+	}
+	if firstHits != 1 || secondHits != 1 {
+		t.Errorf("hits = (%d, %d), want (1, 1)", firstHits, secondHits)
+	}
+}
+
+func TestCall_RPCErrorFailsOver(t *testing.T) {
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"limit exceeded"}}`)
+	}))
+	defer first.Close()
+
+	second := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x89"}`)
+	}))
+	defer second.Close()
+
+	raw, err := NewClient([]string{first.URL, second.URL}).Call(context.Background(), "eth_chainId")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(raw) != `"0x89"` {
+		t.Errorf("result = %s, want \"0x89\"", raw)
+	}
+}
+
+func TestCall_AllFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient([]string{srv.URL, srv.URL}).Call(context.Background(), "eth_chainId"); err == nil {
+		t.Fatal("expected error when every RPC fails")
+	}
+}
+
+func TestUSDCAllowance_EncodesOwnerAndSpender(t *testing.T) {
+	owner := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	spender := "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+
+	var gotData string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			var call map[string]string
+			_ = json.Unmarshal(req.Params[0], &call)
+			gotData = call["data"]
+		}
+		// 3 USDC = 3_000_000 = 0x2dc6c0
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x00000000000000000000000000000000000000000000000000000000002dc6c0"}`)
+	}))
+	defer srv.Close()
+
+	allowance, err := NewClient([]string{srv.URL}).USDCAllowance(context.Background(), owner, spender)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if allowance != 3 {
+		t.Errorf("allowance = %v, want 3", allowance)
+	}
+
+	want := allowanceSelector +
+		"000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266" +
+		"0000000000000000000000004bfb41d5b3570defd03c39a9a4d8de6bd8b8982e"
+	if gotData != want {
+		t.Errorf("call data = %s, want %s", gotData, want)
+	}
+}
+
+func TestPendingApprovals_SkipsApprovedSpenders(t *testing.T) {
+	owner := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	approved := Approval{Name: "CTF Exchange", Spender: "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"}
+	lacking := Approval{Name: "Neg Risk CTF Exchange", Spender: "0xC5d563A36AE78145C45a50134d48A1215220f80a"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		var call map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &call)
+		}
+		// The approved spender has the maximum allowance, the other 5 USDC
+		result := "0x00000000000000000000000000000000000000000000000000000000004c4b40"
+		if strings.HasSuffix(call["data"], "4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e") {
+			result = "0x" + strings.Repeat("f", 64)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"%s"}`, result)
+	}))
+	defer srv.Close()
+
+	pending, err := NewClient([]string{srv.URL}).PendingApprovals(context.Background(), owner, []Approval{approved, lacking}, 1_000_000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("pending = %+v, want only %s", pending, lacking.Name)
+	}
+	if pending[0].Spender != lacking.Spender || pending[0].Amount != 5 {
+		t.Errorf("pending[0] = %+v, want %s with allowance 5", pending[0], lacking.Name)
+	}
+}
+
+func TestExchangeNonce_EncodesMaker(t *testing.T) {
+	exchange := "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+	maker := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+	var gotTo, gotData string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			var call map[string]string
+			_ = json.Unmarshal(req.Params[0], &call)
+			gotTo, gotData = call["to"], call["data"]
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000007"}`)
+	}))
+	defer srv.Close()
+
+	nonce, err := NewClient([]string{srv.URL}).ExchangeNonce(context.Background(), exchange, maker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nonce.Int64() != 7 {
+		t.Errorf("nonce = %s, want 7", nonce)
+	}
+	if gotTo != exchange {
+		t.Errorf("call to = %s, want %s", gotTo, exchange)
+	}
+	if want := noncesSelector + "000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266"; gotData != want {
+		t.Errorf("call data = %s, want %s", gotData, want)
+	}
+}
@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrGasPriceTooHigh is returned by DynamicFees when the network's gas price
+// is above the configured maximum.
+var ErrGasPriceTooHigh = errors.New("gas price above maximum")
+
+// FeeSuggester is the part of an Ethereum client EIP-1559 fee estimation
+// needs. *ethclient.Client implements it.
+type FeeSuggester interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// GasSettings tunes the fees of a transaction, in gwei. Zero fields fall
+// back to the network's suggestion or, for MaxPriceGwei, no limit.
+type GasSettings struct {
+	MaxPriceGwei float64 // Refuse to send when base fee plus tip is above this
+	TipGwei      float64 // Priority fee per gas
+	FeeCapGwei   float64 // Most paid per gas (default: twice the base fee plus the tip)
+}
+
+// DynamicFees returns the tip and fee cap for an EIP-1559 transaction. The
+// price it is expected to pay, the latest base fee plus the tip, must be
+// within settings.MaxPriceGwei, or ErrGasPriceTooHigh is returned; the fee
+// cap is then lowered to the maximum too, so a base fee rising before
+// inclusion can't push the price past it either.
+func DynamicFees(ctx context.Context, fs FeeSuggester, settings GasSettings) (tip, feeCap *big.Int, err error) {
+	head, err := fs.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, fmt.Errorf("chain does not support EIP-1559 fees")
+	}
+
+	tip = gweiToWei(settings.TipGwei)
+	if settings.TipGwei <= 0 {
+		if tip, err = fs.SuggestGasTipCap(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to get gas tip: %w", err)
+		}
+	}
+
+	feeCap = gweiToWei(settings.FeeCapGwei)
+	if settings.FeeCapGwei <= 0 {
+		feeCap = new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+	}
+	if feeCap.Cmp(tip) < 0 {
+		return nil, nil, fmt.Errorf("fee cap %s gwei is below the tip %s gwei", FormatGwei(feeCap), FormatGwei(tip))
+	}
+
+	if settings.MaxPriceGwei > 0 {
+		maxPrice := gweiToWei(settings.MaxPriceGwei)
+		if price := new(big.Int).Add(head.BaseFee, tip); price.Cmp(maxPrice) > 0 {
+			return nil, nil, fmt.Errorf("%w: %s gwei (base fee %s + tip %s) exceeds %s gwei", ErrGasPriceTooHigh,
+				FormatGwei(price), FormatGwei(head.BaseFee), FormatGwei(tip), FormatGwei(maxPrice))
+		}
+		if feeCap.Cmp(maxPrice) > 0 {
+			feeCap = maxPrice
+		}
+	}
+	return tip, feeCap, nil
+}
+
+// FormatGwei formats an amount of wei in gwei.
+func FormatGwei(wei *big.Int) string {
+	gwei := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei))
+	return gwei.Text('f', 2)
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// fakeFees answers fee lookups with a fixed base fee and tip, in gwei.
+type fakeFees struct {
+	baseFee, tip int64
+}
+
+func (f fakeFees) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(f.tip * params.GWei), nil
+}
+
+func (f fakeFees) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(f.baseFee * params.GWei)}, nil
+}
+
+func TestDynamicFees(t *testing.T) {
+	tests := []struct {
+		name       string
+		fees       fakeFees
+		settings   GasSettings
+		wantTip    string
+		wantFeeCap string
+		wantErr    error
+	}{
+		{"suggested", fakeFees{100, 30}, GasSettings{}, "30.00", "230.00", nil},
+		{"configured tip and cap", fakeFees{100, 30}, GasSettings{TipGwei: 40, FeeCapGwei: 300}, "40.00", "300.00", nil},
+		{"cap lowers the fee cap", fakeFees{100, 30}, GasSettings{MaxPriceGwei: 200}, "30.00", "200.00", nil},
+		{"spike refused", fakeFees{900, 30}, GasSettings{MaxPriceGwei: 500}, "", "", ErrGasPriceTooHigh},
+		{"tip counts toward the cap", fakeFees{100, 30}, GasSettings{MaxPriceGwei: 120}, "", "", ErrGasPriceTooHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tip, feeCap, err := DynamicFees(context.Background(), tt.fees, tt.settings)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DynamicFees: %v", err)
+			}
+			if got := FormatGwei(tip); got != tt.wantTip {
+				t.Errorf("tip = %s gwei, want %s", got, tt.wantTip)
+			}
+			if got := FormatGwei(feeCap); got != tt.wantFeeCap {
+				t.Errorf("fee cap = %s gwei, want %s", got, tt.wantFeeCap)
+			}
+		})
+	}
+}
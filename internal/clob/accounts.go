@@ -0,0 +1,240 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Account is one wallet trading through its own CLOB credentials. Client
+// must be authenticated as the wallet Builder signs for.
+type Account struct {
+	Client  CLOBClient
+	Builder *OrderBuilder
+}
+
+// AccountPool spreads orders across several accounts, taking builders in
+// turn from NextBuilder. It implements CLOBClient, so a strategy can use it
+// in place of a single client: orders are submitted by the account whose
+// builder signed them, cancels go to the account that placed the order,
+// open orders are gathered from every account, and market data comes from
+// the first account.
+type AccountPool struct {
+	accounts []Account
+
+	mu     sync.Mutex
+	next   int
+	owners map[string]int // Order ID to the index of the account holding it
+}
+
+var _ CLOBClient = (*AccountPool)(nil)
+
+// NewAccountPool creates a pool over accounts, the first of which answers
+// market data requests.
+func NewAccountPool(accounts ...Account) (*AccountPool, error) {
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("account pool needs at least one account")
+	}
+	for i, a := range accounts {
+		if a.Client == nil || a.Builder == nil {
+			return nil, fmt.Errorf("account %d is missing its client or builder", i)
+		}
+	}
+	return &AccountPool{accounts: accounts, owners: make(map[string]int)}, nil
+}
+
+// Len returns the number of accounts.
+func (p *AccountPool) Len() int {
+	return len(p.accounts)
+}
+
+// Accounts returns the pool's accounts, the primary first.
+func (p *AccountPool) Accounts() []Account {
+	return append([]Account(nil), p.accounts...)
+}
+
+// NextBuilder returns the builder of the next account in turn.
+func (p *AccountPool) NextBuilder() *OrderBuilder {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b := p.accounts[p.next].Builder
+	p.next = (p.next + 1) % len(p.accounts)
+	return b
+}
+
+// BuilderFor returns the builder of the account holding orderID, so sells
+// of what the order bought are signed by the wallet that owns the shares.
+// Unknown orders get the first account's builder.
+func (p *AccountPool) BuilderFor(orderID string) *OrderBuilder {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.accounts[p.owners[orderID]].Builder
+}
+
+// signerOf returns the index of the account whose builder made order, or
+// -1 if none did.
+func (p *AccountPool) signerOf(order *OrderRequest) int {
+	for i, a := range p.accounts {
+		if strings.EqualFold(order.Order.Maker, a.Builder.Address().Hex()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// primary is the account that answers market data requests.
+func (p *AccountPool) primary() CLOBClient {
+	return p.accounts[0].Client
+}
+
+// CreateOrder submits order through the account that signed it.
+func (p *AccountPool) CreateOrder(order *OrderRequest) (*OrderResponse, error) {
+	return p.CreateOrderCtx(context.Background(), order)
+}
+
+// CreateOrderCtx submits order through the account that signed it and
+// remembers which account holds the resulting order.
+func (p *AccountPool) CreateOrderCtx(ctx context.Context, order *OrderRequest) (*OrderResponse, error) {
+	i := p.signerOf(order)
+	if i < 0 {
+		return nil, fmt.Errorf("order maker %s is not an account in the pool", order.Order.Maker)
+	}
+	resp, err := p.accounts[i].Client.CreateOrderCtx(ctx, order)
+	if err == nil && resp.Success && resp.OrderID != "" {
+		p.mu.Lock()
+		p.owners[resp.OrderID] = i
+		p.mu.Unlock()
+	}
+	return resp, err
+}
+
+// CreateOrders submits each order through the account that signed it, one
+// batch per account. Responses line up with orders; an order no account
+// signed is rejected without being sent.
+func (p *AccountPool) CreateOrders(orders []*OrderRequest) ([]*OrderResponse, error) {
+	resps := make([]*OrderResponse, len(orders))
+	groups := make(map[int][]int) // Account index to the positions of its orders
+	for i, order := range orders {
+		signer := p.signerOf(order)
+		if signer < 0 {
+			resps[i] = &OrderResponse{Error: fmt.Sprintf("order maker %s is not an account in the pool", order.Order.Maker)}
+			continue
+		}
+		groups[signer] = append(groups[signer], i)
+	}
+
+	for signer, positions := range groups {
+		batch := make([]*OrderRequest, len(positions))
+		for j, i := range positions {
+			batch[j] = orders[i]
+		}
+		for j, resp := range SubmitOrders(p.accounts[signer].Client, batch) {
+			resps[positions[j]] = resp
+			if resp.Success && resp.OrderID != "" {
+				p.mu.Lock()
+				p.owners[resp.OrderID] = signer
+				p.mu.Unlock()
+			}
+		}
+	}
+	return resps, nil
+}
+
+// CancelOrder cancels through the account holding orderID, or the first
+// account when the order isn't known.
+func (p *AccountPool) CancelOrder(orderID string) error {
+	p.mu.Lock()
+	i := p.owners[orderID]
+	p.mu.Unlock()
+	return p.accounts[i].Client.CancelOrder(orderID)
+}
+
+// GetOpenOrders returns the open orders of every account, failing if any
+// account can't be read: a missing account's orders would look filled.
+func (p *AccountPool) GetOpenOrders() ([]Order, error) {
+	var all []Order
+	for i, a := range p.accounts {
+		orders, err := a.Client.GetOpenOrders()
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", a.Builder.Address().Hex(), err)
+		}
+		p.mu.Lock()
+		for _, o := range orders {
+			if id := o.GetID(); id != "" {
+				p.owners[id] = i
+			}
+		}
+		p.mu.Unlock()
+		all = append(all, orders...)
+	}
+	return all, nil
+}
+
+// GetOrder looks orderID up through the account holding it, or the first
+// account when the order isn't known.
+func (p *AccountPool) GetOrder(orderID string) (*OrderStatus, error) {
+	p.mu.Lock()
+	i := p.owners[orderID]
+	p.mu.Unlock()
+	return p.accounts[i].Client.GetOrder(orderID)
+}
+
+// GetUSDCBalance returns the combined balance of every account.
+func (p *AccountPool) GetUSDCBalance() (float64, error) {
+	var total float64
+	for _, a := range p.accounts {
+		balance, err := a.Client.GetUSDCBalance()
+		if err != nil {
+			return 0, fmt.Errorf("account %s: %w", a.Builder.Address().Hex(), err)
+		}
+		total += balance
+	}
+	return total, nil
+}
+
+// GetAllowance returns the smallest allowance of any account, since an
+// account without one has every order it's given rejected.
+func (p *AccountPool) GetAllowance(spender string) (float64, error) {
+	lowest := -1.0
+	for _, a := range p.accounts {
+		allowance, err := a.Client.GetAllowance(spender)
+		if err != nil {
+			return 0, fmt.Errorf("account %s: %w", a.Builder.Address().Hex(), err)
+		}
+		if lowest < 0 || allowance < lowest {
+			lowest = allowance
+		}
+	}
+	return lowest, nil
+}
+
+// GetOrderBook is answered by the first account.
+func (p *AccountPool) GetOrderBook(tokenID string) (*OrderBook, error) {
+	return p.primary().GetOrderBook(tokenID)
+}
+
+// GetPrices is answered by the first account.
+func (p *AccountPool) GetPrices(tokenIDs []string) (map[string]PriceSides, error) {
+	return p.primary().GetPrices(tokenIDs)
+}
+
+// GetNegRisk is answered by the first account.
+func (p *AccountPool) GetNegRisk(tokenID string) (bool, error) {
+	return p.primary().GetNegRisk(tokenID)
+}
+
+// GetFeeRateBps is answered by the first account.
+func (p *AccountPool) GetFeeRateBps(tokenID string) (int, error) {
+	return p.primary().GetFeeRateBps(tokenID)
+}
+
+// GetTickSize is answered by the first account.
+func (p *AccountPool) GetTickSize(tokenID string) (float64, error) {
+	return p.primary().GetTickSize(tokenID)
+}
+
+// GetMinOrderSize is answered by the first account.
+func (p *AccountPool) GetMinOrderSize(tokenID string) (float64, error) {
+	return p.primary().GetMinOrderSize(tokenID)
+}
@@ -0,0 +1,146 @@
+package clob
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ClobAuthDomain EIP-712 constants for L1 (wallet-signed) requests
+const (
+	clobAuthDomainName = "ClobAuthDomain"
+	clobAuthVersion    = "1"
+	clobAuthMessage    = "This message attests that I control the given wallet"
+	headerNonce        = "POLY_NONCE"
+)
+
+var (
+	clobAuthDomainTypeHash = crypto.Keccak256Hash(
+		[]byte("EIP712Domain(string name,string version,uint256 chainId)"),
+	)
+	clobAuthTypeHash = crypto.Keccak256Hash(
+		[]byte("ClobAuth(address address,string timestamp,uint256 nonce,string message)"),
+	)
+)
+
+// APICreds are the L2 credentials the CLOB issues for a wallet.
+type APICreds struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// DeriveAPICreds fetches the API credentials for w, signing the request with
+// the wallet itself (always the EOA, even with a proxy wallet). The CLOB
+// rejects timestamps too far from its own clock, so pass one from
+// GetServerTime when the local clock may be off.
+func (c *Client) DeriveAPICreds(w *wallet.Wallet, chainID int64, timestamp string) (*APICreds, error) {
+	const nonce = 0
+
+	signature, err := clobAuthSignature(w, chainID, timestamp, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign auth message: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/auth/derive-api-key", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set(headerAddress, w.AddressHex())
+	req.Header.Set(headerSignature, signature)
+	req.Header.Set(headerTimestamp, timestamp)
+	req.Header.Set(headerNonce, strconv.Itoa(nonce))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	var creds APICreds
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w (body: %s)", err, string(body))
+	}
+	if creds.APIKey == "" {
+		return nil, fmt.Errorf("no API key in response: %s", string(body))
+	}
+	return &creds, nil
+}
+
+// clobAuthSignature signs the ClobAuth EIP-712 message for w.
+func clobAuthSignature(w *wallet.Wallet, chainID int64, timestamp string, nonce int) (string, error) {
+	domainSeparator := clobAuthDomainSeparator(chainID)
+	structHash := clobAuthStructHash(w.AddressHex(), timestamp, nonce)
+
+	digest := crypto.Keccak256Hash(
+		[]byte{0x19, 0x01},
+		domainSeparator[:],
+		structHash[:],
+	)
+
+	signature, err := w.Sign(digest.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	// Adjust V value from 0/1 to 27/28
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
+
+	return "0x" + hex.EncodeToString(signature), nil
+}
+
+func clobAuthDomainSeparator(chainID int64) [32]byte {
+	nameHash := crypto.Keccak256Hash([]byte(clobAuthDomainName))
+	versionHash := crypto.Keccak256Hash([]byte(clobAuthVersion))
+
+	chainIDBytes := make([]byte, 32)
+	big.NewInt(chainID).FillBytes(chainIDBytes)
+
+	return crypto.Keccak256Hash(
+		clobAuthDomainTypeHash.Bytes(),
+		nameHash.Bytes(),
+		versionHash.Bytes(),
+		chainIDBytes,
+	)
+}
+
+func clobAuthStructHash(address, timestamp string, nonce int) [32]byte {
+	// Address type: 20-byte address left-padded to 32 bytes
+	addr := common.HexToAddress(address)
+	addressPadded := make([]byte, 32)
+	copy(addressPadded[12:], addr.Bytes())
+
+	// String types: keccak256 hash of the string
+	timestampHash := crypto.Keccak256Hash([]byte(timestamp))
+	messageHash := crypto.Keccak256Hash([]byte(clobAuthMessage))
+
+	// Uint type: uint256 padded to 32 bytes
+	nonceBytes := make([]byte, 32)
+	big.NewInt(int64(nonce)).FillBytes(nonceBytes)
+
+	return crypto.Keccak256Hash(
+		clobAuthTypeHash.Bytes(),
+		addressPadded,
+		timestampHash.Bytes(),
+		nonceBytes,
+		messageHash.Bytes(),
+	)
+}
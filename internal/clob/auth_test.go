@@ -0,0 +1,63 @@
+package clob
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDeriveAPICreds(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/derive-api-key" {
+			http.NotFound(rw, r)
+			return
+		}
+		if r.Header.Get(headerAddress) != w.AddressHex() || r.Header.Get(headerTimestamp) != "1700000000" || r.Header.Get(headerNonce) != "0" {
+			http.Error(rw, `{"message":"bad headers"}`, http.StatusUnauthorized)
+			return
+		}
+
+		// The signature must recover to the wallet over the ClobAuth digest
+		sig, err := hexutil.Decode(r.Header.Get(headerSignature))
+		if err != nil || len(sig) != 65 {
+			http.Error(rw, `{"message":"bad signature"}`, http.StatusUnauthorized)
+			return
+		}
+		sig[64] -= 27
+		domain := clobAuthDomainSeparator(137)
+		structHash := clobAuthStructHash(w.AddressHex(), "1700000000", 0)
+		digest := crypto.Keccak256Hash([]byte{0x19, 0x01}, domain[:], structHash[:])
+		pub, err := crypto.SigToPub(digest.Bytes(), sig)
+		if err != nil || crypto.PubkeyToAddress(*pub) != common.HexToAddress(w.AddressHex()) {
+			http.Error(rw, `{"message":"wrong signer"}`, http.StatusUnauthorized)
+			return
+		}
+		rw.Write([]byte(`{"apiKey":"key-1","secret":"c2VjcmV0","passphrase":"pass-1"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("", "", "", w.AddressHex()).WithBaseURL(srv.URL)
+	creds, err := c.DeriveAPICreds(w, 137, "1700000000")
+	if err != nil {
+		t.Fatalf("DeriveAPICreds: %v", err)
+	}
+	if *creds != (APICreds{APIKey: "key-1", Secret: "c2VjcmV0", Passphrase: "pass-1"}) {
+		t.Errorf("creds = %+v", creds)
+	}
+
+	// Signed for the wrong chain, the server refuses
+	if _, err := c.DeriveAPICreds(w, 80002, "1700000000"); err == nil || !strings.Contains(err.Error(), "wrong signer") {
+		t.Errorf("wrong chain error = %v, want the server's rejection", err)
+	}
+}
@@ -0,0 +1,98 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+// ErrAuthExpired is returned when the CLOB rejects the client's API
+// credentials (401), as after they were revoked or rotated. Every later
+// request fails the same way until they are replaced.
+var ErrAuthExpired = errors.New("CLOB rejected the API credentials")
+
+// WithCredentialRenewal has the client re-derive its API credentials from
+// w, the same way cmd/derive-creds does, when the CLOB rejects them, and
+// retry the request once with the new ones. builder, if not nil, names the
+// new key as the owner of orders built afterwards; an order already built
+// keeps the old one, so its retry may still be rejected.
+func (c *Client) WithCredentialRenewal(w *wallet.Wallet, chainID int64, builder *OrderBuilder) *Client {
+	c.renewWallet = w
+	c.renewChainID = chainID
+	c.renewBuilder = builder
+	return c
+}
+
+// OnAuthExpired sets a function called with the ErrAuthExpired error each
+// time a request is rejected for its credentials and renewal, if enabled,
+// didn't help.
+func (c *Client) OnAuthExpired(fn func(error)) *Client {
+	c.onAuthExpired = fn
+	return c
+}
+
+// doRequestCtx performs an authenticated request, rotating proxies as
+// needed. A 401 is turned into ErrAuthExpired, after one retry with
+// re-derived credentials when WithCredentialRenewal is set.
+func (c *Client) doRequestCtx(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	resp, err := c.doRequestProxied(ctx, method, path, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if c.renewCreds() {
+		resp.Body.Close()
+		resp, err = c.doRequestProxied(ctx, method, path, body)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+	}
+	return nil, c.authExpired(resp)
+}
+
+// authExpired closes a 401 response and reports it as ErrAuthExpired.
+func (c *Client) authExpired(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	err := fmt.Errorf("%w: status %d: %s", ErrAuthExpired, resp.StatusCode, string(body))
+	if c.onAuthExpired != nil {
+		c.onAuthExpired(err)
+	}
+	return err
+}
+
+// renewCreds re-derives the API credentials, reporting whether the client
+// now has fresh ones. Concurrent rejections re-derive one at a time.
+func (c *Client) renewCreds() bool {
+	if c.renewWallet == nil {
+		return false
+	}
+	c.renewMu.Lock()
+	defer c.renewMu.Unlock()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	if serverTime, err := c.GetServerTime(); err == nil {
+		timestamp = strconv.FormatInt(serverTime.Unix(), 10)
+	}
+	creds, err := c.DeriveAPICreds(c.renewWallet, c.renewChainID, timestamp)
+	if err != nil {
+		log.Printf("[clob] failed to re-derive API credentials: %v", err)
+		return false
+	}
+
+	c.credsMu.Lock()
+	c.apiKey, c.secret, c.passphrase = creds.APIKey, creds.Secret, creds.Passphrase
+	c.credsMu.Unlock()
+	if c.renewBuilder != nil {
+		c.renewBuilder.SetAPIKey(creds.APIKey)
+	}
+	log.Printf("[clob] API credentials rejected, re-derived them for %s", c.renewWallet.AddressHex())
+	return true
+}
@@ -0,0 +1,91 @@
+package clob
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+func TestDoRequest_AuthExpired(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.Error(w, `{"error":"Unauthorized/Invalid api key"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	var reported []error
+	c := newRetryTestClient(srv.URL).OnAuthExpired(func(err error) { reported = append(reported, err) })
+
+	if _, err := c.GetOpenOrders(); !errors.Is(err, ErrAuthExpired) {
+		t.Errorf("GetOpenOrders error = %v, want ErrAuthExpired", err)
+	}
+
+	// A rejected order is never retried, and its salt may be reused
+	atomic.StoreInt32(&hits, 0)
+	order := &OrderRequest{Order: Order{Salt: 21}}
+	if _, err := c.CreateOrder(order); !errors.Is(err, ErrAuthExpired) {
+		t.Errorf("CreateOrder error = %v, want ErrAuthExpired", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("order sent %d times, want 1", got)
+	}
+	if !c.claimSalt(21) {
+		t.Error("salt of the rejected order is still claimed")
+	}
+
+	if len(reported) != 2 || !errors.Is(reported[0], ErrAuthExpired) {
+		t.Errorf("OnAuthExpired got %v, want both failures", reported)
+	}
+}
+
+func TestDoRequest_RenewsCredentials(t *testing.T) {
+	w, err := wallet.NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	var derived int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/time":
+			rw.Write([]byte(`1700000000`))
+		case r.URL.Path == "/auth/derive-api-key":
+			atomic.AddInt32(&derived, 1)
+			rw.Write([]byte(`{"apiKey":"key-2","secret":"c2VjcmV0","passphrase":"pass-2"}`))
+		case r.Header.Get(headerAPIKey) != "key-2":
+			http.Error(rw, `{"error":"Unauthorized/Invalid api key"}`, http.StatusUnauthorized)
+		default:
+			rw.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	builder := NewOrderBuilder(w, "key-1")
+	expired := false
+	c := newRetryTestClient(srv.URL).
+		WithCredentialRenewal(w, 137, builder).
+		OnAuthExpired(func(error) { expired = true })
+
+	if _, err := c.GetOpenOrders(); err != nil {
+		t.Fatalf("GetOpenOrders: %v", err)
+	}
+	if atomic.LoadInt32(&derived) != 1 {
+		t.Errorf("derived credentials %d times, want 1", derived)
+	}
+	if expired {
+		t.Error("renewed credentials were reported as expired")
+	}
+	if got := builder.ownerKey(); got != "key-2" {
+		t.Errorf("builder owner = %q, want the renewed key-2", got)
+	}
+
+	// The new credentials stick
+	if _, err := c.GetOpenOrders(); err != nil || atomic.LoadInt32(&derived) != 1 {
+		t.Errorf("second request: err %v after %d derivations, want no new one", err, derived)
+	}
+}
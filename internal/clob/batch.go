@@ -0,0 +1,174 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// MaxBatchOrders is the most orders the CLOB accepts in one batch request.
+const MaxBatchOrders = 15
+
+// BatchOrderer submits several orders in one request. *Client and
+// *AccountPool implement it.
+type BatchOrderer interface {
+	CreateOrders(orders []*OrderRequest) ([]*OrderResponse, error)
+}
+
+var (
+	_ BatchOrderer = (*Client)(nil)
+	_ BatchOrderer = (*AccountPool)(nil)
+)
+
+// CreateOrders submits up to MaxBatchOrders orders in one request. The
+// exchange accepts or rejects each order on its own: the responses line up
+// with orders, and a rejected order has Success false with its reason in
+// Error. An error is only returned when the batch as a whole failed.
+//
+// Network errors and 5xx responses are retried like CreateOrderCtx's, with
+// the same signed orders, so any the exchange already took are rejected as
+// duplicates rather than placed twice. A single order goes through
+// CreateOrderCtx itself.
+func (c *Client) CreateOrders(orders []*OrderRequest) ([]*OrderResponse, error) {
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	if len(orders) == 1 {
+		resp, err := c.CreateOrderCtx(context.Background(), orders[0])
+		if err != nil {
+			return nil, err
+		}
+		return []*OrderResponse{resp}, nil
+	}
+	if len(orders) > MaxBatchOrders {
+		return nil, fmt.Errorf("batch of %d orders exceeds the limit of %d", len(orders), MaxBatchOrders)
+	}
+
+	body, err := json.Marshal(orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal orders: %w", err)
+	}
+
+	for i, order := range orders {
+		if !c.claimSalt(order.Order.Salt) {
+			for _, claimed := range orders[:i] {
+				c.releaseSalt(claimed.Order.Salt)
+			}
+			return nil, fmt.Errorf("%w (salt %d)", ErrDuplicateOrder, order.Order.Salt)
+		}
+	}
+
+	var lastErr error
+	ambiguous := false
+	for attempt := 0; attempt <= c.orderRetries; attempt++ {
+		if attempt > 0 {
+			if err := c.waitRetry(context.Background(), attempt); err != nil {
+				break
+			}
+			log.Printf("[clob] retrying batch of %d orders (%d/%d): %v", len(orders), attempt, c.orderRetries, lastErr)
+		}
+
+		resps, err := c.submitOrders(body, len(orders))
+		if err == nil {
+			for i, resp := range resps {
+				// A rejected order never reached the book, so its salt may be
+				// reused, unless an earlier attempt may have placed it
+				if !resp.Success {
+					if !ambiguous {
+						c.releaseSalt(orders[i].Order.Salt)
+					}
+					continue
+				}
+				c.checkOrderID(orders[i], resp)
+			}
+			return resps, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			break
+		}
+		// The exchange may have accepted this attempt
+		ambiguous = true
+	}
+
+	// A clean rejection never reached the book, so the salts may be reused
+	if !ambiguous {
+		for _, order := range orders {
+			c.releaseSalt(order.Order.Salt)
+		}
+	}
+	return nil, lastErr
+}
+
+// submitOrders posts a marshalled batch of n orders once. Failures that may
+// not have been processed by the exchange are wrapped as retryable.
+func (c *Client) submitOrders(body []byte, n int) ([]*OrderResponse, error) {
+	resp, err := c.doRequestCtx(context.Background(), http.MethodPost, "/orders", body)
+	if err != nil {
+		err = fmt.Errorf("failed to create orders: %w", err)
+		if errors.Is(err, ErrAuthExpired) {
+			return nil, err
+		}
+		return nil, &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &retryableError{err: fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))}
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var orderResps []*OrderResponse
+	if err := json.Unmarshal(respBody, &orderResps); err != nil {
+		return nil, fmt.Errorf("failed to decode orders response: %w (body: %s)", err, string(respBody))
+	}
+	if len(orderResps) != n {
+		return nil, &retryableError{err: fmt.Errorf("got %d responses for %d orders (body: %s)", len(orderResps), n, string(respBody))}
+	}
+	for i, r := range orderResps {
+		if r == nil {
+			orderResps[i] = &OrderResponse{Error: "no response for order"}
+		}
+	}
+	return orderResps, nil
+}
+
+// SubmitOrders submits orders through oc, in batches of MaxBatchOrders when
+// it implements BatchOrderer and one at a time otherwise. It always returns
+// one response per order: an order whose submission failed gets a response
+// with Success false and the error.
+func SubmitOrders(oc CLOBClient, orders []*OrderRequest) []*OrderResponse {
+	resps := make([]*OrderResponse, len(orders))
+
+	batcher, ok := oc.(BatchOrderer)
+	if !ok {
+		for i, order := range orders {
+			resp, err := oc.CreateOrder(order)
+			if err != nil {
+				resp = &OrderResponse{Error: err.Error()}
+			}
+			resps[i] = resp
+		}
+		return resps
+	}
+
+	for start := 0; start < len(orders); start += MaxBatchOrders {
+		end := min(start+MaxBatchOrders, len(orders))
+		batch, err := batcher.CreateOrders(orders[start:end])
+		for i := start; i < end; i++ {
+			if err != nil {
+				resps[i] = &OrderResponse{Error: err.Error()}
+			} else {
+				resps[i] = batch[i-start]
+			}
+		}
+	}
+	return resps
+}
@@ -0,0 +1,153 @@
+package clob
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCreateOrders_MixedBatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/orders" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var orders []OrderRequest
+		if err := json.Unmarshal(body, &orders); err != nil || len(orders) != 3 {
+			http.Error(w, `{"error":"bad batch"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`[
+			{"success":true,"orderID":"0xaaa"},
+			{"success":false,"error":"not enough balance / allowance"},
+			{"success":true,"orderID":"0xccc"}
+		]`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	resps, err := c.CreateOrders([]*OrderRequest{
+		{Order: Order{Salt: 1, TokenID: "1"}, OrderType: string(OrderTypeGTC)},
+		{Order: Order{Salt: 2, TokenID: "2"}, OrderType: string(OrderTypeGTC)},
+		{Order: Order{Salt: 3, TokenID: "3"}, OrderType: string(OrderTypeGTC)},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if len(resps) != 3 {
+		t.Fatalf("got %d responses, want 3", len(resps))
+	}
+	if !resps[0].Success || resps[0].OrderID != "0xaaa" {
+		t.Errorf("first = %+v, want placed as 0xaaa", resps[0])
+	}
+	if resps[1].Success || resps[1].Error != "not enough balance / allowance" {
+		t.Errorf("second = %+v, want rejected with the exchange's reason", resps[1])
+	}
+	if !resps[2].Success || resps[2].OrderID != "0xccc" {
+		t.Errorf("third = %+v, want placed as 0xccc", resps[2])
+	}
+}
+
+func TestCreateOrders_RejectedBatchReleasesSalts(t *testing.T) {
+	status := int32(http.StatusBadRequest)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+		w.Write([]byte(`[{"success":true,"orderID":"0xaaa"},{"success":true,"orderID":"0xbbb"}]`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	orders := []*OrderRequest{{Order: Order{Salt: 11}}, {Order: Order{Salt: 12}}}
+	if _, err := c.CreateOrders(orders); err == nil {
+		t.Fatal("expected the rejected batch to fail")
+	}
+
+	// Nothing reached the book, so the same signed orders may be resent
+	atomic.StoreInt32(&status, http.StatusOK)
+	resps, err := c.CreateOrders(orders)
+	if err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+	if len(resps) != 2 || !resps[0].Success || !resps[1].Success {
+		t.Errorf("resubmit = %+v, want both placed", resps)
+	}
+
+	// Once accepted, neither may be sent again
+	if _, err := c.CreateOrders(orders[1:]); err == nil {
+		t.Error("accepted salt was resubmitted")
+	}
+}
+
+func TestCreateOrders_RetriesServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`[{"success":true,"orderID":"0xaaa"},{"success":true,"orderID":"0xbbb"}]`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	resps, err := c.CreateOrders([]*OrderRequest{{Order: Order{Salt: 21}}, {Order: Order{Salt: 22}}})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if len(resps) != 2 || !resps[0].Success || !resps[1].Success {
+		t.Errorf("resps = %+v, want both placed", resps)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("calls = %d, want 2", got)
+	}
+}
+
+func TestCreateOrders_ReleasesRejectedSalts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"success":true,"orderID":"0xaaa"},{"success":false,"error":"not enough balance / allowance"}]`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	if _, err := c.CreateOrders([]*OrderRequest{{Order: Order{Salt: 31}}, {Order: Order{Salt: 32}}}); err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if c.claimSalt(31) {
+		t.Error("salt of the placed order was released")
+	}
+	if !c.claimSalt(32) {
+		t.Error("salt of the rejected order is still claimed")
+	}
+}
+
+func TestCreateOrders_SingleOrderUsesOrderEndpoint(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/order" {
+			http.NotFound(w, r)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true,"orderID":"0xaaa"}`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	resps, err := c.CreateOrders([]*OrderRequest{{Order: Order{Salt: 41}}})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if len(resps) != 1 || resps[0].OrderID != "0xaaa" {
+		t.Errorf("resps = %+v, want the one order placed", resps)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("calls = %d, want the 503 retried once", got)
+	}
+}
@@ -0,0 +1,40 @@
+package clob
+
+import (
+	"sort"
+	"strconv"
+)
+
+// SweepBids works out a sell of up to size shares into the bids at or above
+// minPrice, best first. It returns the worst price the sell reaches, which
+// is the limit a fill-or-kill order needs, and how many shares the bids
+// there can absorb. fillable is 0 when no bid clears minPrice. Levels are
+// sorted here, so the API's ordering doesn't matter.
+func (ob *OrderBook) SweepBids(size, minPrice float64) (price, fillable float64) {
+	type level struct{ price, size float64 }
+	levels := make([]level, 0, len(ob.Bids))
+	for _, l := range ob.Bids {
+		p, err := strconv.ParseFloat(l.Price, 64)
+		if err != nil || p < minPrice || p <= 0 {
+			continue
+		}
+		s, err := strconv.ParseFloat(l.Size, 64)
+		if err != nil || s <= 0 {
+			continue
+		}
+		levels = append(levels, level{p, s})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].price > levels[j].price })
+
+	for _, l := range levels {
+		if fillable >= size {
+			break
+		}
+		price = l.price
+		fillable += l.size
+	}
+	if fillable > size {
+		fillable = size
+	}
+	return price, fillable
+}
@@ -0,0 +1,40 @@
+package clob
+
+import "testing"
+
+func TestOrderBook_SweepBids(t *testing.T) {
+	// Ascending, as the CLOB sends them
+	book := &OrderBook{Bids: []PriceLevel{
+		{Price: "0.05", Size: "1000"},
+		{Price: "0.40", Size: "30"},
+		{Price: "0.45", Size: "20"},
+		{Price: "0.47", Size: "10"},
+	}}
+
+	tests := []struct {
+		name         string
+		size         float64
+		minPrice     float64
+		wantPrice    float64
+		wantFillable float64
+	}{
+		{"best bid covers it", 8, 0.01, 0.47, 8},
+		{"sweeps levels", 25, 0.01, 0.45, 25},
+		{"sweeps into the floor", 100, 0.01, 0.05, 100},
+		{"min price stops the sweep", 100, 0.10, 0.40, 60},
+		{"nothing above min price", 10, 0.50, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, fillable := book.SweepBids(tt.size, tt.minPrice)
+			if price != tt.wantPrice || fillable != tt.wantFillable {
+				t.Errorf("SweepBids(%v, %v) = %v, %v; want %v, %v",
+					tt.size, tt.minPrice, price, fillable, tt.wantPrice, tt.wantFillable)
+			}
+		})
+	}
+
+	if price, fillable := (&OrderBook{}).SweepBids(10, 0); price != 0 || fillable != 0 {
+		t.Errorf("empty book = %v, %v; want 0, 0", price, fillable)
+	}
+}
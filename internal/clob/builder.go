@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dantezy/polymarket-sniper/internal/pricing"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -18,35 +20,112 @@ const (
 	defaultFeeRateBps = 0
 	// Default expiration (1 hour from now)
 	defaultExpirationSeconds = 3600
-	// Tick size for prices - Polymarket minimum is 1 cent (0.01)
+	// Tick size for prices when the market's own tick is unknown (1 cent)
 	tickSize = 0.01
+	// Finest tick Polymarket supports; prices are computed in these units
+	minSupportedTickSize = 0.0001
+	priceUnitsPerDollar  = 10000
+	// Sizes are sent in hundredths of a share
+	sizeUnitsPerShare = 100
 )
 
+// MinOrderShares is the smallest size the CLOB accepts for resting
+// (GTC/GTD) orders when the market's own minimum is unknown.
+const MinOrderShares = 5.0
+
+// RoundingMode controls how BuildOrder rounds a size to the CLOB's
+// 0.01-share precision. Prices are always rounded to the nearest tick first
+// and both amounts are derived from the rounded size and price, so the
+// implied price of the signed order is exactly the tick price.
+type RoundingMode int
+
+const (
+	// RoundFloor truncates, so an order never exceeds the requested size or
+	// budget. This is the default.
+	RoundFloor RoundingMode = iota
+	// RoundNearest rounds half up, so an order may exceed the requested size
+	// by up to half a hundredth of a share.
+	RoundNearest
+)
+
+// String returns the mode's config name.
+func (m RoundingMode) String() string {
+	if m == RoundNearest {
+		return "nearest"
+	}
+	return "floor"
+}
+
+// ParseRoundingMode parses "floor" or "nearest". Empty means floor.
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "floor":
+		return RoundFloor, nil
+	case "nearest":
+		return RoundNearest, nil
+	default:
+		return RoundFloor, fmt.Errorf("invalid rounding mode %q: must be floor or nearest", s)
+	}
+}
+
+// RoundSize rounds size to the CLOB's 0.01-share precision.
+func RoundSize(size float64, mode RoundingMode) float64 {
+	return float64(sizeUnits(size, mode)) / sizeUnitsPerShare
+}
+
+// sizeUnits converts size to hundredths of a share.
+func sizeUnits(size float64, mode RoundingMode) int64 {
+	rounded := pricing.RoundShares(size, pricing.ShareDecimals)
+	if mode == RoundNearest {
+		rounded = pricing.RoundSharesNearest(size, pricing.ShareDecimals)
+	}
+	return int64(math.Round(rounded * sizeUnitsPerShare))
+}
+
+// TickSizer looks up the tick size of a token's market. *Client implements it.
+type TickSizer interface {
+	GetTickSize(tokenID string) (float64, error)
+}
+
+// MinOrderSizer looks up the minimum order size of a token's market.
+// *Client implements it.
+type MinOrderSizer interface {
+	GetMinOrderSize(tokenID string) (float64, error)
+}
+
+// MinOrderSize returns the minimum resting order size for a token, falling
+// back to MinOrderShares when ms is nil or the lookup fails.
+func MinOrderSize(ms MinOrderSizer, tokenID string) float64 {
+	if ms == nil {
+		return MinOrderShares
+	}
+	size, err := ms.GetMinOrderSize(tokenID)
+	if err != nil || size <= 0 {
+		return MinOrderShares
+	}
+	return size
+}
+
 // OrderBuilder constructs and signs orders for the CLOB.
 type OrderBuilder struct {
 	signer        *wallet.Signer // Standard CTF Exchange signer
 	negRiskSigner *wallet.Signer // Neg Risk CTF Exchange signer
 	maker         common.Address // The maker/funder address (proxy wallet if set, else EOA)
 	signerAddr    common.Address // The EOA that signs orders
-	apiKey        string         // API key used as owner for orders
+	apiKeyMu      sync.RWMutex
+	apiKey        string // API key used as owner for orders
 	nonce         *big.Int
-	signatureType uint8 // 0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+	negRiskNonce  *big.Int      // Neg Risk exchange nonce, nonce is the standard one
+	signatureType uint8         // 0=EOA, 1=POLY_PROXY, 2=GNOSIS_SAFE
+	tickSizes     TickSizer     // Optional per-market tick lookup
+	minSizes      MinOrderSizer // Optional per-market minimum size lookup
+	rounding      RoundingMode  // How sizes round to 0.01 shares
 }
 
 // NewOrderBuilder creates a new OrderBuilder with the given wallet and API key.
 // This creates an EOA-mode builder (signature type 0).
 func NewOrderBuilder(w *wallet.Wallet, apiKey string) *OrderBuilder {
-	signer := wallet.NewSigner(w)
-	negRiskSigner := wallet.NewSignerWithConfig(w, wallet.ChainID, wallet.NegRiskExchangeContract)
-	return &OrderBuilder{
-		signer:        signer,
-		negRiskSigner: negRiskSigner,
-		maker:         w.Address(),
-		signerAddr:    w.Address(),
-		apiKey:        apiKey,
-		nonce:         big.NewInt(0),
-		signatureType: wallet.SignatureTypeEOA, // Type 0
-	}
+	return newOrderBuilder(w, apiKey, wallet.ChainID, wallet.ExchangeContract, wallet.NegRiskExchangeContract)
 }
 
 // NewOrderBuilderWithProxy creates an OrderBuilder that uses a Polymarket proxy wallet.
@@ -54,9 +133,11 @@ func NewOrderBuilder(w *wallet.Wallet, apiKey string) *OrderBuilder {
 // signatureType should be:
 //   - 1 (POLY_PROXY) for Magic Link email/Google login accounts
 //   - 2 (GNOSIS_SAFE) for browser wallet (MetaMask) connected accounts
+//
+// Orders on either exchange are made by the proxy wallet and signed by the
+// EOA, against that exchange's own EIP-712 domain.
 func NewOrderBuilderWithProxy(w *wallet.Wallet, apiKey string, proxyWalletAddress common.Address, signatureType int) *OrderBuilder {
-	signer := wallet.NewSigner(w)
-	negRiskSigner := wallet.NewSignerWithConfig(w, wallet.ChainID, wallet.NegRiskExchangeContract)
+	b := newOrderBuilder(w, apiKey, wallet.ChainID, wallet.ExchangeContract, wallet.NegRiskExchangeContract)
 
 	// Validate signature type, default to GNOSIS_SAFE if invalid
 	sigType := uint8(signatureType)
@@ -64,33 +145,100 @@ func NewOrderBuilderWithProxy(w *wallet.Wallet, apiKey string, proxyWalletAddres
 		sigType = wallet.SignatureTypePolyGnosis // Default to type 2
 	}
 
-	return &OrderBuilder{
-		signer:        signer,
-		negRiskSigner: negRiskSigner,
-		maker:         proxyWalletAddress, // The proxy wallet is the maker/funder
-		signerAddr:    w.Address(),        // The EOA signs the orders
-		apiKey:        apiKey,
-		nonce:         big.NewInt(0),
-		signatureType: sigType,
-	}
+	b.maker = proxyWalletAddress // The proxy wallet is the maker/funder
+	b.signatureType = sigType
+	return b
 }
 
 // NewOrderBuilderWithConfig creates an OrderBuilder with custom chain configuration.
 // Use this for testnet deployments.
-func NewOrderBuilderWithConfig(w *wallet.Wallet, apiKey string, chainID int64, exchangeAddress common.Address) *OrderBuilder {
-	signer := wallet.NewSignerWithConfig(w, chainID, exchangeAddress)
+func NewOrderBuilderWithConfig(w *wallet.Wallet, apiKey string, chainID int64, exchangeAddress, negRiskExchangeAddress common.Address) *OrderBuilder {
+	return newOrderBuilder(w, apiKey, chainID, exchangeAddress, negRiskExchangeAddress)
+}
+
+// newOrderBuilder creates an EOA-mode builder signing for the given
+// exchanges, which the exported constructors then adjust.
+func newOrderBuilder(w *wallet.Wallet, apiKey string, chainID int64, exchange, negRiskExchange common.Address) *OrderBuilder {
 	return &OrderBuilder{
-		signer: signer,
-		maker:  w.Address(),
-		apiKey: apiKey,
-		nonce:  big.NewInt(0),
+		signer:        wallet.NewSignerWithConfig(w, chainID, exchange),
+		negRiskSigner: wallet.NewSignerWithConfig(w, chainID, negRiskExchange),
+		maker:         w.Address(),
+		signerAddr:    w.Address(), // The EOA always signs, even for a proxy maker
+		apiKey:        apiKey,
+		nonce:         big.NewInt(0),
+		negRiskNonce:  big.NewInt(0),
+		signatureType: wallet.SignatureTypeEOA, // Type 0
 	}
 }
 
-// SetNonce sets the nonce for subsequent orders.
-// The CLOB uses nonce for order cancellation groups.
+// WithTickSizes makes BuildOrder look up each market's tick size when
+// BuildParams.TickSize is not set, instead of assuming the default.
+func (b *OrderBuilder) WithTickSizes(ts TickSizer) *OrderBuilder {
+	b.tickSizes = ts
+	return b
+}
+
+// WithMinOrderSizes makes BuildOrder check resting orders against each
+// market's own minimum size instead of MinOrderShares.
+func (b *OrderBuilder) WithMinOrderSizes(ms MinOrderSizer) *OrderBuilder {
+	b.minSizes = ms
+	return b
+}
+
+// WithRoundingMode sets how BuildOrder rounds sizes (default RoundFloor).
+func (b *OrderBuilder) WithRoundingMode(mode RoundingMode) *OrderBuilder {
+	b.rounding = mode
+	return b
+}
+
+// RoundSize rounds size the way BuildOrder will, so callers can size
+// orders and account for cost using the shares actually submitted.
+func (b *OrderBuilder) RoundSize(size float64) float64 {
+	return RoundSize(size, b.rounding)
+}
+
+// SetAPIKey sets the API key later orders name as their owner, as after
+// the client's credentials were re-derived.
+func (b *OrderBuilder) SetAPIKey(apiKey string) {
+	b.apiKeyMu.Lock()
+	b.apiKey = apiKey
+	b.apiKeyMu.Unlock()
+}
+
+// ownerKey returns the API key orders are owned by.
+func (b *OrderBuilder) ownerKey() string {
+	b.apiKeyMu.RLock()
+	defer b.apiKeyMu.RUnlock()
+	return b.apiKey
+}
+
+// SetNonce sets the nonce for subsequent orders on both exchanges.
+//
+// Every order carries its maker's exchange nonce, and the CTF Exchange only
+// fills orders whose nonce equals its current nonces(maker). Calling
+// incrementNonce() on-chain bumps that value, cancelling every outstanding
+// order signed with the old one at once: orders sharing a nonce form a
+// cancellation group. The standard and Neg Risk exchanges keep separate
+// nonces, so after a bump on either the builder has to be resynced (see
+// GetOnChainNonces and SetNonces) or its orders are rejected.
 func (b *OrderBuilder) SetNonce(nonce *big.Int) {
 	b.nonce = new(big.Int).Set(nonce)
+	b.negRiskNonce = new(big.Int).Set(nonce)
+}
+
+// SetNonces sets separate nonces for the standard and Neg Risk exchanges.
+func (b *OrderBuilder) SetNonces(standard, negRisk *big.Int) {
+	b.nonce = new(big.Int).Set(standard)
+	b.negRiskNonce = new(big.Int).Set(negRisk)
+}
+
+// nonceFor returns the nonce for orders on the standard or Neg Risk
+// exchange.
+func (b *OrderBuilder) nonceFor(negRisk bool) *big.Int {
+	if negRisk && b.negRiskNonce != nil {
+		return b.negRiskNonce
+	}
+	return b.nonce
 }
 
 // Address returns the wallet address used for orders.
@@ -98,6 +246,27 @@ func (b *OrderBuilder) Address() common.Address {
 	return b.maker
 }
 
+// DomainSeparator returns the EIP-712 domain separator used to sign orders
+// for the standard or neg risk exchange.
+func (b *OrderBuilder) DomainSeparator(negRisk bool) common.Hash {
+	if negRisk {
+		return b.negRiskSigner.DomainSeparator()
+	}
+	return b.signer.DomainSeparator()
+}
+
+// OrderHash returns the EIP-712 digest of an API order, as signed by this builder.
+func (b *OrderBuilder) OrderHash(order *Order, negRisk bool) (common.Hash, error) {
+	signable, err := order.ToSignable()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if negRisk {
+		return b.negRiskSigner.GetOrderHash(signable)
+	}
+	return b.signer.GetOrderHash(signable)
+}
+
 // BuildParams holds parameters for building an order.
 type BuildParams struct {
 	TokenID    string
@@ -105,9 +274,10 @@ type BuildParams struct {
 	Price      float64 // Price in range [0, 1]
 	Size       float64 // Size in USDC
 	OrderType  OrderType
-	Expiration int64 // Unix timestamp, 0 for default
-	FeeRateBps int   // Fee rate in basis points, -1 for default
-	NegRisk    bool  // True if market uses Neg Risk CTF Exchange
+	Expiration int64   // Unix timestamp, 0 for default
+	FeeRateBps int     // Fee rate in basis points, -1 for default
+	NegRisk    bool    // True if market uses Neg Risk CTF Exchange
+	TickSize   float64 // Market tick size, 0 to look it up or use the default
 }
 
 // BuildOrder creates a signed order request.
@@ -125,9 +295,11 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 		return nil, fmt.Errorf("failed to generate salt: %w", err)
 	}
 
+	tick := b.resolveTickSize(params)
+
 	// Calculate amounts using integer math to avoid float precision issues.
 	// Polymarket requirements:
-	// - Price must be at tick size (0.001) - implicit price from amounts must match
+	// - Price must be at the market's tick size - implicit price from amounts must match
 	// - BUY orders: makerAmount (USDC) calculated from size*price, takerAmount = size (tokens)
 	// - SELL orders: makerAmount = size (tokens), takerAmount (USDC) calculated from size*price
 	// - All amounts in wei (6 decimals)
@@ -137,19 +309,30 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 	// 2. Calculate amounts based on rounded price
 	// This ensures makerAmount/takerAmount = rounded_price (at tick size)
 
-	// Round price to tick size (0.001)
-	priceRounded := roundToTickSize(params.Price)
+	// Round price to the market's tick size
+	priceRounded := pricing.RoundToTick(params.Price, tick)
 
 	// Ensure rounded price is at least one tick (prevents 0 amount errors)
-	if priceRounded < tickSize {
-		return nil, fmt.Errorf("price %f rounds to less than minimum tick size %f", params.Price, tickSize)
+	if priceRounded < tick || priceRounded > 1-tick {
+		return nil, fmt.Errorf("price %f rounds to %f, outside [%g, %g] for tick size %g",
+			params.Price, priceRounded, tick, 1-tick, tick)
 	}
 
 	// Convert to integer representations for precise calculation
-	// priceInt = rounded_price * 1000 (milli-units, guaranteed integer since price is at tick)
-	// sizeInt = floor(size * 100) (centi-units, 2 decimal precision)
-	priceInt := int64(math.Round(priceRounded * 1000))
-	sizeInt := int64(math.Floor(params.Size * 100))
+	// priceInt = rounded_price * 10000 (guaranteed integer since every tick is a multiple of 0.0001)
+	// sizeInt = size in centi-units (2 decimal precision), rounded per the builder's mode
+	priceInt := int64(math.Round(priceRounded * priceUnitsPerDollar))
+	sizeInt := sizeUnits(params.Size, b.rounding)
+	if sizeInt <= 0 {
+		return nil, fmt.Errorf("size %f rounds to zero shares", params.Size)
+	}
+	if params.OrderType == OrderTypeGTC || params.OrderType == OrderTypeGTD {
+		minShares := MinOrderSize(b.minSizes, params.TokenID)
+		if sizeInt < int64(math.Round(minShares*sizeUnitsPerShare)) {
+			return nil, fmt.Errorf("size %f rounds to %.2f shares, below the %g-share minimum for resting orders",
+				params.Size, float64(sizeInt)/sizeUnitsPerShare, minShares)
+		}
+	}
 
 	// sizeWei = sizeInt * 10000 (convert centi-units to wei)
 	sizeWei := sizeInt * 10000
@@ -158,15 +341,15 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 
 	if params.Side == OrderSideBuy {
 		// Buying tokens: pay USDC, receive tokens
-		// costWei = sizeWei * priceInt / 1000
-		// This ensures costWei / sizeWei = priceInt / 1000 = priceRounded
-		costWei := (sizeWei * priceInt) / 1000
+		// costWei = sizeWei * priceInt / 10000
+		// This ensures costWei / sizeWei = priceInt / 10000 = priceRounded
+		costWei := (sizeWei * priceInt) / priceUnitsPerDollar
 		makerAmount = big.NewInt(costWei)
 		takerAmount = big.NewInt(sizeWei)
 	} else {
 		// Selling tokens: pay tokens, receive USDC
 		makerAmount = big.NewInt(sizeWei)
-		proceedsWei := (sizeWei * priceInt) / 1000
+		proceedsWei := (sizeWei * priceInt) / priceUnitsPerDollar
 		takerAmount = big.NewInt(proceedsWei)
 	}
 
@@ -200,6 +383,7 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 	// Type 1 (POLY_PROXY): Polymarket email/Google login
 	// Type 2 (GNOSIS_SAFE): Browser wallet (MetaMask) connected to Polymarket
 	sigType := b.signatureType
+	nonce := b.nonceFor(params.NegRisk)
 
 	// Build the order struct for signing
 	// For proxy wallet: maker = proxy wallet, signer = EOA
@@ -213,7 +397,7 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 		MakerAmount:   makerAmount,
 		TakerAmount:   takerAmount,
 		Expiration:    big.NewInt(expiration),
-		Nonce:         new(big.Int).Set(b.nonce),
+		Nonce:         new(big.Int).Set(nonce),
 		FeeRateBps:    big.NewInt(int64(feeRate)),
 		Side:          sideToUint8(params.Side),
 		SignatureType: sigType,
@@ -241,7 +425,7 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 		MakerAmount:   makerAmount.String(),
 		TakerAmount:   takerAmount.String(),
 		Expiration:    strconv.FormatInt(expiration, 10),
-		Nonce:         b.nonce.String(),
+		Nonce:         nonce.String(),
 		FeeRateBps:    strconv.Itoa(feeRate),
 		Side:          string(params.Side),
 		SignatureType: int(sigType),
@@ -250,7 +434,7 @@ func (b *OrderBuilder) BuildOrder(params BuildParams) (*OrderRequest, error) {
 
 	return &OrderRequest{
 		Order:     apiOrder,
-		Owner:     b.apiKey, // API key is used as owner
+		Owner:     b.ownerKey(), // API key is used as owner
 		OrderType: string(params.OrderType),
 	}, nil
 }
@@ -292,7 +476,8 @@ func (b *OrderBuilder) BuildGTCBuyOrder(tokenID string, price, size float64, neg
 }
 
 // BuildGTCSellOrder creates a good-till-cancelled sell order.
-func (b *OrderBuilder) BuildGTCSellOrder(tokenID string, price, size float64) (*OrderRequest, error) {
+// negRisk should be true if the market uses the Neg Risk CTF Exchange.
+func (b *OrderBuilder) BuildGTCSellOrder(tokenID string, price, size float64, negRisk bool) (*OrderRequest, error) {
 	return b.BuildOrder(BuildParams{
 		TokenID:    tokenID,
 		Side:       OrderSideSell,
@@ -300,9 +485,62 @@ func (b *OrderBuilder) BuildGTCSellOrder(tokenID string, price, size float64) (*
 		Size:       size,
 		OrderType:  OrderTypeGTC,
 		FeeRateBps: defaultFeeRateBps,
+		NegRisk:    negRisk,
 	})
 }
 
+// ToSignable converts an API order back into the struct that is hashed and
+// signed, so a built or returned order can be re-verified.
+func (o *Order) ToSignable() (*wallet.Order, error) {
+	parse := func(name, value string) (*big.Int, error) {
+		n, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: %q", name, value)
+		}
+		return n, nil
+	}
+
+	tokenID, err := parse("token ID", o.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	makerAmount, err := parse("maker amount", o.MakerAmount)
+	if err != nil {
+		return nil, err
+	}
+	takerAmount, err := parse("taker amount", o.TakerAmount)
+	if err != nil {
+		return nil, err
+	}
+	expiration, err := parse("expiration", o.Expiration)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := parse("nonce", o.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	feeRate, err := parse("fee rate", o.FeeRateBps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet.Order{
+		Salt:          big.NewInt(o.Salt),
+		Maker:         common.HexToAddress(o.Maker),
+		Signer:        common.HexToAddress(o.Signer),
+		Taker:         common.HexToAddress(o.Taker),
+		TokenID:       tokenID,
+		MakerAmount:   makerAmount,
+		TakerAmount:   takerAmount,
+		Expiration:    expiration,
+		Nonce:         nonce,
+		FeeRateBps:    feeRate,
+		Side:          sideToUint8(OrderSide(o.Side)),
+		SignatureType: uint8(o.SignatureType),
+	}, nil
+}
+
 // generateSalt generates a cryptographically random salt for order uniqueness.
 // Returns a random int64 in range [0, 2^32) to match official Polymarket implementation.
 func generateSalt() (*big.Int, error) {
@@ -315,10 +553,22 @@ func generateSalt() (*big.Int, error) {
 	return salt, nil
 }
 
-// roundToTickSize rounds a price to the nearest tick size (0.01 = 1 cent).
-// This ensures the price is valid for Polymarket's tick size rules.
-func roundToTickSize(price float64) float64 {
-	return math.Round(price/tickSize) * tickSize
+// resolveTickSize picks the tick for an order: the explicit param, then the
+// builder's lookup, then the default.
+func (b *OrderBuilder) resolveTickSize(params BuildParams) float64 {
+	tick := params.TickSize
+	if tick <= 0 && b.tickSizes != nil {
+		if t, err := b.tickSizes.GetTickSize(params.TokenID); err == nil {
+			tick = t
+		}
+	}
+	if tick <= 0 {
+		return tickSize
+	}
+	if tick < minSupportedTickSize {
+		return minSupportedTickSize
+	}
+	return tick
 }
 
 // floatToUSDCWei converts a float USDC amount to wei (6 decimals).
@@ -0,0 +1,511 @@
+package clob
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/pricing"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+const testTokenID = "123456789"
+
+type stubTickSizer struct {
+	tick  float64
+	err   error
+	calls int
+}
+
+func (s *stubTickSizer) GetTickSize(tokenID string) (float64, error) {
+	s.calls++
+	return s.tick, s.err
+}
+
+type stubMinOrderSizer struct {
+	size float64
+	err  error
+}
+
+func (s stubMinOrderSizer) GetMinOrderSize(tokenID string) (float64, error) {
+	return s.size, s.err
+}
+
+func newTestBuilder(t *testing.T) *OrderBuilder {
+	t.Helper()
+	w, err := wallet.NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	return NewOrderBuilder(w, "test-key")
+}
+
+func TestBuildOrder_TickSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		side      OrderSide
+		price     float64
+		tick      float64
+		wantMaker string
+		wantTaker string
+	}{
+		{"default tick rounds to cents", OrderSideBuy, 0.5555, 0, "5600000", "10000000"},
+		{"cent tick", OrderSideBuy, 0.5555, 0.01, "5600000", "10000000"},
+		{"tenth-cent tick", OrderSideBuy, 0.5555, 0.001, "5560000", "10000000"},
+		{"finest tick", OrderSideBuy, 0.5555, 0.0001, "5555000", "10000000"},
+		{"sell at tenth-cent tick", OrderSideSell, 0.9871, 0.001, "10000000", "9870000"},
+	}
+
+	b := newTestBuilder(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := b.BuildOrder(BuildParams{
+				TokenID:   testTokenID,
+				Side:      tt.side,
+				Price:     tt.price,
+				Size:      10,
+				OrderType: OrderTypeGTC,
+				TickSize:  tt.tick,
+			})
+			if err != nil {
+				t.Fatalf("BuildOrder: %v", err)
+			}
+			if req.Order.MakerAmount != tt.wantMaker || req.Order.TakerAmount != tt.wantTaker {
+				t.Errorf("amounts = %s/%s, want %s/%s",
+					req.Order.MakerAmount, req.Order.TakerAmount, tt.wantMaker, tt.wantTaker)
+			}
+		})
+	}
+}
+
+func TestBuildOrder_PriceOutsideTickRange(t *testing.T) {
+	b := newTestBuilder(t)
+
+	tests := []struct {
+		name  string
+		price float64
+		tick  float64
+	}{
+		{"rounds to zero", 0.004, 0.01},
+		{"rounds to one", 0.996, 0.01},
+		{"below tick", 0.0004, 0.001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := b.BuildOrder(BuildParams{
+				TokenID:   testTokenID,
+				Side:      OrderSideBuy,
+				Price:     tt.price,
+				Size:      10,
+				OrderType: OrderTypeGTC,
+				TickSize:  tt.tick,
+			})
+			if err == nil {
+				t.Errorf("BuildOrder(price=%v, tick=%v) succeeded, want error", tt.price, tt.tick)
+			}
+		})
+	}
+}
+
+func TestRoundSize(t *testing.T) {
+	tests := []struct {
+		size float64
+		mode RoundingMode
+		want float64
+	}{
+		{5.999, RoundFloor, 5.99},
+		{5.999, RoundNearest, 6.00},
+		{5.005, RoundFloor, 5.00},
+		{5.0049, RoundNearest, 5.00},
+		{0.29, RoundFloor, 0.29}, // 0.29*100 is 28.999... in float64
+		{4.9999999999, RoundFloor, 5.00},
+		{0.004, RoundFloor, 0},
+		{0.005, RoundNearest, 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.size, tt.mode), func(t *testing.T) {
+			if got := RoundSize(tt.size, tt.mode); got != tt.want {
+				t.Errorf("RoundSize(%v, %v) = %v, want %v", tt.size, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RoundingMode
+		wantErr bool
+	}{
+		{"", RoundFloor, false},
+		{"floor", RoundFloor, false},
+		{" Nearest ", RoundNearest, false},
+		{"ceil", RoundFloor, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRoundingMode(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRoundingMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRoundingMode(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildOrder_RoundingModeImpliedPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      RoundingMode
+		side      OrderSide
+		price     float64
+		tick      float64
+		size      float64
+		wantShare string // Share amount in wei
+	}{
+		{"floor just under a cent", RoundFloor, OrderSideBuy, 0.57, 0.01, 5.999, "5990000"},
+		{"nearest just under a cent", RoundNearest, OrderSideBuy, 0.57, 0.01, 5.999, "6000000"},
+		{"floor half cent", RoundFloor, OrderSideBuy, 0.333, 0.001, 5.005, "5000000"},
+		{"nearest half cent", RoundNearest, OrderSideBuy, 0.333, 0.001, 5.005, "5010000"},
+		{"floor odd size fine tick", RoundFloor, OrderSideBuy, 0.0123, 0.0001, 7.777, "7770000"},
+		{"sell floor", RoundFloor, OrderSideSell, 0.987, 0.001, 12.349, "12340000"},
+		{"sell nearest", RoundNearest, OrderSideSell, 0.987, 0.001, 12.349, "12350000"},
+		{"price rounded to tick first", RoundFloor, OrderSideBuy, 0.5555, 0.01, 5.01, "5010000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBuilder(t).WithRoundingMode(tt.mode)
+			req, err := b.BuildOrder(BuildParams{
+				TokenID:   testTokenID,
+				Side:      tt.side,
+				Price:     tt.price,
+				Size:      tt.size,
+				OrderType: OrderTypeGTC,
+				TickSize:  tt.tick,
+			})
+			if err != nil {
+				t.Fatalf("BuildOrder: %v", err)
+			}
+
+			usdc, shares := req.Order.MakerAmount, req.Order.TakerAmount
+			if tt.side == OrderSideSell {
+				usdc, shares = shares, usdc
+			}
+			if shares != tt.wantShare {
+				t.Errorf("share amount = %s, want %s", shares, tt.wantShare)
+			}
+
+			implied, ok := new(big.Rat).SetString(usdc + "/" + shares)
+			if !ok {
+				t.Fatalf("bad amounts %s/%s", usdc, shares)
+			}
+			want := big.NewRat(int64(math.Round(pricing.RoundToTick(tt.price, tt.tick)*priceUnitsPerDollar)), priceUnitsPerDollar)
+			if implied.Cmp(want) != 0 {
+				t.Errorf("implied price = %s, want %s", implied.FloatString(6), want.FloatString(4))
+			}
+		})
+	}
+}
+
+func TestBuildOrder_MinimumSize(t *testing.T) {
+	b := newTestBuilder(t)
+
+	tests := []struct {
+		name      string
+		size      float64
+		orderType OrderType
+		wantErr   bool
+	}{
+		{"gtc at minimum", 5, OrderTypeGTC, false},
+		{"gtc float error at minimum", 4.9999999999, OrderTypeGTC, false},
+		{"gtc floors below minimum", 4.999, OrderTypeGTC, true},
+		{"gtd below minimum", 3, OrderTypeGTD, true},
+		{"fok below minimum", 3, OrderTypeFOK, false},
+		{"rounds to zero", 0.004, OrderTypeFOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := b.BuildOrder(BuildParams{
+				TokenID:   testTokenID,
+				Side:      OrderSideBuy,
+				Price:     0.5,
+				Size:      tt.size,
+				OrderType: tt.orderType,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildOrder(size=%v, %s) error = %v, wantErr %v", tt.size, tt.orderType, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildOrder_MarketMinimumSize(t *testing.T) {
+	b := newTestBuilder(t).WithMinOrderSizes(stubMinOrderSizer{size: 15})
+
+	build := func(size float64, orderType OrderType) error {
+		_, err := b.BuildOrder(BuildParams{
+			TokenID:   testTokenID,
+			Side:      OrderSideBuy,
+			Price:     0.5,
+			Size:      size,
+			OrderType: orderType,
+		})
+		return err
+	}
+
+	if err := build(10, OrderTypeGTC); err == nil || !strings.Contains(err.Error(), "15-share minimum") {
+		t.Errorf("10 shares against a 15-share market: err = %v, want minimum size error", err)
+	}
+	if err := build(15, OrderTypeGTC); err != nil {
+		t.Errorf("15 shares against a 15-share market: %v", err)
+	}
+	if err := build(10, OrderTypeFOK); err != nil {
+		t.Errorf("FOK orders skip the resting minimum: %v", err)
+	}
+}
+
+func TestMinOrderSize_FallsBack(t *testing.T) {
+	tests := []struct {
+		name string
+		ms   MinOrderSizer
+		want float64
+	}{
+		{"no lookup", nil, MinOrderShares},
+		{"market minimum", stubMinOrderSizer{size: 1}, 1},
+		{"lookup error", stubMinOrderSizer{err: errors.New("down")}, MinOrderShares},
+		{"zero reported", stubMinOrderSizer{}, MinOrderShares},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinOrderSize(tt.ms, testTokenID); got != tt.want {
+				t.Errorf("MinOrderSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTickSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		param     float64
+		lookup    *stubTickSizer
+		want      float64
+		wantCalls int
+	}{
+		{"no lookup uses default", 0, nil, tickSize, 0},
+		{"explicit param skips lookup", 0.001, &stubTickSizer{tick: 0.01}, 0.001, 0},
+		{"lookup used when param unset", 0, &stubTickSizer{tick: 0.001}, 0.001, 1},
+		{"lookup error falls back to default", 0, &stubTickSizer{err: errors.New("down")}, tickSize, 1},
+		{"clamped to finest tick", 0.00001, nil, minSupportedTickSize, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBuilder(t)
+			if tt.lookup != nil {
+				b.WithTickSizes(tt.lookup)
+			}
+			got := b.resolveTickSize(BuildParams{TokenID: testTokenID, TickSize: tt.param})
+			if got != tt.want {
+				t.Errorf("resolveTickSize() = %v, want %v", got, tt.want)
+			}
+			if tt.lookup != nil && tt.lookup.calls != tt.wantCalls {
+				t.Errorf("lookup calls = %d, want %d", tt.lookup.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGetTickSize_Cached(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/tick-size" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"minimum_tick_size": 0.001}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		tick, err := c.GetTickSize(testTokenID)
+		if err != nil {
+			t.Fatalf("GetTickSize: %v", err)
+		}
+		if tick != 0.001 {
+			t.Errorf("tick = %v, want 0.001", tick)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hits = %d, want 1", got)
+	}
+}
+
+func TestGetTickSize_Invalid(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"minimum_tick_size": 1.5}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+	if _, err := c.GetTickSize(testTokenID); err == nil {
+		t.Error("expected error for out-of-range tick size")
+	}
+}
+
+func TestGetMinOrderSize_Cached(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/book" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"bids":[],"asks":[],"min_order_size":"15","tick_size":"0.01"}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		size, err := c.GetMinOrderSize(testTokenID)
+		if err != nil {
+			t.Fatalf("GetMinOrderSize: %v", err)
+		}
+		if size != 15 {
+			t.Errorf("size = %v, want 15", size)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hits = %d, want 1", got)
+	}
+}
+
+func TestGetFeeRateBps_Cached(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.URL.Path != "/fee-rate" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"base_fee": 200}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+
+	for i := 0; i < 3; i++ {
+		bps, err := c.GetFeeRateBps(testTokenID)
+		if err != nil {
+			t.Fatalf("GetFeeRateBps: %v", err)
+		}
+		if bps != 200 {
+			t.Errorf("bps = %d, want 200", bps)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hits = %d, want 1", got)
+	}
+}
+
+func TestBuildOrder_ProxyNegRisk(t *testing.T) {
+	w, err := wallet.NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	proxy := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	b := NewOrderBuilderWithProxy(w, "test-key", proxy, int(wallet.SignatureTypePolyGnosis))
+
+	req, err := b.BuildOrder(BuildParams{
+		TokenID:   testTokenID,
+		Side:      OrderSideBuy,
+		Price:     0.5,
+		Size:      10,
+		OrderType: OrderTypeGTC,
+		NegRisk:   true,
+	})
+	if err != nil {
+		t.Fatalf("BuildOrder: %v", err)
+	}
+	if req.Order.Maker != strings.ToLower(proxy.Hex()) {
+		t.Errorf("maker = %s, want the proxy %s", req.Order.Maker, proxy.Hex())
+	}
+	if req.Order.Signer != strings.ToLower(w.AddressHex()) {
+		t.Errorf("signer = %s, want the EOA %s", req.Order.Signer, w.AddressHex())
+	}
+	if req.Order.SignatureType != int(wallet.SignatureTypePolyGnosis) {
+		t.Errorf("signature type = %d, want %d", req.Order.SignatureType, wallet.SignatureTypePolyGnosis)
+	}
+
+	negRisk := wallet.NewSignerWithConfig(w, wallet.ChainID, wallet.NegRiskExchangeContract)
+	if b.DomainSeparator(true) != negRisk.DomainSeparator() {
+		t.Fatal("neg risk orders use the wrong domain separator")
+	}
+
+	// The signature must recover to the EOA over the neg risk domain only
+	signable, err := req.Order.ToSignable()
+	if err != nil {
+		t.Fatalf("ToSignable: %v", err)
+	}
+	sig, err := hexutil.Decode(req.Order.Signature)
+	if err != nil || len(sig) != 65 {
+		t.Fatalf("signature %q: %v", req.Order.Signature, err)
+	}
+	sig[64] -= 27
+	for _, s := range []struct {
+		name   string
+		signer *wallet.Signer
+		want   bool
+	}{
+		{"neg risk domain", negRisk, true},
+		{"standard domain", wallet.NewSigner(w), false},
+	} {
+		digest, err := s.signer.GetOrderHash(signable)
+		if err != nil {
+			t.Fatalf("GetOrderHash: %v", err)
+		}
+		pub, err := crypto.SigToPub(digest.Bytes(), sig)
+		if got := err == nil && crypto.PubkeyToAddress(*pub) == w.Address(); got != s.want {
+			t.Errorf("recovers to the EOA over the %s = %v, want %v", s.name, got, s.want)
+		}
+	}
+}
+
+func TestNewOrderBuilderWithConfig_SignsNegRisk(t *testing.T) {
+	w, err := wallet.NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	exchange := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	negRiskExchange := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	b := NewOrderBuilderWithConfig(w, "test-key", 80002, exchange, negRiskExchange)
+
+	req, err := b.BuildOrder(BuildParams{TokenID: testTokenID, Side: OrderSideBuy, Price: 0.5, Size: 10, OrderType: OrderTypeGTC, NegRisk: true})
+	if err != nil {
+		t.Fatalf("BuildOrder: %v", err)
+	}
+	if req.Order.Signer != strings.ToLower(w.AddressHex()) {
+		t.Errorf("signer = %s, want the EOA %s", req.Order.Signer, w.AddressHex())
+	}
+	if b.DomainSeparator(true) != wallet.NewSignerWithConfig(w, 80002, negRiskExchange).DomainSeparator() {
+		t.Error("neg risk domain separator doesn't use the configured neg risk exchange")
+	}
+}
@@ -0,0 +1,182 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateOrderCtx_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.CreateOrderCtx(ctx, &OrderRequest{})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected error from slow server")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("request took %v, want it cancelled near the 50ms deadline", elapsed)
+	}
+}
+
+func TestGetAllowance(t *testing.T) {
+	const spender = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+
+	tests := []struct {
+		name string
+		body string
+		want float64
+	}{
+		{"single allowance", `{"balance":"1000000","allowance":"2500000"}`, 2.5},
+		{"per-spender allowance", `{"balance":"1000000","allowances":{"0x4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e":"7000000"}}`, 7},
+		{"spender missing from map", `{"balance":"1000000","allowances":{"0xC5d563A36AE78145C45a50134d48A1215220f80a":"7000000"}}`, 0},
+		{"empty allowance", `{"balance":"1000000","allowance":""}`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+			got, err := c.GetAllowance(spender)
+			if err != nil {
+				t.Fatalf("GetAllowance: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("allowance = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"id":"0xabc","status":"CANCELED","original_size":"50","size_matched":"20.5"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+	order, err := c.GetOrder("0xabc")
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if path != "/data/order/0xabc" {
+		t.Errorf("path = %s, want /data/order/0xabc", path)
+	}
+	if order.Status != "CANCELED" || order.MatchedShares() != 20.5 {
+		t.Errorf("order = %s with %v matched, want CANCELED with 20.5", order.Status, order.MatchedShares())
+	}
+}
+
+func TestGetPrices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/prices" {
+			t.Errorf("request = %s %s, want POST /prices", r.Method, r.URL.Path)
+		}
+		var reqs []priceRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(reqs) != 6 {
+			t.Errorf("got %d price requests, want 6 (BUY and SELL per token)", len(reqs))
+		}
+		w.Write([]byte(`{
+			"111": {"BUY": "0.52", "SELL": "0.54"},
+			"222": {"BUY": "0.45", "SELL": "0.47"},
+			"333": {"BUY": "0.01"}
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(srv.URL)
+	prices, err := c.GetPrices([]string{"111", "222", "333"})
+	if err != nil {
+		t.Fatalf("GetPrices: %v", err)
+	}
+
+	want := map[string]PriceSides{
+		"111": {Bid: 0.52, Ask: 0.54},
+		"222": {Bid: 0.45, Ask: 0.47},
+		"333": {Bid: 0.01, Ask: 0},
+	}
+	if len(prices) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(prices), len(want))
+	}
+	for id, w := range want {
+		if prices[id] != w {
+			t.Errorf("prices[%s] = %+v, want %+v", id, prices[id], w)
+		}
+	}
+}
+
+func TestGetPrices_Empty(t *testing.T) {
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL("http://127.0.0.1:0")
+	prices, err := c.GetPrices(nil)
+	if err != nil || len(prices) != 0 {
+		t.Errorf("GetPrices(nil) = %v, %v, want empty map without a request", prices, err)
+	}
+}
+
+func TestGetServerTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    int64
+		wantErr bool
+	}{
+		{"plain", http.StatusOK, "1737801900", 1737801900, false},
+		{"trailing newline", http.StatusOK, "1737801900\n", 1737801900, false},
+		{"quoted", http.StatusOK, `"1737801900"`, 1737801900, false},
+		{"garbage", http.StatusOK, "soon", 0, true},
+		{"server error", http.StatusInternalServerError, `{"error":"down"}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/time" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got, err := NewClient("", "", "", "").WithBaseURL(srv.URL).GetServerTime()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetServerTime error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Unix() != tt.want {
+				t.Errorf("GetServerTime = %d, want %d", got.Unix(), tt.want)
+			}
+		})
+	}
+}
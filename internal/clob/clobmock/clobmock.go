@@ -0,0 +1,230 @@
+// Package clobmock provides an in-memory clob.CLOBClient for strategy tests.
+package clobmock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+)
+
+// Client answers CLOB calls from canned data and records submitted orders.
+// Set the exported fields before use; lookups for unknown tokens return
+// zero values, like an empty market.
+type Client struct {
+	OrderBooks  map[string]*clob.OrderBook
+	Prices      map[string]clob.PriceSides
+	NegRisk     map[string]bool
+	TickSizes   map[string]float64 // Unset tokens use 0.01
+	MinSizes    map[string]float64 // Unset tokens use clob.MinOrderShares
+	FeeRates    map[string]int
+	OpenOrders  []clob.Order
+	Statuses    map[string]*clob.OrderStatus // GetOrder answers, by order ID
+	USDCBalance float64
+	Allowance   float64
+	Err         error  // Returned by every call when set
+	Reject      string // When set, orders are recorded but rejected with this error
+
+	mu       sync.Mutex
+	orders   []*clob.OrderRequest
+	batches  int
+	canceled []string
+}
+
+var (
+	_ clob.CLOBClient   = (*Client)(nil)
+	_ clob.BatchOrderer = (*Client)(nil)
+)
+
+// New creates an empty mock.
+func New() *Client {
+	return &Client{
+		OrderBooks: make(map[string]*clob.OrderBook),
+		Prices:     make(map[string]clob.PriceSides),
+		NegRisk:    make(map[string]bool),
+		TickSizes:  make(map[string]float64),
+		MinSizes:   make(map[string]float64),
+		FeeRates:   make(map[string]int),
+		Statuses:   make(map[string]*clob.OrderStatus),
+	}
+}
+
+// GetOrderBook returns the canned book, or an empty one.
+func (c *Client) GetOrderBook(tokenID string) (*clob.OrderBook, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if book, ok := c.OrderBooks[tokenID]; ok {
+		return book, nil
+	}
+	return &clob.OrderBook{}, nil
+}
+
+// GetPrices returns the canned prices for the known tokens.
+func (c *Client) GetPrices(tokenIDs []string) (map[string]clob.PriceSides, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	prices := make(map[string]clob.PriceSides, len(tokenIDs))
+	for _, id := range tokenIDs {
+		if p, ok := c.Prices[id]; ok {
+			prices[id] = p
+		}
+	}
+	return prices, nil
+}
+
+// CreateOrder records the order and reports it placed.
+func (c *Client) CreateOrder(order *clob.OrderRequest) (*clob.OrderResponse, error) {
+	return c.CreateOrderCtx(context.Background(), order)
+}
+
+// CreateOrderCtx records the order and reports it placed, or rejected when
+// Reject is set.
+func (c *Client) CreateOrderCtx(ctx context.Context, order *clob.OrderRequest) (*clob.OrderResponse, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.orders = append(c.orders, order)
+	if c.Reject != "" {
+		return &clob.OrderResponse{Success: false, Error: c.Reject}, nil
+	}
+	return &clob.OrderResponse{Success: true, OrderID: fmt.Sprintf("mock-%d", len(c.orders))}, nil
+}
+
+// CreateOrders records the orders as one batch and places or rejects each
+// like CreateOrderCtx.
+func (c *Client) CreateOrders(orders []*clob.OrderRequest) ([]*clob.OrderResponse, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	c.mu.Lock()
+	c.batches++
+	c.mu.Unlock()
+
+	resps := make([]*clob.OrderResponse, len(orders))
+	for i, order := range orders {
+		resp, err := c.CreateOrderCtx(context.Background(), order)
+		if err != nil {
+			return nil, err
+		}
+		resps[i] = resp
+	}
+	return resps, nil
+}
+
+// CancelOrder records the cancellation.
+func (c *Client) CancelOrder(orderID string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.canceled = append(c.canceled, orderID)
+	return nil
+}
+
+// GetOpenOrders returns the canned open orders.
+func (c *Client) GetOpenOrders() ([]clob.Order, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.OpenOrders, nil
+}
+
+// GetOrder returns the canned status, or an error for unknown orders.
+func (c *Client) GetOrder(orderID string) (*clob.OrderStatus, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	status, ok := c.Statuses[orderID]
+	if !ok {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	return status, nil
+}
+
+// GetNegRisk returns the canned neg risk flag.
+func (c *Client) GetNegRisk(tokenID string) (bool, error) {
+	if c.Err != nil {
+		return false, c.Err
+	}
+	return c.NegRisk[tokenID], nil
+}
+
+// GetFeeRateBps returns the canned fee rate.
+func (c *Client) GetFeeRateBps(tokenID string) (int, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	return c.FeeRates[tokenID], nil
+}
+
+// GetTickSize returns the canned tick size, defaulting to 0.01.
+func (c *Client) GetTickSize(tokenID string) (float64, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	if tick, ok := c.TickSizes[tokenID]; ok {
+		return tick, nil
+	}
+	return 0.01, nil
+}
+
+// GetMinOrderSize returns the canned minimum order size, defaulting to
+// clob.MinOrderShares.
+func (c *Client) GetMinOrderSize(tokenID string) (float64, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	if size, ok := c.MinSizes[tokenID]; ok {
+		return size, nil
+	}
+	return clob.MinOrderShares, nil
+}
+
+// GetUSDCBalance returns USDCBalance.
+func (c *Client) GetUSDCBalance() (float64, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	return c.USDCBalance, nil
+}
+
+// GetAllowance returns Allowance for any spender.
+func (c *Client) GetAllowance(spender string) (float64, error) {
+	if c.Err != nil {
+		return 0, c.Err
+	}
+	return c.Allowance, nil
+}
+
+// Orders returns the orders submitted so far.
+func (c *Client) Orders() []*clob.OrderRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*clob.OrderRequest(nil), c.orders...)
+}
+
+// Batches returns the number of CreateOrders calls so far.
+func (c *Client) Batches() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batches
+}
+
+// Canceled returns the order IDs canceled so far.
+func (c *Client) Canceled() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.canceled...)
+}
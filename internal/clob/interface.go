@@ -0,0 +1,24 @@
+package clob
+
+import "context"
+
+// CLOBClient is the subset of the CLOB API the strategies use. *Client
+// implements it; clobmock provides an in-memory version for tests.
+type CLOBClient interface {
+	TickSizer
+	MinOrderSizer
+
+	GetOrderBook(tokenID string) (*OrderBook, error)
+	GetPrices(tokenIDs []string) (map[string]PriceSides, error)
+	CreateOrder(order *OrderRequest) (*OrderResponse, error)
+	CreateOrderCtx(ctx context.Context, order *OrderRequest) (*OrderResponse, error)
+	CancelOrder(orderID string) error
+	GetOpenOrders() ([]Order, error)
+	GetOrder(orderID string) (*OrderStatus, error)
+	GetNegRisk(tokenID string) (bool, error)
+	GetFeeRateBps(tokenID string) (int, error)
+	GetUSDCBalance() (float64, error)
+	GetAllowance(spender string) (float64, error)
+}
+
+var _ CLOBClient = (*Client)(nil)
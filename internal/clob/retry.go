@@ -0,0 +1,115 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const (
+	// Extra attempts after a transient order submission failure
+	defaultOrderRetries = 2
+	// First retry waits about this long; later retries double it
+	orderRetryBaseDelay = 100 * time.Millisecond
+	// Sent salts are remembered this long, well past the last retry of any
+	// submission, then pruned so the set doesn't grow for the process' life
+	saltRetention = 10 * time.Minute
+)
+
+// ErrDuplicateOrder is returned when an order with the same salt has already
+// been sent. The salt is part of the signed order hash, so a resend could
+// only be a second copy of an order the exchange may already hold.
+var ErrDuplicateOrder = errors.New("order with this salt was already submitted")
+
+// retryableError marks a submission failure where the exchange may not have
+// processed the order: a network error or a 5xx response. Rejections the
+// exchange actually returned are never retried.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable reports whether err is a transient submission failure.
+func isRetryable(err error) bool {
+	var re *retryableError
+	return errors.As(err, &re)
+}
+
+// WithOrderRetries sets how many times CreateOrder retries a transient
+// failure (default 2, 0 disables retries). Retries resend the identical
+// signed order, so the exchange sees at most one order per salt.
+func (c *Client) WithOrderRetries(n int) *Client {
+	if n < 0 {
+		n = 0
+	}
+	c.orderRetries = n
+	return c
+}
+
+// waitRetry sleeps before retry attempt n with exponential backoff plus up to
+// one base delay of jitter. It returns early with ctx's error if ctx is done.
+func (c *Client) waitRetry(ctx context.Context, attempt int) error {
+	delay := c.retryDelay << (attempt - 1)
+	if c.retryDelay > 0 {
+		delay += time.Duration(rand.Int63n(int64(c.retryDelay)))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// claimSalt records salt as sent, returning false if it already was. A zero
+// salt is not tracked.
+func (c *Client) claimSalt(salt int64) bool {
+	if salt == 0 {
+		return true
+	}
+
+	now := time.Now()
+	c.saltMu.Lock()
+	defer c.saltMu.Unlock()
+	if c.salts == nil {
+		c.salts = make(map[int64]time.Time)
+	}
+	c.pruneSalts(now)
+	if _, ok := c.salts[salt]; ok {
+		return false
+	}
+	c.salts[salt] = now
+	return true
+}
+
+// pruneSalts forgets salts sent more than saltRetention before now, at most
+// once per saltRetention. The caller must hold saltMu.
+func (c *Client) pruneSalts(now time.Time) {
+	if now.Sub(c.saltsPruned) < saltRetention {
+		return
+	}
+	c.saltsPruned = now
+	for salt, sent := range c.salts {
+		if now.Sub(sent) >= saltRetention {
+			delete(c.salts, salt)
+		}
+	}
+}
+
+// releaseSalt forgets salt after the exchange cleanly rejected the order.
+func (c *Client) releaseSalt(salt int64) {
+	c.saltMu.Lock()
+	delete(c.salts, salt)
+	c.saltMu.Unlock()
+}
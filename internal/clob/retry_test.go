@@ -0,0 +1,175 @@
+package clob
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(url string) *Client {
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0").WithBaseURL(url)
+	c.retryDelay = time.Millisecond
+	return c
+}
+
+func TestCreateOrder_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int // Response status per attempt, last one repeats
+		wantErr   bool
+		wantCalls int32
+	}{
+		{"success first try", []int{http.StatusOK}, false, 1},
+		{"5xx then success", []int{http.StatusBadGateway, http.StatusOK}, false, 2},
+		{"5xx exhausts retries", []int{http.StatusServiceUnavailable}, true, 3},
+		{"rejection is not retried", []int{http.StatusBadRequest}, true, 1},
+		{"auth failure is not retried", []int{http.StatusUnauthorized}, true, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&calls, 1)) - 1
+				if n >= len(tt.statuses) {
+					n = len(tt.statuses) - 1
+				}
+				w.WriteHeader(tt.statuses[n])
+				w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+			}))
+			defer srv.Close()
+
+			c := newRetryTestClient(srv.URL)
+			_, err := c.CreateOrder(&OrderRequest{Order: Order{Salt: int64(1000 + i)}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_RetriesNetworkError(t *testing.T) {
+	// Grab a free port, then close it so connections are refused
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	url := "http://" + ln.Addr().String()
+	ln.Close()
+
+	c := newRetryTestClient(url).WithOrderRetries(1)
+	_, err = c.CreateOrder(&OrderRequest{Order: Order{Salt: 42}})
+	if err == nil {
+		t.Fatal("expected error from refused connection")
+	}
+	if !isRetryable(err) {
+		t.Errorf("network error %v should be retryable", err)
+	}
+}
+
+func TestCreateOrder_DuplicateSalt(t *testing.T) {
+	status := int32(http.StatusOK)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+		w.Write([]byte(`{"success":true,"orderID":"0xabc"}`))
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+
+	t.Run("accepted salt is refused", func(t *testing.T) {
+		order := &OrderRequest{Order: Order{Salt: 7}}
+		if _, err := c.CreateOrder(order); err != nil {
+			t.Fatalf("first submit: %v", err)
+		}
+		if _, err := c.CreateOrder(order); !errors.Is(err, ErrDuplicateOrder) {
+			t.Errorf("second submit err = %v, want ErrDuplicateOrder", err)
+		}
+	})
+
+	t.Run("ambiguous failure keeps salt claimed", func(t *testing.T) {
+		atomic.StoreInt32(&status, http.StatusInternalServerError)
+		order := &OrderRequest{Order: Order{Salt: 8}}
+		if _, err := c.CreateOrder(order); err == nil {
+			t.Fatal("expected error from 5xx")
+		}
+		atomic.StoreInt32(&status, http.StatusOK)
+		if _, err := c.CreateOrder(order); !errors.Is(err, ErrDuplicateOrder) {
+			t.Errorf("resubmit err = %v, want ErrDuplicateOrder", err)
+		}
+	})
+
+	t.Run("clean rejection releases salt", func(t *testing.T) {
+		atomic.StoreInt32(&status, http.StatusBadRequest)
+		order := &OrderRequest{Order: Order{Salt: 9}}
+		if _, err := c.CreateOrder(order); err == nil || errors.Is(err, ErrDuplicateOrder) {
+			t.Fatalf("expected rejection, got %v", err)
+		}
+		atomic.StoreInt32(&status, http.StatusOK)
+		if _, err := c.CreateOrder(order); err != nil {
+			t.Errorf("resubmit after rejection: %v", err)
+		}
+	})
+}
+
+func TestCreateOrderCtx_NoRetryAfterDeadline(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := newRetryTestClient(srv.URL)
+	c.retryDelay = time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := c.CreateOrderCtx(ctx, &OrderRequest{Order: Order{Salt: 99}}); err == nil {
+		t.Fatal("expected error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("took %v, want backoff cut short by the deadline", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestClaimSalt_PrunesOldSalts(t *testing.T) {
+	c := NewClient("key", "c2VjcmV0", "pass", "0x0")
+	if !c.claimSalt(1) || !c.claimSalt(2) {
+		t.Fatal("fresh salts should be claimable")
+	}
+	if c.claimSalt(2) {
+		t.Fatal("a salt sent moments ago should still be a duplicate")
+	}
+
+	// Age salt 1 past retention and let the next claim prune
+	c.saltMu.Lock()
+	c.salts[1] = time.Now().Add(-saltRetention - time.Second)
+	c.saltsPruned = time.Now().Add(-saltRetention)
+	c.saltMu.Unlock()
+
+	if !c.claimSalt(3) {
+		t.Fatal("fresh salt should be claimable")
+	}
+	c.saltMu.Lock()
+	_, kept1 := c.salts[1]
+	_, kept2 := c.salts[2]
+	n := len(c.salts)
+	c.saltMu.Unlock()
+	if kept1 || !kept2 || n != 2 {
+		t.Errorf("after pruning: salt 1 kept=%v, salt 2 kept=%v, %d tracked; want false, true, 2", kept1, kept2, n)
+	}
+}
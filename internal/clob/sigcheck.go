@@ -0,0 +1,66 @@
+package clob
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrOrderHashMismatch is returned by VerifyOrderID when the ID the exchange
+// assigned an order isn't the hash we signed it over.
+var ErrOrderHashMismatch = errors.New("order ID does not match the signed order hash")
+
+// OrderHasher computes the EIP-712 hash an order is signed over.
+// *OrderBuilder implements it.
+type OrderHasher interface {
+	OrderHash(order *Order, negRisk bool) (common.Hash, error)
+}
+
+var _ OrderHasher = (*OrderBuilder)(nil)
+
+// VerifyOrderID checks orderID, as returned when the order was placed,
+// against the hash h computes for order. The exchange's order ID is its own
+// EIP-712 hash of the order, so a mismatch means our encoding differs from
+// the exchange's even though it accepted the signature. The order doesn't
+// record which exchange it was signed for, so either domain may match.
+func VerifyOrderID(h OrderHasher, order *Order, orderID string) error {
+	id, err := hexutil.Decode(orderID)
+	if err != nil || len(id) != common.HashLength {
+		return fmt.Errorf("order ID %q is not an order hash", orderID)
+	}
+
+	var signed [2]common.Hash
+	for i, negRisk := range []bool{false, true} {
+		if signed[i], err = h.OrderHash(order, negRisk); err != nil {
+			return fmt.Errorf("failed to hash order: %w", err)
+		}
+		if signed[i] == common.BytesToHash(id) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: exchange assigned %s, we signed %s (neg risk %s)",
+		ErrOrderHashMismatch, orderID, signed[0].Hex(), signed[1].Hex())
+}
+
+// WithSignatureCheck has the client verify the ID of every order it places
+// against h with VerifyOrderID, logging a warning when they differ. This
+// catches signing regressions on the first order instead of when fills go
+// missing. nil disables the check (the default).
+func (c *Client) WithSignatureCheck(h OrderHasher) *Client {
+	c.orderHasher = h
+	return c
+}
+
+// checkOrderID warns when a placed order's ID isn't the hash it was signed
+// over.
+func (c *Client) checkOrderID(order *OrderRequest, resp *OrderResponse) {
+	if c.orderHasher == nil || resp == nil || !resp.Success || resp.OrderID == "" {
+		return
+	}
+	if err := VerifyOrderID(c.orderHasher, &order.Order, resp.OrderID); err != nil {
+		log.Printf("[clob] WARNING: signature check failed for order %s: %v", resp.OrderID, err)
+	}
+}
@@ -0,0 +1,92 @@
+package clob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVerifyOrderID(t *testing.T) {
+	b := newTestBuilder(t)
+	req, err := b.BuildOrder(BuildParams{TokenID: testTokenID, Side: OrderSideBuy, Price: 0.5, Size: 10, OrderType: OrderTypeGTC, FeeRateBps: -1, TickSize: 0.01})
+	if err != nil {
+		t.Fatalf("BuildOrder: %v", err)
+	}
+	standard, err := b.OrderHash(&req.Order, false)
+	if err != nil {
+		t.Fatalf("OrderHash: %v", err)
+	}
+	negRisk, err := b.OrderHash(&req.Order, true)
+	if err != nil {
+		t.Fatalf("OrderHash: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		orderID      string
+		wantErr      bool
+		wantMismatch bool
+	}{
+		{"standard exchange hash", standard.Hex(), false, false},
+		{"neg risk exchange hash", negRisk.Hex(), false, false},
+		{"another order's hash", "0x" + strings.Repeat("ab", 32), true, true},
+		{"not a hash", "order-1", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyOrderID(b, &req.Order, tt.orderID)
+			if (err != nil) != tt.wantErr || errors.Is(err, ErrOrderHashMismatch) != tt.wantMismatch {
+				t.Errorf("VerifyOrderID(%s) = %v, want err %v, mismatch %v", tt.orderID, err, tt.wantErr, tt.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_SignatureCheck(t *testing.T) {
+	b := newTestBuilder(t)
+	req, err := b.BuildOrder(BuildParams{TokenID: testTokenID, Side: OrderSideBuy, Price: 0.5, Size: 10, OrderType: OrderTypeGTC, FeeRateBps: -1, TickSize: 0.01})
+	if err != nil {
+		t.Fatalf("BuildOrder: %v", err)
+	}
+	hash, err := b.OrderHash(&req.Order, false)
+	if err != nil {
+		t.Fatalf("OrderHash: %v", err)
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	tests := []struct {
+		name     string
+		orderID  string
+		wantWarn bool
+	}{
+		{"exchange agrees", hash.Hex(), false},
+		{"exchange disagrees", "0x" + strings.Repeat("cd", 32), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"success":true,"orderID":%q}`, tt.orderID)
+			}))
+			defer srv.Close()
+
+			logs.Reset()
+			order := *req
+			c := newRetryTestClient(srv.URL).WithSignatureCheck(b)
+			if _, err := c.CreateOrder(&order); err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+			if warned := strings.Contains(logs.String(), "signature check failed"); warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v (log: %q)", warned, tt.wantWarn, logs.String())
+			}
+		})
+	}
+}
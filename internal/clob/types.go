@@ -1,5 +1,7 @@
 package clob
 
+import "strconv"
+
 // OrderBook represents the current state of bids and asks for a token.
 type OrderBook struct {
 	Bids []PriceLevel `json:"bids"`
@@ -90,6 +92,28 @@ type CancelOrderRequest struct {
 	OrderID string `json:"orderID"`
 }
 
+// OrderStatus is one order as GET /data/order/{id} reports it. Sizes are
+// in shares.
+type OrderStatus struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"` // "LIVE", "MATCHED", "CANCELED", ...
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	Price        string `json:"price"`
+	OriginalSize string `json:"original_size"`
+	SizeMatched  string `json:"size_matched"`
+}
+
+// MatchedShares returns how many shares of the order have filled, 0 if the
+// size can't be read.
+func (o *OrderStatus) MatchedShares() float64 {
+	shares, err := strconv.ParseFloat(o.SizeMatched, 64)
+	if err != nil || shares < 0 {
+		return 0
+	}
+	return shares
+}
+
 // OpenOrdersResponse represents the response from fetching open orders.
 type OpenOrdersResponse struct {
 	Data []Order `json:"data"`
@@ -117,4 +141,6 @@ const (
 type BalanceAllowanceResponse struct {
 	Balance   string `json:"balance"`
 	Allowance string `json:"allowance"`
+	// Per-spender allowances, keyed by contract address (newer API versions)
+	Allowances map[string]string `json:"allowances,omitempty"`
 }
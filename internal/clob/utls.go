@@ -0,0 +1,77 @@
+//go:build !noutls
+
+package clob
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	// utls reproduces a real browser's TLS ClientHello. Cloudflare
+	// fingerprints the handshake (JA3/JA4), and Go's crypto/tls hello is
+	// distinctive enough to earn 403s regardless of User-Agent. Build with
+	// -tags noutls to drop the dependency and use crypto/tls only.
+	utls "github.com/refraction-networking/utls"
+)
+
+// utlsAvailable reports whether this build includes the uTLS transport.
+const utlsAvailable = true
+
+// newUTLSTransport returns a transport whose TLS handshake mimics Chrome.
+// dial opens the underlying TCP connection (directly or through SOCKS5).
+func newUTLSTransport(dial func(ctx context.Context, network, addr string) (net.Conn, error)) (*http.Transport, error) {
+	// Fail at construction rather than on the first request
+	if _, err := chromeHelloSpec(); err != nil {
+		return nil, err
+	}
+
+	return &http.Transport{
+		DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+			}
+
+			conn, err := dial(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+
+			// A spec holds per-handshake state, so build one per connection
+			spec, err := chromeHelloSpec()
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+
+			uconn := utls.UClient(conn, &utls.Config{ServerName: host}, utls.HelloCustom)
+			if err := uconn.ApplyPreset(spec); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("failed to apply TLS fingerprint: %w", err)
+			}
+			if err := uconn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, fmt.Errorf("TLS handshake failed: %w", err)
+			}
+			return uconn, nil
+		},
+	}, nil
+}
+
+// chromeHelloSpec returns Chrome's ClientHello, limited to HTTP/1.1.
+func chromeHelloSpec() (*utls.ClientHelloSpec, error) {
+	spec, err := utls.UTLSIdToSpec(utls.HelloChrome_Auto)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Chrome TLS fingerprint: %w", err)
+	}
+
+	// http.Transport only speaks HTTP/2 over crypto/tls connections, so
+	// advertise HTTP/1.1 alone. ALPN values are not part of the JA3 hash.
+	for _, ext := range spec.Extensions {
+		if alpn, ok := ext.(*utls.ALPNExtension); ok {
+			alpn.AlpnProtocols = []string{"http/1.1"}
+		}
+	}
+	return &spec, nil
+}
@@ -0,0 +1,19 @@
+//go:build noutls
+
+package clob
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// utlsAvailable reports whether this build includes the uTLS transport.
+const utlsAvailable = false
+
+// newUTLSTransport is unavailable in noutls builds; callers fall back to
+// the standard transport.
+func newUTLSTransport(func(ctx context.Context, network, addr string) (net.Conn, error)) (*http.Transport, error) {
+	return nil, errors.New("uTLS support not compiled in (built with -tags noutls)")
+}
@@ -0,0 +1,101 @@
+//go:build !noutls
+
+package clob
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+// isGREASE reports whether v is a GREASE value (RFC 8701). Chrome sends them;
+// Go's crypto/tls never does.
+func isGREASE(v uint16) bool {
+	return v&0x0f0f == 0x0a0a && v>>8 == v&0xff
+}
+
+func TestUTLSTransport_SendsChromeHello(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	hellos := make(chan *tls.ClientHelloInfo, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		srv := tls.Server(conn, &tls.Config{
+			GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+				hellos <- hello
+				return nil, net.ErrClosed // Abort; only the hello matters
+			},
+		})
+		srv.Handshake()
+	}()
+
+	transport, err := buildTransport("", true)
+	if err != nil {
+		t.Fatalf("buildTransport: %v", err)
+	}
+	client := &http.Client{Transport: transport, Timeout: 2 * time.Second}
+	client.Get("https://" + ln.Addr().String())
+
+	var hello *tls.ClientHelloInfo
+	select {
+	case hello = <-hellos:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no ClientHello received")
+	}
+
+	grease := false
+	for _, c := range hello.CipherSuites {
+		if isGREASE(c) {
+			grease = true
+		}
+	}
+	if !grease {
+		t.Error("expected GREASE cipher suites in a Chrome-like hello")
+	}
+	if len(hello.SupportedProtos) != 1 || hello.SupportedProtos[0] != "http/1.1" {
+		t.Errorf("ALPN = %v, want [http/1.1]", hello.SupportedProtos)
+	}
+}
+
+func TestWithUTLS(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   func() *Client
+		enabled  bool
+		wantUTLS bool
+	}{
+		{"disabled", func() *Client { return NewClient("k", "s", "p", "0x0") }, false, false},
+		{"direct", func() *Client { return NewClient("k", "s", "p", "0x0") }, true, true},
+		{"socks5 proxy", func() *Client {
+			c, _ := NewClientWithProxy("k", "s", "p", "0x0", "socks5://127.0.0.1:1080")
+			return c
+		}, true, true},
+		{"http proxy falls back", func() *Client {
+			c, _ := NewClientWithProxy("k", "s", "p", "0x0", "127.0.0.1:8080")
+			return c
+		}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.client().WithUTLS(tt.enabled)
+			transport, ok := httpx.Unwrap(c.httpClient.Transport).(*http.Transport)
+			gotUTLS := ok && transport.DialTLSContext != nil
+			if c.useUTLS != tt.wantUTLS || gotUTLS != tt.wantUTLS {
+				t.Errorf("useUTLS = %v, uTLS transport = %v, want %v", c.useUTLS, gotUTLS, tt.wantUTLS)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,7 +15,8 @@ import (
 )
 
 const (
-	wsURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+	// DefaultWSURL is the CLOB WebSocket root; channels live under it
+	DefaultWSURL = "wss://ws-subscriptions-clob.polymarket.com/ws"
 
 	// Reconnection settings
 	initialBackoff = 1 * time.Second
@@ -27,6 +29,16 @@ const (
 	writeTimeout = 10 * time.Second
 )
 
+// WSChannel is a CLOB WebSocket channel.
+type WSChannel string
+
+const (
+	// ChannelMarket streams public order book updates for subscribed tokens.
+	ChannelMarket WSChannel = "market"
+	// ChannelUser streams the authenticated account's orders and trades.
+	ChannelUser WSChannel = "user"
+)
+
 // MarketUpdate represents a real-time market data update.
 type MarketUpdate struct {
 	TokenID string
@@ -36,22 +48,71 @@ type MarketUpdate struct {
 	AskSize float64
 }
 
-// WSClient is a WebSocket client for real-time market data.
+// TradeUpdate is a fill of one of our orders from the user channel. The
+// same trade is sent again as its status advances.
+type TradeUpdate struct {
+	ID           string
+	TokenID      string
+	Side         string
+	Price        float64
+	Size         float64
+	Status       string // MATCHED, MINED, CONFIRMED, RETRYING or FAILED
+	TakerOrderID string
+	MakerOrders  []MakerFill // Resting orders the taker matched
+}
+
+// MakerFill is the part of a trade that matched one resting order.
+type MakerFill struct {
+	OrderID string
+	Size    float64
+}
+
+// MatchedShares returns how many shares of orderID the trade matched, as
+// its taker or as one of its makers.
+func (t TradeUpdate) MatchedShares(orderID string) float64 {
+	if orderID == "" {
+		return 0
+	}
+	if t.TakerOrderID == orderID {
+		return t.Size
+	}
+	var shares float64
+	for _, m := range t.MakerOrders {
+		if m.OrderID == orderID {
+			shares += m.Size
+		}
+	}
+	return shares
+}
+
+// WSClient is a WebSocket client for one CLOB channel: public market data,
+// or the user channel with our own order and trade events.
 type WSClient struct {
 	conn       *websocket.Conn
-	url        string
+	baseURL    string
+	channel    WSChannel
+	auth       *wsAuth // Sent on every connect, nil for the market channel
 	subscribed map[string]bool
 	handlers   []func(update MarketUpdate)
+	trades     []func(trade TradeUpdate)
 	done       chan struct{}
 	mu         sync.RWMutex
 	connMu     sync.Mutex
 }
 
+// wsAuth is the user channel's L2 API credentials.
+type wsAuth struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
 // wsMessage represents an outbound WebSocket message.
 type wsMessage struct {
 	Type    string   `json:"type"`
 	Channel string   `json:"channel,omitempty"`
 	Markets []string `json:"markets,omitempty"`
+	Auth    *wsAuth  `json:"auth,omitempty"`
 }
 
 // wsEvent represents an inbound WebSocket event.
@@ -62,18 +123,65 @@ type wsEvent struct {
 	Side      string     `json:"side,omitempty"`
 	Bids      [][]string `json:"bids,omitempty"`
 	Asks      [][]string `json:"asks,omitempty"`
+
+	// User channel trade fields
+	ID           string         `json:"id,omitempty"`
+	AssetID      string         `json:"asset_id,omitempty"`
+	Size         string         `json:"size,omitempty"`
+	Status       string         `json:"status,omitempty"`
+	TakerOrderID string         `json:"taker_order_id,omitempty"`
+	MakerOrders  []wsMakerOrder `json:"maker_orders,omitempty"`
+}
+
+// wsMakerOrder is one resting order a user channel trade matched.
+type wsMakerOrder struct {
+	OrderID       string `json:"order_id"`
+	MatchedAmount string `json:"matched_amount"`
 }
 
-// NewWSClient creates a new WebSocket client.
+// NewWSClient creates a WebSocket client for the market channel.
 func NewWSClient() *WSClient {
 	return &WSClient{
-		url:        wsURL,
+		baseURL:    DefaultWSURL,
+		channel:    ChannelMarket,
 		subscribed: make(map[string]bool),
 		handlers:   make([]func(update MarketUpdate), 0),
 		done:       make(chan struct{}),
 	}
 }
 
+// NewUserWSClient creates a WebSocket client for the user channel, which
+// authenticates with the account's API credentials on every connect.
+func NewUserWSClient(apiKey, secret, passphrase string) *WSClient {
+	c := NewWSClient()
+	c.channel = ChannelUser
+	return c.WithAuth(apiKey, secret, passphrase)
+}
+
+// WithURL sets the WebSocket root the channel path is appended to, for
+// testing or proxying. Empty keeps DefaultWSURL.
+func (c *WSClient) WithURL(baseURL string) *WSClient {
+	if baseURL != "" {
+		c.baseURL = baseURL
+	}
+	return c
+}
+
+// WithAuth sets the API credentials sent on connect. Empty credentials
+// disable auth.
+func (c *WSClient) WithAuth(apiKey, secret, passphrase string) *WSClient {
+	c.auth = nil
+	if apiKey != "" {
+		c.auth = &wsAuth{APIKey: apiKey, Secret: secret, Passphrase: passphrase}
+	}
+	return c
+}
+
+// URL returns the channel's full WebSocket URL.
+func (c *WSClient) URL() string {
+	return strings.TrimRight(c.baseURL, "/") + "/" + string(c.channel)
+}
+
 // Connect establishes a WebSocket connection.
 func (c *WSClient) Connect() error {
 	c.connMu.Lock()
@@ -84,11 +192,23 @@ func (c *WSClient) Connect() error {
 	}
 
 	dialer := websocket.DefaultDialer
-	conn, _, err := dialer.Dial(c.url, nil)
+	conn, _, err := dialer.Dial(c.URL(), nil)
 	if err != nil {
 		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	// Authenticate before anything else, so reconnects re-authenticate too
+	if c.auth != nil {
+		msg := wsMessage{Type: string(c.channel), Auth: c.auth}
+		if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err == nil {
+			err = conn.WriteJSON(msg)
+		}
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to authenticate WebSocket: %w", err)
+		}
+	}
+
 	c.conn = conn
 	return nil
 }
@@ -109,7 +229,7 @@ func (c *WSClient) Subscribe(tokenIDs ...string) error {
 
 	msg := wsMessage{
 		Type:    "subscribe",
-		Channel: "market",
+		Channel: string(c.channel),
 		Markets: tokenIDs,
 	}
 
@@ -142,7 +262,7 @@ func (c *WSClient) Unsubscribe(tokenIDs ...string) error {
 
 	msg := wsMessage{
 		Type:    "unsubscribe",
-		Channel: "market",
+		Channel: string(c.channel),
 		Markets: tokenIDs,
 	}
 
@@ -166,6 +286,13 @@ func (c *WSClient) OnUpdate(handler func(MarketUpdate)) {
 	c.handlers = append(c.handlers, handler)
 }
 
+// OnTrade registers a callback handler for user channel trades.
+func (c *WSClient) OnTrade(handler func(TradeUpdate)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.trades = append(c.trades, handler)
+}
+
 // Run starts the main WebSocket loop with automatic reconnection.
 // Note: WebSocket is optional - REST polling is used as primary price source.
 func (c *WSClient) Run(ctx context.Context) error {
@@ -326,6 +453,9 @@ func (c *WSClient) handleMessage(data []byte) {
 		update = c.handlePriceChange(event)
 	case "book":
 		update = c.handleBookUpdate(event)
+	case "trade":
+		c.handleTrade(event)
+		return
 	default:
 		// Ignore unknown event types
 		return
@@ -360,6 +490,32 @@ func (c *WSClient) handlePriceChange(event wsEvent) MarketUpdate {
 	return update
 }
 
+// handleTrade passes a user channel trade event to the trade handlers.
+func (c *WSClient) handleTrade(event wsEvent) {
+	trade := TradeUpdate{
+		ID:           event.ID,
+		TokenID:      event.AssetID,
+		Side:         event.Side,
+		Status:       event.Status,
+		TakerOrderID: event.TakerOrderID,
+	}
+	trade.Price, _ = strconv.ParseFloat(event.Price, 64)
+	trade.Size, _ = strconv.ParseFloat(event.Size, 64)
+	for _, m := range event.MakerOrders {
+		size, _ := strconv.ParseFloat(m.MatchedAmount, 64)
+		trade.MakerOrders = append(trade.MakerOrders, MakerFill{OrderID: m.OrderID, Size: size})
+	}
+
+	c.mu.RLock()
+	handlers := make([]func(TradeUpdate), len(c.trades))
+	copy(handlers, c.trades)
+	c.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(trade)
+	}
+}
+
 // handleBookUpdate processes a book update event.
 func (c *WSClient) handleBookUpdate(event wsEvent) MarketUpdate {
 	update := MarketUpdate{
@@ -420,7 +576,7 @@ func (c *WSClient) resubscribe() error {
 
 	msg := wsMessage{
 		Type:    "subscribe",
-		Channel: "market",
+		Channel: string(c.channel),
 		Markets: tokenIDs,
 	}
 
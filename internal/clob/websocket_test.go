@@ -0,0 +1,152 @@
+package clob
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsRecorder is a WebSocket server that records each connection's path and
+// first message.
+type wsRecorder struct {
+	paths chan string
+	first chan wsMessage
+}
+
+func newWSRecorder(t *testing.T) (*wsRecorder, string) {
+	t.Helper()
+	r := &wsRecorder{paths: make(chan string, 4), first: make(chan wsMessage, 4)}
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r.paths <- req.URL.Path
+
+		var msg wsMessage
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		if err := conn.ReadJSON(&msg); err == nil {
+			r.first <- msg
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return r, "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/"
+}
+
+func TestWSClient_AuthOnConnect(t *testing.T) {
+	server, url := newWSRecorder(t)
+	c := NewUserWSClient("key", "secret", "pass").WithURL(url)
+
+	// Reconnects go through Connect, so each one must authenticate again
+	for i := 0; i < 2; i++ {
+		if err := c.Connect(); err != nil {
+			t.Fatalf("Connect #%d: %v", i+1, err)
+		}
+		if path := <-server.paths; path != "/ws/user" {
+			t.Errorf("path = %s, want /ws/user", path)
+		}
+		select {
+		case msg := <-server.first:
+			if msg.Type != "user" || msg.Auth == nil || *msg.Auth != (wsAuth{APIKey: "key", Secret: "secret", Passphrase: "pass"}) {
+				t.Errorf("first message = %+v, want user auth", msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no auth message on connect #%d", i+1)
+		}
+		c.closeConnection()
+	}
+}
+
+func TestWSClient_MarketChannelSkipsAuth(t *testing.T) {
+	server, url := newWSRecorder(t)
+	c := NewWSClient().WithURL(url)
+	if err := c.Connect(); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.closeConnection()
+	if path := <-server.paths; path != "/ws/market" {
+		t.Errorf("path = %s, want /ws/market", path)
+	}
+	if err := c.Subscribe("123"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	select {
+	case msg := <-server.first:
+		if msg.Auth != nil || msg.Type != "subscribe" || msg.Channel != "market" {
+			t.Errorf("first message = %+v, want an unauthenticated market subscribe", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no subscribe message")
+	}
+}
+
+func TestWSClient_TradeEvent(t *testing.T) {
+	c := NewUserWSClient("key", "secret", "pass")
+	var got []TradeUpdate
+	c.OnTrade(func(trade TradeUpdate) { got = append(got, trade) })
+
+	c.handleMessage([]byte(`{"event_type":"trade","id":"t-1","asset_id":"987","side":"BUY",` +
+		`"price":"0.97","size":"12.5","status":"MATCHED","taker_order_id":"0xtaker",` +
+		`"maker_orders":[{"order_id":"0xmaker","matched_amount":"12.5"}]}`))
+
+	want := TradeUpdate{
+		ID: "t-1", TokenID: "987", Side: "BUY", Price: 0.97, Size: 12.5, Status: "MATCHED",
+		TakerOrderID: "0xtaker",
+		MakerOrders:  []MakerFill{{OrderID: "0xmaker", Size: 12.5}},
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("trades = %+v, want [%+v]", got, want)
+	}
+	if got[0].MatchedShares("0xmaker") != 12.5 || got[0].MatchedShares("0xtaker") != 12.5 || got[0].MatchedShares("0xother") != 0 {
+		t.Errorf("matched shares = %v/%v/%v, want 12.5/12.5/0",
+			got[0].MatchedShares("0xmaker"), got[0].MatchedShares("0xtaker"), got[0].MatchedShares("0xother"))
+	}
+}
+
+func TestWSClient_UserChannelDeliversTrades(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Trades only follow a valid auth message
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil || msg.Auth == nil || msg.Auth.APIKey != "key" {
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event_type":"trade","id":"t-1","asset_id":"987",`+
+			`"side":"BUY","price":"0.96","size":"10","status":"MATCHED","taker_order_id":"0xtaker",`+
+			`"maker_orders":[{"order_id":"0xours","matched_amount":"10"}]}`))
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		conn.ReadMessage()
+	}))
+	defer srv.Close()
+
+	c := NewUserWSClient("key", "secret", "pass").WithURL("ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/")
+	trades := make(chan TradeUpdate, 1)
+	c.OnTrade(func(trade TradeUpdate) { trades <- trade })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+
+	select {
+	case trade := <-trades:
+		if trade.MatchedShares("0xours") != 10 {
+			t.Errorf("trade = %+v, want 10 shares of 0xours", trade)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no trade delivered")
+	}
+}
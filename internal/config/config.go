@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,10 +14,15 @@ import (
 type Config struct {
 	// Wallet
 	PrivateKey         string
-	ProxyWalletAddress string // Polymarket proxy wallet (Gnosis Safe), empty = EOA mode
-	SignatureType      int    // 0=EOA, 1=POLY_PROXY (email/Google), 2=GNOSIS_SAFE (browser wallet)
+	PrivateKeys        []string // Every wallet orders round-robin across: PrivateKey, then PRIVATE_KEYS (blackswan)
+	ProxyWalletAddress string   // Polymarket proxy wallet (Gnosis Safe), empty = EOA mode
+	SignatureType      int      // 0=EOA, 1=POLY_PROXY (email/Google), 2=GNOSIS_SAFE (browser wallet)
 	PolygonChainID     int
 	PolygonRPCURL      string
+	PolygonRPCURLs     []string // Failover list from POLYGON_RPC_URLS (default: [PolygonRPCURL])
+	MaxGasPriceGwei    float64  // On-chain transactions are refused above this gas price (default: 0 = no cap)
+	GasTipGwei         float64  // EIP-1559 priority fee (default: 0 = the RPC's suggestion)
+	GasFeeCapGwei      float64  // EIP-1559 max fee per gas (default: 0 = twice the base fee plus the tip)
 
 	// CLOB API credentials
 	CLOBApiKey     string
@@ -26,48 +32,135 @@ type Config struct {
 	// Proxy (optional) - supports multiple proxies comma-separated
 	ProxyURL  string   // Single proxy (legacy): user:pass@host:port
 	ProxyURLs []string // Multiple proxies for rotation
+	CLOBUTLS  bool     // Mimic Chrome's TLS fingerprint on CLOB requests (direct/SOCKS5 only)
+
+	CLOBOrderRetries  int    // Retries for order submissions failing with network errors or 5xx (default: 2)
+	OrderSizeRounding string // How order sizes round to 0.01 shares: floor or nearest (default: floor)
+	CLOBWSURL         string // WebSocket root the market and user channel paths are appended to (default: Polymarket's)
+
+	ValidateSignatures bool // Warn when a placed order's ID differs from the EIP-712 hash we signed (default: false)
+	CLOBRederiveCreds  bool // On a 401, re-derive API credentials from the private key and retry once (default: false)
 
 	// Telegram notifications (optional)
-	TelegramBotToken string
-	TelegramChatID   string
+	TelegramBotToken  string
+	TelegramChatID    string
+	TelegramRateLimit int // Max non-critical messages per minute before batching into digests (default: 20, 0 = unlimited)
+
+	EmptyScanAlertAfter int // Consecutive scans finding no markets before a Telegram alert (default: 10, 0 = disabled)
+
+	// Read-only status API (optional)
+	APIPort  int    // Port for the JSON state API (default: 0 = disabled)
+	APIToken string // Bearer token the API requires (default: empty = no auth)
 
 	// Trading parameters
 	DryRun          bool
-	MaxPositionSize float64
+	PaperBalance    float64 // Starting paper-trading balance in dry run (default: 0 = strategy bankroll)
+	MaxSessionLoss  float64 // Net realized loss that halts new weather/blackswan trades for the rest of the session (default: 0 = disabled)
+	MaxPositionSize float64 // Deprecated: fallback for SNIPE_MAX_POSITION, SPORTS_SHARES_PER_TRADE and BLACKSWAN_BANKROLL
 	SnipePrice      float64
 	TriggerSeconds  int
-	MinLiquidity    float64
+
+	LiveArmDelay time.Duration // Live mode: scan and log but hold orders this long after startup (default: 0 = trade immediately)
+
+	CancelOrphansOnStart bool // Live mode: on startup, cancel open buys on the strategy's markets it isn't tracking (default: false)
+
+	// Liquidity at the best ask; both gates apply when both are set
+	MinLiquidityShares float64 // Min shares at the ask (MIN_LIQUIDITY is a legacy alias)
+	MinLiquidityUSD    float64 // Min dollars at the ask, i.e. size * ask (default: 0 = disabled)
 
 	// Strategy parameters
 	MinConfidence  float64 // Minimum winner confidence (e.g., 0.50 = 50%)
 	MaxUncertainty float64 // Max gap between sides to consider uncertain (e.g., 0.10 = 10%)
 
+	// Sniper execution parameters
+	SnipeStopLossMomentum float64 // Sell a snipe if momentum reverses by this much before expiry (default: 0 = disabled)
+	SnipeOrderTimeoutMs   int     // Per-order submit timeout, also capped by market end (default: 2000)
+	SnipeCutoffSeconds    float64 // Send no new snipes with less than this many seconds to market end (default: 0 = disabled)
+	SnipeCoinFlipSeconds  float64 // Give up at once on a market still within MAX_UNCERTAINTY this close to expiry, warmed up or not (default: 0 = TRIGGER_SECONDS)
+	SnipeWarmupSnapshots  int     // Price snapshots a market needs before it can be sniped (default: 4, max 10)
+	SnipeWarmupSeconds    int     // Seconds a market must be tracked before it can be sniped (default: 10)
+	SnipeMinMarketAge     int     // Seconds since a market's window opened, per its slug, before it is tracked (default: 30, 0 = disabled)
+	SnipePollIdleMs       int     // Price poll interval with over 60s to expiry (default: 5000)
+	SnipePollNearMs       int     // Price poll interval inside 60s of expiry (default: 500)
+	SnipePollFinalMs      int     // Price poll interval inside 10s of expiry (default: 100)
+	SnipeMaxPriceAgeMs    int     // Refresh the winner's order book before sniping if its price is older than this (default: 500, 0 = disabled)
+	SnipeMaxPosition      float64 // Most dollars per snipe, scaled down by confidence (default: 0 = MAX_POSITION_SIZE)
+	SnipeMode             string  // "taker" buys the ask with FOK, "maker" rests a GTC bid one tick inside it (default: taker)
+	SnipeMomentumMode     string  // "simple" is newest minus oldest YES bid, "ema" compares exponentially smoothed ends (default: simple)
+	MinExpectedProfit     float64 // Skip snipes expected to make less than this many dollars (default: 0 = disabled)
+	SnipeRecordFile       string  // JSON-lines file every tracked market's price snapshots are appended to, for sniper-replay (default: empty = disabled)
+
 	// Black Swan strategy parameters ($15 bankroll optimized)
-	BlackSwanMaxPrice     float64 // Max price to consider (default: 0.10 = 10¢)
-	BlackSwanMinPrice     float64 // Min price to avoid dust (default: 0.005 = 0.5¢)
-	BlackSwanBetPercent   float64 // Bankroll percentage per bet (default: 0.05 = 5%)
-	BlackSwanMaxPositions int     // Maximum concurrent open positions (default: 10)
-	BlackSwanMaxExposure  float64 // Maximum total exposure in USD (default: 10)
-	BlackSwanBidDiscount  float64 // How far below market to bid (default: 0.25 = 25%)
-	BlackSwanMinVolume    float64 // Minimum market volume to consider (default: 100)
-	BlackSwanMaxVolume    float64 // Maximum market volume (avoid liquid markets) (default: 10000)
-	BlackSwanMaxDays      int     // Maximum days until resolution (default: 30) - prefer fast-resolving markets
+	BlackSwanMaxPrice      float64 // Max price to consider (default: 0.10 = 10¢)
+	BlackSwanMinPrice      float64 // Min price to avoid dust (default: 0.005 = 0.5¢)
+	BlackSwanMinOpposite   float64 // Min opposite-side price for it to count as overconfident (default: 0.90)
+	BlackSwanBankroll      float64 // Dollars bets are a percentage of (default: 0 = MAX_POSITION_SIZE)
+	BlackSwanBetPercent    float64 // Bankroll percentage per bet (default: 0.05 = 5%)
+	BlackSwanMaxPositions  int     // Maximum concurrent open positions (default: 10)
+	BlackSwanMaxExposure   float64 // Maximum total exposure in USD (default: 10)
+	BlackSwanBidDiscount   float64 // How far below market to bid (default: 0.25 = 25%)
+	BlackSwanDiscounts     string  // Bid discount by days to resolution, e.g. "1=0.05,7=0.20,30=0.35" (default: empty = flat discount)
+	BlackSwanMinVolume     float64 // Minimum market volume to consider (default: 100)
+	BlackSwanMaxVolume     float64 // Maximum market volume (avoid liquid markets) (default: 10000)
+	BlackSwanMinWeeklyVol  float64 // Minimum 7-day volume (default: 0 = disabled)
+	BlackSwanMinVolTrend   float64 // Min last-day volume over the week's daily average, e.g. 0.5 (default: 0 = disabled)
+	BlackSwanMaxDays       int     // Maximum days until resolution (default: 30) - prefer fast-resolving markets
+	BlackSwanIlliquidHours string  // UTC hours to avoid resolving in, e.g. "22-6" (default: empty = any hour)
+	BlackSwanSellTarget    float64 // Resting sell placed on fill at entry price times this (default: 0 = disabled)
+	BlackSwanLiveBalance   bool    // Size bets off the wallets' combined USDC balance, refreshed each scan (default: false = BLACKSWAN_BANKROLL)
+	BlackSwanDigestMins    int     // Minutes between Telegram digests of open positions (default: 0 = disabled)
+	MinEconomicalBet       float64 // Skip bets whose max payout less settlement gas is below this in USD (default: 0)
+	SettlementGasUSD       float64 // Rough gas cost in USD to redeem a winning position (default: 0.02)
 
 	// Weather sniper strategy parameters (dynamic sizing)
-	WeatherBalance        float64 // Your actual USDC balance (set this! 0 = try API)
-	WeatherBankroll       float64 // Fallback if balance not set and API fails
-	WeatherMinEdge        float64 // Minimum edge to trade (default: 0.12 = 12%)
-	WeatherMinConfidence  float64 // Minimum confidence in forecast (default: 0.70 = 70%)
-	WeatherMaxPosition    float64 // Maximum position size per trade (default: 5.00)
-	WeatherBetPercent     float64 // Balance percentage per bet (legacy, replaced by Kelly)
-	WeatherDailyLossLimit float64 // Daily loss limit (default: 10.00)
-	WeatherMaxTrades      int     // Maximum concurrent trades (default: 5)
-	WeatherMaxExposure    float64 // Maximum total exposure (default: 50.00)
-	WeatherMinVolume      float64 // Minimum market volume (default: 500)
-	WeatherMaxSpread      float64 // Maximum bid-ask spread (default: 0.05 = 5%)
-	WeatherBidDiscount    float64 // How far below market to bid (default: 0.12 = 12%)
-	WeatherMinPrice       float64 // Minimum market price to consider (default: 0.05 = 5¢)
-	WeatherMaxDivergence  float64 // Max divergence from market before skepticism (default: 0.30 = 30%)
+	WeatherBalance         float64 // Your actual USDC balance (set this! 0 = try API)
+	WeatherBankroll        float64 // Fallback if balance not set and API fails
+	WeatherMinEdge         float64 // Minimum edge to trade (default: 0.12 = 12%)
+	WeatherMinConfidence   float64 // Minimum confidence in forecast (default: 0.70 = 70%)
+	WeatherMaxPosition     float64 // Maximum position size per trade (default: 5.00)
+	WeatherBetPercent      float64 // Balance percentage per bet (legacy, replaced by Kelly)
+	WeatherDailyLossLimit  float64 // Daily loss limit (default: 10.00)
+	WeatherMaxTrades       int     // Maximum concurrent trades (default: 5)
+	WeatherMaxExposure     float64 // Maximum total exposure (default: 50.00)
+	WeatherMinVolume       float64 // Minimum market volume (default: 500)
+	WeatherMaxSpread       float64 // Maximum bid-ask spread (default: 0.05 = 5%)
+	WeatherBidDiscount     float64 // How far below market to bid (default: 0.12 = 12%)
+	WeatherMaxDiscountAbs  float64 // Cap on the bid discount in dollars, e.g. 0.03 = at most 3¢ below market (default: 0 = no cap)
+	WeatherMinPrice        float64 // Minimum market price to consider (default: 0.05 = 5¢)
+	WeatherMaxDivergence   float64 // Max divergence from market before skepticism (default: 0.30 = 30%)
+	WeatherProbMin         float64 // Model probabilities are clamped to at least this, for irreducible forecast error (default: 0.02)
+	WeatherProbMax         float64 // Model probabilities are clamped to at most this (default: 0.98)
+	WeatherCoinFlipMargin  float64 // °C from the forecast mean within which a threshold cuts confidence, not boosts it (default: 0.5, 0 = disabled)
+	WeatherModelOverrides  string  // Per-city model preferences, e.g. "London=ukmo_seamless;Tokyo=jma_seamless"
+	WeatherTempBias        string  // Per-city °C added to forecast temps, e.g. "London=-1.2;Tokyo=0.5" (default: none)
+	WeatherTempDoF         float64 // Student's t degrees of freedom for forecast error, above 2 (default: 0 = normal)
+	WeatherStrictAgreement float64 // Strict mode: skip markets whose model agreement is below this (default: 0 = disabled)
+	WeatherSellTarget      float64 // Resting sell placed on fill at entry price times this (default: 0 = disabled)
+	WeatherScanWorkers     int     // Markets whose forecasts are fetched in parallel during a scan (default: 4)
+	WeatherCalibration     string  // CSV each resolved trade's predicted probability and outcome is appended to (default: empty = disabled)
+	WeatherReevaluate      bool    // Hourly, cancel resting orders whose fresh forecast puts our side below the bid (default: false)
+	WeatherMaxBuckets      int     // Sibling bucket positions per city/date, sharing one max position (default: 1)
+	WeatherAPIRetries      int     // Retries for Open-Meteo requests rate-limited (429) or failing with 5xx (default: 3)
+	WeatherAgreement       string  // Model spread to agreement formula, "linear:N" or "exp:N" (default: linear:10)
+	WeatherHorizonBonus    string  // Score multiplier by days to resolution, e.g. "1=2.0,3=1.5" (default: those, 1 beyond)
+
+	WeatherEdgeHalflife time.Duration // Age at which a resting order's edge counts for half, as its forecast goes stale (default: 0 = no decay)
+	WeatherEdgeFloor    float64       // Cancel resting orders whose decayed edge falls below this (default: 0.05 = 5%)
+
+	WeatherRequireConsensus bool // Skip markets where fewer than 2 models returned data (default: false)
+
+	// Sports sniper parameters
+	ESPNTimeout    time.Duration // Per-request ESPN scoreboard timeout (default: 10s)
+	ESPNRetries    int           // Extra attempts after a failed ESPN fetch, with backoff (default: 2)
+	ESPNCacheTTL   time.Duration // Scan and check reuse ESPN games fetched within this (default: 5s, 0 = always fetch)
+	SportsMaxStale time.Duration // Refuse to trade on game data fetched longer ago than this (default: 30s, 0 = no limit)
+	SportsMaxEntry float64       // Highest price paid for either branch (default: 0 = 0.99 for final games, 0.95 live)
+	SportsMargin   float64       // Minimum expected return on the stake, e.g. 0.03 = 3% (default: 0 = any)
+
+	SportsDecidedLeads string // Per-quarter leads that call an NFL game decided, e.g. "2=35,3=28,4=21" (default: those, Q1 never)
+	SportsMode         string // Games to trade: "final" only, "live" in-progress only, or "both" (default: both)
+
+	SportsSharesPerTrade float64 // Shares bought per trade, not dollars (default: 0 = MAX_POSITION_SIZE)
 }
 
 func Load() (*Config, error) {
@@ -79,51 +172,108 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
-		PolygonChainID:  getEnvInt("POLYGON_CHAIN_ID", 137),
-		PolygonRPCURL:   getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
-		DryRun:          getEnvBool("DRY_RUN", true),
-		MaxPositionSize: getEnvFloat("MAX_POSITION_SIZE", 15),
-		SnipePrice:      getEnvFloat("SNIPE_PRICE", 0.99),
-		TriggerSeconds:  getEnvInt("TRIGGER_SECONDS", 1),
-		MinLiquidity:    getEnvFloat("MIN_LIQUIDITY", 5),
-		MinConfidence:   getEnvFloat("MIN_CONFIDENCE", 0.50),
-		MaxUncertainty:  getEnvFloat("MAX_UNCERTAINTY", 0.10),
+		PolygonChainID:     getEnvInt("POLYGON_CHAIN_ID", 137),
+		PolygonRPCURL:      getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
+		DryRun:             getEnvBool("DRY_RUN", true),
+		PaperBalance:       getEnvFloat("PAPER_BALANCE", 0),
+		MaxSessionLoss:     getEnvFloat("MAX_SESSION_LOSS", 0),
+		LiveArmDelay:       getEnvDuration("LIVE_ARM_DELAY", 0),
+		MaxPositionSize:    getEnvFloat("MAX_POSITION_SIZE", 15),
+		SnipePrice:         getEnvFloat("SNIPE_PRICE", 0.99),
+		TriggerSeconds:     getEnvInt("TRIGGER_SECONDS", 1),
+		MinLiquidityShares: getEnvFloat("MIN_LIQUIDITY_SHARES", getEnvFloat("MIN_LIQUIDITY", 0)),
+		MinLiquidityUSD:    getEnvFloat("MIN_LIQUIDITY_USD", 0),
+		MinConfidence:      getEnvFloat("MIN_CONFIDENCE", 0.50),
+		MaxUncertainty:     getEnvFloat("MAX_UNCERTAINTY", 0.10),
+
+		// Sniper execution
+		SnipeStopLossMomentum: getEnvFloat("SNIPE_STOP_LOSS_MOMENTUM", 0),
+		SnipeOrderTimeoutMs:   getEnvInt("SNIPE_ORDER_TIMEOUT_MS", 2000),
+		SnipeCutoffSeconds:    getEnvFloat("SNIPE_CUTOFF_SECONDS", 0),
+		SnipeCoinFlipSeconds:  getEnvFloat("SNIPE_COIN_FLIP_SECONDS", 0),
+		SnipeWarmupSnapshots:  getEnvInt("SNIPE_WARMUP_SNAPSHOTS", 4),
+		SnipeWarmupSeconds:    getEnvInt("SNIPE_WARMUP_SECONDS", 10),
+		SnipeMinMarketAge:     getEnvInt("SNIPE_MIN_MARKET_AGE_SECONDS", 30),
+		SnipePollIdleMs:       getEnvInt("SNIPE_POLL_IDLE_MS", 5000),
+		SnipePollNearMs:       getEnvInt("SNIPE_POLL_NEAR_MS", 500),
+		SnipePollFinalMs:      getEnvInt("SNIPE_POLL_FINAL_MS", 100),
+		SnipeMaxPriceAgeMs:    getEnvInt("SNIPE_MAX_PRICE_AGE_MS", 500),
+		SnipeMaxPosition:      getEnvFloat("SNIPE_MAX_POSITION", 0),
+		SnipeMode:             getEnvString("SNIPE_MODE", "taker"),
+		SnipeMomentumMode:     getEnvString("SNIPE_MOMENTUM_MODE", "simple"),
+		MinExpectedProfit:     getEnvFloat("MIN_EXPECTED_PROFIT", 0),
+		SnipeRecordFile:       os.Getenv("SNIPE_RECORD_FILE"),
 
 		// Black Swan defaults ($15 bankroll optimized)
-		BlackSwanMaxPrice:     getEnvFloat("BLACKSWAN_MAX_PRICE", 0.10),
-		BlackSwanMinPrice:     getEnvFloat("BLACKSWAN_MIN_PRICE", 0.001), // 0.1¢ minimum
-		BlackSwanBetPercent:   getEnvFloat("BLACKSWAN_BET_PERCENT", 0.05),
-		BlackSwanMaxPositions: getEnvInt("BLACKSWAN_MAX_POSITIONS", 10),
-		BlackSwanMaxExposure:  getEnvFloat("BLACKSWAN_MAX_EXPOSURE", 10),
-		BlackSwanBidDiscount:  getEnvFloat("BLACKSWAN_BID_DISCOUNT", 0.25),
-		BlackSwanMinVolume:    getEnvFloat("BLACKSWAN_MIN_VOLUME", 100),
-		BlackSwanMaxVolume:    getEnvFloat("BLACKSWAN_MAX_VOLUME", 10000),
-		BlackSwanMaxDays:      getEnvInt("BLACKSWAN_MAX_DAYS", 30), // Prefer markets resolving within 30 days
+		BlackSwanMaxPrice:      getEnvFloat("BLACKSWAN_MAX_PRICE", 0.10),
+		BlackSwanMinPrice:      getEnvFloat("BLACKSWAN_MIN_PRICE", 0.001), // 0.1¢ minimum
+		BlackSwanMinOpposite:   getEnvFloat("BLACKSWAN_MIN_OPPOSITE_CONFIDENCE", 0.90),
+		BlackSwanBankroll:      getEnvFloat("BLACKSWAN_BANKROLL", 0),
+		BlackSwanBetPercent:    getEnvFloat("BLACKSWAN_BET_PERCENT", 0.05),
+		BlackSwanMaxPositions:  getEnvInt("BLACKSWAN_MAX_POSITIONS", 10),
+		BlackSwanMaxExposure:   getEnvFloat("BLACKSWAN_MAX_EXPOSURE", 10),
+		BlackSwanBidDiscount:   getEnvFloat("BLACKSWAN_BID_DISCOUNT", 0.25),
+		BlackSwanDiscounts:     os.Getenv("BLACKSWAN_BID_DISCOUNT_SCHEDULE"),
+		BlackSwanMinVolume:     getEnvFloat("BLACKSWAN_MIN_VOLUME", 100),
+		BlackSwanMaxVolume:     getEnvFloat("BLACKSWAN_MAX_VOLUME", 10000),
+		BlackSwanMinWeeklyVol:  getEnvFloat("BLACKSWAN_MIN_WEEKLY_VOLUME", 0),
+		BlackSwanMinVolTrend:   getEnvFloat("BLACKSWAN_MIN_VOLUME_TREND", 0),
+		BlackSwanMaxDays:       getEnvInt("BLACKSWAN_MAX_DAYS", 30), // Prefer markets resolving within 30 days
+		BlackSwanIlliquidHours: os.Getenv("BLACKSWAN_ILLIQUID_HOURS"),
+		BlackSwanSellTarget:    getEnvFloat("BLACKSWAN_SELL_TARGET_MULTIPLE", 0),
+		BlackSwanLiveBalance:   getEnvBool("BLACKSWAN_USE_LIVE_BALANCE", false),
+		BlackSwanDigestMins:    getEnvInt("BLACKSWAN_DIGEST_MINUTES", 0),
+		MinEconomicalBet:       getEnvFloat("MIN_ECONOMICAL_BET", 0),
+		SettlementGasUSD:       getEnvFloat("SETTLEMENT_GAS_USD", 0.02),
 
 		// Weather sniper defaults (calibrated model + Quarter-Kelly sizing)
 		// Note: Polymarket requires minimum 5 shares per order
 		// Set WEATHER_BALANCE to your actual USDC balance for accurate sizing
-		WeatherBalance:        getEnvFloat("WEATHER_BALANCE", 0),             // Your balance (0 = try API)
-		WeatherBankroll:       getEnvFloat("WEATHER_BANKROLL", 15),           // Fallback if balance not set
-		WeatherMinEdge:        getEnvFloat("WEATHER_MIN_EDGE", 0.12),         // 12% minimum edge (calibrated model)
-		WeatherMinConfidence:  getEnvFloat("WEATHER_MIN_CONFIDENCE", 0.70),   // 70% confidence
-		WeatherMaxPosition:    getEnvFloat("WEATHER_MAX_POSITION", 5.00),     // $5 max per trade
-		WeatherBetPercent:     getEnvFloat("WEATHER_BET_PERCENT", 0.20),      // Legacy (Kelly sizing used instead)
-		WeatherDailyLossLimit: getEnvFloat("WEATHER_DAILY_LOSS_LIMIT", 10.0), // Daily loss limit
-		WeatherMaxTrades:      getEnvInt("WEATHER_MAX_TRADES", 5),            // Fewer, higher-quality trades
-		WeatherMaxExposure:    getEnvFloat("WEATHER_MAX_EXPOSURE", 50.00),
-		WeatherMinVolume:      getEnvFloat("WEATHER_MIN_VOLUME", 500),
-		WeatherMaxSpread:      getEnvFloat("WEATHER_MAX_SPREAD", 0.05), // 5% max spread
-		WeatherBidDiscount:    getEnvFloat("WEATHER_BID_DISCOUNT", 0.12),
-		WeatherMinPrice:       getEnvFloat("WEATHER_MIN_PRICE", 0.03),      // 3¢ price floor
-		WeatherMaxDivergence:  getEnvFloat("WEATHER_MAX_DIVERGENCE", 0.30), // 30% divergence cap
+		WeatherBalance:         getEnvFloat("WEATHER_BALANCE", 0),             // Your balance (0 = try API)
+		WeatherBankroll:        getEnvFloat("WEATHER_BANKROLL", 15),           // Fallback if balance not set
+		WeatherMinEdge:         getEnvFloat("WEATHER_MIN_EDGE", 0.12),         // 12% minimum edge (calibrated model)
+		WeatherMinConfidence:   getEnvFloat("WEATHER_MIN_CONFIDENCE", 0.70),   // 70% confidence
+		WeatherMaxPosition:     getEnvFloat("WEATHER_MAX_POSITION", 5.00),     // $5 max per trade
+		WeatherBetPercent:      getEnvFloat("WEATHER_BET_PERCENT", 0.20),      // Legacy (Kelly sizing used instead)
+		WeatherDailyLossLimit:  getEnvFloat("WEATHER_DAILY_LOSS_LIMIT", 10.0), // Daily loss limit
+		WeatherMaxTrades:       getEnvInt("WEATHER_MAX_TRADES", 5),            // Fewer, higher-quality trades
+		WeatherMaxExposure:     getEnvFloat("WEATHER_MAX_EXPOSURE", 50.00),
+		WeatherMinVolume:       getEnvFloat("WEATHER_MIN_VOLUME", 500),
+		WeatherMaxSpread:       getEnvFloat("WEATHER_MAX_SPREAD", 0.05), // 5% max spread
+		WeatherBidDiscount:     getEnvFloat("WEATHER_BID_DISCOUNT", 0.12),
+		WeatherMaxDiscountAbs:  getEnvFloat("WEATHER_MAX_BID_DISCOUNT_ABS", 0),
+		WeatherMinPrice:        getEnvFloat("WEATHER_MIN_PRICE", 0.03),      // 3¢ price floor
+		WeatherMaxDivergence:   getEnvFloat("WEATHER_MAX_DIVERGENCE", 0.30), // 30% divergence cap
+		WeatherProbMin:         getEnvFloat("WEATHER_PROB_MIN", 0.02),
+		WeatherProbMax:         getEnvFloat("WEATHER_PROB_MAX", 0.98),
+		WeatherCoinFlipMargin:  getEnvFloat("WEATHER_COIN_FLIP_MARGIN", 0.5),
+		WeatherModelOverrides:  os.Getenv("WEATHER_MODEL_OVERRIDES"),
+		WeatherTempBias:        os.Getenv("WEATHER_TEMP_BIAS"),
+		WeatherTempDoF:         getEnvFloat("WEATHER_TEMP_DOF", 0),
+		WeatherStrictAgreement: getEnvFloat("WEATHER_STRICT_AGREEMENT", 0),
+		WeatherSellTarget:      getEnvFloat("WEATHER_SELL_TARGET_MULTIPLE", 0),
+		WeatherScanWorkers:     getEnvInt("WEATHER_SCAN_CONCURRENCY", 4),
+		WeatherCalibration:     os.Getenv("WEATHER_CALIBRATION_CSV"),
+		WeatherReevaluate:      getEnvBool("WEATHER_REEVALUATE", false),
+		WeatherMaxBuckets:      getEnvInt("WEATHER_MAX_BUCKETS_PER_GROUP", 1),
+		WeatherAPIRetries:      getEnvInt("OPEN_METEO_RETRIES", 3),
+		WeatherAgreement:       os.Getenv("WEATHER_AGREEMENT_FORMULA"),
+		WeatherHorizonBonus:    os.Getenv("WEATHER_HORIZON_BONUS"),
 	}
 
+	cfg.PolygonRPCURLs = getRPCURLs(cfg.PolygonRPCURL)
+	cfg.CancelOrphansOnStart = getEnvBool("CANCEL_ORPHANS_ON_START", false)
+	cfg.WeatherEdgeHalflife = getEnvDuration("WEATHER_EDGE_HALFLIFE", 0)
+	cfg.WeatherEdgeFloor = getEnvFloat("WEATHER_EDGE_FLOOR", 0.05)
+	cfg.WeatherRequireConsensus = getEnvBool("WEATHER_REQUIRE_CONSENSUS", false)
+
 	var missingFields []string
 
-	cfg.PrivateKey = os.Getenv("PRIVATE_KEY")
-	if cfg.PrivateKey == "" {
+	cfg.PrivateKeys = getPrivateKeys(os.Getenv("PRIVATE_KEY"))
+	if len(cfg.PrivateKeys) == 0 {
 		missingFields = append(missingFields, "PRIVATE_KEY")
+	} else {
+		cfg.PrivateKey = cfg.PrivateKeys[0]
 	}
 
 	cfg.CLOBApiKey = os.Getenv("CLOB_API_KEY")
@@ -148,6 +298,12 @@ func Load() (*Config, error) {
 	// Optional telegram config
 	cfg.TelegramBotToken = os.Getenv("TELEGRAM_BOT_TOKEN")
 	cfg.TelegramChatID = os.Getenv("TELEGRAM_CHAT_ID")
+	cfg.TelegramRateLimit = getEnvInt("TELEGRAM_RATE_LIMIT", 20)
+	cfg.EmptyScanAlertAfter = getEnvInt("EMPTY_SCAN_ALERT_AFTER", 10)
+
+	// Optional status API
+	cfg.APIPort = getEnvInt("API_PORT", 0)
+	cfg.APIToken = os.Getenv("API_TOKEN")
 
 	// Optional proxy config - supports comma-separated list
 	proxyEnv := os.Getenv("PROXY_URL")
@@ -163,7 +319,33 @@ func Load() (*Config, error) {
 			cfg.ProxyURL = cfg.ProxyURLs[0] // First proxy as default
 		}
 	}
+	cfg.CLOBUTLS = getEnvBool("CLOB_UTLS", false)
+	cfg.CLOBOrderRetries = getEnvInt("CLOB_ORDER_RETRIES", 2)
+	cfg.ValidateSignatures = getEnvBool("VALIDATE_SIGNATURES", false)
+	cfg.CLOBRederiveCreds = getEnvBool("CLOB_REDERIVE_CREDS", false)
+	cfg.OrderSizeRounding = getEnvString("ORDER_SIZE_ROUNDING", "floor")
+	cfg.CLOBWSURL = os.Getenv("CLOB_WS_URL")
+
+	// Sports sniper data freshness
+	cfg.ESPNTimeout = getEnvDuration("ESPN_TIMEOUT", 10*time.Second)
+	cfg.ESPNRetries = getEnvInt("ESPN_RETRIES", 2)
+	cfg.ESPNCacheTTL = getEnvDuration("ESPN_CACHE_TTL", 5*time.Second)
+	cfg.SportsMaxStale = getEnvDuration("SPORTS_MAX_DATA_AGE", 30*time.Second)
+	cfg.SportsMaxEntry = getEnvFloat("SPORTS_MAX_ENTRY_PRICE", 0)
+	cfg.SportsMargin = getEnvFloat("SPORTS_MIN_PROFIT_MARGIN", 0)
+	cfg.SportsDecidedLeads = os.Getenv("SPORTS_DECIDED_LEADS")
+	cfg.SportsMode = getEnvString("SPORTS_MODE", "both")
+	cfg.SportsSharesPerTrade = getEnvFloat("SPORTS_SHARES_PER_TRADE", 0)
+
+	if err := loadWalletOptions(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
 
+// loadWalletOptions reads the optional proxy wallet and signature type.
+func loadWalletOptions(cfg *Config) error {
 	// Optional proxy wallet (Gnosis Safe)
 	cfg.ProxyWalletAddress = os.Getenv("PROXY_WALLET_ADDRESS")
 
@@ -172,14 +354,14 @@ func Load() (*Config, error) {
 	if sigTypeStr := os.Getenv("SIGNATURE_TYPE"); sigTypeStr != "" {
 		sigType, err := strconv.Atoi(sigTypeStr)
 		if err != nil || sigType < 0 || sigType > 2 {
-			return nil, fmt.Errorf("invalid SIGNATURE_TYPE: must be 0, 1, or 2")
+			return fmt.Errorf("invalid SIGNATURE_TYPE: must be 0, 1, or 2")
 		}
 		cfg.SignatureType = sigType
 	} else if cfg.ProxyWalletAddress != "" {
 		cfg.SignatureType = 2 // Default to GNOSIS_SAFE for browser wallet connections
 	}
 
-	return cfg, nil
+	return nil
 }
 
 // LoadMinimal loads only basic config without requiring API credentials.
@@ -192,18 +374,30 @@ func LoadMinimal() (*Config, error) {
 		}
 	}
 
-	return &Config{
-		PolygonChainID:  getEnvInt("POLYGON_CHAIN_ID", 137),
-		PolygonRPCURL:   getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
-		DryRun:          getEnvBool("DRY_RUN", true),
-		MaxPositionSize: getEnvFloat("MAX_POSITION_SIZE", 10),
-		SnipePrice:      getEnvFloat("SNIPE_PRICE", 0.99),
-		TriggerSeconds:  getEnvInt("TRIGGER_SECONDS", 1),
-		MinLiquidity:    getEnvFloat("MIN_LIQUIDITY", 5),
-		MinConfidence:   getEnvFloat("MIN_CONFIDENCE", 0.50),
-		MaxUncertainty:  getEnvFloat("MAX_UNCERTAINTY", 0.10),
-		PrivateKey:      os.Getenv("PRIVATE_KEY"),
-	}, nil
+	cfg := &Config{
+		PolygonChainID:     getEnvInt("POLYGON_CHAIN_ID", 137),
+		PolygonRPCURL:      getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
+		DryRun:             getEnvBool("DRY_RUN", true),
+		MaxPositionSize:    getEnvFloat("MAX_POSITION_SIZE", 10),
+		SnipePrice:         getEnvFloat("SNIPE_PRICE", 0.99),
+		TriggerSeconds:     getEnvInt("TRIGGER_SECONDS", 1),
+		MinLiquidityShares: getEnvFloat("MIN_LIQUIDITY_SHARES", getEnvFloat("MIN_LIQUIDITY", 0)),
+		MinLiquidityUSD:    getEnvFloat("MIN_LIQUIDITY_USD", 0),
+		MinConfidence:      getEnvFloat("MIN_CONFIDENCE", 0.50),
+		MaxUncertainty:     getEnvFloat("MAX_UNCERTAINTY", 0.10),
+		PrivateKey:         os.Getenv("PRIVATE_KEY"),
+	}
+	cfg.PolygonRPCURLs = getRPCURLs(cfg.PolygonRPCURL)
+
+	// Sniper settings offline tools replay with
+	cfg.SnipeWarmupSnapshots = getEnvInt("SNIPE_WARMUP_SNAPSHOTS", 4)
+	cfg.SnipeWarmupSeconds = getEnvInt("SNIPE_WARMUP_SECONDS", 10)
+	cfg.SnipeMomentumMode = getEnvString("SNIPE_MOMENTUM_MODE", "simple")
+	cfg.SnipeMaxPosition = getEnvFloat("SNIPE_MAX_POSITION", 0)
+	cfg.MinExpectedProfit = getEnvFloat("MIN_EXPECTED_PROFIT", 0)
+	cfg.SnipeRecordFile = os.Getenv("SNIPE_RECORD_FILE")
+
+	return cfg, nil
 }
 
 // LoadWithPrivateKey loads config requiring only the private key.
@@ -216,25 +410,77 @@ func LoadWithPrivateKey() (*Config, error) {
 	}
 
 	cfg := &Config{
-		PolygonChainID:  getEnvInt("POLYGON_CHAIN_ID", 137),
-		PolygonRPCURL:   getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
-		DryRun:          getEnvBool("DRY_RUN", true),
-		MaxPositionSize: getEnvFloat("MAX_POSITION_SIZE", 10),
-		SnipePrice:      getEnvFloat("SNIPE_PRICE", 0.99),
-		TriggerSeconds:  getEnvInt("TRIGGER_SECONDS", 1),
-		MinLiquidity:    getEnvFloat("MIN_LIQUIDITY", 5),
-		MinConfidence:   getEnvFloat("MIN_CONFIDENCE", 0.50),
-		MaxUncertainty:  getEnvFloat("MAX_UNCERTAINTY", 0.10),
+		PolygonChainID:     getEnvInt("POLYGON_CHAIN_ID", 137),
+		PolygonRPCURL:      getEnvString("POLYGON_RPC_URL", "https://polygon-rpc.com"),
+		DryRun:             getEnvBool("DRY_RUN", true),
+		MaxPositionSize:    getEnvFloat("MAX_POSITION_SIZE", 10),
+		SnipePrice:         getEnvFloat("SNIPE_PRICE", 0.99),
+		TriggerSeconds:     getEnvInt("TRIGGER_SECONDS", 1),
+		MinLiquidityShares: getEnvFloat("MIN_LIQUIDITY_SHARES", getEnvFloat("MIN_LIQUIDITY", 0)),
+		MinLiquidityUSD:    getEnvFloat("MIN_LIQUIDITY_USD", 0),
+		MinConfidence:      getEnvFloat("MIN_CONFIDENCE", 0.50),
+		MaxUncertainty:     getEnvFloat("MAX_UNCERTAINTY", 0.10),
 	}
 
+	cfg.PolygonRPCURLs = getRPCURLs(cfg.PolygonRPCURL)
+	cfg.MaxGasPriceGwei = getEnvFloat("MAX_GAS_PRICE_GWEI", 0)
+	cfg.GasTipGwei = getEnvFloat("GAS_TIP_GWEI", 0)
+	cfg.GasFeeCapGwei = getEnvFloat("GAS_FEE_CAP_GWEI", 0)
+
 	cfg.PrivateKey = os.Getenv("PRIVATE_KEY")
 	if cfg.PrivateKey == "" {
 		return nil, errors.New("missing required config: PRIVATE_KEY")
 	}
 
+	// Optional - used as order owner by tools that build orders offline
+	cfg.CLOBApiKey = os.Getenv("CLOB_API_KEY")
+	cfg.OrderSizeRounding = getEnvString("ORDER_SIZE_ROUNDING", "floor")
+
+	if err := loadWalletOptions(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// LogConfig controls where the standard logger writes.
+type LogConfig struct {
+	File       string // Also write logs to this file when set
+	Stdout     bool   // Keep writing logs to stdout (default: true)
+	MaxSizeMB  int    // Rotate the log file at this size (default: 50)
+	MaxBackups int    // Rotated log files to keep, 0 = all (default: 5)
+}
+
+// LoadLogConfig loads the logging settings. It reads .env itself so logging
+// can be set up before the rest of the config is loaded.
+func LoadLogConfig() LogConfig {
+	_ = godotenv.Load() // .env is optional; Load reports a real error later
+
+	return LogConfig{
+		File:       os.Getenv("LOG_FILE"),
+		Stdout:     getEnvBool("LOG_STDOUT", true),
+		MaxSizeMB:  getEnvInt("LOG_MAX_SIZE_MB", 50),
+		MaxBackups: getEnvInt("LOG_MAX_BACKUPS", 5),
+	}
+}
+
+// TelegramConfig holds the Telegram notification settings.
+type TelegramConfig struct {
+	BotToken string
+	ChatID   string
+}
+
+// LoadTelegramConfig loads just the Telegram settings, so notifications can
+// be tested before trading credentials are set up.
+func LoadTelegramConfig() TelegramConfig {
+	_ = godotenv.Load() // .env is optional
+
+	return TelegramConfig{
+		BotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
+		ChatID:   os.Getenv("TELEGRAM_CHAT_ID"),
+	}
+}
+
 // HasTelegram returns true if Telegram notifications are configured
 func (c *Config) HasTelegram() bool {
 	return c.TelegramBotToken != "" && c.TelegramChatID != ""
@@ -245,6 +491,33 @@ func (c *Config) UseProxyWallet() bool {
 	return c.ProxyWalletAddress != ""
 }
 
+// SnipePositionSize returns the sniper's most dollars per snipe:
+// SNIPE_MAX_POSITION, or MAX_POSITION_SIZE when that isn't set.
+func (c *Config) SnipePositionSize() float64 {
+	return positiveOr(c.SnipeMaxPosition, c.MaxPositionSize)
+}
+
+// SportsShares returns the shares the sports sniper buys per trade:
+// SPORTS_SHARES_PER_TRADE, or MAX_POSITION_SIZE when that isn't set. Unlike
+// the sniper's dollar cap it is a share count, so the stake is this times
+// the entry price.
+func (c *Config) SportsShares() float64 {
+	return positiveOr(c.SportsSharesPerTrade, c.MaxPositionSize)
+}
+
+// BlackSwanBankrollSize returns the bankroll black swan bets are sized
+// from: BLACKSWAN_BANKROLL, or MAX_POSITION_SIZE when that isn't set.
+func (c *Config) BlackSwanBankrollSize() float64 {
+	return positiveOr(c.BlackSwanBankroll, c.MaxPositionSize)
+}
+
+func positiveOr(v, fallback float64) float64 {
+	if v > 0 {
+		return v
+	}
+	return fallback
+}
+
 // Validate performs runtime validation of config values
 func (c *Config) Validate() error {
 	if c.SnipePrice < 0 || c.SnipePrice > 1 {
@@ -253,9 +526,36 @@ func (c *Config) Validate() error {
 	if c.MaxPositionSize <= 0 {
 		return errors.New("MAX_POSITION_SIZE must be greater than 0")
 	}
+	if c.SnipeMaxPosition < 0 || c.SportsSharesPerTrade < 0 || c.BlackSwanBankroll < 0 {
+		return errors.New("SNIPE_MAX_POSITION, SPORTS_SHARES_PER_TRADE and BLACKSWAN_BANKROLL must be non-negative")
+	}
 	if c.TriggerSeconds < 0 {
 		return errors.New("TRIGGER_SECONDS must be non-negative")
 	}
+	if c.MinExpectedProfit < 0 {
+		return errors.New("MIN_EXPECTED_PROFIT must be non-negative")
+	}
+	if c.MinEconomicalBet < 0 || c.SettlementGasUSD < 0 {
+		return errors.New("MIN_ECONOMICAL_BET and SETTLEMENT_GAS_USD must be non-negative")
+	}
+	if c.WeatherEdgeHalflife < 0 || c.WeatherEdgeFloor < 0 {
+		return errors.New("WEATHER_EDGE_HALFLIFE and WEATHER_EDGE_FLOOR must be non-negative")
+	}
+	if c.WeatherCoinFlipMargin < 0 {
+		return errors.New("WEATHER_COIN_FLIP_MARGIN must be non-negative")
+	}
+	if c.WeatherProbMin < 0 || c.WeatherProbMax > 1 || c.WeatherProbMin >= c.WeatherProbMax {
+		return errors.New("WEATHER_PROB_MIN and WEATHER_PROB_MAX must satisfy 0 <= min < max <= 1")
+	}
+	if c.SportsMaxEntry < 0 || c.SportsMaxEntry > 1 {
+		return errors.New("SPORTS_MAX_ENTRY_PRICE must be between 0 and 1")
+	}
+	if c.SportsMargin < 0 {
+		return errors.New("SPORTS_MIN_PROFIT_MARGIN must be non-negative")
+	}
+	if c.BlackSwanMinOpposite < 0 || c.BlackSwanMinOpposite > 1 {
+		return errors.New("BLACKSWAN_MIN_OPPOSITE_CONFIDENCE must be between 0 and 1")
+	}
 	return nil
 }
 
@@ -295,6 +595,56 @@ func getEnvBool(key string, defaultVal bool) bool {
 	return parsed
 }
 
+// getRPCURLs parses POLYGON_RPC_URLS (comma-separated) into a failover list.
+// Falls back to the single primary URL when unset.
+func getRPCURLs(primary string) []string {
+	var urls []string
+	for _, u := range strings.Split(os.Getenv("POLYGON_RPC_URLS"), ",") {
+		u = strings.TrimSpace(u)
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 && primary != "" {
+		urls = []string{primary}
+	}
+	return urls
+}
+
+// getPrivateKeys returns primary followed by the comma-separated keys in
+// PRIVATE_KEYS, without duplicates. With no primary the first listed key
+// takes its place.
+func getPrivateKeys(primary string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, k := range append([]string{primary}, strings.Split(os.Getenv("PRIVATE_KEYS"), ",")...) {
+		k = strings.TrimSpace(k)
+		if k == "" || seen[strings.TrimPrefix(k, "0x")] {
+			continue
+		}
+		seen[strings.TrimPrefix(k, "0x")] = true
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// getEnvDuration parses a Go duration such as "30s", or a bare number of
+// seconds.
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	if secs, err := strconv.Atoi(val); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	parsed, err := time.ParseDuration(val)
+	if err != nil {
+		return defaultVal
+	}
+	return parsed
+}
+
 func getEnvString(key string, defaultVal string) string {
 	val := os.Getenv(key)
 	if val == "" {
@@ -0,0 +1,131 @@
+// Package dataapi is a client for Polymarket's public Data API, which
+// reports a wallet's actual holdings and their P&L.
+package dataapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+// DefaultBaseURL is the public Data API.
+const DefaultBaseURL = "https://data-api.polymarket.com"
+
+// positionsLimit is how many positions GetPositions asks for.
+const positionsLimit = 500
+
+// Position is a token holding as reported by the Data API.
+type Position struct {
+	ProxyWallet  string  `json:"proxyWallet"`
+	Asset        string  `json:"asset"` // CLOB token ID
+	ConditionID  string  `json:"conditionId"`
+	Size         float64 `json:"size"`
+	AvgPrice     float64 `json:"avgPrice"`
+	InitialValue float64 `json:"initialValue"`
+	CurrentValue float64 `json:"currentValue"`
+	CurPrice     float64 `json:"curPrice"`
+	CashPnl      float64 `json:"cashPnl"` // Unrealized, at the current price
+	RealizedPnl  float64 `json:"realizedPnl"`
+	Redeemable   bool    `json:"redeemable"`
+	Title        string  `json:"title"`
+	Slug         string  `json:"slug"`
+	Outcome      string  `json:"outcome"`
+}
+
+// value is one entry of the /value response.
+type value struct {
+	User  string  `json:"user"`
+	Value float64 `json:"value"`
+}
+
+// Client queries the Data API. No credentials are needed.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClient creates a Data API client.
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 10 * time.Second, Transport: httpx.Transport(nil)},
+		baseURL:    DefaultBaseURL,
+	}
+}
+
+// WithBaseURL sets a custom API URL (useful for testing).
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	c.baseURL = baseURL
+	return c
+}
+
+// GetPositions returns the open positions held by address, which should be
+// the proxy wallet when trading through one.
+func (c *Client) GetPositions(address string) ([]Position, error) {
+	params := url.Values{}
+	params.Set("user", address)
+	params.Set("limit", fmt.Sprint(positionsLimit))
+
+	var positions []Position
+	if err := c.get("/positions", params, &positions); err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	return positions, nil
+}
+
+// GetValue returns the current value of everything address holds, in USDC.
+// An address with no holdings is worth 0.
+func (c *Client) GetValue(address string) (float64, error) {
+	params := url.Values{}
+	params.Set("user", address)
+
+	var values []value
+	if err := c.get("/value", params, &values); err != nil {
+		return 0, fmt.Errorf("failed to get holdings value: %w", err)
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+	return values[0].Value, nil
+}
+
+// get fetches path and decodes the JSON response into out.
+func (c *Client) get(path string, params url.Values, out interface{}) error {
+	endpoint := fmt.Sprintf("%s%s?%s", c.baseURL, path, params.Encode())
+	resp, err := c.httpClient.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// Summary totals a set of positions.
+type Summary struct {
+	Cost          float64 // What the positions cost at their average price
+	Value         float64 // What they're worth at the current price
+	UnrealizedPnL float64
+	RealizedPnL   float64
+}
+
+// Summarize totals positions' cost, value and P&L.
+func Summarize(positions []Position) Summary {
+	var s Summary
+	for _, p := range positions {
+		s.Cost += p.InitialValue
+		s.Value += p.CurrentValue
+		s.UnrealizedPnL += p.CashPnl
+		s.RealizedPnL += p.RealizedPnl
+	}
+	return s
+}
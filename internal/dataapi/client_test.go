@@ -0,0 +1,117 @@
+package dataapi
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Recorded from data-api.polymarket.com, trimmed to the fields we read
+const positionsResponse = `[
+  {
+    "proxyWallet": "0x56687bf447db6ffa42ffe2204a05edaa20f55839",
+    "asset": "52114319501245915516055106046884209969926127482827954674443846427813813222426",
+    "conditionId": "0xdd22472e552920b8438158ea7238bfadfa4f736aa4cee91a6b86c39ead110917",
+    "size": 12.5,
+    "avgPrice": 0.4,
+    "initialValue": 5,
+    "currentValue": 7.5,
+    "cashPnl": 2.5,
+    "percentPnl": 50,
+    "totalBought": 12.5,
+    "realizedPnl": 0,
+    "curPrice": 0.6,
+    "redeemable": false,
+    "title": "Will the highest temperature in London be 12°C on March 4?",
+    "slug": "highest-temperature-in-london-on-march-4-12c",
+    "outcome": "Yes",
+    "outcomeIndex": 0,
+    "endDate": "2026-03-04"
+  },
+  {
+    "proxyWallet": "0x56687bf447db6ffa42ffe2204a05edaa20f55839",
+    "asset": "10101",
+    "conditionId": "0xabc",
+    "size": 100,
+    "avgPrice": 0.03,
+    "initialValue": 3,
+    "currentValue": 1,
+    "cashPnl": -2,
+    "realizedPnl": 1.25,
+    "curPrice": 0.01,
+    "redeemable": true,
+    "title": "Long shot",
+    "slug": "long-shot",
+    "outcome": "No"
+  }
+]`
+
+func TestClient_GetPositions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/positions" || r.URL.Query().Get("user") != "0xabc" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(positionsResponse))
+	}))
+	defer srv.Close()
+
+	positions, err := NewClient().WithBaseURL(srv.URL).GetPositions("0xabc")
+	if err != nil {
+		t.Fatalf("GetPositions: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2", len(positions))
+	}
+	p := positions[0]
+	if p.Size != 12.5 || p.AvgPrice != 0.4 || p.CurPrice != 0.6 || p.Outcome != "Yes" ||
+		p.Slug != "highest-temperature-in-london-on-march-4-12c" {
+		t.Errorf("position = %+v", p)
+	}
+	if !positions[1].Redeemable {
+		t.Error("second position should be redeemable")
+	}
+
+	s := Summarize(positions)
+	want := Summary{Cost: 8, Value: 8.5, UnrealizedPnL: 0.5, RealizedPnL: 1.25}
+	if math.Abs(s.Cost-want.Cost) > 1e-9 || math.Abs(s.Value-want.Value) > 1e-9 ||
+		math.Abs(s.UnrealizedPnL-want.UnrealizedPnL) > 1e-9 || math.Abs(s.RealizedPnL-want.RealizedPnL) > 1e-9 {
+		t.Errorf("Summarize = %+v, want %+v", s, want)
+	}
+}
+
+func TestClient_GetValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    float64
+		wantErr bool
+	}{
+		{"holdings", http.StatusOK, `[{"user":"0xabc","value":42.17}]`, 42.17, false},
+		{"no holdings", http.StatusOK, `[]`, 0, false},
+		{"server error", http.StatusInternalServerError, `{"error":"boom"}`, 0, true},
+		{"bad json", http.StatusOK, `{`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/value" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got, err := NewClient().WithBaseURL(srv.URL).GetValue("0xabc")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValue error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetValue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
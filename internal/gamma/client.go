@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
 )
 
 const (
@@ -29,7 +31,8 @@ type Client struct {
 func NewClient() *Client {
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: defaultTimeout,
+			Timeout:   defaultTimeout,
+			Transport: httpx.Transport(nil),
 		},
 		baseURL: baseURL,
 	}
@@ -39,12 +42,19 @@ func NewClient() *Client {
 func NewClientWithTimeout(timeout time.Duration) *Client {
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: httpx.Transport(nil),
 		},
 		baseURL: baseURL,
 	}
 }
 
+// WithBaseURL sets a custom base URL (useful for testing).
+func (c *Client) WithBaseURL(url string) *Client {
+	c.baseURL = url
+	return c
+}
+
 // NewClientWithProxy creates a new Gamma API client with HTTP or SOCKS5 proxy support.
 func NewClientWithProxy(proxyURL string) *Client {
 	var transport *http.Transport
@@ -85,7 +95,7 @@ func NewClientWithProxy(proxyURL string) *Client {
 	return &Client{
 		httpClient: &http.Client{
 			Timeout:   defaultTimeout,
-			Transport: transport,
+			Transport: httpx.Transport(transport),
 		},
 		baseURL: baseURL,
 	}
@@ -196,7 +206,7 @@ func (c *Client) SearchMarkets(query string) ([]Market, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newGammaError(resp)
 	}
 
 	var markets []Market
@@ -230,6 +240,10 @@ func (c *Client) GetActiveUpDownMarkets() ([]Market, error) {
 			slug := fmt.Sprintf("%s-updown-15m-%d", asset, targetStartTime)
 
 			market, err := c.GetMarketBySlug(slug)
+			if IsRateLimited(err) {
+				// More lookups would only keep the limit tripped
+				return nil, err
+			}
 			if err == nil && market != nil && market.Active && !market.Closed {
 				// Verify the market hasn't ended
 				endTime, _ := market.EndTime()
@@ -282,7 +296,7 @@ func (c *Client) GetMarketBySlug(slug string) (*Market, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newGammaError(resp)
 	}
 
 	var markets []Market
@@ -291,7 +305,7 @@ func (c *Client) GetMarketBySlug(slug string) (*Market, error) {
 	}
 
 	if len(markets) == 0 {
-		return nil, fmt.Errorf("market not found: %s", slug)
+		return nil, &GammaError{StatusCode: http.StatusNotFound, Message: "market not found: " + slug}
 	}
 
 	return &markets[0], nil
@@ -360,6 +374,9 @@ func (c *Client) SearchMarketsWithParams(params SearchParams) ([]Market, error)
 	if params.EndDateMax != "" {
 		queryParams.Set("end_date_max", params.EndDateMax)
 	}
+	if params.TagID != "" {
+		queryParams.Set("tag_id", params.TagID)
+	}
 
 	endpoint := fmt.Sprintf("%s/markets?%s", c.baseURL, queryParams.Encode())
 
@@ -370,7 +387,7 @@ func (c *Client) SearchMarketsWithParams(params SearchParams) ([]Market, error)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newGammaError(resp)
 	}
 
 	var markets []Market
@@ -381,6 +398,42 @@ func (c *Client) SearchMarketsWithParams(params SearchParams) ([]Market, error)
 	return markets, nil
 }
 
+// GetMarketsClosingWithin retrieves open markets whose end time falls within
+// d from now, soonest first. tag is a Gamma tag ID to narrow the search, or
+// empty for all markets. The date range is sent to the API and re-checked
+// here, since Gamma doesn't always apply it.
+func (c *Client) GetMarketsClosingWithin(d time.Duration, tag string) ([]Market, error) {
+	now := time.Now()
+	deadline := now.Add(d)
+
+	markets, err := c.SearchMarketsWithParams(SearchParams{
+		Active:     true,
+		Closed:     false,
+		Limit:      500,
+		OrderBy:    "endDate",
+		Order:      "ASC",
+		EndDateMin: now.UTC().Format(time.RFC3339),
+		EndDateMax: deadline.UTC().Format(time.RFC3339),
+		TagID:      tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	closing := make([]Market, 0, len(markets))
+	for _, market := range markets {
+		if !market.Active || market.Closed {
+			continue
+		}
+		endTime, err := market.EndTime()
+		if err != nil || !endTime.After(now) || endTime.After(deadline) {
+			continue
+		}
+		closing = append(closing, market)
+	}
+	return closing, nil
+}
+
 // GetSportsMarkets retrieves active sports betting markets (NFL, NBA, etc.).
 func (c *Client) GetSportsMarkets() ([]Market, error) {
 	marketMap := make(map[string]Market)
@@ -494,12 +547,8 @@ func (c *Client) GetMarketByConditionID(conditionID string) (*Market, error) {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("market not found: %s", conditionID)
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newGammaError(resp)
 	}
 
 	var market Market
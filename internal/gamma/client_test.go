@@ -0,0 +1,123 @@
+package gamma
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetMarketsClosingWithin(t *testing.T) {
+	now := time.Now().UTC()
+	at := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets" {
+			t.Errorf("path = %s, want /markets", r.URL.Path)
+		}
+		query = r.URL.Query()
+		fmt.Fprintf(w, `[
+			{"slug": "soon", "active": true, "endDate": %q},
+			{"slug": "later", "active": true, "endDate": %q},
+			{"slug": "ended", "active": true, "endDate": %q},
+			{"slug": "closed", "active": true, "closed": true, "endDate": %q},
+			{"slug": "no-date", "active": true}
+		]`, at(30*time.Minute), at(3*time.Hour), at(-time.Minute), at(20*time.Minute))
+	}))
+	defer srv.Close()
+
+	markets, err := NewClient().WithBaseURL(srv.URL).GetMarketsClosingWithin(time.Hour, "100639")
+	if err != nil {
+		t.Fatalf("GetMarketsClosingWithin: %v", err)
+	}
+	if len(markets) != 1 || markets[0].Slug != "soon" {
+		t.Fatalf("markets = %+v, want only soon", markets)
+	}
+
+	minEnd, err := time.Parse(time.RFC3339, query.Get("end_date_min"))
+	if err != nil {
+		t.Fatalf("end_date_min = %q: %v", query.Get("end_date_min"), err)
+	}
+	maxEnd, err := time.Parse(time.RFC3339, query.Get("end_date_max"))
+	if err != nil {
+		t.Fatalf("end_date_max = %q: %v", query.Get("end_date_max"), err)
+	}
+	if window := maxEnd.Sub(minEnd); window != time.Hour {
+		t.Errorf("date window = %v, want 1h", window)
+	}
+	if d := minEnd.Sub(now); d < -time.Second || d > time.Second {
+		t.Errorf("end_date_min = %v, want about now (%v)", minEnd, now)
+	}
+
+	want := map[string]string{"active": "true", "closed": "false", "tag_id": "100639", "_sort": "endDate", "_order": "ASC"}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestClient_ClassifiesErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/markets/gone":
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+		case r.URL.Query().Get("slug") == "empty":
+			fmt.Fprint(w, `[]`)
+		case r.URL.Query().Get("slug") == "limited":
+			w.Header().Set("Retry-After", "30")
+			http.Error(w, `{"error":"too many requests"}`, http.StatusTooManyRequests)
+		default:
+			http.Error(w, `upstream down`, http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient().WithBaseURL(srv.URL)
+
+	tests := []struct {
+		name        string
+		call        func() error
+		status      int
+		notFound    bool
+		rateLimited bool
+		retryAfter  time.Duration
+	}{
+		{"404 by condition ID", func() error { _, err := c.GetMarketByConditionID("gone"); return err }, http.StatusNotFound, true, false, 0},
+		{"unknown slug", func() error { _, err := c.GetMarketBySlug("empty"); return err }, http.StatusNotFound, true, false, 0},
+		{"rate limited", func() error { _, err := c.GetMarketBySlug("limited"); return err }, http.StatusTooManyRequests, false, true, 30 * time.Second},
+		{"server error", func() error { _, err := c.SearchMarkets("btc"); return err }, http.StatusBadGateway, false, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var ge *GammaError
+			if !errors.As(err, &ge) {
+				t.Fatalf("err = %v, want a *GammaError", err)
+			}
+			if ge.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", ge.StatusCode, tt.status)
+			}
+			if IsNotFound(err) != tt.notFound {
+				t.Errorf("IsNotFound = %v, want %v", IsNotFound(err), tt.notFound)
+			}
+			if IsRateLimited(err) != tt.rateLimited {
+				t.Errorf("IsRateLimited = %v, want %v", IsRateLimited(err), tt.rateLimited)
+			}
+			if ge.IsServerError() != (tt.status >= 500) {
+				t.Errorf("IsServerError = %v for status %d", ge.IsServerError(), tt.status)
+			}
+			if ge.RetryAfter != tt.retryAfter {
+				t.Errorf("RetryAfter = %v, want %v", ge.RetryAfter, tt.retryAfter)
+			}
+		})
+	}
+
+	// Wrapped errors classify the same
+	if !IsRateLimited(fmt.Errorf("failed to scan: %w", &GammaError{StatusCode: http.StatusTooManyRequests})) {
+		t.Error("wrapped rate limit not recognized")
+	}
+}
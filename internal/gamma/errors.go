@@ -0,0 +1,73 @@
+package gamma
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBody caps how much of an error response is kept in a GammaError.
+const maxErrorBody = 512
+
+// GammaError is returned when the Gamma API answers with a status other than
+// 200, so callers can tell a missing market from a rate limit or an outage.
+type GammaError struct {
+	StatusCode int
+	Message    string        // Response body, or a description when there was none
+	RetryAfter time.Duration // From the Retry-After header, 0 when absent
+}
+
+func (e *GammaError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code: %d: %s", e.StatusCode, e.Message)
+}
+
+// IsRateLimited reports whether Gamma refused the request for being over its
+// rate limit.
+func (e *GammaError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNotFound reports whether the requested market or event doesn't exist.
+func (e *GammaError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsServerError reports whether Gamma failed on its side with a 5xx.
+func (e *GammaError) IsServerError() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsRateLimited reports whether err, or any error it wraps, is a rate-limited
+// GammaError.
+func IsRateLimited(err error) bool {
+	var ge *GammaError
+	return errors.As(err, &ge) && ge.IsRateLimited()
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a GammaError for
+// a market or event that doesn't exist.
+func IsNotFound(err error) bool {
+	var ge *GammaError
+	return errors.As(err, &ge) && ge.IsNotFound()
+}
+
+// newGammaError builds a GammaError from a non-200 response, reading a
+// bounded amount of its body.
+func newGammaError(resp *http.Response) *GammaError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	ge := &GammaError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		ge.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return ge
+}
@@ -0,0 +1,84 @@
+// Package gammamock provides an in-memory gamma.MarketSource for strategy
+// tests.
+package gammamock
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// Client serves canned market lists. GetMarketBySlug looks a slug up across
+// all of them, with Markets taking precedence.
+type Client struct {
+	UpDown     []gamma.Market // GetActiveUpDownMarkets
+	Weather    []gamma.Market // GetWeatherMarkets
+	NFLPlayoff []gamma.Market // GetNFLPlayoffMarkets
+	Search     []gamma.Market // SearchMarketsWithParams
+	Markets    []gamma.Market // Extra markets only reachable by slug
+	Err        error          // Returned by every call when set
+
+	mu       sync.Mutex
+	searches []gamma.SearchParams
+}
+
+var _ gamma.MarketSource = (*Client)(nil)
+
+// New creates an empty mock.
+func New() *Client {
+	return &Client{}
+}
+
+// GetActiveUpDownMarkets returns UpDown.
+func (c *Client) GetActiveUpDownMarkets() ([]gamma.Market, error) {
+	return c.list(c.UpDown)
+}
+
+// GetWeatherMarkets returns Weather.
+func (c *Client) GetWeatherMarkets() ([]gamma.Market, error) {
+	return c.list(c.Weather)
+}
+
+// GetNFLPlayoffMarkets returns NFLPlayoff.
+func (c *Client) GetNFLPlayoffMarkets() ([]gamma.Market, error) {
+	return c.list(c.NFLPlayoff)
+}
+
+// SearchMarketsWithParams records params and returns Search unfiltered.
+func (c *Client) SearchMarketsWithParams(params gamma.SearchParams) ([]gamma.Market, error) {
+	c.mu.Lock()
+	c.searches = append(c.searches, params)
+	c.mu.Unlock()
+	return c.list(c.Search)
+}
+
+// GetMarketBySlug finds a market in any of the canned lists.
+func (c *Client) GetMarketBySlug(slug string) (*gamma.Market, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	for _, list := range [][]gamma.Market{c.Markets, c.UpDown, c.Weather, c.NFLPlayoff, c.Search} {
+		for i := range list {
+			if list[i].Slug == slug {
+				m := list[i]
+				return &m, nil
+			}
+		}
+	}
+	return nil, &gamma.GammaError{StatusCode: http.StatusNotFound, Message: "market not found: " + slug}
+}
+
+// Searches returns the params of every search so far.
+func (c *Client) Searches() []gamma.SearchParams {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]gamma.SearchParams(nil), c.searches...)
+}
+
+func (c *Client) list(markets []gamma.Market) ([]gamma.Market, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return append([]gamma.Market(nil), markets...), nil
+}
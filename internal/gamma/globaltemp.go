@@ -0,0 +1,66 @@
+package gamma
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	anomalyValueRegex = regexp.MustCompile(`(\d+\.\d+)`)
+	monthYearRegex    = regexp.MustCompile(`(january|february|march|april|may|june|july|august|september|october|november|december)\s+(\d{4})`)
+)
+
+// GlobalTempBounds returns the anomaly range in °C that resolves a global
+// temperature market YES. NASA publishes two decimals, so the bounds are
+// cutoffs halfway between hundredths: "more than 1.30ºC" gives
+// (1.305, +Inf) and "between 1.20ºC and 1.24ºC" gives (1.195, 1.245).
+func (wm *WeatherMarket) GlobalTempBounds() (low, high float64, ok bool) {
+	const halfHundredth = 0.005
+
+	question := strings.ToLower(wm.Market.Question)
+
+	var values []float64
+	for _, m := range anomalyValueRegex.FindAllStringSubmatch(question, -1) {
+		if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+			values = append(values, v)
+		}
+	}
+
+	switch {
+	case len(values) >= 2:
+		return math.Min(values[0], values[1]) - halfHundredth, math.Max(values[0], values[1]) + halfHundredth, true
+	case len(values) == 1:
+		v := values[0]
+		switch {
+		case strings.Contains(question, "or less") || strings.Contains(question, "or lower"):
+			return math.Inf(-1), v + halfHundredth, true
+		case strings.Contains(question, "less than") || strings.Contains(question, "below"):
+			return math.Inf(-1), v - halfHundredth, true
+		case strings.Contains(question, "or more") || strings.Contains(question, "or higher") ||
+			strings.Contains(question, "at least"):
+			return v - halfHundredth, math.Inf(1), true
+		case strings.Contains(question, "more than") || strings.Contains(question, "above") ||
+			strings.Contains(question, "exceed"):
+			return v + halfHundredth, math.Inf(1), true
+		}
+	}
+	return 0, 0, false
+}
+
+// TargetMonth returns the month a global temperature market measures, taken
+// from the question ("... in March 2025?"). Without one it assumes the month
+// before resolution, since NASA publishes each month mid-way through the next.
+func (wm *WeatherMarket) TargetMonth() time.Time {
+	question := strings.ToLower(wm.Market.Question)
+	if m := monthYearRegex.FindStringSubmatch(question); m != nil {
+		if t, err := time.Parse("January 2006", strings.ToUpper(m[1][:1])+m[1][1:]+" "+m[2]); err == nil {
+			return t
+		}
+	}
+
+	prev := wm.ResolutionDate.AddDate(0, 0, -wm.ResolutionDate.Day())
+	return time.Date(prev.Year(), prev.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,71 @@
+package gamma
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGlobalTempBounds(t *testing.T) {
+	tests := []struct {
+		question string
+		wantLow  float64
+		wantHigh float64
+		wantOK   bool
+	}{
+		{"Will global temperature increase by more than 1.30ºC in March 2025?", 1.305, math.Inf(1), true},
+		{"Will global temperature increase by 1.30ºC or more in March 2025?", 1.295, math.Inf(1), true},
+		{"Will global temperature increase by less than 1.05ºC in March 2025?", math.Inf(-1), 1.045, true},
+		{"Will global temperature increase by between 1.20ºC and 1.24ºC in March 2025?", 1.195, 1.245, true},
+		{"Will global temperature increase in March 2025?", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.question, func(t *testing.T) {
+			wm := &WeatherMarket{Market: Market{Question: tt.question}}
+			low, high, ok := wm.GlobalTempBounds()
+			if ok != tt.wantOK || !closeTo(low, tt.wantLow) || !closeTo(high, tt.wantHigh) {
+				t.Errorf("GlobalTempBounds() = (%v, %v, %v), want (%v, %v, %v)",
+					low, high, ok, tt.wantLow, tt.wantHigh, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTargetMonth(t *testing.T) {
+	tests := []struct {
+		name       string
+		question   string
+		resolution time.Time
+		want       time.Time
+	}{
+		{
+			name:       "month in question",
+			question:   "Will global temperature increase by more than 1.30ºC in March 2025?",
+			resolution: time.Date(2025, time.April, 15, 12, 0, 0, 0, time.UTC),
+			want:       time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "falls back to month before resolution",
+			question:   "Will global temperature increase by more than 1.30ºC?",
+			resolution: time.Date(2025, time.January, 10, 12, 0, 0, 0, time.UTC),
+			want:       time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wm := &WeatherMarket{Market: Market{Question: tt.question}, ResolutionDate: tt.resolution}
+			if got := wm.TargetMonth(); !got.Equal(tt.want) {
+				t.Errorf("TargetMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func closeTo(a, b float64) bool {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,13 @@
+package gamma
+
+// MarketSource is the subset of the Gamma API the strategies use. *Client
+// implements it; gammamock provides an in-memory version for tests.
+type MarketSource interface {
+	GetActiveUpDownMarkets() ([]Market, error)
+	GetWeatherMarkets() ([]Market, error)
+	GetNFLPlayoffMarkets() ([]Market, error)
+	SearchMarketsWithParams(params SearchParams) ([]Market, error)
+	GetMarketBySlug(slug string) (*Market, error)
+}
+
+var _ MarketSource = (*Client)(nil)
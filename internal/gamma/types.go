@@ -2,8 +2,12 @@ package gamma
 
 import (
 	"encoding/json"
+	"fmt"
+	"log"
+	"math"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -14,8 +18,9 @@ type Market struct {
 	QuestionID    string  `json:"question_id"`
 	Question      string  `json:"question"`
 	Slug          string  `json:"slug"`
-	EndDateISO    string  `json:"end_date_iso"`
+	EndDateISO    string  `json:"end_date_iso"` // snake_case end_date_iso, a full timestamp
 	EndDate       string  `json:"endDate"`
+	EndDateOnly   string  `json:"endDateIso"` // camelCase endDateIso, usually date-only
 	GameStartTime string  `json:"game_start_time"`
 	Active        bool    `json:"active"`
 	Closed        bool    `json:"closed"`
@@ -28,14 +33,60 @@ type Market struct {
 	BestBid float64 `json:"bestBid"`
 	BestAsk float64 `json:"bestAsk"`
 	// Volume and activity tracking (API returns mixed string/number types)
-	Volume         json.Number `json:"volume"`
-	Volume24hr     json.Number `json:"volume24hr"`
-	Liquidity      json.Number `json:"liquidity"`
-	VolumeNum      json.Number `json:"volumeNum"`
-	Volume24hrClob json.Number `json:"volume24hrClob"`
-	LastTradePrice json.Number `json:"lastTradePrice"`
-	UpdatedAt      string      `json:"updatedAt"`
-	CreatedAt      string      `json:"createdAt"`
+	Volume         FlexNumber `json:"volume"`
+	Volume24hr     FlexNumber `json:"volume24hr"`
+	Volume1wk      FlexNumber `json:"volume1wk"`
+	Liquidity      FlexNumber `json:"liquidity"`
+	VolumeNum      FlexNumber `json:"volumeNum"`
+	VolumeClob     FlexNumber `json:"volumeClob"`
+	Volume24hrClob FlexNumber `json:"volume24hrClob"`
+	Volume1wkClob  FlexNumber `json:"volume1wkClob"`
+	LiquidityNum   FlexNumber `json:"liquidityNum"`
+	LiquidityClob  FlexNumber `json:"liquidityClob"`
+	LastTradePrice FlexNumber `json:"lastTradePrice"`
+	UpdatedAt      string     `json:"updatedAt"`
+	CreatedAt      string     `json:"createdAt"`
+}
+
+// FlexNumber is a numeric field that Gamma may send as a number, a quoted
+// number, an empty string, null, or an object wrapping the value. Anything
+// unparseable decodes to 0 instead of failing the whole market.
+type FlexNumber float64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *FlexNumber) UnmarshalJSON(data []byte) error {
+	*f = 0
+
+	var n float64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*f = FlexNumber(n)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			*f = FlexNumber(v)
+		}
+		return nil
+	}
+
+	// Nested shapes like {"value": "123.4"} or {"amount": 123.4}
+	var obj map[string]FlexNumber
+	if err := json.Unmarshal(data, &obj); err == nil {
+		for _, key := range []string{"value", "amount", "total"} {
+			if v, ok := obj[key]; ok {
+				*f = v
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// Float64 returns the value as a float64.
+func (f FlexNumber) Float64() float64 {
+	return float64(f)
 }
 
 // GetConditionID returns the condition ID (handles both field names)
@@ -66,16 +117,52 @@ func (m *Market) ParseOutcomePrices() []float64 {
 	json.Unmarshal([]byte(m.OutcomePrices), &priceStrs)
 	prices := make([]float64, len(priceStrs))
 	for i, s := range priceStrs {
-		prices[i], _ = strconv.ParseFloat(s, 64)
+		price, _ := strconv.ParseFloat(s, 64)
+		prices[i] = normalizePrice(price)
 	}
 	return prices
 }
 
+// YesNoPrices returns the outcome prices of the "Yes" (or "Up") and "No" (or
+// "Down") outcomes, matched by name through the outcomes array, since Gamma
+// doesn't always list Yes first. Without usable outcome names it falls back
+// to Yes first, No second. ok is false when there are fewer than two prices.
+func (m *Market) YesNoPrices() (yes, no float64, ok bool) {
+	prices := m.ParseOutcomePrices()
+	if len(prices) < 2 {
+		return 0, 0, false
+	}
+
+	outcomes := m.ParseOutcomes()
+	if len(outcomes) == len(prices) {
+		yesIdx, noIdx := -1, -1
+		for i, outcome := range outcomes {
+			switch strings.ToLower(outcome) {
+			case "yes", "up":
+				yesIdx = i
+			case "no", "down":
+				noIdx = i
+			}
+		}
+		if yesIdx >= 0 && noIdx >= 0 {
+			return prices[yesIdx], prices[noIdx], true
+		}
+	}
+	return prices[0], prices[1], true
+}
+
 // Is15MinMarket returns true if this is a 15-minute up/down market.
 func (m *Market) Is15MinMarket() bool {
 	return strings.Contains(m.Slug, "-updown-15m-")
 }
 
+// IsCryptoUpDownMarket returns true for a crypto up/down market of any
+// window: 15-minute and hourly ("btc-updown-1h-1737801900") as well as the
+// dated daily and hourly series ("bitcoin-up-or-down-january-5-3pm-et").
+func (m *Market) IsCryptoUpDownMarket() bool {
+	return strings.Contains(m.Slug, "-updown-") || strings.Contains(m.Slug, "-up-or-down-")
+}
+
 // ExtractEndTimeFromSlug extracts unix timestamp from slug like "btc-updown-15m-1737801900"
 func (m *Market) ExtractEndTimeFromSlug() (time.Time, error) {
 	parts := strings.Split(m.Slug, "-")
@@ -90,6 +177,20 @@ func (m *Market) ExtractEndTimeFromSlug() (time.Time, error) {
 	return time.Unix(ts, 0), nil
 }
 
+// StartTimeFromSlug returns when an up/down market's window opened, from the
+// unix timestamp its slug ends with ("btc-updown-15m-1737801900"). ok is
+// false for markets whose slug carries no start time.
+func (m *Market) StartTimeFromSlug() (start time.Time, ok bool) {
+	if !strings.Contains(m.Slug, "-updown-") {
+		return time.Time{}, false
+	}
+	ts, err := strconv.ParseInt(m.Slug[strings.LastIndex(m.Slug, "-")+1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(ts, 0), true
+}
+
 // Token represents a tradeable outcome token within a market.
 type Token struct {
 	TokenID string  `json:"token_id"`
@@ -97,34 +198,95 @@ type Token struct {
 	Price   float64 `json:"price,string"`
 }
 
-// EndTime parses the end time from various fields or extracts from slug for 15M markets.
-func (m *Market) EndTime() (time.Time, error) {
-	// Try EndDate (used by 15M markets)
-	if m.EndDate != "" {
-		t, err := time.Parse(time.RFC3339, m.EndDate)
-		if err == nil {
-			return t, nil
-		}
+// UnmarshalJSON implements json.Unmarshaler. The price may arrive as a
+// number or a string, in dollars ("0.65") or cents ("65"); it is stored as
+// a 0–1 probability either way.
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		TokenID string     `json:"token_id"`
+		Outcome string     `json:"outcome"`
+		Price   FlexNumber `json:"price"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.TokenID = raw.TokenID
+	t.Outcome = raw.Outcome
+	t.Price = normalizePrice(raw.Price.Float64())
+	return nil
+}
+
+// Probability returns the token's price as a 0–1 probability. Use it rather
+// than Price, which may hold cents on tokens built outside JSON decoding.
+func (t *Token) Probability() float64 {
+	return normalizePrice(t.Price)
+}
+
+// normalizePrice converts a quoted price to a 0–1 probability. Token prices
+// never exceed $1, so anything above 1 is read as cents.
+func normalizePrice(price float64) float64 {
+	if price > 1 {
+		price /= 100
 	}
-	// Try EndDateISO
-	if m.EndDateISO != "" {
-		t, err := time.Parse(time.RFC3339, m.EndDateISO)
-		if err == nil {
+	return math.Max(0, math.Min(price, 1))
+}
+
+// timestampLayouts are the formats Gamma has returned for market dates, tried
+// in order. Fractional seconds are optional in each; zoneless times are UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,                      // 2026-01-25T12:15:00Z, 2026-01-25T12:15:00.123Z
+	"2006-01-02T15:04:05.999999999Z0700",  // 2026-01-25T12:15:00+0000
+	"2006-01-02T15:04:05.999999999",       // 2026-01-25T12:15:00
+	"2006-01-02 15:04:05.999999999Z07:00", // 2026-01-25 12:15:00+00:00
+	"2006-01-02 15:04:05.999999999Z07",    // 2026-01-25 12:15:00+00 (game_start_time)
+	"2006-01-02 15:04:05.999999999",       // 2026-01-25 12:15:00
+	"2006-01-02",                          // 2026-01-25 (endDateIso), read as midnight UTC
+}
+
+// parseTimestamp parses a Gamma date in any of timestampLayouts.
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
 			return t, nil
 		}
 	}
-	// Try GameStartTime
-	if m.GameStartTime != "" {
-		t, err := time.Parse(time.RFC3339, m.GameStartTime)
-		if err == nil {
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
+
+// EndTime parses the end time from the first date field that holds a
+// recognizable timestamp, falling back to the slug for 15M markets. It
+// errors when no field parses, naming the values it couldn't read.
+func (m *Market) EndTime() (time.Time, error) {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"endDate", m.EndDate}, // Used by 15M markets
+		{"end_date_iso", m.EndDateISO},
+		{"game_start_time", m.GameStartTime},
+		{"endDateIso", m.EndDateOnly}, // Date only, so least precise
+	}
+
+	var unparsed []string
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if t, err := parseTimestamp(f.value); err == nil {
 			return t, nil
 		}
+		unparsed = append(unparsed, fmt.Sprintf("%s=%q", f.name, f.value))
 	}
+
 	// For 15M markets, extract from slug as fallback
 	if m.Is15MinMarket() {
 		return m.ExtractEndTimeFromSlug()
 	}
-	return time.Time{}, nil
+	if len(unparsed) > 0 {
+		return time.Time{}, fmt.Errorf("unrecognized end time format: %s", strings.Join(unparsed, ", "))
+	}
+	return time.Time{}, fmt.Errorf("no end time for market %q", m.Slug)
 }
 
 // IsExpiringSoon returns true if the market ends within the given duration.
@@ -136,70 +298,101 @@ func (m *Market) IsExpiringSoon(within time.Duration) bool {
 	return time.Until(endTime) <= within && time.Until(endTime) > 0
 }
 
-// GetYesToken returns the "Yes" or "Up" outcome token if present.
-func (m *Market) GetYesToken() *Token {
-	// Check standard tokens array first
-	for i := range m.Tokens {
-		o := strings.ToLower(m.Tokens[i].Outcome)
-		if o == "yes" || o == "up" {
-			return &m.Tokens[i]
-		}
+// OutcomeTokens returns every outcome token in the market, in outcome
+// order: the tokens array when present, otherwise the JSON-encoded
+// clobTokenIds/outcomes/outcomePrices strings zipped together.
+func (m *Market) OutcomeTokens() []Token {
+	if len(m.Tokens) > 0 {
+		return m.Tokens
 	}
-	// Check 15M market format (JSON-encoded strings)
 	tokenIDs := m.ParseClobTokenIDs()
 	outcomes := m.ParseOutcomes()
 	prices := m.ParseOutcomePrices()
-	if len(tokenIDs) >= 2 && len(outcomes) >= 2 {
-		for i, outcome := range outcomes {
-			o := strings.ToLower(outcome)
-			if o == "yes" || o == "up" {
-				price := 0.0
-				if i < len(prices) {
-					price = prices[i]
-				}
-				return &Token{
-					TokenID: tokenIDs[i],
-					Outcome: outcome,
-					Price:   price,
-				}
-			}
+	n := min(len(tokenIDs), len(outcomes))
+	tokens := make([]Token, n)
+	for i := range tokens {
+		tokens[i] = Token{TokenID: tokenIDs[i], Outcome: outcomes[i]}
+		if i < len(prices) {
+			tokens[i].Price = prices[i]
+		}
+	}
+	return tokens
+}
+
+// TokenByOutcome returns the token whose outcome matches name, ignoring
+// case, or nil if there is none.
+func (m *Market) TokenByOutcome(name string) *Token {
+	tokens := m.OutcomeTokens()
+	for i := range tokens {
+		if strings.EqualFold(tokens[i].Outcome, name) {
+			return &tokens[i]
 		}
 	}
 	return nil
 }
 
-// GetNoToken returns the "No" or "Down" outcome token if present.
-func (m *Market) GetNoToken() *Token {
-	// Check standard tokens array first
-	for i := range m.Tokens {
-		o := strings.ToLower(m.Tokens[i].Outcome)
-		if o == "no" || o == "down" {
-			return &m.Tokens[i]
+// nonBinaryWarned holds the slugs of markets already logged as non-binary,
+// so the warning appears once per market rather than once per scan.
+var nonBinaryWarned sync.Map
+
+// binaryToken returns the token for either outcome name of a two-outcome
+// market. Markets with any other number of outcomes have no Yes/No side;
+// they return nil, with a one-time log, instead of a misleading token.
+func (m *Market) binaryToken(names ...string) *Token {
+	tokens := m.OutcomeTokens()
+	if len(tokens) != 2 {
+		if len(tokens) > 2 {
+			if _, seen := nonBinaryWarned.LoadOrStore(m.Slug, true); !seen {
+				log.Printf("[gamma] market %s has %d outcomes, not binary: no Yes/No token", m.Slug, len(tokens))
+			}
 		}
+		return nil
 	}
-	// Check 15M market format (JSON-encoded strings)
-	tokenIDs := m.ParseClobTokenIDs()
-	outcomes := m.ParseOutcomes()
-	prices := m.ParseOutcomePrices()
-	if len(tokenIDs) >= 2 && len(outcomes) >= 2 {
-		for i, outcome := range outcomes {
-			o := strings.ToLower(outcome)
-			if o == "no" || o == "down" {
-				price := 0.0
-				if i < len(prices) {
-					price = prices[i]
-				}
-				return &Token{
-					TokenID: tokenIDs[i],
-					Outcome: outcome,
-					Price:   price,
-				}
+	for _, name := range names {
+		for i := range tokens {
+			if strings.EqualFold(tokens[i].Outcome, name) {
+				return &tokens[i]
 			}
 		}
 	}
 	return nil
 }
 
+// GetYesToken returns the "Yes" or "Up" outcome token of a binary market.
+func (m *Market) GetYesToken() *Token {
+	return m.binaryToken("yes", "up")
+}
+
+// GetNoToken returns the "No" or "Down" outcome token of a binary market.
+func (m *Market) GetNoToken() *Token {
+	return m.binaryToken("no", "down")
+}
+
+// HasValidTokens reports whether the market has both a YES and a NO token
+// with distinct, non-empty decimal token IDs. Orders can't be built for
+// anything else, so markets failing this should be skipped before tracking.
+func (m *Market) HasValidTokens() bool {
+	yes, no := m.GetYesToken(), m.GetNoToken()
+	if yes == nil || no == nil {
+		return false
+	}
+	return isTokenID(yes.TokenID) && isTokenID(no.TokenID) && yes.TokenID != no.TokenID
+}
+
+// isTokenID reports whether id is a CLOB token ID: a non-empty string of
+// decimal digits.
+func isTokenID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // SearchParams holds query parameters for market search.
 type SearchParams struct {
 	Query  string
@@ -213,33 +406,55 @@ type SearchParams struct {
 	// Date range filters (RFC3339 format)
 	EndDateMin string // Minimum end date (e.g., "2026-01-26T00:00:00Z")
 	EndDateMax string // Maximum end date (e.g., "2026-02-26T00:00:00Z")
+	// Tag filter
+	TagID string // Gamma tag ID, empty = any tag
 }
 
-// GetVolume returns the total volume.
+// GetVolume returns the total volume, or 0 if Gamma reported none.
 func (m *Market) GetVolume() float64 {
-	if v, err := m.VolumeNum.Float64(); err == nil && v > 0 {
-		return v
-	}
-	v, _ := m.Volume.Float64()
-	return v
+	return firstPositive(m.VolumeNum, m.Volume, m.VolumeClob)
 }
 
 // GetVolume24hr returns specifically the 24-hour trading volume.
 // This is the key metric for determining if a market is actively traded.
 func (m *Market) GetVolume24hr() float64 {
-	if v, err := m.Volume24hr.Float64(); err == nil && v > 0 {
-		return v
-	}
-	if v, err := m.Volume24hrClob.Float64(); err == nil && v > 0 {
-		return v
+	return firstPositive(m.Volume24hr, m.Volume24hrClob)
+}
+
+// GetVolume1wk returns the trading volume over the last 7 days.
+func (m *Market) GetVolume1wk() float64 {
+	return firstPositive(m.Volume1wk, m.Volume1wkClob)
+}
+
+// RecentVolumeTrend compares the last day's volume with the daily average
+// over the last week: above 1 trading is picking up, below 1 it is dying
+// off, and 0 means nothing traded all week. Without a weekly figure from
+// Gamma a market that traded today is taken as steady (1).
+func (m *Market) RecentVolumeTrend() float64 {
+	day, week := m.GetVolume24hr(), m.GetVolume1wk()
+	if week <= 0 {
+		if day > 0 {
+			return 1
+		}
+		return 0
 	}
-	return 0
+	return day / (week / 7)
 }
 
 // GetLiquidity returns the market liquidity.
 func (m *Market) GetLiquidity() float64 {
-	v, _ := m.Liquidity.Float64()
-	return v
+	return firstPositive(m.LiquidityNum, m.Liquidity, m.LiquidityClob)
+}
+
+// firstPositive returns the first positive value, checking fields in order
+// of preference, or 0 if none is set.
+func firstPositive(values ...FlexNumber) float64 {
+	for _, v := range values {
+		if v > 0 {
+			return v.Float64()
+		}
+	}
+	return 0
 }
 
 // HasRecentActivity checks if the market has activity within the given duration.
@@ -267,3 +482,38 @@ func (m *Market) IsActive(minVolume float64) bool {
 	}
 	return false
 }
+
+// Thresholds for LikelyResolved.
+const (
+	resolvedPriceExtreme  = 0.02           // Cheaper side at or below 2¢
+	resolvedMinVolume24hr = 100            // Dollars traded in the last day
+	resolvedVolumeShare   = 0.02           // Last day's share of lifetime volume
+	resolvedEndWindow     = 48 * time.Hour // Time left before the end date
+)
+
+// LikelyResolved reports whether a market's outcome looks decided even though
+// Gamma still lists it as open: one side is priced at an extreme, trading has
+// dried up, and the end date is near or past. A cheap long shot that is
+// still actively traded, or still far from its end, doesn't count.
+func (m *Market) LikelyResolved(now time.Time) bool {
+	yes, no := m.GetYesToken(), m.GetNoToken()
+	if yes == nil || no == nil {
+		return false
+	}
+	if math.Min(yes.Probability(), no.Probability()) > resolvedPriceExtreme {
+		return false
+	}
+
+	recent := m.GetVolume24hr()
+	total := m.GetVolume()
+	quiet := recent < resolvedMinVolume24hr || (total > 0 && recent < total*resolvedVolumeShare)
+	if !quiet {
+		return false
+	}
+
+	endTime, err := m.EndTime()
+	if err != nil || endTime.IsZero() {
+		return false
+	}
+	return endTime.Sub(now) <= resolvedEndWindow
+}
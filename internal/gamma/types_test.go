@@ -0,0 +1,478 @@
+package gamma
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMarket_VolumeAccessors(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		wantVolume    float64
+		wantVolume24h float64
+		wantLiquidity float64
+	}{
+		{
+			name:          "numbers",
+			json:          `{"volumeNum":1500.5,"volume24hr":320,"liquidityNum":800}`,
+			wantVolume:    1500.5,
+			wantVolume24h: 320,
+			wantLiquidity: 800,
+		},
+		{
+			name:          "quoted numbers",
+			json:          `{"volume":"1500.5","volume24hr":"320","liquidity":"800.25"}`,
+			wantVolume:    1500.5,
+			wantVolume24h: 320,
+			wantLiquidity: 800.25,
+		},
+		{
+			name:          "volumeNum preferred over volume string",
+			json:          `{"volume":"10","volumeNum":12.5}`,
+			wantVolume:    12.5,
+			wantVolume24h: 0,
+			wantLiquidity: 0,
+		},
+		{
+			name:          "clob fallbacks",
+			json:          `{"volumeClob":900,"volume24hrClob":"45.5","liquidityClob":60}`,
+			wantVolume:    900,
+			wantVolume24h: 45.5,
+			wantLiquidity: 60,
+		},
+		{
+			name:          "empty strings and nulls",
+			json:          `{"volume":"","volumeNum":null,"volume24hr":"","liquidity":null}`,
+			wantVolume:    0,
+			wantVolume24h: 0,
+			wantLiquidity: 0,
+		},
+		{
+			name:          "nested values",
+			json:          `{"volume":{"value":"2500"},"volume24hr":{"amount":75}}`,
+			wantVolume:    2500,
+			wantVolume24h: 75,
+			wantLiquidity: 0,
+		},
+		{
+			name:          "garbage",
+			json:          `{"volume":"n/a","volume24hr":true,"liquidity":[1,2]}`,
+			wantVolume:    0,
+			wantVolume24h: 0,
+			wantLiquidity: 0,
+		},
+		{
+			name:          "absent",
+			json:          `{"slug":"no-volume"}`,
+			wantVolume:    0,
+			wantVolume24h: 0,
+			wantLiquidity: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Market
+			if err := json.Unmarshal([]byte(tt.json), &m); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if got := m.GetVolume(); got != tt.wantVolume {
+				t.Errorf("GetVolume() = %v, want %v", got, tt.wantVolume)
+			}
+			if got := m.GetVolume24hr(); got != tt.wantVolume24h {
+				t.Errorf("GetVolume24hr() = %v, want %v", got, tt.wantVolume24h)
+			}
+			if got := m.GetLiquidity(); got != tt.wantLiquidity {
+				t.Errorf("GetLiquidity() = %v, want %v", got, tt.wantLiquidity)
+			}
+		})
+	}
+}
+
+func TestMarket_RecentVolumeTrend(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want float64
+	}{
+		{"reviving", `{"volume24hr":3000,"volume1wk":7000}`, 3},
+		{"steady", `{"volume24hr":"1000","volume1wk":"7000"}`, 1},
+		{"dying", `{"volume24hr":200,"volume1wk":14000}`, 0.1},
+		{"clob weekly fallback", `{"volume24hr":500,"volume1wkClob":7000}`, 0.5},
+		{"no weekly figure", `{"volume24hr":500}`, 1},
+		{"dead", `{"volume24hr":0,"volume1wk":0}`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Market
+			if err := json.Unmarshal([]byte(tt.json), &m); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if got := m.RecentVolumeTrend(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RecentVolumeTrend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_UnmarshalList(t *testing.T) {
+	// One malformed volume must not drop the rest of the response
+	data := `[{"slug":"a","volume":"1200"},{"slug":"b","volume":""},{"slug":"c","volumeNum":50}]`
+
+	var markets []Market
+	if err := json.Unmarshal([]byte(data), &markets); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(markets) != 3 {
+		t.Fatalf("got %d markets, want 3", len(markets))
+	}
+
+	want := []float64{1200, 0, 50}
+	for i, m := range markets {
+		if got := m.GetVolume(); got != want[i] {
+			t.Errorf("%s: GetVolume() = %v, want %v", m.Slug, got, want[i])
+		}
+	}
+}
+
+func TestMarket_LikelyResolved(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	market := func(yes, volume24h, volume float64, end time.Time) Market {
+		m := Market{
+			Volume24hr: FlexNumber(volume24h),
+			VolumeNum:  FlexNumber(volume),
+			Tokens: []Token{
+				{TokenID: "1", Outcome: "Yes", Price: yes},
+				{TokenID: "2", Outcome: "No", Price: 1 - yes},
+			},
+		}
+		if !end.IsZero() {
+			m.EndDate = end.Format(time.RFC3339)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		market Market
+		want   bool
+	}{
+		{"decided and quiet near end", market(0.005, 20, 50000, now.Add(6*time.Hour)), true},
+		{"decided, past end date", market(0.995, 0, 50000, now.Add(-time.Hour)), true},
+		{"decided, volume dried up", market(0.01, 400, 80000, now.Add(24*time.Hour)), true},
+		{"cheap long shot still traded", market(0.01, 5000, 80000, now.Add(6*time.Hour)), false},
+		{"cheap long shot far from end", market(0.005, 20, 50000, now.Add(10*24*time.Hour)), false},
+		{"long shot above extreme", market(0.05, 20, 50000, now.Add(6*time.Hour)), false},
+		{"no end date", market(0.005, 20, 50000, time.Time{}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.market.LikelyResolved(now); got != tt.want {
+				t.Errorf("LikelyResolved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_HasValidTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		market Market
+		want   bool
+	}{
+		{"token array", Market{Tokens: []Token{{TokenID: "123", Outcome: "Yes"}, {TokenID: "456", Outcome: "No"}}}, true},
+		{"encoded strings", Market{ClobTokenIDs: `["123","456"]`, Outcomes: `["Up","Down"]`}, true},
+		{"no tokens", Market{}, false},
+		{"missing no", Market{Tokens: []Token{{TokenID: "123", Outcome: "Yes"}}}, false},
+		{"empty id", Market{Tokens: []Token{{TokenID: "", Outcome: "Yes"}, {TokenID: "456", Outcome: "No"}}}, false},
+		{"empty encoded id", Market{ClobTokenIDs: `["123",""]`, Outcomes: `["Up","Down"]`}, false},
+		{"not decimal", Market{Tokens: []Token{{TokenID: "0xabc", Outcome: "Yes"}, {TokenID: "456", Outcome: "No"}}}, false},
+		{"same id twice", Market{Tokens: []Token{{TokenID: "123", Outcome: "Yes"}, {TokenID: "123", Outcome: "No"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.market.HasValidTokens(); got != tt.want {
+				t.Errorf("HasValidTokens() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_MultiOutcomeTokens(t *testing.T) {
+	data := `{
+		"slug": "fed-decision-march",
+		"clobTokenIds": "[\"111\",\"222\",\"333\"]",
+		"outcomes": "[\"Cut\",\"Hold\",\"Hike\"]",
+		"outcomePrices": "[\"0.15\",\"0.80\",\"0.05\"]"
+	}`
+	var m Market
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	tokens := m.OutcomeTokens()
+	want := []Token{{"111", "Cut", 0.15}, {"222", "Hold", 0.80}, {"333", "Hike", 0.05}}
+	if len(tokens) != len(want) {
+		t.Fatalf("OutcomeTokens() returned %d tokens, want %d", len(tokens), len(want))
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Errorf("token %d = %+v, want %+v", i, tokens[i], want[i])
+		}
+	}
+
+	if tok := m.TokenByOutcome("hold"); tok == nil || tok.TokenID != "222" {
+		t.Errorf("TokenByOutcome(\"hold\") = %+v, want token 222", tok)
+	}
+	if tok := m.TokenByOutcome("Pause"); tok != nil {
+		t.Errorf("TokenByOutcome(\"Pause\") = %+v, want nil", tok)
+	}
+	if m.GetYesToken() != nil || m.GetNoToken() != nil || m.HasValidTokens() {
+		t.Error("a three-outcome market must not have Yes/No tokens")
+	}
+}
+
+func TestMarket_TokenByOutcome_TokenArray(t *testing.T) {
+	m := Market{Tokens: []Token{{TokenID: "123", Outcome: "Yes"}, {TokenID: "456", Outcome: "No"}}}
+	if tok := m.TokenByOutcome("NO"); tok != &m.Tokens[1] {
+		t.Errorf("TokenByOutcome(\"NO\") = %p, want the market's own token %p", tok, &m.Tokens[1])
+	}
+	if tok := m.GetYesToken(); tok == nil || tok.TokenID != "123" {
+		t.Errorf("GetYesToken() = %+v, want token 123", tok)
+	}
+}
+
+func TestMarket_YesNoOrdering(t *testing.T) {
+	tests := []struct {
+		name             string
+		market           Market
+		wantYesToken     string
+		wantNoToken      string
+		wantYes, wantNo  float64
+		wantPricesParsed bool
+	}{
+		{
+			name:         "yes first",
+			market:       Market{ClobTokenIDs: `["111","222"]`, Outcomes: `["Yes","No"]`, OutcomePrices: `["0.92","0.08"]`},
+			wantYesToken: "111", wantNoToken: "222", wantYes: 0.92, wantNo: 0.08, wantPricesParsed: true,
+		},
+		{
+			name:         "no first",
+			market:       Market{ClobTokenIDs: `["222","111"]`, Outcomes: `["No","Yes"]`, OutcomePrices: `["0.08","0.92"]`},
+			wantYesToken: "111", wantNoToken: "222", wantYes: 0.92, wantNo: 0.08, wantPricesParsed: true,
+		},
+		{
+			name:         "down first",
+			market:       Market{ClobTokenIDs: `["222","111"]`, Outcomes: `["Down","Up"]`, OutcomePrices: `["0.35","0.65"]`},
+			wantYesToken: "111", wantNoToken: "222", wantYes: 0.65, wantNo: 0.35, wantPricesParsed: true,
+		},
+		{
+			name:         "no outcome names falls back to position",
+			market:       Market{Tokens: []Token{{TokenID: "111", Outcome: "Yes"}, {TokenID: "222", Outcome: "No"}}, OutcomePrices: `["0.92","0.08"]`},
+			wantYesToken: "111", wantNoToken: "222", wantYes: 0.92, wantNo: 0.08, wantPricesParsed: true,
+		},
+		{
+			name:         "no prices",
+			market:       Market{ClobTokenIDs: `["222","111"]`, Outcomes: `["No","Yes"]`},
+			wantYesToken: "111", wantNoToken: "222",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tok := tt.market.GetYesToken(); tok == nil || tok.TokenID != tt.wantYesToken {
+				t.Errorf("GetYesToken() = %+v, want token %s", tok, tt.wantYesToken)
+			}
+			if tok := tt.market.GetNoToken(); tok == nil || tok.TokenID != tt.wantNoToken {
+				t.Errorf("GetNoToken() = %+v, want token %s", tok, tt.wantNoToken)
+			}
+			yes, no, ok := tt.market.YesNoPrices()
+			if ok != tt.wantPricesParsed || yes != tt.wantYes || no != tt.wantNo {
+				t.Errorf("YesNoPrices() = %v, %v, %v; want %v, %v, %v", yes, no, ok, tt.wantYes, tt.wantNo, tt.wantPricesParsed)
+			}
+		})
+	}
+}
+
+func TestParseWeatherMarket_RejectsInvalidTokens(t *testing.T) {
+	market := testWeatherMarket("Will the highest temperature in London be 12°C on March 3?")
+	if ParseWeatherMarket(market) == nil {
+		t.Fatal("market with valid tokens not parsed")
+	}
+
+	market.Tokens[1].TokenID = ""
+	if wm := ParseWeatherMarket(market); wm != nil {
+		t.Errorf("parsed market with an empty NO token ID: %+v", wm)
+	}
+}
+
+func TestMarket_IsCryptoUpDownMarket(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{"btc-updown-15m-1737801900", true},
+		{"eth-updown-1h-1737801900", true},
+		{"sol-updown-4h-1737801900", true},
+		{"xrp-updown-1d-1737801900", true},
+		{"bitcoin-up-or-down-january-5-3pm-et", true},
+		{"solana-up-or-down-on-january-5", true},
+		{"highest-temperature-in-london-on-march-3", false},
+		{"will-bitcoin-reach-100k", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			m := Market{Slug: tt.slug}
+			if got := m.IsCryptoUpDownMarket(); got != tt.want {
+				t.Errorf("IsCryptoUpDownMarket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_StartTimeFromSlug(t *testing.T) {
+	tests := []struct {
+		slug   string
+		want   int64
+		wantOK bool
+	}{
+		{"btc-updown-15m-1737801900", 1737801900, true},
+		{"eth-updown-1h-1737801900", 1737801900, true},
+		{"bitcoin-up-or-down-january-5-3pm-et", 0, false},
+		{"btc-updown-15m-soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			m := Market{Slug: tt.slug}
+			got, ok := m.StartTimeFromSlug()
+			if ok != tt.wantOK || (ok && got.Unix() != tt.want) {
+				t.Errorf("StartTimeFromSlug() = %v, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsWeatherMarket_ExcludesUpDownMarkets(t *testing.T) {
+	market := testWeatherMarket("Will the highest temperature in London be 12°C on March 3?")
+	market.Slug = "highest-temperature-in-london-on-march-3"
+	if !isWeatherMarket(market) {
+		t.Fatal("weather market rejected")
+	}
+
+	// Up/down windows the weather tag can leak in, with a question that would
+	// otherwise pass the keyword checks
+	for _, slug := range []string{
+		"btc-updown-15m-1737801900",
+		"btc-updown-1h-1737801900",
+		"eth-updown-1d-1737801900",
+		"bitcoin-up-or-down-january-5-3pm-et",
+	} {
+		market.Slug = slug
+		if isWeatherMarket(market) {
+			t.Errorf("%s classified as a weather market", slug)
+		}
+	}
+}
+
+func TestMarket_EndTime(t *testing.T) {
+	want := time.Date(2026, 1, 25, 12, 15, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		market  Market
+		want    time.Time
+		wantErr bool
+	}{
+		{"RFC3339", Market{EndDate: "2026-01-25T12:15:00Z"}, want, false},
+		{"fractional seconds", Market{EndDate: "2026-01-25T12:15:00.000Z"}, want, false},
+		{"offset without colon", Market{EndDate: "2026-01-25T14:15:00+0200"}, want, false},
+		{"no zone", Market{EndDate: "2026-01-25T12:15:00"}, want, false},
+		{"space separated short offset", Market{GameStartTime: "2026-01-25 12:15:00+00"}, want, false},
+		{"space separated full offset", Market{GameStartTime: "2026-01-25 07:15:00-05:00"}, want, false},
+		{"snake case ISO field", Market{EndDateISO: "2026-01-25T12:15:00Z"}, want, false},
+		{"camel case ISO date", Market{EndDateOnly: "2026-01-25"}, time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC), false},
+		{
+			"unparseable endDate falls through to the next field",
+			Market{EndDate: "soon", EndDateOnly: "2026-01-25"},
+			time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC),
+			false,
+		},
+		{"15M slug fallback", Market{Slug: "btc-updown-15m-1769343300"}, time.Unix(1769343300, 0), false},
+		{"unrecognized", Market{EndDate: "25/01/2026"}, time.Time{}, true},
+		{"missing", Market{Slug: "no-dates"}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.market.EndTime()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EndTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("EndTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_EndTimeFromJSON(t *testing.T) {
+	var m Market
+	if err := json.Unmarshal([]byte(`{"slug":"nyc-high","endDateIso":"2026-01-25"}`), &m); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	got, err := m.EndTime()
+	if err != nil || !got.Equal(time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("EndTime() = %v, %v; want 2026-01-25 from endDateIso", got, err)
+	}
+}
+
+func TestToken_Probability(t *testing.T) {
+	tests := []struct {
+		name  string
+		price string
+		want  float64
+	}{
+		{"dollars", `"0.65"`, 0.65},
+		{"cents", `"65"`, 0.65},
+		{"padded dollars", `"0.6500"`, 0.65},
+		{"number", `0.65`, 0.65},
+		{"one dollar", `"1"`, 1},
+		{"empty", `""`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tok Token
+			data := `{"token_id":"101","outcome":"Yes","price":` + tt.price + `}`
+			if err := json.Unmarshal([]byte(data), &tok); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if tok.TokenID != "101" || tok.Outcome != "Yes" {
+				t.Errorf("token = %+v, want ID 101 outcome Yes", tok)
+			}
+			if got := tok.Probability(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Probability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	cents := Token{Price: 65}
+	if got := cents.Probability(); math.Abs(got-0.65) > 1e-9 {
+		t.Errorf("Probability() of literal 65 = %v, want 0.65", got)
+	}
+}
+
+func TestMarket_ParseOutcomePricesInCents(t *testing.T) {
+	m := Market{OutcomePrices: `["65", "0.3500"]`}
+	got := m.ParseOutcomePrices()
+	if len(got) != 2 || math.Abs(got[0]-0.65) > 1e-9 || math.Abs(got[1]-0.35) > 1e-9 {
+		t.Errorf("ParseOutcomePrices() = %v, want [0.65 0.35]", got)
+	}
+}
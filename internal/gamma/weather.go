@@ -24,6 +24,8 @@ const (
 	WeatherTypeRain         WeatherMarketType = "rain"
 	WeatherTypePrecipitation WeatherMarketType = "precipitation"
 	WeatherTypeGlobalTemp   WeatherMarketType = "global_temp"
+	WeatherTypeWind         WeatherMarketType = "wind"
+	WeatherTypeUV           WeatherMarketType = "uv"
 	WeatherTypeUnknown      WeatherMarketType = "unknown"
 )
 
@@ -33,7 +35,7 @@ type WeatherMarket struct {
 	MarketType     WeatherMarketType
 	Location       string  // City name extracted from question
 	Threshold      float64 // Temperature threshold in Fahrenheit (for temp markets)
-	ThresholdUnits string  // "F" or "C"
+	ThresholdUnits string  // "F" or "C", "mph" or "km/h" for wind, "UV" for UV index
 	ResolutionDate time.Time
 	YesTokenID     string
 	NoTokenID      string
@@ -126,8 +128,9 @@ func (c *Client) GetWeatherEvents() ([]WeatherEvent, error) {
 		}
 
 		if resp.StatusCode != 200 {
+			ge := newGammaError(resp)
 			resp.Body.Close()
-			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			return nil, ge
 		}
 
 		var paginatedResp WeatherEventsPaginationResponse
@@ -160,8 +163,9 @@ func (c *Client) GetWeatherEvents() ([]WeatherEvent, error) {
 }
 
 // ParseWeatherMarket extracts weather market details from a generic market.
+// Markets without valid YES/NO token IDs can't be traded and return nil.
 func ParseWeatherMarket(market Market) *WeatherMarket {
-	if !isWeatherMarket(market) {
+	if !isWeatherMarket(market) || !market.HasValidTokens() {
 		return nil
 	}
 
@@ -170,9 +174,19 @@ func ParseWeatherMarket(market Market) *WeatherMarket {
 		MarketType: classifyWeatherMarket(market),
 		Location:   extractLocation(market.Question),
 	}
+	if wm.MarketType == WeatherTypeGlobalTemp {
+		wm.Location = "Global"
+	}
 
 	// Extract threshold from question
-	wm.Threshold, wm.ThresholdUnits = extractThreshold(market.Question)
+	switch wm.MarketType {
+	case WeatherTypeWind:
+		wm.Threshold, wm.ThresholdUnits = extractWindThreshold(market.Question)
+	case WeatherTypeUV:
+		wm.Threshold, wm.ThresholdUnits = extractUVThreshold(market.Question)
+	default:
+		wm.Threshold, wm.ThresholdUnits = extractThreshold(market.Question)
+	}
 
 	// Parse resolution date
 	endTime, err := market.EndTime()
@@ -183,14 +197,10 @@ func ParseWeatherMarket(market Market) *WeatherMarket {
 	// Get token info
 	yesToken := market.GetYesToken()
 	noToken := market.GetNoToken()
-	if yesToken != nil {
-		wm.YesTokenID = yesToken.TokenID
-		wm.YesPrice = yesToken.Price
-	}
-	if noToken != nil {
-		wm.NoTokenID = noToken.TokenID
-		wm.NoPrice = noToken.Price
-	}
+	wm.YesTokenID = yesToken.TokenID
+	wm.YesPrice = yesToken.Probability()
+	wm.NoTokenID = noToken.TokenID
+	wm.NoPrice = noToken.Probability()
 
 	return wm
 }
@@ -203,8 +213,8 @@ func isWeatherMarket(market Market) bool {
 		return false
 	}
 
-	// Skip 15-min crypto markets
-	if market.Is15MinMarket() {
+	// Skip crypto up/down markets, whatever their window
+	if market.IsCryptoUpDownMarket() {
 		return false
 	}
 
@@ -245,6 +255,10 @@ func isWeatherMarket(market Market) bool {
 		"rain",
 		"precipitation",
 		"inches",
+		"wind",
+		"mph",
+		"km/h",
+		"uv index",
 	}
 
 	for _, indicator := range weatherIndicators {
@@ -304,6 +318,14 @@ func classifyWeatherMarket(market Market) WeatherMarketType {
 		return WeatherTypeGlobalTemp
 	}
 
+	// Wind speed and UV index threshold markets
+	if isWindMarket(question) {
+		return WeatherTypeWind
+	}
+	if isUVMarket(question) {
+		return WeatherTypeUV
+	}
+
 	// Daily high/low temperature range markets (e.g., "highest temperature in NYC be between 20-21°F")
 	if strings.Contains(question, "highest temperature") || strings.Contains(question, "lowest temperature") {
 		// Check for specific range (bucket markets like "8°C")
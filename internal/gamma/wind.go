@@ -0,0 +1,91 @@
+package gamma
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const kmhPerMph = 1.609344
+
+var (
+	windSpeedRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(mph|km/h|kmh|kph)`)
+	windWordRegex  = regexp.MustCompile(`\b(?:winds?|windy|gusts?|gusty)\b`)
+	gustWordRegex  = regexp.MustCompile(`\bgusts?\b|\bgusty\b`)
+	uvIndexRegex   = regexp.MustCompile(`uv(?: index)?\D{0,20}?(\d+(?:\.\d+)?)`)
+)
+
+// isWindMarket reports whether a lowercased question asks about wind speed.
+// Wind is matched as a whole word, so cities like Windsor and Windhoek don't
+// count. Wind chill is a temperature, so those markets are left to the
+// temperature classifiers.
+func isWindMarket(question string) bool {
+	if strings.Contains(question, "wind chill") {
+		return false
+	}
+	return windWordRegex.MatchString(question) || strings.Contains(question, "mph") ||
+		strings.Contains(question, "km/h")
+}
+
+// isUVMarket reports whether a lowercased question asks about the UV index.
+func isUVMarket(question string) bool {
+	return strings.Contains(question, "uv index") || strings.Contains(question, "uv of")
+}
+
+// extractWindThreshold extracts a wind speed from a market question.
+// Returns the value and its units ("mph" or "km/h").
+func extractWindThreshold(question string) (float64, string) {
+	m := windSpeedRegex.FindStringSubmatch(strings.ToLower(question))
+	if m == nil {
+		return 0, ""
+	}
+	val, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, ""
+	}
+	if m[2] == "mph" {
+		return val, "mph"
+	}
+	return val, "km/h"
+}
+
+// extractUVThreshold extracts the UV index level from a market question.
+func extractUVThreshold(question string) (float64, string) {
+	m := uvIndexRegex.FindStringSubmatch(strings.ToLower(question))
+	if m == nil {
+		return 0, ""
+	}
+	val, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, ""
+	}
+	return val, "UV"
+}
+
+// GetThresholdKmh returns a wind market's threshold in km/h, the unit
+// Open-Meteo reports wind speed in.
+func (wm *WeatherMarket) GetThresholdKmh() float64 {
+	if wm.ThresholdUnits == "mph" {
+		return wm.Threshold * kmhPerMph
+	}
+	return wm.Threshold
+}
+
+// AsksGusts reports whether a wind market is about gusts rather than
+// sustained wind speed.
+func (wm *WeatherMarket) AsksGusts() bool {
+	return gustWordRegex.MatchString(strings.ToLower(wm.Market.Question))
+}
+
+// AsksBelow reports whether a wind or UV market resolves YES when the value
+// stays under its threshold ("Will max wind be below 20 mph?") rather than
+// reaching it.
+func (wm *WeatherMarket) AsksBelow() bool {
+	question := strings.ToLower(wm.Market.Question)
+	for _, kw := range []string{"below", "under", "less than", "lower than"} {
+		if strings.Contains(question, kw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package gamma
+
+import (
+	"math"
+	"testing"
+)
+
+// testWeatherMarket returns an active market for question with valid tokens.
+func testWeatherMarket(question string) Market {
+	return Market{
+		Question: question,
+		Active:   true,
+		Tokens: []Token{
+			{TokenID: "101", Outcome: "Yes", Price: 0.4},
+			{TokenID: "102", Outcome: "No", Price: 0.6},
+		},
+	}
+}
+
+func TestParseWeatherMarket_Wind(t *testing.T) {
+	tests := []struct {
+		question  string
+		wantType  WeatherMarketType
+		wantKmh   float64
+		wantBelow bool
+	}{
+		{"Will wind gusts in Chicago exceed 40 mph on March 3?", WeatherTypeWind, 64.37376, false},
+		{"Will max wind speed in London be above 50 km/h on March 3?", WeatherTypeWind, 50, false},
+		{"Will wind in Denver stay below 20mph on March 3?", WeatherTypeWind, 32.18688, true},
+		{"Will Tokyo see winds of 60 kph or higher on March 3?", WeatherTypeWind, 60, false},
+		{"Will the wind chill in Chicago drop below -10°F on March 3?", WeatherTypeTempBelow, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.question, func(t *testing.T) {
+			wm := ParseWeatherMarket(testWeatherMarket(tt.question))
+			if wm == nil {
+				t.Fatal("not parsed as a weather market")
+			}
+			if wm.MarketType != tt.wantType {
+				t.Fatalf("type = %s, want %s", wm.MarketType, tt.wantType)
+			}
+			if tt.wantType != WeatherTypeWind {
+				return
+			}
+			if math.Abs(wm.GetThresholdKmh()-tt.wantKmh) > 1e-6 {
+				t.Errorf("GetThresholdKmh() = %v, want %v", wm.GetThresholdKmh(), tt.wantKmh)
+			}
+			if wm.AsksBelow() != tt.wantBelow {
+				t.Errorf("AsksBelow() = %v, want %v", wm.AsksBelow(), tt.wantBelow)
+			}
+		})
+	}
+}
+
+func TestIsWindMarket_WholeWords(t *testing.T) {
+	tests := []struct {
+		question string
+		want     bool
+	}{
+		{"will it be windy in chicago on march 3?", true},
+		{"will gusts in denver reach 50 on march 3?", true},
+		{"will the highest temperature in windsor be 20°c or higher on march 3?", false},
+		{"will the highest temperature in windhoek be 30°c or higher on march 3?", false},
+	}
+	for _, tt := range tests {
+		if got := isWindMarket(tt.question); got != tt.want {
+			t.Errorf("isWindMarket(%q) = %v, want %v", tt.question, got, tt.want)
+		}
+	}
+}
+
+func TestAsksGusts(t *testing.T) {
+	gust := ParseWeatherMarket(testWeatherMarket("Will wind gusts in Chicago exceed 40 mph on March 3?"))
+	sustained := ParseWeatherMarket(testWeatherMarket("Will max wind speed in London be above 50 km/h on March 3?"))
+	if gust == nil || sustained == nil {
+		t.Fatal("not parsed as weather markets")
+	}
+	if !gust.AsksGusts() {
+		t.Error("gust question: AsksGusts() = false, want true")
+	}
+	if sustained.AsksGusts() {
+		t.Error("sustained wind question: AsksGusts() = true, want false")
+	}
+}
+
+func TestParseWeatherMarket_UV(t *testing.T) {
+	wm := ParseWeatherMarket(testWeatherMarket("Will the UV index in Sydney reach 11 or higher on January 5?"))
+	if wm == nil || wm.MarketType != WeatherTypeUV {
+		t.Fatalf("parsed = %+v, want a UV market", wm)
+	}
+	if wm.Threshold != 11 || wm.ThresholdUnits != "UV" {
+		t.Errorf("threshold = %v %s, want 11 UV", wm.Threshold, wm.ThresholdUnits)
+	}
+}
@@ -0,0 +1,156 @@
+// Package httpx wraps HTTP transports to record per-host request counts,
+// error counts and latency, so slow or failing upstreams show up next to
+// the strategy's own stats.
+package httpx
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram. Requests
+// slower than the last bound are counted in a final overflow bucket.
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// HostStats is what has been recorded for one host.
+type HostStats struct {
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"` // Transport errors and 5xx responses
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+	Histogram    []int64 `json:"histogram"` // Counts per LatencyBuckets bound, plus overflow
+}
+
+// ErrorRate is the fraction of requests that failed.
+func (h HostStats) ErrorRate() float64 {
+	if h.Requests == 0 {
+		return 0
+	}
+	return float64(h.Errors) / float64(h.Requests)
+}
+
+// hostStats accumulates one host's stats.
+type hostStats struct {
+	requests  int64
+	errors    int64
+	total     time.Duration
+	max       time.Duration
+	histogram []int64
+}
+
+// Registry records stats for every transport it wraps.
+type Registry struct {
+	mu    sync.Mutex
+	hosts map[string]*hostStats
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{hosts: make(map[string]*hostStats)}
+}
+
+// defaultRegistry backs the package-level Transport and Stats.
+var defaultRegistry = NewRegistry()
+
+// Transport wraps next so its requests are recorded in the default
+// registry. A nil next uses http.DefaultTransport.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return defaultRegistry.Transport(next)
+}
+
+// Stats returns a snapshot of the default registry, keyed by host.
+func Stats() map[string]HostStats {
+	return defaultRegistry.Stats()
+}
+
+// Transport wraps next so its requests are recorded in r. A nil next uses
+// http.DefaultTransport.
+func (r *Registry) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next, registry: r}
+}
+
+// record adds one request to host's stats.
+func (r *Registry) record(host string, latency time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.hosts[host]
+	if !ok {
+		h = &hostStats{histogram: make([]int64, len(LatencyBuckets)+1)}
+		r.hosts[host] = h
+	}
+	h.requests++
+	if failed {
+		h.errors++
+	}
+	h.total += latency
+	if latency > h.max {
+		h.max = latency
+	}
+	bucket := len(LatencyBuckets)
+	for i, bound := range LatencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	h.histogram[bucket]++
+}
+
+// Stats returns a snapshot of r, keyed by host.
+func (r *Registry) Stats() map[string]HostStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]HostStats, len(r.hosts))
+	for host, h := range r.hosts {
+		s := HostStats{
+			Requests:     h.requests,
+			Errors:       h.errors,
+			MaxLatencyMs: float64(h.max) / float64(time.Millisecond),
+			Histogram:    append([]int64(nil), h.histogram...),
+		}
+		if h.requests > 0 {
+			s.AvgLatencyMs = float64(h.total) / float64(h.requests) / float64(time.Millisecond)
+		}
+		stats[host] = s
+	}
+	return stats
+}
+
+// Unwrap returns the transport rt records for, or rt itself when it isn't
+// a recording transport.
+func Unwrap(rt http.RoundTripper) http.RoundTripper {
+	if t, ok := rt.(*transport); ok {
+		return t.next
+	}
+	return rt
+}
+
+// transport is the recording http.RoundTripper.
+type transport struct {
+	next     http.RoundTripper
+	registry *Registry
+}
+
+// RoundTrip times the request up to the response headers.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	t.registry.record(req.URL.Host, time.Since(start), failed)
+	return resp, err
+}
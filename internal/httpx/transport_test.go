@@ -0,0 +1,91 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestTransport_RecordsStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	r := NewRegistry()
+	client := &http.Client{Transport: r.Transport(nil)}
+	for _, path := range []string{"/ok", "/ok", "/missing", "/fail"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	// A transport error against another host
+	down := &http.Client{Transport: r.Transport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}))}
+	if _, err := down.Get("http://down.invalid/x"); err == nil {
+		t.Fatal("expected transport error")
+	}
+
+	host := mustHost(t, srv.URL)
+	stats := r.Stats()
+	tests := []struct {
+		host     string
+		requests int64
+		errors   int64
+	}{
+		{host, 4, 1}, // 404 isn't an upstream failure, 502 is
+		{"down.invalid", 1, 1},
+	}
+	for _, tt := range tests {
+		got, ok := stats[tt.host]
+		if !ok {
+			t.Fatalf("no stats for %s in %v", tt.host, stats)
+		}
+		if got.Requests != tt.requests || got.Errors != tt.errors {
+			t.Errorf("%s: requests/errors = %d/%d, want %d/%d", tt.host, got.Requests, got.Errors, tt.requests, tt.errors)
+		}
+		var bucketed int64
+		for _, n := range got.Histogram {
+			bucketed += n
+		}
+		if bucketed != tt.requests {
+			t.Errorf("%s: histogram holds %d requests, want %d", tt.host, bucketed, tt.requests)
+		}
+	}
+	if rate := stats[host].ErrorRate(); rate != 0.25 {
+		t.Errorf("ErrorRate = %v, want 0.25", rate)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	base := &http.Transport{}
+	if got := Unwrap(Transport(base)); got != base {
+		t.Errorf("Unwrap(Transport(base)) = %v, want base", got)
+	}
+	if got := Unwrap(base); got != base {
+		t.Errorf("Unwrap(base) = %v, want base", got)
+	}
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", raw, err)
+	}
+	return u.Host
+}
@@ -0,0 +1,58 @@
+// Package logx configures the standard logger shared by every command.
+package logx
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Setup sets the standard logger's flags and "[name] " prefix and routes
+// output to stdout and, when cfg.File is set, a size-rotated log file.
+// Stdout is kept whenever there is no file, so logs are never dropped.
+// Close the returned io.Closer on exit to flush the file.
+func Setup(name string, cfg config.LogConfig) (io.Closer, error) {
+	log.SetFlags(log.Ltime | log.Lmsgprefix)
+	log.SetPrefix(fmt.Sprintf("[%s] ", name))
+
+	out, closer, err := writer(cfg, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	log.SetOutput(out)
+	return closer, nil
+}
+
+// writer builds the log destination for cfg, with console as the terminal
+// stream.
+func writer(cfg config.LogConfig, console io.Writer) (io.Writer, io.Closer, error) {
+	if cfg.File == "" {
+		return console, nopCloser{}, nil
+	}
+
+	if dir := filepath.Dir(cfg.File); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+	}
+
+	file := &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		LocalTime:  true,
+	}
+	if !cfg.Stdout {
+		return file, file, nil
+	}
+	return io.MultiWriter(console, file), file, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
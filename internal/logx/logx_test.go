@@ -0,0 +1,60 @@
+package logx
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        bool
+		stdout      bool
+		wantConsole bool
+		wantFile    bool
+	}{
+		{"console only", false, true, true, false},
+		{"no file keeps console", false, false, true, false},
+		{"file and console", true, true, true, true},
+		{"file only", true, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.LogConfig{Stdout: tt.stdout, MaxSizeMB: 1}
+			if tt.file {
+				cfg.File = filepath.Join(t.TempDir(), "logs", "bot.log")
+			}
+
+			var console bytes.Buffer
+			out, closer, err := writer(cfg, &console)
+			if err != nil {
+				t.Fatalf("writer: %v", err)
+			}
+			if _, err := out.Write([]byte("hello\n")); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if err := closer.Close(); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+
+			if got := console.String() == "hello\n"; got != tt.wantConsole {
+				t.Errorf("console got %q, want written=%v", console.String(), tt.wantConsole)
+			}
+			if tt.file {
+				data, err := os.ReadFile(cfg.File)
+				if err != nil {
+					t.Fatalf("read log file: %v", err)
+				}
+				if got := strings.Contains(string(data), "hello"); got != tt.wantFile {
+					t.Errorf("file got %q, want written=%v", data, tt.wantFile)
+				}
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
 )
 
 const (
@@ -30,7 +32,7 @@ type cachedPrice struct {
 // NewBinanceClient creates a new Binance price feed client.
 func NewBinanceClient() *BinanceClient {
 	return &BinanceClient{
-		httpClient: &http.Client{Timeout: 2 * time.Second},
+		httpClient: &http.Client{Timeout: 2 * time.Second, Transport: httpx.Transport(nil)},
 		cache:      make(map[string]cachedPrice),
 	}
 }
@@ -0,0 +1,43 @@
+// Package pricing rounds order prices and share sizes. The order builder
+// and the strategies share it, so the price a strategy plans with is the
+// price the builder signs.
+package pricing
+
+import "math"
+
+const (
+	// PriceDecimals is the finest price precision Polymarket supports
+	// (a 0.0001 tick).
+	PriceDecimals = 4
+	// ShareDecimals is the CLOB's share size precision.
+	ShareDecimals = 2
+
+	// roundingEpsilon absorbs float error so 4.9999999999 shares still
+	// floors to 5.00.
+	roundingEpsilon = 1e-6
+)
+
+// RoundToTick rounds price to the nearest multiple of tick, halves away from
+// zero, cleaned to PriceDecimals so float error can't leave a stray digit.
+// A non-positive tick leaves price unchanged.
+func RoundToTick(price, tick float64) float64 {
+	if tick <= 0 {
+		return price
+	}
+	ticks := math.Round(price / tick)
+	scale := math.Pow10(PriceDecimals)
+	return math.Round(ticks*tick*scale) / scale
+}
+
+// RoundShares truncates shares to decimals places, so an order never
+// exceeds the size or budget it was sized from.
+func RoundShares(shares float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Floor(shares*scale+roundingEpsilon) / scale
+}
+
+// RoundSharesNearest rounds shares to decimals places, halves up.
+func RoundSharesNearest(shares float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(shares*scale) / scale
+}
@@ -0,0 +1,62 @@
+package pricing
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRoundToTick(t *testing.T) {
+	tests := []struct {
+		price float64
+		tick  float64
+		want  float64
+	}{
+		{0.55, 0.01, 0.55},
+		{0.554, 0.01, 0.55},
+		{0.555, 0.01, 0.56},
+		{0.125, 0.01, 0.13}, // Exact halves round away from zero
+		{0.99 - 0.01, 0.01, 0.98},
+		{0.1 + 0.2, 0.01, 0.30}, // 0.30000000000000004
+		{0.5149, 0.001, 0.515},
+		{0.12345, 0.0001, 0.1235},
+		{0.004, 0.01, 0},
+		{0.95, 0.05, 0.95},
+		{0.42, 0, 0.42},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.price, tt.tick), func(t *testing.T) {
+			if got := RoundToTick(tt.price, tt.tick); got != tt.want {
+				t.Errorf("RoundToTick(%v, %v) = %v, want %v", tt.price, tt.tick, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundShares(t *testing.T) {
+	tests := []struct {
+		shares  float64
+		nearest bool
+		want    float64
+	}{
+		{5.999, false, 5.99},
+		{5.999, true, 6.00},
+		{5.005, false, 5.00},
+		{5.0049, true, 5.00},
+		{0.29, false, 0.29}, // 0.29*100 is 28.999... in float64
+		{4.9999999999, false, 5.00},
+		{0.004, false, 0},
+		{0.005, true, 0.01},
+		{25, false, 25},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v/%v", tt.shares, tt.nearest), func(t *testing.T) {
+			got := RoundShares(tt.shares, ShareDecimals)
+			if tt.nearest {
+				got = RoundSharesNearest(tt.shares, ShareDecimals)
+			}
+			if got != tt.want {
+				t.Errorf("round %v (nearest=%v) = %v, want %v", tt.shares, tt.nearest, got, tt.want)
+			}
+		})
+	}
+}
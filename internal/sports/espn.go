@@ -5,26 +5,85 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
 )
 
 const (
-	espnNFLScoreboardURL = "https://site.api.espn.com/apis/site/v2/sports/football/nfl/scoreboard"
-	espnNBAScoreboardURL = "https://site.api.espn.com/apis/site/v2/sports/basketball/nba/scoreboard"
+	espnBaseURL           = "https://site.api.espn.com/apis/site/v2/sports"
+	espnNFLScoreboardPath = "/football/nfl/scoreboard"
+	espnNBAScoreboardPath = "/basketball/nba/scoreboard"
+
+	// Extra attempts after a failed scoreboard fetch
+	defaultESPNRetries = 2
+	// First retry waits this long; later retries double it
+	espnRetryBaseDelay = 250 * time.Millisecond
 )
 
-// ESPNClient fetches live sports data from ESPN's free API.
+// ESPNClient fetches live sports data from ESPN's free API. Failed fetches
+// are retried with backoff, and the last successful result for each league
+// is kept so callers can tell how old their game data is.
 type ESPNClient struct {
 	httpClient *http.Client
+	baseURL    string
+	retries    int
+	retryDelay time.Duration
+	cacheTTL   time.Duration // Reuse a fetch this recent instead of calling ESPN, 0 disables
+
+	mu   sync.Mutex
+	last map[string]gamesSnapshot // By scoreboard path
+}
+
+// gamesSnapshot is a scoreboard's games as of a successful fetch.
+type gamesSnapshot struct {
+	games     []Game
+	fetchedAt time.Time
 }
 
 // NewESPNClient creates a new ESPN API client.
 func NewESPNClient() *ESPNClient {
 	return &ESPNClient{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		httpClient: &http.Client{Timeout: 10 * time.Second, Transport: httpx.Transport(nil)},
+		baseURL:    espnBaseURL,
+		retries:    defaultESPNRetries,
+		retryDelay: espnRetryBaseDelay,
+		last:       make(map[string]gamesSnapshot),
 	}
 }
 
+// WithBaseURL sets a custom API URL (useful for testing).
+func (c *ESPNClient) WithBaseURL(baseURL string) *ESPNClient {
+	c.baseURL = baseURL
+	return c
+}
+
+// WithTimeout sets the per-request timeout (default 10s).
+func (c *ESPNClient) WithTimeout(timeout time.Duration) *ESPNClient {
+	if timeout > 0 {
+		c.httpClient.Timeout = timeout
+	}
+	return c
+}
+
+// WithRetries sets how many times a failed fetch is retried (default 2, 0
+// disables retries).
+func (c *ESPNClient) WithRetries(n int) *ESPNClient {
+	if n < 0 {
+		n = 0
+	}
+	c.retries = n
+	return c
+}
+
+// WithCacheTTL makes fetches within ttl of the last successful one return its
+// games without calling ESPN (default 0, always fetch).
+func (c *ESPNClient) WithCacheTTL(ttl time.Duration) *ESPNClient {
+	c.cacheTTL = ttl
+	return c
+}
+
 // Game represents a live sports game.
 type Game struct {
 	ID           string
@@ -133,15 +192,60 @@ func (g *Game) Winner() *Team {
 
 // GetNFLGames fetches current NFL games from ESPN.
 func (c *ESPNClient) GetNFLGames() ([]Game, error) {
-	return c.getGames(espnNFLScoreboardURL)
+	return c.getGames(espnNFLScoreboardPath)
 }
 
 // GetNBAGames fetches current NBA games from ESPN.
 func (c *ESPNClient) GetNBAGames() ([]Game, error) {
-	return c.getGames(espnNBAScoreboardURL)
+	return c.getGames(espnNBAScoreboardPath)
+}
+
+// LastNFLGames returns the NFL games from the last successful fetch and when
+// they were fetched, or a zero time if no fetch has succeeded.
+func (c *ESPNClient) LastNFLGames() ([]Game, time.Time) {
+	return c.lastGames(espnNFLScoreboardPath)
+}
+
+// LastNBAGames returns the NBA games from the last successful fetch and when
+// they were fetched, or a zero time if no fetch has succeeded.
+func (c *ESPNClient) LastNBAGames() ([]Game, time.Time) {
+	return c.lastGames(espnNBAScoreboardPath)
+}
+
+func (c *ESPNClient) lastGames(path string) ([]Game, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snap := c.last[path]
+	return snap.games, snap.fetchedAt
+}
+
+// getGames fetches a scoreboard, retrying failures with exponential backoff,
+// and caches the result on success. A result younger than the cache TTL is
+// returned as is.
+func (c *ESPNClient) getGames(path string) ([]Game, error) {
+	if c.cacheTTL > 0 {
+		if games, fetchedAt := c.lastGames(path); !fetchedAt.IsZero() && time.Since(fetchedAt) < c.cacheTTL {
+			return games, nil
+		}
+	}
+
+	var err error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(c.retryDelay << (attempt - 1))
+		}
+		var games []Game
+		if games, err = c.fetchGames(c.baseURL + path); err == nil {
+			c.mu.Lock()
+			c.last[path] = gamesSnapshot{games: games, fetchedAt: time.Now()}
+			c.mu.Unlock()
+			return games, nil
+		}
+	}
+	return nil, err
 }
 
-func (c *ESPNClient) getGames(url string) ([]Game, error) {
+func (c *ESPNClient) fetchGames(url string) ([]Game, error) {
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch ESPN data: %w", err)
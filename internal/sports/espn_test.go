@@ -0,0 +1,99 @@
+package sports
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const scoreboardResponse = `{
+  "events": [
+    {
+      "id": "401671789",
+      "name": "Los Angeles Rams at Philadelphia Eagles",
+      "shortName": "LAR @ PHI",
+      "date": "2026-01-19T20:00Z",
+      "status": {"type": {"name": "STATUS_IN_PROGRESS"}, "period": 4, "displayClock": "2:30"},
+      "competitions": [{
+        "competitors": [
+          {"homeAway": "home", "score": "28", "team": {"id": "21", "displayName": "Philadelphia Eagles", "abbreviation": "PHI"}},
+          {"homeAway": "away", "score": "7", "team": {"id": "14", "displayName": "Los Angeles Rams", "abbreviation": "LAR"}}
+        ]
+      }]
+    }
+  ]
+}`
+
+func TestESPNClient_RetriesFailedFetch(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != espnNFLScoreboardPath {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(scoreboardResponse))
+	}))
+	defer srv.Close()
+
+	c := NewESPNClient().WithBaseURL(srv.URL)
+	c.retryDelay = time.Millisecond
+
+	if _, at := c.LastNFLGames(); !at.IsZero() {
+		t.Fatalf("fetch time before any fetch = %v, want zero", at)
+	}
+	before := time.Now()
+	games, err := c.GetNFLGames()
+	if err != nil {
+		t.Fatalf("GetNFLGames: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("made %d requests, want 2", got)
+	}
+	if len(games) != 1 || games[0].HomeTeam.Score != 28 || games[0].Status != StatusInProgress {
+		t.Fatalf("games = %+v", games)
+	}
+
+	cached, at := c.LastNFLGames()
+	if len(cached) != 1 || at.Before(before) {
+		t.Errorf("LastNFLGames = %d games at %v, want 1 game fetched after %v", len(cached), at, before)
+	}
+}
+
+func TestESPNClient_FailureKeepsLastGames(t *testing.T) {
+	var fail atomic.Bool
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(scoreboardResponse))
+	}))
+	defer srv.Close()
+
+	c := NewESPNClient().WithBaseURL(srv.URL).WithRetries(2)
+	c.retryDelay = time.Millisecond
+	if _, err := c.GetNFLGames(); err != nil {
+		t.Fatalf("GetNFLGames: %v", err)
+	}
+	_, fetchedAt := c.LastNFLGames()
+
+	fail.Store(true)
+	atomic.StoreInt32(&hits, 0)
+	if _, err := c.GetNFLGames(); err == nil {
+		t.Fatal("GetNFLGames succeeded against a failing server")
+	}
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("made %d requests, want 3 (1 + 2 retries)", got)
+	}
+	cached, at := c.LastNFLGames()
+	if len(cached) != 1 || !at.Equal(fetchedAt) {
+		t.Errorf("LastNFLGames = %d games at %v, want the earlier fetch at %v", len(cached), at, fetchedAt)
+	}
+}
@@ -0,0 +1,86 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+// extraAccounts builds an account for each wallet after the first in
+// PrivateKeys, deriving its API credentials from the CLOB. They trade from
+// their own EOA: PROXY_WALLET_ADDRESS and the CLOB_* credentials belong to
+// the primary wallet only. Their rejected credentials trip stop like the
+// primary's.
+func extraAccounts(prefix string, cfg *config.Config, rounding clob.RoundingMode, stop *authStop) ([]clob.Account, error) {
+	if len(cfg.PrivateKeys) < 2 {
+		return nil, nil
+	}
+
+	accounts := make([]clob.Account, 0, len(cfg.PrivateKeys)-1)
+	for i, key := range cfg.PrivateKeys[1:] {
+		w, err := wallet.NewWalletFromHex(key)
+		if err != nil {
+			// Never echo the key itself
+			return nil, fmt.Errorf("invalid wallet %d in PRIVATE_KEYS: %w", i+2, err)
+		}
+		addr := w.AddressHex()
+
+		unauthed, err := newAccountClient(cfg, clob.APICreds{}, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CLOB client for %s: %w", addr, err)
+		}
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		if serverTime, err := unauthed.GetServerTime(); err == nil {
+			timestamp = strconv.FormatInt(serverTime.Unix(), 10)
+		}
+		creds, err := unauthed.DeriveAPICreds(w, int64(cfg.PolygonChainID), timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive API credentials for %s: %w", addr, err)
+		}
+
+		client, err := newAccountClient(cfg, *creds, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CLOB client for %s: %w", addr, err)
+		}
+		builder := clob.NewOrderBuilder(w, creds.APIKey)
+		builder.WithTickSizes(client).WithMinOrderSizes(client).WithRoundingMode(rounding)
+		withSignatureCheck(cfg, client, builder)
+		stop.watch(cfg, client, w, builder)
+
+		log.Printf("[%s] wallet %s ready for orders", prefix, addr)
+		accounts = append(accounts, clob.Account{Client: client, Builder: builder})
+	}
+	return accounts, nil
+}
+
+// withSignatureCheck has client check placed order IDs against builder's
+// hashes when VALIDATE_SIGNATURES is set.
+func withSignatureCheck(cfg *config.Config, client *clob.Client, builder *clob.OrderBuilder) {
+	if cfg.ValidateSignatures {
+		client.WithSignatureCheck(builder)
+	}
+}
+
+// newAccountClient creates a CLOB client for one wallet, going through the
+// configured proxies like the primary client.
+func newAccountClient(cfg *config.Config, creds clob.APICreds, addr string) (*clob.Client, error) {
+	var client *clob.Client
+	var err error
+	switch {
+	case len(cfg.ProxyURLs) > 1:
+		client, err = clob.NewClientWithProxyRotation(creds.APIKey, creds.Secret, creds.Passphrase, addr, cfg.ProxyURLs)
+	case cfg.ProxyURL != "":
+		client, err = clob.NewClientWithProxy(creds.APIKey, creds.Secret, creds.Passphrase, addr, cfg.ProxyURL)
+	default:
+		client = clob.NewClient(creds.APIKey, creds.Secret, creds.Passphrase, addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client.WithUTLS(cfg.CLOBUTLS).WithOrderRetries(cfg.CLOBOrderRetries), nil
+}
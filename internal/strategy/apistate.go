@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"sort"
+
+	"github.com/dantezy/polymarket-sniper/internal/api"
+)
+
+// WithStateBoard publishes stats, positions and scan results to board for
+// the status API.
+func (ws *WeatherSniper) WithStateBoard(board *api.Board) *WeatherSniper {
+	ws.board = board
+	return ws
+}
+
+// publishState copies the current stats and positions to the board. It runs
+// on the strategy loop so the API never reads live positions.
+func (ws *WeatherSniper) publishState() {
+	if ws.board == nil {
+		return
+	}
+	var positions []api.Position
+	for _, pos := range ws.tracker.GetAll() {
+		positions = append(positions, api.Position{
+			OrderID:  pos.OrderID,
+			TokenID:  pos.TokenID,
+			Market:   pos.MarketSlug,
+			Question: pos.MarketQuestion,
+			Side:     pos.Side,
+			Price:    pos.BidPrice,
+			Shares:   pos.Shares,
+			Status:   pos.Status,
+			PlacedAt: pos.PlacedAt,
+		})
+	}
+	sortPositions(positions)
+	ws.board.PublishState(ws.GetStats(), positions)
+}
+
+// publishScan copies a scan's opportunities to the board, best first.
+func (ws *WeatherSniper) publishScan(opportunities []*WeatherOpportunity) {
+	if ws.board == nil {
+		return
+	}
+	var scan []api.Opportunity
+	for _, opp := range opportunities {
+		scan = append(scan, api.Opportunity{
+			Market:      opp.WeatherMarket.Market.Slug,
+			Question:    opp.WeatherMarket.Market.Question,
+			Side:        opp.Side,
+			TokenID:     opp.TokenID,
+			MarketPrice: opp.MarketPriceForSide,
+			BidPrice:    opp.BidPrice,
+			Probability: opp.OurProbForSide,
+			Edge:        opp.NetEdge,
+			Score:       opp.Score,
+		})
+	}
+	sortOpportunities(scan)
+	ws.board.PublishScan(scan)
+}
+
+// WithStateBoard publishes stats, positions and scan results to board for
+// the status API.
+func (h *BlackSwanHunter) WithStateBoard(board *api.Board) *BlackSwanHunter {
+	h.board = board
+	return h
+}
+
+// publishState copies the current stats and positions to the board.
+func (h *BlackSwanHunter) publishState() {
+	if h.board == nil {
+		return
+	}
+	var positions []api.Position
+	for _, pos := range h.tracker.GetAll() {
+		positions = append(positions, api.Position{
+			OrderID:  pos.OrderID,
+			TokenID:  pos.TokenID,
+			Market:   pos.MarketSlug,
+			Question: pos.MarketTitle,
+			Side:     pos.Outcome,
+			Price:    pos.BidPrice,
+			Shares:   pos.Size,
+			Status:   pos.Status,
+			PlacedAt: pos.PlacedAt,
+		})
+	}
+	sortPositions(positions)
+	h.board.PublishState(h.GetStats(), positions)
+}
+
+// publishScan copies a scan's candidates to the board, best first.
+func (h *BlackSwanHunter) publishScan(candidates []BlackSwanCandidate) {
+	if h.board == nil {
+		return
+	}
+	var scan []api.Opportunity
+	for _, c := range candidates {
+		scan = append(scan, api.Opportunity{
+			Market:      c.Market.Slug,
+			Question:    c.Market.Question,
+			Side:        c.Outcome,
+			TokenID:     c.TokenID,
+			MarketPrice: c.CurrentPrice,
+			BidPrice:    c.BidPrice,
+			Score:       c.Score,
+		})
+	}
+	sortOpportunities(scan)
+	h.board.PublishScan(scan)
+}
+
+// sortPositions orders positions oldest first, as trackers are maps.
+func sortPositions(positions []api.Position) {
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].PlacedAt.Before(positions[j].PlacedAt)
+	})
+}
+
+// sortOpportunities orders a scan by score, best first.
+func sortOpportunities(scan []api.Opportunity) {
+	sort.Slice(scan, func(i, j int) bool {
+		return scan[i].Score > scan[j].Score
+	})
+}
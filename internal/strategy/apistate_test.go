@@ -0,0 +1,76 @@
+package strategy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/api"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+func getJSON(t *testing.T, url, token string, out interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("failed to decode %s: %v", url, err)
+	}
+}
+
+func TestStateAPI_ServesWeatherState(t *testing.T) {
+	board := api.NewBoard("weather")
+	ws := (&WeatherSniper{
+		config:      &config.Config{DryRun: true},
+		tracker:     NewWeatherPositionTracker(),
+		bankroll:    50,
+		totalTrades: 3,
+	}).WithStateBoard(board)
+
+	placed := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	ws.tracker.Add(&WeatherPosition{OrderID: "o-2", TokenID: "202", MarketSlug: "tokyo-20c", Side: "no", BidPrice: 0.6, Shares: 5, Status: "open", PlacedAt: placed.Add(time.Hour)})
+	ws.tracker.Add(&WeatherPosition{OrderID: "o-1", TokenID: "101", MarketSlug: "london-12c", Side: "yes", BidPrice: 0.3, Shares: 10, Status: "filled", PlacedAt: placed})
+	ws.publishState()
+
+	london := &gamma.WeatherMarket{Market: gamma.Market{Slug: "london-12c", Question: "London 12°C?"}}
+	ws.publishScan([]*WeatherOpportunity{
+		{WeatherMarket: london, Side: "no", TokenID: "102", Score: 0.2},
+		{WeatherMarket: london, Side: "yes", TokenID: "101", BidPrice: 0.29, MarketPriceForSide: 0.3, OurProbForSide: 0.42, NetEdge: 0.1, Score: 0.8},
+	})
+
+	srv := httptest.NewServer(api.NewServer(board, "secret").Handler())
+	defer srv.Close()
+
+	var state api.State
+	getJSON(t, srv.URL+"/state", "secret", &state)
+	if state.Strategy != "weather" || state.Stats["mode"] != "DRY_RUN" || state.Stats["total_trades"] != float64(3) {
+		t.Errorf("state = %s %v, want weather DRY_RUN with 3 trades", state.Strategy, state.Stats)
+	}
+	if len(state.Positions) != 2 || state.Positions[0].OrderID != "o-1" || state.Positions[0].Shares != 10 {
+		t.Errorf("positions = %+v, want o-1 (10 shares) first", state.Positions)
+	}
+
+	var scan api.Scan
+	getJSON(t, srv.URL+"/opportunities", "secret", &scan)
+	if len(scan.Opportunities) != 2 {
+		t.Fatalf("got %d opportunities, want 2", len(scan.Opportunities))
+	}
+	best := scan.Opportunities[0]
+	if best.TokenID != "101" || best.Probability != 0.42 || best.Edge != 0.1 || best.Market != "london-12c" {
+		t.Errorf("best opportunity = %+v, want the YES side first", best)
+	}
+}
@@ -0,0 +1,118 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/chain"
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+// allowanceCheckTimeout bounds the on-chain allowance read at startup.
+const allowanceCheckTimeout = 15 * time.Second
+
+// ErrNoAllowance means the exchange cannot move the wallet's USDC, so every
+// live order would be rejected.
+var ErrNoAllowance = errors.New("USDC allowance for the CTF exchange is zero: run cmd/approve first")
+
+// ErrNoNegRiskAllowance means the Neg Risk exchange cannot move the wallet's
+// USDC, so every order on a neg risk market would be rejected.
+var ErrNoNegRiskAllowance = errors.New("USDC allowance for the Neg Risk CTF exchange is zero: run cmd/approve first")
+
+// allowanceLookup returns a USDC allowance in dollars.
+type allowanceLookup func() (float64, error)
+
+// requireAllowance tries each lookup in order and fails with ErrNoAllowance
+// if the first one to answer reports a zero allowance for the named
+// exchange. When no lookup answers the check is skipped with a warning
+// rather than blocking startup.
+func requireAllowance(prefix, exchange string, lookups ...allowanceLookup) error {
+	for _, lookup := range lookups {
+		allowance, err := lookup()
+		if err != nil {
+			log.Printf("[%s] %s allowance check failed: %v", prefix, exchange, err)
+			continue
+		}
+		if allowance <= 0 {
+			return ErrNoAllowance
+		}
+		log.Printf("[%s] USDC allowance for the %s exchange: $%.2f", prefix, exchange, allowance)
+		return nil
+	}
+
+	log.Printf("[%s] warning: could not verify USDC allowance for the %s exchange, orders may be rejected", prefix, exchange)
+	return nil
+}
+
+// checkLiveAllowance verifies in live mode that the order funder has approved
+// the CTF exchange, reading the allowance on-chain and falling back to the
+// CLOB's balance-allowance endpoint. The Neg Risk exchange needs its own
+// approval: without it startup fails when requireNegRisk is set, for
+// strategies trading mostly neg risk markets, and only warns otherwise.
+func checkLiveAllowance(ctx context.Context, prefix string, cfg *config.Config, clobClient clob.CLOBClient, builder *clob.OrderBuilder, requireNegRisk bool) error {
+	if cfg.DryRun {
+		return nil
+	}
+
+	owner := builder.Address().Hex()
+	chainClient := chain.NewClient(cfg.PolygonRPCURLs)
+	lookups := func(spender string) []allowanceLookup {
+		return []allowanceLookup{
+			func() (float64, error) {
+				ctx, cancel := context.WithTimeout(ctx, allowanceCheckTimeout)
+				defer cancel()
+				return chainClient.USDCAllowance(ctx, owner, spender)
+			},
+			func() (float64, error) {
+				return clobClient.GetAllowance(spender)
+			},
+		}
+	}
+
+	if err := requireAllowance(prefix, "standard", lookups(wallet.ExchangeContract.Hex())...); err != nil {
+		return fmt.Errorf("%s: %w", owner, err)
+	}
+
+	if err := requireAllowance(prefix, "neg risk", lookups(wallet.NegRiskExchangeContract.Hex())...); err != nil {
+		if requireNegRisk {
+			return fmt.Errorf("%s: %w", owner, ErrNoNegRiskAllowance)
+		}
+		log.Printf("[%s] warning: %s: %v, orders on neg risk markets will be rejected", prefix, owner, ErrNoNegRiskAllowance)
+	}
+	return nil
+}
+
+// prepareLiveAccounts runs checkLiveAllowance and syncLiveNonce for each
+// account, so every wallet an order may be signed by is approved and on its
+// current nonce. It fails on the first account that isn't approved.
+func prepareLiveAccounts(ctx context.Context, prefix string, cfg *config.Config, accounts []clob.Account, requireNegRisk bool) error {
+	for _, a := range accounts {
+		if err := checkLiveAllowance(ctx, prefix, cfg, a.Client, a.Builder, requireNegRisk); err != nil {
+			return err
+		}
+		syncLiveNonce(prefix, cfg, a.Builder)
+	}
+	return nil
+}
+
+// syncLiveNonce sets the builder's order nonces from the exchange contracts
+// in live mode, so orders stay valid after an on-chain cancel-all bumped the
+// nonce. If the nonces can't be read the builder keeps its current ones.
+func syncLiveNonce(prefix string, cfg *config.Config, builder *clob.OrderBuilder) {
+	if cfg.DryRun {
+		return
+	}
+
+	standard, negRisk, err := clob.GetOnChainNonces(builder.Address().Hex(), cfg.PolygonRPCURLs...)
+	if err != nil {
+		log.Printf("[%s] warning: could not read order nonce for %s, using 0: %v", prefix, builder.Address().Hex(), err)
+		return
+	}
+	builder.SetNonces(standard, negRisk)
+	log.Printf("[%s] order nonce for %s: %s (neg risk: %s)", prefix, builder.Address().Hex(), standard, negRisk)
+}
@@ -0,0 +1,211 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+func TestRequireAllowance(t *testing.T) {
+	fixed := func(v float64) allowanceLookup {
+		return func() (float64, error) { return v, nil }
+	}
+	failing := func() (float64, error) { return 0, errors.New("rpc down") }
+
+	tests := []struct {
+		name    string
+		lookups []allowanceLookup
+		wantErr bool
+	}{
+		{"zero allowance refuses", []allowanceLookup{fixed(0)}, true},
+		{"approved allowance passes", []allowanceLookup{fixed(100)}, false},
+		{"falls back after a failed lookup", []allowanceLookup{failing, fixed(0)}, true},
+		{"first answer wins", []allowanceLookup{fixed(5), fixed(0)}, false},
+		{"unverifiable does not block", []allowanceLookup{failing, failing}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requireAllowance("test", "standard", tt.lookups...)
+			if tt.wantErr && !errors.Is(err, ErrNoAllowance) {
+				t.Errorf("err = %v, want ErrNoAllowance", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckLiveAllowance_ZeroRefusesToStart(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000000"}`)
+	}))
+	defer rpc.Close()
+
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	builder := clob.NewOrderBuilder(w, "key")
+	clobClient := clob.NewClient("key", "c2VjcmV0", "pass", w.AddressHex())
+
+	live := &config.Config{DryRun: false, PolygonRPCURLs: []string{rpc.URL}}
+	err = checkLiveAllowance(context.Background(), "test", live, clobClient, builder, false)
+	if !errors.Is(err, ErrNoAllowance) {
+		t.Errorf("live err = %v, want ErrNoAllowance", err)
+	}
+
+	dry := &config.Config{DryRun: true, PolygonRPCURLs: []string{rpc.URL}}
+	if err := checkLiveAllowance(context.Background(), "test", dry, clobClient, builder, false); err != nil {
+		t.Errorf("dry run err = %v, want nil", err)
+	}
+}
+
+func TestCheckLiveAllowance_NegRiskExchange(t *testing.T) {
+	// Only the standard exchange is approved
+	negRiskSpender := strings.ToLower(wallet.NegRiskExchangeContract.Hex()[2:])
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		var call map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &call)
+		}
+		allowance := 1_000_000_000
+		if strings.Contains(strings.ToLower(call["data"]), negRiskSpender) {
+			allowance = 0
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%064x"}`, allowance)
+	}))
+	defer rpc.Close()
+
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	builder := clob.NewOrderBuilder(w, "key")
+	clobClient := clob.NewClient("key", "c2VjcmV0", "pass", w.AddressHex())
+	cfg := &config.Config{PolygonRPCURLs: []string{rpc.URL}}
+
+	err = checkLiveAllowance(context.Background(), "test", cfg, clobClient, builder, true)
+	if !errors.Is(err, ErrNoNegRiskAllowance) {
+		t.Errorf("required err = %v, want ErrNoNegRiskAllowance", err)
+	}
+	if err := checkLiveAllowance(context.Background(), "test", cfg, clobClient, builder, false); err != nil {
+		t.Errorf("optional err = %v, want nil (warning only)", err)
+	}
+}
+
+func TestSyncLiveNonce_AdoptsExchangeNonce(t *testing.T) {
+	// nonces(maker) is 3 on the standard exchange and 5 on Neg Risk
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		var call map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &call)
+		}
+		nonce := 3
+		if strings.EqualFold(call["to"], wallet.NegRiskExchangeContract.Hex()) {
+			nonce = 5
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%064x"}`, nonce)
+	}))
+	defer rpc.Close()
+
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	builder := clob.NewOrderBuilder(w, "key")
+
+	syncLiveNonce("test", &config.Config{PolygonRPCURLs: []string{rpc.URL}}, builder)
+
+	for _, tt := range []struct {
+		negRisk bool
+		want    string
+	}{{false, "3"}, {true, "5"}} {
+		order, err := builder.BuildGTCBuyOrder("123456789", 0.5, 10, tt.negRisk)
+		if err != nil {
+			t.Fatalf("BuildGTCBuyOrder: %v", err)
+		}
+		if order.Order.Nonce != tt.want {
+			t.Errorf("negRisk=%v nonce = %s, want %s", tt.negRisk, order.Order.Nonce, tt.want)
+		}
+	}
+}
+
+func TestPrepareLiveAccounts_ChecksEveryWallet(t *testing.T) {
+	primary, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	extra, err := wallet.NewWalletFromHex("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	// The extra wallet has no allowance and nonce 7; the primary has both
+	// approved and nonce 0
+	extraAddr := strings.ToLower(extra.AddressHex()[2:])
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		var call map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) > 0 {
+			_ = json.Unmarshal(req.Params[0], &call)
+		}
+		isExtra := strings.Contains(strings.ToLower(call["data"]), extraAddr)
+		isNonce := strings.EqualFold(call["to"], wallet.ExchangeContract.Hex()) ||
+			strings.EqualFold(call["to"], wallet.NegRiskExchangeContract.Hex())
+		var result int
+		switch {
+		case isNonce && isExtra:
+			result = 7
+		case !isNonce && !isExtra:
+			result = 1_000_000_000
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%064x"}`, result)
+	}))
+	defer rpc.Close()
+
+	account := func(w *wallet.Wallet) clob.Account {
+		return clob.Account{
+			Client:  clob.NewClient("key", "c2VjcmV0", "pass", w.AddressHex()),
+			Builder: clob.NewOrderBuilder(w, "key"),
+		}
+	}
+	accounts := []clob.Account{account(primary), account(extra)}
+	cfg := &config.Config{PolygonRPCURLs: []string{rpc.URL}}
+
+	err = prepareLiveAccounts(context.Background(), "test", cfg, accounts, false)
+	if !errors.Is(err, ErrNoAllowance) {
+		t.Fatalf("err = %v, want ErrNoAllowance", err)
+	}
+	if !strings.Contains(err.Error(), extra.AddressHex()) {
+		t.Errorf("err = %v, want it to name the extra wallet", err)
+	}
+
+	// Each wallet's builder adopts its own exchange nonce
+	syncLiveNonce("test", cfg, accounts[1].Builder)
+	order, err := accounts[1].Builder.BuildGTCBuyOrder("123456789", 0.5, 10, false)
+	if err != nil {
+		t.Fatalf("BuildGTCBuyOrder: %v", err)
+	}
+	if order.Order.Nonce != "7" {
+		t.Errorf("extra wallet nonce = %s, want 7", order.Order.Nonce)
+	}
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+)
+
+// errNotArmed is returned in place of submitting a live order while the arm
+// delay is still running.
+var errNotArmed = errors.New("skipping: not armed yet, holding live orders until LIVE_ARM_DELAY passes")
+
+// armGate holds back live orders for LIVE_ARM_DELAY after Run starts, so a
+// misconfigured bot can be stopped before it trades. Scans carry on and log
+// what they would have placed. A nil gate is always armed.
+type armGate struct {
+	prefix string
+	delay  time.Duration
+	armed  atomic.Bool
+}
+
+// newArmGate returns nil in dry run or when no delay is configured.
+func newArmGate(prefix string, cfg *config.Config) *armGate {
+	if cfg.DryRun || cfg.LiveArmDelay <= 0 {
+		return nil
+	}
+	return &armGate{prefix: prefix, delay: cfg.LiveArmDelay}
+}
+
+// start arms the gate once the delay has passed, unless ctx ends first.
+func (g *armGate) start(ctx context.Context) {
+	if g == nil {
+		return
+	}
+	log.Printf("[%s] DISARMED for %s: scanning without submitting orders, Ctrl-C now if anything looks wrong",
+		g.prefix, g.delay)
+
+	timer := time.NewTimer(g.delay)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			g.armed.Store(true)
+			log.Printf("[%s] ARMED — live trading active", g.prefix)
+		}
+	}()
+}
+
+// check returns errNotArmed until the gate arms.
+func (g *armGate) check() error {
+	if g == nil || g.armed.Load() {
+		return nil
+	}
+	return errNotArmed
+}
@@ -0,0 +1,88 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+func TestNewArmGate_Disabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{"no delay", &config.Config{}},
+		{"dry run", &config.Config{DryRun: true, LiveArmDelay: time.Minute}},
+	}
+	for _, tt := range tests {
+		g := newArmGate("test", tt.cfg)
+		if g != nil {
+			t.Errorf("%s: want nil gate", tt.name)
+		}
+		g.start(context.Background())
+		if err := g.check(); err != nil {
+			t.Errorf("%s: nil gate check = %v, want armed", tt.name, err)
+		}
+	}
+}
+
+func TestArmGate_HoldsOrdersUntilDelay(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	const delay = 50 * time.Millisecond
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	cfg.LiveArmDelay = delay
+	mock := clobmock.New()
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		clob:     mock,
+		builder:  clob.NewOrderBuilder(w, "key"),
+		tracker:  NewPositionTracker(),
+		arming:   newArmGate("blackswan", cfg),
+		bankroll: 10,
+	}
+	candidate := BlackSwanCandidate{
+		Market:       testBlackSwanMarket("longshot", 0.03, 0.97, 5000, time.Now().Add(24*time.Hour)),
+		TokenID:      "987654321",
+		Outcome:      "Yes",
+		CurrentPrice: 0.03,
+		BidPrice:     0.02,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h.arming.start(ctx)
+
+	if err := h.PlaceBet(candidate); !errors.Is(err, errNotArmed) {
+		t.Fatalf("PlaceBet before arming = %v, want errNotArmed", err)
+	}
+	if len(mock.Orders()) != 0 || h.tracker.Count() != 0 {
+		t.Fatalf("orders=%d positions=%d before the delay, want none", len(mock.Orders()), h.tracker.Count())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for h.arming.check() != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("gate never armed")
+		}
+		time.Sleep(delay / 5)
+	}
+
+	if err := h.PlaceBet(candidate); err != nil {
+		t.Fatalf("PlaceBet after arming: %v", err)
+	}
+	if len(mock.Orders()) != 1 {
+		t.Errorf("orders = %d after arming, want 1", len(mock.Orders()))
+	}
+}
@@ -0,0 +1,83 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/telegram"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+// authStop halts a strategy once the CLOB rejects its API credentials for
+// good, alerting once, instead of failing every request until restarted.
+// A nil stop never trips.
+type authStop struct {
+	prefix   string
+	telegram *telegram.Bot
+
+	mu     sync.Mutex
+	err    error
+	cancel context.CancelCauseFunc
+}
+
+func newAuthStop(prefix string, tg *telegram.Bot) *authStop {
+	return &authStop{prefix: prefix, telegram: tg}
+}
+
+// watch has client report rejected credentials to the stop, after first
+// re-deriving them from w when CLOB_REDERIVE_CREDS is set. Renewed
+// credentials become the owner of builder's later orders.
+func (a *authStop) watch(cfg *config.Config, client *clob.Client, w *wallet.Wallet, builder *clob.OrderBuilder) {
+	if cfg.CLOBRederiveCreds {
+		client.WithCredentialRenewal(w, int64(cfg.PolygonChainID), builder)
+	}
+	client.OnAuthExpired(a.trip)
+}
+
+// bind returns ctx canceled with the credentials error as its cause once
+// the stop trips. Run loops return context.Cause of it.
+func (a *authStop) bind(ctx context.Context) context.Context {
+	if a == nil {
+		return ctx
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	a.mu.Lock()
+	a.cancel = cancel
+	err := a.err
+	a.mu.Unlock()
+	if err != nil {
+		cancel(err)
+	}
+	return ctx
+}
+
+// trip halts the bound Run with err, alerting on the first call only.
+func (a *authStop) trip(err error) {
+	a.mu.Lock()
+	first := a.err == nil
+	if first {
+		a.err = err
+	}
+	cancel := a.cancel
+	a.mu.Unlock()
+	if !first {
+		return
+	}
+
+	log.Printf("[%s] halting: %v", a.prefix, err)
+	if a.telegram != nil {
+		// Plain text and unthrottled: the error carries the CLOB's raw
+		// response body, and the process exits right after
+		msg := fmt.Sprintf("API Credentials Rejected\n\n%s halted: %v\n\nRun derive-creds, update CLOB_API_KEY/SECRET/PASSPHRASE and restart.", a.prefix, err)
+		if err := a.telegram.SendCritical(msg); err != nil {
+			log.Printf("[%s] telegram error: %v", a.prefix, err)
+		}
+	}
+	if cancel != nil {
+		cancel(err)
+	}
+}
@@ -0,0 +1,40 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+)
+
+func TestAuthStop_HaltsRun(t *testing.T) {
+	expired := fmt.Errorf("%w: status 401", clob.ErrAuthExpired)
+
+	// Tripped while Run is going
+	stop := newAuthStop("test", nil)
+	ctx := stop.bind(context.Background())
+	if ctx.Err() != nil {
+		t.Fatal("context canceled before the credentials were rejected")
+	}
+	stop.trip(expired)
+	stop.trip(errors.New("second rejection"))
+	<-ctx.Done()
+	if cause := context.Cause(ctx); !errors.Is(cause, clob.ErrAuthExpired) {
+		t.Errorf("cause = %v, want the first rejection", cause)
+	}
+
+	// Tripped before Run started
+	early := newAuthStop("test", nil)
+	early.trip(expired)
+	if ctx := early.bind(context.Background()); !errors.Is(context.Cause(ctx), clob.ErrAuthExpired) {
+		t.Errorf("cause = %v, want the rejection", context.Cause(ctx))
+	}
+
+	// A strategy built without one runs on
+	var none *authStop
+	if ctx := none.bind(context.Background()); ctx.Err() != nil {
+		t.Errorf("nil stop canceled the context: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,45 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+)
+
+// liveBalanceTTL is how long a wallet balance lookup is reused.
+const liveBalanceTTL = time.Minute
+
+// balanceLookup reads the wallet's spendable USDC.
+type balanceLookup func() (float64, error)
+
+// balanceCache reuses the last balance lookup for ttl, so strategies can ask
+// for it every scan without hitting the RPC each time. Failed lookups are
+// not cached.
+type balanceCache struct {
+	lookup balanceLookup
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	balance float64
+	fetched time.Time
+}
+
+func newBalanceCache(lookup balanceLookup, ttl time.Duration) *balanceCache {
+	return &balanceCache{lookup: lookup, ttl: ttl}
+}
+
+// get returns the cached balance, or looks it up again once ttl has passed.
+func (c *balanceCache) get(now time.Time) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetched.IsZero() && now.Sub(c.fetched) < c.ttl {
+		return c.balance, nil
+	}
+	balance, err := c.lookup()
+	if err != nil {
+		return 0, err
+	}
+	c.balance = balance
+	c.fetched = now
+	return balance, nil
+}
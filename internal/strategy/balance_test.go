@@ -0,0 +1,78 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+)
+
+func TestBalanceCache(t *testing.T) {
+	calls := 0
+	balance := 12.0
+	c := newBalanceCache(func() (float64, error) {
+		calls++
+		if calls == 2 {
+			return 0, errors.New("rpc down")
+		}
+		return balance, nil
+	}, time.Minute)
+
+	now := time.Now()
+	if got, err := c.get(now); err != nil || got != 12 {
+		t.Fatalf("get() = %v, %v; want 12", got, err)
+	}
+	if got, _ := c.get(now.Add(30 * time.Second)); got != 12 || calls != 1 {
+		t.Errorf("within ttl: got %v after %d lookups, want cached 12 after 1", got, calls)
+	}
+	if _, err := c.get(now.Add(time.Minute)); err == nil {
+		t.Error("want the failed lookup's error once the ttl passes")
+	}
+
+	// Failures aren't cached, so the next call looks up again
+	balance = 20
+	if got, err := c.get(now.Add(time.Minute)); err != nil || got != 20 || calls != 3 {
+		t.Errorf("get() = %v, %v after %d lookups; want 20 after 3", got, err, calls)
+	}
+}
+
+func TestScanAndBet_LiveBalanceUpdatesBankroll(t *testing.T) {
+	balance := 15.0
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	cfg.MaxPositionSize = 15
+
+	h := (&BlackSwanHunter{
+		config:   cfg,
+		tracker:  NewPositionTracker(),
+		bankroll: cfg.MaxPositionSize,
+		balances: newBalanceCache(func() (float64, error) { return balance, nil }, 0),
+	}).WithMarketSource(gammamock.New())
+
+	balance = 42.5
+	if err := h.ScanAndBet(); err != nil {
+		t.Fatalf("ScanAndBet: %v", err)
+	}
+	if h.bankroll != 42.5 {
+		t.Errorf("bankroll = %.2f after first scan, want 42.50", h.bankroll)
+	}
+
+	balance = 30
+	if err := h.ScanAndBet(); err != nil {
+		t.Fatalf("ScanAndBet: %v", err)
+	}
+	if h.bankroll != 30 {
+		t.Errorf("bankroll = %.2f after second scan, want 30.00", h.bankroll)
+	}
+
+	// Without live balance the configured bankroll stays put
+	h.balances = nil
+	balance = 99
+	if err := h.ScanAndBet(); err != nil {
+		t.Fatalf("ScanAndBet: %v", err)
+	}
+	if h.bankroll != 30 {
+		t.Errorf("bankroll = %.2f with a fixed bankroll, want 30.00", h.bankroll)
+	}
+}
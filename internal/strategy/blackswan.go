@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dantezy/polymarket-sniper/internal/api"
 	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
 	"github.com/dantezy/polymarket-sniper/internal/gamma"
@@ -47,6 +48,7 @@ type OpenPosition struct {
 	BidPrice     float64
 	Size         float64
 	PlacedAt     time.Time
+	EndTime      time.Time // When the market resolves
 	CurrentPrice float64
 	Status       string // "open", "filled", "cancelled"
 }
@@ -128,18 +130,33 @@ func (pt *PositionTracker) HasMarket(marketSlug string) bool {
 
 // BlackSwanHunter implements the power-law distribution betting strategy.
 type BlackSwanHunter struct {
-	config   *config.Config
-	gamma    *gamma.Client
-	clob     *clob.Client
-	builder  *clob.OrderBuilder
-	telegram *telegram.Bot
-	tracker  *PositionTracker
+	config     *config.Config
+	gamma      gamma.MarketSource
+	clob       clob.CLOBClient
+	builder    *clob.OrderBuilder
+	accounts   *clob.AccountPool // Orders round-robin across PRIVATE_KEYS wallets, nil with one wallet
+	telegram   *telegram.Bot
+	discounts  discountSchedule   // Bid discount by days to resolution, empty = flat discount
+	illiquid   *hourWindow        // Skip markets resolving in these illiquid hours, nil when disabled
+	emptyScans *emptyScanWatchdog // Alerts when scans keep finding no markets
+	gammaLimit *rateLimitBackoff  // Pauses scans after a Gamma rate limit
+	brackets   *bracketSeller     // Take-profit sells placed on fill, nil when disabled
+	lossStop   *sessionLossStop   // Halts new bets past MAX_SESSION_LOSS, nil when disabled
+	held       heldPositions      // Filled live positions awaiting resolution
+	arming     *armGate           // Holds live orders for LIVE_ARM_DELAY, nil when disabled
+	authStop   *authStop          // Halts Run once the CLOB rejects the API credentials
+	holdings   *holdingsReport    // Wallet P&L from the Data API, nil in dry run
+	tracker    *PositionTracker
+	board      *api.Board
+	paper      *PaperAccount // Simulated balance, dry run only
 
 	// Bankroll tracking
 	bankroll float64
+	balances *balanceCache // Live wallet balance, nil when the bankroll is fixed
 	mu       sync.RWMutex
 
 	// Stats
+	startedAt     time.Time
 	totalBets     int
 	totalFilled   int
 	totalCanceled int
@@ -184,6 +201,7 @@ func NewBlackSwanHunter(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot)
 	} else {
 		clobClient = clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, walletAddr)
 	}
+	clobClient.WithUTLS(cfg.CLOBUTLS).WithOrderRetries(cfg.CLOBOrderRetries)
 
 	// Create order builder - use proxy wallet if configured
 	var builder *clob.OrderBuilder
@@ -192,34 +210,167 @@ func NewBlackSwanHunter(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot)
 	} else {
 		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
 	}
+	rounding, err := clob.ParseRoundingMode(cfg.OrderSizeRounding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_SIZE_ROUNDING: %w", err)
+	}
+	builder.WithTickSizes(clobClient).WithMinOrderSizes(clobClient).WithRoundingMode(rounding)
+	withSignatureCheck(cfg, clobClient, builder)
+	authStop := newAuthStop("blackswan", tg)
+	authStop.watch(cfg, clobClient, w, builder)
+
+	// Optional bid discount schedule replaces the flat discount
+	var discounts discountSchedule
+	if cfg.BlackSwanDiscounts != "" {
+		discounts, err = parseDiscountSchedule(cfg.BlackSwanDiscounts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BLACKSWAN_BID_DISCOUNT_SCHEDULE: %w", err)
+		}
+		log.Printf("[blackswan] bid discount schedule: %s", cfg.BlackSwanDiscounts)
+	}
+
+	// Optional illiquid hours to avoid resolving in, when a take-profit
+	// would struggle to fill
+	var illiquid *hourWindow
+	if cfg.BlackSwanIlliquidHours != "" {
+		illiquid, err = parseHourWindow(cfg.BlackSwanIlliquidHours)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BLACKSWAN_ILLIQUID_HOURS: %w", err)
+		}
+		log.Printf("[blackswan] skipping markets resolving %s", illiquid)
+	}
+
+	h := &BlackSwanHunter{
+		config:     cfg,
+		discounts:  discounts,
+		illiquid:   illiquid,
+		gamma:      gammaClient,
+		clob:       clobClient,
+		builder:    builder,
+		telegram:   tg,
+		emptyScans: newEmptyScanWatchdog("blackswan", cfg.EmptyScanAlertAfter, tg),
+		gammaLimit: newRateLimitBackoff("blackswan"),
+		arming:     newArmGate("blackswan", cfg),
+		authStop:   authStop,
+		brackets:   newBracketSeller("blackswan", cfg.BlackSwanSellTarget),
+		lossStop:   newSessionLossStop("blackswan", cfg.MaxSessionLoss),
+		tracker:    NewPositionTracker(),
+		bankroll:   cfg.BlackSwanBankrollSize(),
+	}
+
+	// Use proxy wallet for holdings and balance queries if configured
+	balanceAddr := w.AddressHex()
+	if cfg.ProxyWalletAddress != "" {
+		balanceAddr = cfg.ProxyWalletAddress
+	}
+	balanceAddrs := []string{balanceAddr}
+
+	// Spread orders across any extra wallets; the pool stands in for the
+	// primary client so fills and cancels reach the right account
+	if len(cfg.PrivateKeys) > 1 && !cfg.DryRun {
+		extra, err := extraAccounts("blackswan", cfg, rounding, h.authStop)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := clob.NewAccountPool(append([]clob.Account{{Client: clobClient, Builder: builder}}, extra...)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create account pool: %w", err)
+		}
+		log.Printf("[blackswan] placing orders round-robin across %d wallets", pool.Len())
+		h.clob, h.accounts = pool, pool
+		for _, a := range extra {
+			balanceAddrs = append(balanceAddrs, a.Builder.Address().Hex())
+		}
+	}
+
+	h.holdings = newHoldingsReport("blackswan", cfg.DryRun, balanceAddrs...)
+
+	// Optionally track the real balance of every wallet in live mode
+	if cfg.BlackSwanLiveBalance && !cfg.DryRun {
+		h.balances = newBalanceCache(func() (float64, error) {
+			var total float64
+			for _, addr := range balanceAddrs {
+				balance, err := clob.GetOnChainUSDCBalance(addr, cfg.PolygonRPCURLs...)
+				if err != nil {
+					log.Printf("[blackswan] on-chain balance of %s failed: %v, trying CLOB API", addr, err)
+					// The pool's CLOB balance is the sum over its wallets
+					return h.clob.GetUSDCBalance()
+				}
+				total += balance
+			}
+			return total, nil
+		}, liveBalanceTTL)
+	}
+
+	// Dry run bets against a simulated balance
+	if cfg.DryRun {
+		start := cfg.PaperBalance
+		if start <= 0 {
+			start = h.bankroll
+		}
+		h.paper = NewPaperAccount(start)
+	}
+
+	return h, nil
+}
+
+// nextBuilder returns the builder to sign a new order with: with several
+// wallets, the next one's in turn.
+func (h *BlackSwanHunter) nextBuilder() *clob.OrderBuilder {
+	if h.accounts == nil {
+		return h.builder
+	}
+	return h.accounts.NextBuilder()
+}
+
+// builderFor returns the builder for the wallet that placed orderID.
+func (h *BlackSwanHunter) builderFor(orderID string) *clob.OrderBuilder {
+	if h.accounts == nil {
+		return h.builder
+	}
+	return h.accounts.BuilderFor(orderID)
+}
 
-	return &BlackSwanHunter{
-		config:   cfg,
-		gamma:    gammaClient,
-		clob:     clobClient,
-		builder:  builder,
-		telegram: tg,
-		tracker:  NewPositionTracker(),
-		bankroll: cfg.MaxPositionSize, // Use max position as bankroll
-	}, nil
+// WithMarketSource replaces the Gamma client markets are fetched from
+// (useful for testing).
+func (h *BlackSwanHunter) WithMarketSource(src gamma.MarketSource) *BlackSwanHunter {
+	h.gamma = src
+	return h
 }
 
 // Run starts the Black Swan hunter and blocks until context is cancelled.
 func (h *BlackSwanHunter) Run(ctx context.Context) error {
+	h.startedAt = time.Now()
+	ctx = h.authStop.bind(ctx)
 	log.Printf("[blackswan] starting in %s mode", h.modeString())
 	log.Printf("[blackswan] config: max_price=%.4f (%.1f¢), min_price=%.4f (%.2f¢)",
 		h.config.BlackSwanMaxPrice, h.config.BlackSwanMaxPrice*100,
 		h.config.BlackSwanMinPrice, h.config.BlackSwanMinPrice*100)
+	log.Printf("[blackswan] config: min_opposite=%.2f", h.config.BlackSwanMinOpposite)
 	log.Printf("[blackswan] config: bet_percent=%.1f%%, max_positions=%d, max_exposure=$%.2f",
 		h.config.BlackSwanBetPercent*100, h.config.BlackSwanMaxPositions, h.config.BlackSwanMaxExposure)
 	log.Printf("[blackswan] config: bid_discount=%.0f%%, min_volume=$%.0f, max_days=%d",
 		h.config.BlackSwanBidDiscount*100, h.config.BlackSwanMinVolume, h.config.BlackSwanMaxDays)
 	log.Printf("[blackswan] bankroll: $%.2f", h.bankroll)
 
+	// Every wallet in the pool signs orders of its own
+	accounts := []clob.Account{{Client: h.clob, Builder: h.builder}}
+	if h.accounts != nil {
+		accounts = h.accounts.Accounts()
+	}
+	if err := prepareLiveAccounts(ctx, "blackswan", h.config, accounts, true); err != nil {
+		return err
+	}
+	if _, err := cancelOrphanOrders("blackswan", h.config, h.clob, h.trackedOrders(), h.searchMarkets); err != nil {
+		log.Printf("[blackswan] orphaned order check failed: %v", err)
+	}
+	h.arming.start(ctx)
+
 	// Initial scan
 	if err := h.ScanAndBet(); err != nil {
 		log.Printf("[blackswan] initial scan error: %v", err)
 	}
+	h.publishState()
 
 	scanTicker := time.NewTicker(blackSwanScanInterval)
 	checkTicker := time.NewTicker(blackSwanCheckInterval)
@@ -229,11 +380,23 @@ func (h *BlackSwanHunter) Run(ctx context.Context) error {
 	defer checkTicker.Stop()
 	defer statusTicker.Stop()
 
+	// Position digests are off unless BLACKSWAN_DIGEST_MINUTES is set
+	var digestC <-chan time.Time
+	if h.config.BlackSwanDigestMins > 0 {
+		digestTicker := time.NewTicker(time.Duration(h.config.BlackSwanDigestMins) * time.Minute)
+		defer digestTicker.Stop()
+		digestC = digestTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			log.Printf("[blackswan] shutting down")
-			return ctx.Err()
+			if h.paper != nil {
+				h.paper.LogSummary("blackswan")
+			}
+			h.logSessionSummary()
+			return context.Cause(ctx)
 
 		case <-scanTicker.C:
 			if err := h.ScanAndBet(); err != nil {
@@ -247,7 +410,11 @@ func (h *BlackSwanHunter) Run(ctx context.Context) error {
 
 		case <-statusTicker.C:
 			h.logStatus()
+
+		case <-digestC:
+			h.logPositionDigest()
 		}
+		h.publishState()
 	}
 }
 
@@ -255,10 +422,19 @@ func (h *BlackSwanHunter) Run(ctx context.Context) error {
 func (h *BlackSwanHunter) ScanAndBet() error {
 	log.Printf("[blackswan] scanning for black swan opportunities...")
 
+	// The session stop is permanent; CheckPositions still runs
+	if h.lossStop.Halted() {
+		log.Printf("[blackswan] session loss limit reached ($%+.2f realized), skipping scan", h.lossStop.Realized())
+		return nil
+	}
+
+	h.refreshBankroll()
+
 	candidates, err := h.FindCandidates()
 	if err != nil {
 		return fmt.Errorf("failed to find candidates: %w", err)
 	}
+	h.publishScan(candidates)
 
 	if len(candidates) == 0 {
 		log.Printf("[blackswan] no candidates found matching criteria")
@@ -272,72 +448,77 @@ func (h *BlackSwanHunter) ScanAndBet() error {
 		return candidates[i].Score > candidates[j].Score
 	})
 
-	// Place bets on top candidates
-	betsPlaced := 0
+	// Size bets on the top candidates, then place them together
+	var bets []*pendingBet
+	betMarkets := make(map[string]bool)
+	pendingExposure := 0.0
 	for _, candidate := range candidates {
 		// Check position limits
-		if h.tracker.Count() >= h.config.BlackSwanMaxPositions {
+		if h.tracker.Count()+len(bets) >= h.config.BlackSwanMaxPositions {
 			log.Printf("[blackswan] max positions reached (%d)", h.config.BlackSwanMaxPositions)
 			break
 		}
 
 		// Check exposure limit
-		if h.tracker.TotalExposure() >= h.config.BlackSwanMaxExposure {
+		if h.tracker.TotalExposure()+pendingExposure >= h.config.BlackSwanMaxExposure {
 			log.Printf("[blackswan] max exposure reached ($%.2f)", h.config.BlackSwanMaxExposure)
 			break
 		}
 
 		// Skip if we already have position in this market
-		if h.tracker.HasMarket(candidate.Market.Slug) {
+		if h.tracker.HasMarket(candidate.Market.Slug) || betMarkets[candidate.Market.Slug] {
 			continue
 		}
 
-		// Place the bet
-		if err := h.PlaceBet(candidate); err != nil {
+		bet, err := h.sizeBet(candidate, pendingExposure)
+		if err != nil {
 			log.Printf("[blackswan] failed to place bet on %s: %v", candidate.Market.Question, err)
 			continue
 		}
+		bets = append(bets, bet)
+		betMarkets[candidate.Market.Slug] = true
+		pendingExposure += bet.costUSD
 
-		betsPlaced++
-		if betsPlaced >= 5 { // Max 5 new bets per scan
+		if len(bets) >= 5 { // Max 5 new bets per scan
 			break
 		}
 	}
 
+	betsPlaced := 0
+	for i, err := range h.placeBets(bets) {
+		if err != nil {
+			log.Printf("[blackswan] failed to place bet on %s: %v", bets[i].candidate.Market.Question, err)
+			continue
+		}
+		betsPlaced++
+	}
+
 	log.Printf("[blackswan] placed %d new bets", betsPlaced)
 	return nil
 }
 
 // FindCandidates searches for markets matching Black Swan criteria.
 func (h *BlackSwanHunter) FindCandidates() ([]BlackSwanCandidate, error) {
-	// Calculate date range: from now to maxDays in future
 	now := time.Now()
-	maxDays := h.config.BlackSwanMaxDays
-	if maxDays <= 0 {
-		maxDays = 30
-	}
-	endDateMin := now.Format(time.RFC3339)
-	endDateMax := now.Add(time.Duration(maxDays) * 24 * time.Hour).Format(time.RFC3339)
+	maxDays := h.maxDays()
+	maxEnd := now.Add(time.Duration(maxDays) * 24 * time.Hour)
 
-	// Fetch markets sorted by 24hr volume (most active first)
-	// Using API-level date filtering to only get markets ending within our time window
-	params := gamma.SearchParams{
-		Active:     true,
-		Closed:     false,
-		Limit:      500,
-		OrderBy:    "volume24hr",
-		Order:      "DESC",
-		EndDateMin: endDateMin,
-		EndDateMax: endDateMax,
+	if h.gammaLimit.paused(now) {
+		return nil, nil
 	}
-
-	markets, err := h.gamma.SearchMarketsWithParams(params)
+	markets, err := h.searchMarkets()
+	h.emptyScans.observe(len(markets))
 	if err != nil {
+		h.gammaLimit.observe(err, now)
 		return nil, fmt.Errorf("failed to search markets: %w", err)
 	}
 
 	var candidates []BlackSwanCandidate
 	skippedVolume := 0
+	skippedDying := 0
+	skippedResolved := 0
+	skippedFar := 0
+	skippedIlliquid := 0
 
 	log.Printf("[blackswan] searching %d markets ending within %d days", len(markets), maxDays)
 
@@ -360,6 +541,13 @@ func (h *BlackSwanHunter) FindCandidates() ([]BlackSwanCandidate, error) {
 			continue
 		}
 
+		// Clearing the daily floor isn't enough if trading is falling away
+		// from the week's pace: a dying market's resting bids rarely fill
+		if market.GetVolume1wk() < h.config.BlackSwanMinWeeklyVol || market.RecentVolumeTrend() < h.config.BlackSwanMinVolTrend {
+			skippedDying++
+			continue
+		}
+
 		// Get tokens and prices
 		yesToken := market.GetYesToken()
 		noToken := market.GetNoToken()
@@ -367,8 +555,28 @@ func (h *BlackSwanHunter) FindCandidates() ([]BlackSwanCandidate, error) {
 			continue
 		}
 
+		// Extreme prices are the target here, so only skip them when the
+		// outcome already looks decided rather than merely unlikely
+		if market.LikelyResolved(now) {
+			skippedResolved++
+			continue
+		}
+
+		// Gamma's end date filter isn't always applied, so enforce max days
+		// here too. Markets without an end date are let through.
+		if endTime, err := market.EndTime(); err == nil && !endTime.IsZero() {
+			if endTime.After(maxEnd) {
+				skippedFar++
+				continue
+			}
+			if h.illiquid != nil && h.illiquid.contains(endTime) {
+				skippedIlliquid++
+				continue
+			}
+		}
+
 		// Check YES side for black swan opportunity
-		if h.isBlackSwanCandidate(yesToken.Price, noToken.Price) {
+		if h.isBlackSwanCandidate(yesToken.Probability(), noToken.Probability()) {
 			candidate := h.buildCandidate(market, yesToken, noToken)
 			if candidate != nil {
 				candidate.Volume = volume24hr
@@ -377,7 +585,7 @@ func (h *BlackSwanHunter) FindCandidates() ([]BlackSwanCandidate, error) {
 		}
 
 		// Check NO side for black swan opportunity
-		if h.isBlackSwanCandidate(noToken.Price, yesToken.Price) {
+		if h.isBlackSwanCandidate(noToken.Probability(), yesToken.Probability()) {
 			candidate := h.buildCandidateNo(market, noToken, yesToken)
 			if candidate != nil {
 				candidate.Volume = volume24hr
@@ -389,10 +597,58 @@ func (h *BlackSwanHunter) FindCandidates() ([]BlackSwanCandidate, error) {
 	if skippedVolume > 0 {
 		log.Printf("[blackswan] filtered: %d low volume (<$1000)", skippedVolume)
 	}
+	if skippedDying > 0 {
+		log.Printf("[blackswan] filtered: %d with fading volume (weekly < $%.0f or trend < %.2f)",
+			skippedDying, h.config.BlackSwanMinWeeklyVol, h.config.BlackSwanMinVolTrend)
+	}
+	if skippedResolved > 0 {
+		log.Printf("[blackswan] filtered: %d likely resolved", skippedResolved)
+	}
+	if skippedFar > 0 {
+		log.Printf("[blackswan] filtered: %d resolving beyond %d days", skippedFar, maxDays)
+	}
+	if skippedIlliquid > 0 {
+		log.Printf("[blackswan] filtered: %d resolving in illiquid hours (%s)", skippedIlliquid, h.illiquid)
+	}
 
 	return candidates, nil
 }
 
+// maxDays is how far ahead, in days, markets are searched (default 30).
+func (h *BlackSwanHunter) maxDays() int {
+	if h.config.BlackSwanMaxDays <= 0 {
+		return 30
+	}
+	return h.config.BlackSwanMaxDays
+}
+
+// searchMarkets fetches the active markets ending within maxDays, most
+// traded in the last 24 hours first.
+func (h *BlackSwanHunter) searchMarkets() ([]gamma.Market, error) {
+	now := time.Now()
+	maxEnd := now.Add(time.Duration(h.maxDays()) * 24 * time.Hour)
+
+	// Using API-level date filtering to only get markets ending within our time window
+	return h.gamma.SearchMarketsWithParams(gamma.SearchParams{
+		Active:     true,
+		Closed:     false,
+		Limit:      500,
+		OrderBy:    "volume24hr",
+		Order:      "DESC",
+		EndDateMin: now.Format(time.RFC3339),
+		EndDateMax: maxEnd.Format(time.RFC3339),
+	})
+}
+
+// trackedOrders returns the set of order IDs the tracker holds.
+func (h *BlackSwanHunter) trackedOrders() map[string]bool {
+	tracked := make(map[string]bool)
+	for _, pos := range h.tracker.GetAll() {
+		tracked[pos.OrderID] = true
+	}
+	return tracked
+}
+
 // isBlackSwanCandidate checks if a price qualifies as a black swan opportunity.
 func (h *BlackSwanHunter) isBlackSwanCandidate(price, oppositePrice float64) bool {
 	// Price must be in target range (e.g., 0.5¢ - 10¢)
@@ -403,14 +659,41 @@ func (h *BlackSwanHunter) isBlackSwanCandidate(price, oppositePrice float64) boo
 		return false
 	}
 
-	// Opposite side should be overconfident (>90%)
-	if oppositePrice < 0.90 {
+	// Opposite side should be overconfident (e.g., ≥90%)
+	if oppositePrice < h.config.BlackSwanMinOpposite {
 		return false
 	}
 
 	return true
 }
 
+// bidDiscount returns how far below market to bid on a market resolving in
+// daysUntil days, from the schedule when one is configured.
+func (h *BlackSwanHunter) bidDiscount(daysUntil float64) float64 {
+	if len(h.discounts) == 0 {
+		return h.config.BlackSwanBidDiscount
+	}
+	return h.discounts.at(daysUntil)
+}
+
+// refreshBankroll sets the bankroll from the wallet balance when
+// BLACKSWAN_USE_LIVE_BALANCE is on, keeping the last bankroll if the
+// lookup fails.
+func (h *BlackSwanHunter) refreshBankroll() {
+	if h.balances == nil {
+		return
+	}
+	balance, err := h.balances.get(time.Now())
+	if err != nil {
+		log.Printf("[blackswan] balance lookup failed, keeping bankroll $%.2f: %v", h.bankroll, err)
+		return
+	}
+	if balance != h.bankroll {
+		log.Printf("[blackswan] bankroll: $%.2f (live balance)", balance)
+	}
+	h.bankroll = balance
+}
+
 // buildCandidate creates a BlackSwanCandidate for the YES side.
 func (h *BlackSwanHunter) buildCandidate(market gamma.Market, yesToken, noToken *gamma.Token) *BlackSwanCandidate {
 	endTime, _ := market.EndTime()
@@ -428,8 +711,10 @@ func (h *BlackSwanHunter) buildCandidate(market gamma.Market, yesToken, noToken
 		endTime = now.Add(30 * 24 * time.Hour) // Default to 30 days
 	}
 
+	daysUntil := time.Until(endTime).Hours() / 24
+
 	// Calculate bid price (discount from current price)
-	bidPrice := yesToken.Price * (1 - h.config.BlackSwanBidDiscount)
+	bidPrice := yesToken.Probability() * (1 - h.bidDiscount(daysUntil))
 	if bidPrice < h.config.BlackSwanMinPrice {
 		bidPrice = h.config.BlackSwanMinPrice
 	}
@@ -451,25 +736,24 @@ func (h *BlackSwanHunter) buildCandidate(market gamma.Market, yesToken, noToken
 	// Time bonus: prefer faster resolution (days until end)
 	// Markets ending in 1-7 days get 2x bonus, 7-14 days get 1.5x, 14-30 days get 1x
 	timeBonus := 1.0
-	daysUntil := time.Until(endTime).Hours() / 24
 	if daysUntil <= 7 {
 		timeBonus = 2.0 // Ends this week - excellent
 	} else if daysUntil <= 14 {
 		timeBonus = 1.5 // Ends in 2 weeks - good
 	}
 
-	score := (1 - yesToken.Price) * noToken.Price * 100 * volumeBonus * timeBonus
+	score := (1 - yesToken.Probability()) * noToken.Probability() * 100 * volumeBonus * timeBonus
 
 	return &BlackSwanCandidate{
 		Market:        market,
 		TokenID:       yesToken.TokenID,
 		Outcome:       "Yes",
-		CurrentPrice:  yesToken.Price,
+		CurrentPrice:  yesToken.Probability(),
 		BidPrice:      bidPrice,
 		Score:         score,
 		Volume:        volume24hr,
 		EndTime:       endTime,
-		OverConfident: noToken.Price >= 0.90,
+		OverConfident: noToken.Probability() >= h.config.BlackSwanMinOpposite,
 	}
 }
 
@@ -489,7 +773,9 @@ func (h *BlackSwanHunter) buildCandidateNo(market gamma.Market, noToken, yesToke
 		endTime = now.Add(30 * 24 * time.Hour)
 	}
 
-	bidPrice := noToken.Price * (1 - h.config.BlackSwanBidDiscount)
+	daysUntil := time.Until(endTime).Hours() / 24
+
+	bidPrice := noToken.Probability() * (1 - h.bidDiscount(daysUntil))
 	if bidPrice < h.config.BlackSwanMinPrice {
 		bidPrice = h.config.BlackSwanMinPrice
 	}
@@ -506,160 +792,221 @@ func (h *BlackSwanHunter) buildCandidateNo(market gamma.Market, noToken, yesToke
 
 	// Time bonus: prefer faster resolution
 	timeBonus := 1.0
-	daysUntil := time.Until(endTime).Hours() / 24
 	if daysUntil <= 7 {
 		timeBonus = 2.0
 	} else if daysUntil <= 14 {
 		timeBonus = 1.5
 	}
 
-	score := (1 - noToken.Price) * yesToken.Price * 100 * volumeBonus * timeBonus
+	score := (1 - noToken.Probability()) * yesToken.Probability() * 100 * volumeBonus * timeBonus
 
 	return &BlackSwanCandidate{
 		Market:        market,
 		TokenID:       noToken.TokenID,
 		Outcome:       "No",
-		CurrentPrice:  noToken.Price,
+		CurrentPrice:  noToken.Probability(),
 		BidPrice:      bidPrice,
 		Score:         score,
 		Volume:        volume24hr,
 		EndTime:       endTime,
-		OverConfident: yesToken.Price >= 0.90,
+		OverConfident: yesToken.Probability() >= h.config.BlackSwanMinOpposite,
 	}
 }
 
+// pendingBet is a bet sized for a candidate but not yet placed.
+type pendingBet struct {
+	candidate BlackSwanCandidate
+	shares    float64
+	costUSD   float64
+}
+
 // PlaceBet places a limit order for a Black Swan candidate.
 func (h *BlackSwanHunter) PlaceBet(candidate BlackSwanCandidate) error {
+	bet, err := h.sizeBet(candidate, 0)
+	if err != nil {
+		return err
+	}
+	return h.placeBets([]*pendingBet{bet})[0]
+}
+
+// sizeBet works out the shares to bid for candidate. pending is the cost of
+// bets already sized but not yet placed, which count toward the exposure
+// limit.
+func (h *BlackSwanHunter) sizeBet(candidate BlackSwanCandidate, pending float64) (*pendingBet, error) {
 	// Calculate bet amount in USD (% of bankroll)
 	betAmountUSD := h.bankroll * h.config.BlackSwanBetPercent
 
 	// Check if this would exceed max exposure
-	currentExposure := h.tracker.TotalExposure()
+	currentExposure := h.tracker.TotalExposure() + pending
 	if currentExposure+betAmountUSD > h.config.BlackSwanMaxExposure {
 		betAmountUSD = h.config.BlackSwanMaxExposure - currentExposure
 		if betAmountUSD < 0.01 {
-			return fmt.Errorf("insufficient remaining exposure")
+			return nil, fmt.Errorf("insufficient remaining exposure")
 		}
 	}
 
 	// Convert USD amount to number of shares
 	// shares = USD / price (e.g., $0.75 / $0.01 = 75 shares)
-	shares := betAmountUSD / candidate.BidPrice
+	shares := h.builder.RoundSize(betAmountUSD / candidate.BidPrice)
 
-	// Polymarket minimum order size is 5 shares
-	const minShares = 5.0
-	if shares < minShares {
+	// Bump up to the market's minimum order size (usually 5 shares)
+	if minShares := clob.MinOrderSize(h.clob, candidate.TokenID); shares < minShares {
 		shares = minShares
-		betAmountUSD = shares * candidate.BidPrice
+	}
+	betAmountUSD = shares * candidate.BidPrice
+
+	// A win pays $1 a share but has to be redeemed on-chain; skip bets too
+	// small to be worth claiming
+	if net := shares - h.config.SettlementGasUSD; net < 0 || net < h.config.MinEconomicalBet {
+		return nil, fmt.Errorf("bet not economical: payout $%.2f less $%.2f settlement gas is below $%.2f",
+			shares, h.config.SettlementGasUSD, h.config.MinEconomicalBet)
 	}
 
 	log.Printf("[blackswan] placing bet: %s %s at %.4f (%.2f¢) shares=%.1f cost=$%.2f",
 		candidate.Market.Question, candidate.Outcome,
 		candidate.BidPrice, candidate.BidPrice*100, shares, betAmountUSD)
 
+	return &pendingBet{candidate: candidate, shares: shares, costUSD: betAmountUSD}, nil
+}
+
+// placeBets places sized bets, returning one error per bet (nil if it was
+// placed). Live orders go to the CLOB in a single batch; the exchange takes
+// or rejects each on its own, so one rejection doesn't undo the rest.
+func (h *BlackSwanHunter) placeBets(bets []*pendingBet) []error {
+	errs := make([]error, len(bets))
+	if len(bets) == 0 {
+		return errs
+	}
+
 	if h.config.DryRun {
-		log.Printf("[blackswan] DRY_RUN: would place GTC limit order")
-
-		// Track as if placed (Size = shares for exposure tracking)
-		position := &OpenPosition{
-			OrderID:      fmt.Sprintf("dry-%d", time.Now().UnixNano()),
-			TokenID:      candidate.TokenID,
-			MarketSlug:   candidate.Market.Slug,
-			MarketTitle:  candidate.Market.Question,
-			Outcome:      candidate.Outcome,
-			BidPrice:     candidate.BidPrice,
-			Size:         shares,
-			PlacedAt:     time.Now(),
-			CurrentPrice: candidate.CurrentPrice,
-			Status:       "open",
-		}
-		h.tracker.Add(position)
-		h.totalBets++
-
-		if h.telegram != nil {
-			msg := fmt.Sprintf("[DRY RUN] Bet\n\n"+
-				"%s\n\n"+
-				"Side: %s @ %.2f¢\n"+
-				"Size: %.0f shares ($%.2f)\n"+
-				"Volume: $%.0f\n"+
-				"Potential: %.0fx",
-				candidate.Market.Question, candidate.Outcome,
-				candidate.BidPrice*100,
-				shares, betAmountUSD,
-				candidate.Volume,
-				1.0/candidate.BidPrice)
-			h.telegram.SendMessage(msg)
+		for i, bet := range bets {
+			errs[i] = h.placeDryRun(bet)
 		}
-
-		return nil
+		return errs
 	}
 
-	// Check if market uses Neg Risk CTF Exchange
-	negRisk, err := h.clob.GetNegRisk(candidate.TokenID)
-	if err != nil {
-		log.Printf("[blackswan] warning: failed to check neg_risk for %s: %v (assuming standard)", candidate.TokenID, err)
-		negRisk = false
+	if err := h.arming.check(); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
 	}
 
-	// Build GTC limit order (size = number of shares)
-	order, err := h.builder.BuildGTCBuyOrder(candidate.TokenID, candidate.BidPrice, shares, negRisk)
-	if err != nil {
-		return fmt.Errorf("failed to build order: %w", err)
+	orders := make([]*clob.OrderRequest, 0, len(bets))
+	built := make([]int, 0, len(bets)) // Index in bets of each order
+	for i, bet := range bets {
+		// Check if market uses Neg Risk CTF Exchange
+		negRisk, err := h.clob.GetNegRisk(bet.candidate.TokenID)
+		if err != nil {
+			log.Printf("[blackswan] warning: failed to check neg_risk for %s: %v (assuming standard)", bet.candidate.TokenID, err)
+			negRisk = false
+		}
+
+		// Build GTC limit order (size = number of shares)
+		order, err := h.nextBuilder().BuildGTCBuyOrder(bet.candidate.TokenID, bet.candidate.BidPrice, bet.shares, negRisk)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to build order: %w", err)
+			continue
+		}
+		orders = append(orders, order)
+		built = append(built, i)
 	}
 
-	// Submit order
-	resp, err := h.clob.CreateOrder(order)
-	if err != nil {
-		return fmt.Errorf("failed to submit order: %w", err)
+	for j, resp := range clob.SubmitOrders(h.clob, orders) {
+		bet := bets[built[j]]
+		if !resp.Success {
+			errs[built[j]] = fmt.Errorf("order rejected: %s", resp.Error)
+			continue
+		}
+		h.trackBet(bet, resp.OrderID)
+		log.Printf("[blackswan] ORDER PLACED: %s (order ID: %s)", bet.candidate.Market.Question, resp.OrderID)
+		h.notifyBet("Bet Placed", bet)
 	}
+	return errs
+}
 
-	if !resp.Success {
-		return fmt.Errorf("order rejected: %s", resp.Error)
+// placeDryRun records bet as placed without sending an order.
+func (h *BlackSwanHunter) placeDryRun(bet *pendingBet) error {
+	log.Printf("[blackswan] DRY_RUN: would place GTC limit order")
+
+	candidate := bet.candidate
+	orderID := fmt.Sprintf("dry-%d", time.Now().UnixNano())
+	if h.paper != nil {
+		err := h.paper.Open(PaperPosition{
+			ID:         orderID,
+			TokenID:    candidate.TokenID,
+			MarketSlug: candidate.Market.Slug,
+			Label:      fmt.Sprintf("%s %s", candidate.Market.Question, candidate.Outcome),
+			Shares:     bet.shares,
+			Price:      candidate.BidPrice,
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	// Track the position (Size = shares)
-	position := &OpenPosition{
-		OrderID:      resp.OrderID,
+	// Track as if placed (Size = shares for exposure tracking)
+	h.trackBet(bet, orderID)
+	h.notifyBet("[DRY RUN] Bet", bet)
+	return nil
+}
+
+// trackBet records a placed bet as an open position.
+func (h *BlackSwanHunter) trackBet(bet *pendingBet, orderID string) {
+	candidate := bet.candidate
+	h.tracker.Add(&OpenPosition{
+		OrderID:      orderID,
 		TokenID:      candidate.TokenID,
 		MarketSlug:   candidate.Market.Slug,
 		MarketTitle:  candidate.Market.Question,
 		Outcome:      candidate.Outcome,
 		BidPrice:     candidate.BidPrice,
-		Size:         shares,
+		Size:         bet.shares,
 		PlacedAt:     time.Now(),
+		EndTime:      candidate.EndTime,
 		CurrentPrice: candidate.CurrentPrice,
 		Status:       "open",
-	}
-	h.tracker.Add(position)
+	})
 	h.totalBets++
+}
 
-	log.Printf("[blackswan] ORDER PLACED: %s (order ID: %s)", candidate.Market.Question, resp.OrderID)
-
-	if h.telegram != nil {
-		msg := fmt.Sprintf("Bet Placed\n\n"+
-			"%s\n\n"+
-			"Side: %s @ %.2f¢\n"+
-			"Size: %.0f shares ($%.2f)\n"+
-			"Volume: $%.0f\n"+
-			"Potential: %.0fx",
-			candidate.Market.Question, candidate.Outcome,
-			candidate.BidPrice*100,
-			shares, betAmountUSD,
-			candidate.Volume,
-			1.0/candidate.BidPrice)
-		h.telegram.SendMessage(msg)
+// notifyBet sends a Telegram message about a placed bet under title.
+func (h *BlackSwanHunter) notifyBet(title string, bet *pendingBet) {
+	if h.telegram == nil {
+		return
 	}
-
-	return nil
+	candidate := bet.candidate
+	msg := fmt.Sprintf("%s\n\n"+
+		"%s\n\n"+
+		"Side: %s @ %.2f¢\n"+
+		"Size: %.0f shares ($%.2f)\n"+
+		"Volume: $%.0f\n"+
+		"Potential: %.0fx",
+		title,
+		candidate.Market.Question, candidate.Outcome,
+		candidate.BidPrice*100,
+		bet.shares, bet.costUSD,
+		candidate.Volume,
+		1.0/candidate.BidPrice)
+	h.telegram.SendMessage(msg)
 }
 
 // CheckPositions checks the status of open positions and handles fills/cancellations.
 func (h *BlackSwanHunter) CheckPositions() error {
 	if h.config.DryRun {
-		// In dry run, just log positions
+		// In dry run, settle paper positions whose markets have resolved
+		if h.paper != nil {
+			settlePaperPositions(h.paper, h.gamma, "blackswan", func(pos PaperPosition, pnl float64) {
+				h.tracker.Remove(pos.ID)
+				h.recordRealized(pnl)
+			})
+		}
 		return nil
 	}
 
+	// Retry take-profit sells refused while earlier fills were settling
+	h.brackets.retryPending(h.clob, time.Now())
+
 	// Get open orders from CLOB
 	openOrders, err := h.clob.GetOpenOrders()
 	if err != nil {
@@ -675,6 +1022,12 @@ func (h *BlackSwanHunter) CheckPositions() error {
 		}
 	}
 
+	// Take-profits that sold realize their P&L now, not at resolution
+	h.brackets.checkFills(h.clob, openOrderMap, func(tokenID string, shares, pnl float64) {
+		h.held.sell(tokenID, shares)
+		h.recordRealized(pnl)
+	})
+
 	// Check our tracked positions
 	for _, pos := range h.tracker.GetAll() {
 		// Check if order is still open
@@ -682,24 +1035,47 @@ func (h *BlackSwanHunter) CheckPositions() error {
 			// Order was filled or cancelled
 			log.Printf("[blackswan] order %s no longer open (was: %s)", pos.OrderID, pos.MarketTitle)
 
+			shares, ok, err := closedOrderFill(h.clob, pos.OrderID)
+			if err != nil {
+				log.Printf("[blackswan] failed to look up order %s: %v", pos.OrderID, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			builder := h.builderFor(pos.OrderID)
+			h.tracker.Remove(pos.OrderID)
+			if shares <= 0 {
+				log.Printf("[blackswan] order %s canceled unfilled", pos.OrderID)
+				h.totalCanceled++
+				continue
+			}
+
 			// Send Telegram notification for filled order
 			if h.telegram != nil {
-				potentialPayout := pos.Size * 1.0 // Each share pays $1 if wins
-				potentialProfit := potentialPayout - (pos.Size * pos.BidPrice)
+				potentialPayout := shares * 1.0 // Each share pays $1 if wins
+				potentialProfit := potentialPayout - (shares * pos.BidPrice)
 				msg := fmt.Sprintf("Order Filled!\n\n"+
 					"%s\n\n"+
 					"You own: %.0f %s shares\n"+
 					"Cost: $%.2f\n"+
 					"Payout if wins: $%.2f",
 					pos.MarketTitle,
-					pos.Size, pos.Outcome,
-					pos.Size*pos.BidPrice,
+					shares, pos.Outcome,
+					shares*pos.BidPrice,
 					potentialPayout)
-				h.telegram.SendMessage(msg)
+				h.telegram.SendCritical(msg)
 				log.Printf("[blackswan] potential profit if wins: $%.2f", potentialProfit)
 			}
 
-			h.tracker.Remove(pos.OrderID)
+			h.brackets.onFill(h.clob, builder, pos.TokenID, pos.MarketTitle, pos.BidPrice, shares, time.Now())
+			h.held.add(heldPosition{
+				tokenID:    pos.TokenID,
+				marketSlug: pos.MarketSlug,
+				label:      fmt.Sprintf("%s %s", pos.MarketTitle, pos.Outcome),
+				shares:     shares,
+				price:      pos.BidPrice,
+			})
 			h.totalFilled++
 			continue
 		}
@@ -716,9 +1092,48 @@ func (h *BlackSwanHunter) CheckPositions() error {
 		}
 	}
 
+	h.held.settle(h.gamma, "blackswan", func(_ heldPosition, pnl float64) {
+		h.recordRealized(pnl)
+	})
+
 	return nil
 }
 
+// recordRealized books the P&L of a resolved position against the session
+// loss stop.
+func (h *BlackSwanHunter) recordRealized(pnl float64) {
+	if h.lossStop.record(pnl) {
+		h.haltTrading()
+	}
+}
+
+// haltTrading cancels every resting bet after the session loss stop trips.
+// Filled positions are left to resolve; in dry run every bet is already a
+// filled paper position.
+func (h *BlackSwanHunter) haltTrading() {
+	canceled := 0
+	for _, pos := range h.tracker.GetAll() {
+		if h.config.DryRun {
+			break
+		}
+		if err := h.clob.CancelOrder(pos.OrderID); err != nil {
+			log.Printf("[blackswan] failed to cancel order %s: %v", pos.OrderID, err)
+			continue
+		}
+		h.tracker.Remove(pos.OrderID)
+		h.totalCanceled++
+		canceled++
+	}
+
+	if h.telegram != nil {
+		msg := fmt.Sprintf("Black Swan Betting Halted\n\n"+
+			"Session loss limit reached: $%+.2f realized (limit -$%.2f)\n"+
+			"Canceled %d open orders. Existing positions are still managed.",
+			h.lossStop.Realized(), h.config.MaxSessionLoss, canceled)
+		h.telegram.SendCritical(msg)
+	}
+}
+
 // logStatus logs the current status of the hunter.
 func (h *BlackSwanHunter) logStatus() {
 	positions := h.tracker.GetAll()
@@ -735,6 +1150,38 @@ func (h *BlackSwanHunter) logStatus() {
 				pos.MarketTitle, pos.Outcome, pos.BidPrice, pos.BidPrice*100, pos.Size, age)
 		}
 	}
+
+	h.holdings.log(time.Now(), &h.held)
+
+	if h.paper != nil {
+		h.paper.LogSummary("blackswan")
+	}
+}
+
+// logSessionSummary reports the session's trading recap on shutdown.
+func (h *BlackSwanHunter) logSessionSummary() {
+	summary := SessionSummary{
+		Strategy: "Black Swan",
+		Mode:     h.modeString(),
+		Runtime:  time.Since(h.startedAt),
+		Placed:   h.totalBets,
+		Filled:   h.totalFilled,
+		Canceled: h.totalCanceled,
+		Exposure: h.tracker.TotalExposure(),
+		Notes:    []string{fmt.Sprintf("Bankroll: $%.2f", h.bankroll)},
+	}
+	if h.paper != nil {
+		summary.PnL = h.paper.RealizedPnL()
+		summary.PnLBasis = "paper, settled positions"
+		summary.Notes = append(summary.Notes, estimatePaperPnL(h.paper, h.EstimateOutcome).String())
+	}
+	reportSessionSummary("blackswan", h.telegram, summary)
+}
+
+// EstimateOutcome conservatively assumes an open long shot loses; the range
+// still shows what a win would pay.
+func (h *BlackSwanHunter) EstimateOutcome(pos PaperPosition) OutcomeEstimate {
+	return OutcomeEstimate{Shares: pos.Shares, Cost: pos.Cost()}
 }
 
 // modeString returns "LIVE" or "DRY_RUN" based on config.
@@ -747,7 +1194,7 @@ func (h *BlackSwanHunter) modeString() string {
 
 // GetStats returns current hunter statistics.
 func (h *BlackSwanHunter) GetStats() map[string]interface{} {
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"mode":           h.modeString(),
 		"positions":      h.tracker.Count(),
 		"exposure":       h.tracker.TotalExposure(),
@@ -756,6 +1203,12 @@ func (h *BlackSwanHunter) GetStats() map[string]interface{} {
 		"total_canceled": h.totalCanceled,
 		"bankroll":       h.bankroll,
 	}
+	if h.paper != nil {
+		for k, v := range h.paper.Stats() {
+			stats[k] = v
+		}
+	}
+	return stats
 }
 
 // maskProxy masks the password in a proxy URL for logging.
@@ -0,0 +1,448 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+func testBlackSwanMarket(slug string, yes, no, volume float64, end time.Time) gamma.Market {
+	return gamma.Market{
+		Slug:       slug,
+		Question:   slug + "?",
+		EndDate:    end.Format(time.RFC3339),
+		Volume24hr: gamma.FlexNumber(volume),
+		Tokens: []gamma.Token{
+			{TokenID: slug + "-yes", Outcome: "Yes", Price: yes},
+			{TokenID: slug + "-no", Outcome: "No", Price: no},
+		},
+	}
+}
+
+func testBlackSwanConfig() *config.Config {
+	return &config.Config{
+		DryRun:               true,
+		BlackSwanMinPrice:    0.005,
+		BlackSwanMaxPrice:    0.10,
+		BlackSwanMinOpposite: 0.90,
+		BlackSwanBetPercent:  0.05,
+		BlackSwanMaxExposure: 10,
+		BlackSwanBidDiscount: 0.25,
+		BlackSwanMaxDays:     30,
+	}
+}
+
+func TestFindCandidates(t *testing.T) {
+	soon := time.Now().Add(5 * 24 * time.Hour)
+	source := gammamock.New()
+	source.Search = []gamma.Market{
+		testBlackSwanMarket("yes-longshot", 0.03, 0.97, 5000, soon),
+		testBlackSwanMarket("no-longshot", 0.96, 0.04, 5000, soon),
+		testBlackSwanMarket("low-volume", 0.03, 0.97, 500, soon),
+		testBlackSwanMarket("btc-updown-15m-1767225600", 0.03, 0.97, 5000, soon),
+		testBlackSwanMarket("not-overconfident", 0.05, 0.85, 5000, soon),
+		testBlackSwanMarket("already-ended", 0.03, 0.97, 5000, time.Now().Add(-time.Hour)),
+		testBlackSwanMarket("dust", 0.001, 0.999, 5000, soon),
+	}
+
+	h := &BlackSwanHunter{config: testBlackSwanConfig(), gamma: source}
+
+	candidates, err := h.FindCandidates()
+	if err != nil {
+		t.Fatalf("FindCandidates: %v", err)
+	}
+
+	var got []string
+	for _, c := range candidates {
+		got = append(got, c.TokenID)
+	}
+	sort.Strings(got)
+	want := []string{"no-longshot-no", "yes-longshot-yes"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+
+	for _, c := range candidates {
+		if c.BidPrice >= c.CurrentPrice {
+			t.Errorf("%s: bid %.4f not below current %.4f", c.TokenID, c.BidPrice, c.CurrentPrice)
+		}
+		if !c.OverConfident {
+			t.Errorf("%s: want OverConfident", c.TokenID)
+		}
+	}
+
+	searches := source.Searches()
+	if len(searches) != 1 || !searches[0].Active || searches[0].Closed || searches[0].EndDateMax == "" {
+		t.Errorf("searches = %+v, want one for active markets with an end date window", searches)
+	}
+}
+
+func TestFindCandidates_SkipsLikelyResolved(t *testing.T) {
+	// Both trade over the $1000 floor, but the decided market's last day is
+	// a sliver of its lifetime volume and it ends within hours
+	longShot := testBlackSwanMarket("long-shot", 0.02, 0.98, 5000, time.Now().Add(6*time.Hour))
+	longShot.VolumeNum = 60000
+	decided := testBlackSwanMarket("decided", 0.02, 0.98, 1500, time.Now().Add(6*time.Hour))
+	decided.VolumeNum = 400000
+
+	source := gammamock.New()
+	source.Search = []gamma.Market{longShot, decided}
+	h := &BlackSwanHunter{config: testBlackSwanConfig(), gamma: source}
+
+	candidates, err := h.FindCandidates()
+	if err != nil {
+		t.Fatalf("FindCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].TokenID != "long-shot-yes" {
+		t.Fatalf("candidates = %+v, want only long-shot-yes", candidates)
+	}
+}
+
+func TestPlaceBet_SubmitsOrder(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	mock := clobmock.New()
+	mock.NegRisk["987654321"] = true
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		clob:     mock,
+		builder:  clob.NewOrderBuilder(w, "key"),
+		tracker:  NewPositionTracker(),
+		bankroll: 10,
+	}
+
+	candidate := BlackSwanCandidate{
+		Market:       testBlackSwanMarket("longshot", 0.03, 0.97, 5000, time.Now().Add(24*time.Hour)),
+		TokenID:      "987654321",
+		Outcome:      "Yes",
+		CurrentPrice: 0.03,
+		BidPrice:     0.02,
+	}
+	if err := h.PlaceBet(candidate); err != nil {
+		t.Fatalf("PlaceBet: %v", err)
+	}
+
+	orders := mock.Orders()
+	if len(orders) != 1 {
+		t.Fatalf("submitted %d orders, want 1", len(orders))
+	}
+	// 5% of $10 at 2¢ is 25 shares for 50¢
+	if orders[0].Order.TakerAmount != "25000000" || orders[0].Order.MakerAmount != "500000" {
+		t.Errorf("amounts = %s/%s, want 500000/25000000", orders[0].Order.MakerAmount, orders[0].Order.TakerAmount)
+	}
+	if orders[0].OrderType != string(clob.OrderTypeGTC) {
+		t.Errorf("order type = %s, want GTC", orders[0].OrderType)
+	}
+
+	pos := h.tracker.Get("mock-1")
+	if pos == nil || pos.Size != 25 {
+		t.Fatalf("tracked position = %+v, want 25 shares under mock-1", pos)
+	}
+}
+
+func TestIsBlackSwanCandidate_MinOpposite(t *testing.T) {
+	tests := []struct {
+		name        string
+		minOpposite float64
+		opposite    float64
+		want        bool
+	}{
+		{"accepted at 0.85", 0.85, 0.88, true},
+		{"rejected at 0.90", 0.90, 0.88, false},
+		{"at threshold", 0.90, 0.90, true},
+		{"rejected at 0.95", 0.95, 0.93, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testBlackSwanConfig()
+			cfg.BlackSwanMinOpposite = tt.minOpposite
+			h := &BlackSwanHunter{config: cfg}
+			if got := h.isBlackSwanCandidate(0.05, tt.opposite); got != tt.want {
+				t.Errorf("isBlackSwanCandidate(0.05, %.2f) at %.2f = %v, want %v", tt.opposite, tt.minOpposite, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindCandidates_MaxDays(t *testing.T) {
+	source := gammamock.New()
+	source.Search = []gamma.Market{
+		testBlackSwanMarket("near", 0.03, 0.97, 5000, time.Now().Add(5*24*time.Hour)),
+		testBlackSwanMarket("far", 0.03, 0.97, 5000, time.Now().Add(45*24*time.Hour)),
+	}
+	cfg := testBlackSwanConfig()
+	cfg.BlackSwanMaxDays = 10
+	h := &BlackSwanHunter{config: cfg, gamma: source}
+
+	candidates, err := h.FindCandidates()
+	if err != nil {
+		t.Fatalf("FindCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].TokenID != "near-yes" {
+		t.Fatalf("candidates = %+v, want only near-yes", candidates)
+	}
+}
+
+func TestFindCandidates_IlliquidHours(t *testing.T) {
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(3 * 24 * time.Hour)
+	source := gammamock.New()
+	source.Search = []gamma.Market{
+		testBlackSwanMarket("overnight", 0.03, 0.97, 5000, day.Add(3*time.Hour)),
+		testBlackSwanMarket("afternoon", 0.03, 0.97, 5000, day.Add(15*time.Hour)),
+	}
+	h := &BlackSwanHunter{config: testBlackSwanConfig(), gamma: source, illiquid: &hourWindow{start: 22, end: 6}}
+
+	candidates, err := h.FindCandidates()
+	if err != nil {
+		t.Fatalf("FindCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].TokenID != "afternoon-yes" {
+		t.Fatalf("candidates = %+v, want only afternoon-yes", candidates)
+	}
+}
+
+func TestFindCandidates_VolumeTrend(t *testing.T) {
+	soon := time.Now().Add(5 * 24 * time.Hour)
+	reviving := testBlackSwanMarket("reviving", 0.03, 0.97, 5000, soon)
+	reviving.Volume1wk = 14000 // $2000/day average, $5000 today
+	dying := testBlackSwanMarket("dying", 0.03, 0.97, 5000, soon)
+	dying.Volume1wk = 140000 // $20000/day average, $5000 today
+	quiet := testBlackSwanMarket("quiet-week", 0.03, 0.97, 5000, soon)
+	quiet.Volume1wk = 5000 // Today was the whole week
+
+	tests := []struct {
+		name      string
+		minWeekly float64
+		minTrend  float64
+		want      []string
+	}{
+		{"disabled", 0, 0, []string{"dying-yes", "quiet-week-yes", "reviving-yes"}},
+		{"trend", 0, 0.5, []string{"quiet-week-yes", "reviving-yes"}},
+		{"weekly floor", 10000, 0, []string{"dying-yes", "reviving-yes"}},
+		{"both", 10000, 0.5, []string{"reviving-yes"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := gammamock.New()
+			source.Search = []gamma.Market{reviving, dying, quiet}
+			cfg := testBlackSwanConfig()
+			cfg.BlackSwanMinWeeklyVol = tt.minWeekly
+			cfg.BlackSwanMinVolTrend = tt.minTrend
+			h := &BlackSwanHunter{config: cfg, gamma: source}
+
+			candidates, err := h.FindCandidates()
+			if err != nil {
+				t.Fatalf("FindCandidates: %v", err)
+			}
+			var got []string
+			for _, c := range candidates {
+				got = append(got, c.TokenID)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("candidates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceBet_SkipsUneconomicalBet(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		minBet  float64
+		gas     float64
+		wantBet bool
+	}{
+		{"covers gas", 0, 0.02, true},
+		{"gas exceeds payout", 0, 30, false},
+		{"below economical floor", 20, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testBlackSwanConfig()
+			cfg.MinEconomicalBet = tt.minBet
+			cfg.SettlementGasUSD = tt.gas
+			h := &BlackSwanHunter{
+				config:   cfg,
+				clob:     clobmock.New(),
+				builder:  clob.NewOrderBuilder(w, "key"),
+				tracker:  NewPositionTracker(),
+				bankroll: 10,
+			}
+
+			// 5% of $10 at 2¢ is 25 shares, paying $25 on a win
+			err := h.PlaceBet(BlackSwanCandidate{
+				Market:   testBlackSwanMarket("longshot", 0.03, 0.97, 5000, time.Now().Add(24*time.Hour)),
+				TokenID:  "987654321",
+				Outcome:  "Yes",
+				BidPrice: 0.02,
+			})
+			if gotBet := err == nil; gotBet != tt.wantBet {
+				t.Fatalf("PlaceBet error = %v, want bet %v", err, tt.wantBet)
+			}
+			if tracked := len(h.tracker.GetAll()) > 0; tracked != tt.wantBet {
+				t.Errorf("tracked a position = %v, want %v", tracked, tt.wantBet)
+			}
+		})
+	}
+}
+
+func TestScanAndBet_SubmitsOneBatch(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	soon := time.Now().Add(5 * 24 * time.Hour)
+	source := gammamock.New()
+	for i, yes := range []float64{0.03, 0.04, 0.02} {
+		m := testBlackSwanMarket(fmt.Sprintf("longshot-%d", i), yes, 1-yes, 5000, soon)
+		// Orders are only signed for numeric token IDs
+		m.Tokens[0].TokenID = fmt.Sprintf("98765432%d", i)
+		source.Search = append(source.Search, m)
+	}
+
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	cfg.BlackSwanMaxPositions = 10
+	mock := clobmock.New()
+	h := &BlackSwanHunter{
+		config:   cfg,
+		clob:     mock,
+		gamma:    source,
+		builder:  clob.NewOrderBuilder(w, "key"),
+		tracker:  NewPositionTracker(),
+		bankroll: 10,
+	}
+
+	if err := h.ScanAndBet(); err != nil {
+		t.Fatalf("ScanAndBet: %v", err)
+	}
+	if got := mock.Batches(); got != 1 {
+		t.Errorf("submitted %d batches, want 1", got)
+	}
+	if got := len(mock.Orders()); got != 3 {
+		t.Errorf("submitted %d orders, want 3", got)
+	}
+	if got := h.tracker.Count(); got != 3 {
+		t.Errorf("tracking %d positions, want 3", got)
+	}
+}
+
+func TestPlaceBet_RoundRobinAccounts(t *testing.T) {
+	keys := []string{
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+	}
+	var accounts []clob.Account
+	var mocks []*clobmock.Client
+	for _, key := range keys {
+		w, err := wallet.NewWalletFromHex(key)
+		if err != nil {
+			t.Fatalf("failed to create wallet: %v", err)
+		}
+		mock := clobmock.New()
+		mocks = append(mocks, mock)
+		accounts = append(accounts, clob.Account{Client: mock, Builder: clob.NewOrderBuilder(w, "key-"+w.AddressHex())})
+	}
+	pool, err := clob.NewAccountPool(accounts...)
+	if err != nil {
+		t.Fatalf("NewAccountPool: %v", err)
+	}
+
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	h := &BlackSwanHunter{
+		config:   cfg,
+		clob:     pool,
+		builder:  accounts[0].Builder,
+		accounts: pool,
+		tracker:  NewPositionTracker(),
+		bankroll: 10,
+	}
+
+	for i := 0; i < 3; i++ {
+		slug := fmt.Sprintf("longshot-%d", i)
+		err := h.PlaceBet(BlackSwanCandidate{
+			Market:   testBlackSwanMarket(slug, 0.03, 0.97, 5000, time.Now().Add(24*time.Hour)),
+			TokenID:  fmt.Sprintf("98765432%d", i),
+			Outcome:  "Yes",
+			BidPrice: 0.02,
+		})
+		if err != nil {
+			t.Fatalf("PlaceBet %d: %v", i, err)
+		}
+	}
+
+	// Orders alternate: first, second, then back to the first account
+	for i, want := range []int{2, 1} {
+		orders := mocks[i].Orders()
+		if len(orders) != want {
+			t.Fatalf("account %d got %d orders, want %d", i, len(orders), want)
+		}
+		maker := accounts[i].Builder.Address().Hex()
+		for _, o := range orders {
+			if !strings.EqualFold(o.Order.Maker, maker) || o.Owner != "key-"+accounts[i].Builder.Address().Hex() {
+				t.Errorf("account %d submitted an order made by %s for %s", i, o.Order.Maker, o.Owner)
+			}
+		}
+	}
+	if got := mocks[0].Orders()[1].Order.TokenID; got != "987654322" {
+		t.Errorf("first account's second order is for %s, want the third bet 987654322", got)
+	}
+}
+
+func TestNewBlackSwanHunter_Bankroll(t *testing.T) {
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		bankroll float64
+		want     float64
+	}{
+		{"BLACKSWAN_BANKROLL", 40, 40},
+		{"falls back to MAX_POSITION_SIZE", 0, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testBlackSwanConfig()
+			cfg.DryRun = true
+			cfg.MaxPositionSize = 15
+			cfg.BlackSwanBankroll = tt.bankroll
+
+			h, err := NewBlackSwanHunter(cfg, w, nil)
+			if err != nil {
+				t.Fatalf("NewBlackSwanHunter: %v", err)
+			}
+			if h.bankroll != tt.want {
+				t.Errorf("bankroll = %.2f, want %.2f", h.bankroll, tt.want)
+			}
+			if got := h.paper.Balance(); got != tt.want {
+				t.Errorf("paper balance = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// logPositionDigest reports every open position's age, time to resolution,
+// entry and current price, logging it and sending it as one Telegram
+// message. Current prices come from the order book, falling back to the
+// last price seen when the book can't be read.
+func (h *BlackSwanHunter) logPositionDigest() {
+	positions := h.tracker.GetAll()
+	if len(positions) == 0 {
+		return
+	}
+
+	prices := make(map[string]float64, len(positions))
+	for _, pos := range positions {
+		if _, ok := prices[pos.TokenID]; ok {
+			continue
+		}
+		price := pos.CurrentPrice
+		if book, err := h.clob.GetOrderBook(pos.TokenID); err == nil {
+			bid, ask, _ := extractBestPricesWithSize(book)
+			if mid := midpoint(bid, ask); mid > 0 {
+				price = mid
+			}
+		} else {
+			log.Printf("[blackswan] digest: order book for %s unavailable: %v", pos.MarketTitle, err)
+		}
+		prices[pos.TokenID] = price
+	}
+
+	digest := formatPositionDigest(positions, prices, time.Now())
+	for _, line := range strings.Split(digest, "\n") {
+		if line != "" {
+			log.Printf("[blackswan] %s", line)
+		}
+	}
+	if h.telegram != nil {
+		h.telegram.SendMessage(digest)
+	}
+}
+
+// midpoint is the midpoint of bid and ask, or whichever is set.
+func midpoint(bid, ask float64) float64 {
+	switch {
+	case bid > 0 && ask > 0:
+		return (bid + ask) / 2
+	case ask > 0:
+		return ask
+	}
+	return bid
+}
+
+// formatPositionDigest lists positions closest to resolution first, with
+// prices taken from prices by token ID.
+func formatPositionDigest(positions []*OpenPosition, prices map[string]float64, now time.Time) string {
+	sorted := append([]*OpenPosition(nil), positions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// Positions without an end time go last
+		a, b := sorted[i].EndTime, sorted[j].EndTime
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Position Digest (%d open)\n", len(sorted))
+	for _, pos := range sorted {
+		resolves := "unknown"
+		if !pos.EndTime.IsZero() {
+			resolves = shortDuration(pos.EndTime.Sub(now))
+		}
+		current := "unknown"
+		if price := prices[pos.TokenID]; price > 0 {
+			current = fmt.Sprintf("%.2f¢", price*100)
+			if pos.BidPrice > 0 {
+				current += fmt.Sprintf(" (%+.0f%%)", (price/pos.BidPrice-1)*100)
+			}
+		}
+		fmt.Fprintf(&sb, "\n%s %s\n", pos.MarketTitle, pos.Outcome)
+		fmt.Fprintf(&sb, "  age %s, resolves in %s\n", shortDuration(now.Sub(pos.PlacedAt)), resolves)
+		fmt.Fprintf(&sb, "  entry %.2f¢, now %s\n", pos.BidPrice*100, current)
+	}
+	return sb.String()
+}
+
+// shortDuration formats d to the minute as days, hours and minutes, leaving
+// out leading zero units: "3d4h", "2h15m", "45m".
+func shortDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0m"
+	}
+	d = d.Truncate(time.Minute)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
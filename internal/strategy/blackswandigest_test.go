@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatPositionDigest(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	positions := []*OpenPosition{
+		{
+			TokenID:     "late",
+			MarketTitle: "Will it snow in Miami?",
+			Outcome:     "Yes",
+			BidPrice:    0.02,
+			PlacedAt:    now.Add(-26 * time.Hour),
+			EndTime:     now.Add(76 * time.Hour),
+		},
+		{
+			TokenID:     "undated",
+			MarketTitle: "Will the mayor resign?",
+			Outcome:     "No",
+			BidPrice:    0.05,
+			PlacedAt:    now.Add(-45 * time.Minute),
+		},
+		{
+			TokenID:     "soon",
+			MarketTitle: "Will BTC hit $1M today?",
+			Outcome:     "Yes",
+			BidPrice:    0.01,
+			PlacedAt:    now.Add(-135 * time.Minute),
+			EndTime:     now.Add(90 * time.Minute),
+		},
+	}
+	prices := map[string]float64{"late": 0.031, "soon": 0.005}
+
+	got := formatPositionDigest(positions, prices, now)
+	want := `Position Digest (3 open)
+
+Will BTC hit $1M today? Yes
+  age 2h15m, resolves in 1h30m
+  entry 1.00¢, now 0.50¢ (-50%)
+
+Will it snow in Miami? Yes
+  age 1d2h, resolves in 3d4h
+  entry 2.00¢, now 3.10¢ (+55%)
+
+Will the mayor resign? No
+  age 45m, resolves in unknown
+  entry 5.00¢, now unknown
+`
+	if got != want {
+		t.Errorf("digest:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestShortDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Minute, "0m"},
+		{59 * time.Second, "0m"},
+		{45 * time.Minute, "45m"},
+		{2*time.Hour + 15*time.Minute + 30*time.Second, "2h15m"},
+		{76 * time.Hour, "3d4h"},
+	}
+	for _, tt := range tests {
+		if got := shortDuration(tt.d); got != tt.want {
+			t.Errorf("shortDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
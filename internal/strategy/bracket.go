@@ -0,0 +1,203 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+)
+
+const (
+	bracketRetryDelay  = 30 * time.Second // Wait before retrying a sell whose shares haven't settled
+	bracketMaxAttempts = 10               // Give up on a sell after this many rejections
+	bracketMaxPrice    = 0.99             // Highest price a resting sell can ask
+)
+
+// bracketSeller places a resting take-profit sell as soon as a buy fills,
+// so profit is locked in by the book instead of by polling. Sells rejected
+// because the bought shares haven't settled on-chain yet are retried, and
+// placed sells are followed until they leave the book so their fills can be
+// realized.
+type bracketSeller struct {
+	prefix   string
+	multiple float64 // Target price as a multiple of the entry price, <= 1 disables
+
+	pending []*bracketSell
+	resting []*bracketSell // Placed, waiting to fill
+}
+
+// bracketSell is a take-profit sell waiting to be (re)submitted or filled.
+type bracketSell struct {
+	builder  *clob.OrderBuilder // Signs for the wallet holding the shares
+	tokenID  string
+	label    string
+	entry    float64 // Price the shares were bought at
+	price    float64
+	shares   float64
+	attempts int
+	retryAt  time.Time
+	orderID  string // Set once the CLOB accepts the sell
+}
+
+// newBracketSeller returns nil when multiple doesn't describe a profit
+// target; the nil seller ignores fills.
+func newBracketSeller(prefix string, multiple float64) *bracketSeller {
+	if multiple <= 1 {
+		return nil
+	}
+	return &bracketSeller{prefix: prefix, multiple: multiple}
+}
+
+// targetPrice returns the sell price for an entry, capped below $1. ok is
+// false when the cap leaves no profit.
+func (b *bracketSeller) targetPrice(entry float64) (price float64, ok bool) {
+	price = math.Min(entry*b.multiple, bracketMaxPrice)
+	return price, price > entry
+}
+
+// onFill submits the take-profit sell for a filled buy.
+func (b *bracketSeller) onFill(c clob.CLOBClient, builder *clob.OrderBuilder, tokenID, label string, entry, shares float64, now time.Time) {
+	if b == nil {
+		return
+	}
+	price, ok := b.targetPrice(entry)
+	if !ok {
+		log.Printf("[%s] no take-profit for %s: target %.2fx of $%.4f is above the max price", b.prefix, label, b.multiple, entry)
+		return
+	}
+
+	sell := &bracketSell{builder: builder, tokenID: tokenID, label: label, entry: entry, price: price, shares: shares}
+	b.attempt(c, sell, now)
+}
+
+// retryPending resubmits sells whose retry delay has passed.
+func (b *bracketSeller) retryPending(c clob.CLOBClient, now time.Time) {
+	if b == nil || len(b.pending) == 0 {
+		return
+	}
+
+	due := b.pending
+	b.pending = nil
+	for _, sell := range due {
+		if now.Before(sell.retryAt) {
+			b.pending = append(b.pending, sell)
+			continue
+		}
+		b.attempt(c, sell, now)
+	}
+}
+
+// attempt submits a sell, queueing it for retry if the shares haven't
+// settled yet.
+func (b *bracketSeller) attempt(c clob.CLOBClient, sell *bracketSell, now time.Time) {
+	sell.attempts++
+	orderID, err := submitBracketSell(c, sell.builder, sell)
+	if err == nil {
+		log.Printf("[%s] TAKE-PROFIT PLACED: %s %.2f shares @ $%.4f (order ID: %s)",
+			b.prefix, sell.label, sell.shares, sell.price, orderID)
+		sell.orderID = orderID
+		b.resting = append(b.resting, sell)
+		return
+	}
+
+	if !isUnsettledError(err) || sell.attempts >= bracketMaxAttempts {
+		log.Printf("[%s] failed to place take-profit for %s after %d attempt(s): %v", b.prefix, sell.label, sell.attempts, err)
+		return
+	}
+
+	log.Printf("[%s] take-profit for %s not accepted yet (%v), retrying in %v", b.prefix, sell.label, err, bracketRetryDelay)
+	sell.retryAt = now.Add(bracketRetryDelay)
+	b.pending = append(b.pending, sell)
+}
+
+// checkFills looks up placed sells that are no longer in open and calls
+// onFill with the shares each one sold and the P&L realized against its
+// entry. Sells the CLOB can't report on yet are checked again next cycle.
+func (b *bracketSeller) checkFills(c clob.CLOBClient, open map[string]bool, onFill func(tokenID string, shares, pnl float64)) {
+	if b == nil || len(b.resting) == 0 {
+		return
+	}
+
+	resting := b.resting
+	b.resting = nil
+	for _, sell := range resting {
+		if open[sell.orderID] {
+			b.resting = append(b.resting, sell)
+			continue
+		}
+
+		shares, ok, err := closedOrderFill(c, sell.orderID)
+		if err != nil {
+			log.Printf("[%s] failed to look up take-profit %s: %v", b.prefix, sell.orderID, err)
+			b.resting = append(b.resting, sell)
+			continue
+		}
+		if !ok {
+			b.resting = append(b.resting, sell)
+			continue
+		}
+		if shares <= 0 {
+			log.Printf("[%s] take-profit for %s canceled unfilled", b.prefix, sell.label)
+			continue
+		}
+
+		pnl := shares * (sell.price - sell.entry)
+		log.Printf("[%s] TAKE-PROFIT FILLED: %s %.2f shares @ $%.4f pnl=$%+.2f", b.prefix, sell.label, shares, sell.price, pnl)
+		onFill(sell.tokenID, shares, pnl)
+	}
+}
+
+// Pending returns the number of sells waiting to be retried.
+func (b *bracketSeller) Pending() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.pending)
+}
+
+func submitBracketSell(c clob.CLOBClient, builder *clob.OrderBuilder, sell *bracketSell) (string, error) {
+	negRisk, err := c.GetNegRisk(sell.tokenID)
+	if err != nil {
+		negRisk = false // Assume standard, as for the buy
+	}
+
+	order, err := builder.BuildGTCSellOrder(sell.tokenID, sell.price, sell.shares, negRisk)
+	if err != nil {
+		return "", fmt.Errorf("failed to build order: %w", err)
+	}
+
+	resp, err := c.CreateOrder(order)
+	if err != nil {
+		return "", fmt.Errorf("failed to submit order: %w", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("order rejected: %s", resp.Error)
+	}
+	return resp.OrderID, nil
+}
+
+// isUnsettledError reports whether a sell was refused because the seller
+// doesn't hold the shares yet, which is what the CLOB says while a fill is
+// still settling on-chain.
+func isUnsettledError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not enough balance") || strings.Contains(msg, "balance / allowance")
+}
+
+// closedOrderFill looks up an order that has left the open-orders list and
+// returns how many of its shares matched: 0 when it was canceled unfilled,
+// less than its size when canceled part-filled. ok is false while the CLOB
+// still reports the order live, so the caller checks again next cycle.
+func closedOrderFill(c clob.CLOBClient, orderID string) (shares float64, ok bool, err error) {
+	order, err := c.GetOrder(orderID)
+	if err != nil {
+		return 0, false, err
+	}
+	if strings.EqualFold(order.Status, "LIVE") {
+		return 0, false, nil
+	}
+	return order.MatchedShares(), true, nil
+}
@@ -0,0 +1,217 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+func testBracketBuilder(t *testing.T) *clob.OrderBuilder {
+	t.Helper()
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	return clob.NewOrderBuilder(w, "key")
+}
+
+func TestNewBracketSeller_Disabled(t *testing.T) {
+	for _, multiple := range []float64{0, 1, 0.5} {
+		if b := newBracketSeller("test", multiple); b != nil {
+			t.Errorf("multiple %.2f: want nil seller", multiple)
+		}
+	}
+
+	// A nil seller ignores fills
+	mock := clobmock.New()
+	var b *bracketSeller
+	b.onFill(mock, nil, "123", "market", 0.10, 10, time.Now())
+	b.retryPending(mock, time.Now())
+	if len(mock.Orders()) != 0 || b.Pending() != 0 {
+		t.Error("nil seller submitted orders")
+	}
+}
+
+func TestBracketSeller_TargetPrice(t *testing.T) {
+	b := newBracketSeller("test", 3)
+	tests := []struct {
+		entry float64
+		want  float64
+		ok    bool
+	}{
+		{0.02, 0.06, true},
+		{0.30, 0.90, true},
+		{0.50, 0.99, true}, // Capped
+		{0.99, 0.99, false},
+	}
+	for _, tt := range tests {
+		got, ok := b.targetPrice(tt.entry)
+		if ok != tt.ok || (ok && absFloat(got-tt.want) > 1e-9) {
+			t.Errorf("targetPrice(%.2f) = %.4f, %v; want %.4f, %v", tt.entry, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBracketSeller_RetriesUnsettledSell(t *testing.T) {
+	builder := testBracketBuilder(t)
+	mock := clobmock.New()
+	mock.Reject = "not enough balance / allowance"
+
+	b := newBracketSeller("test", 2)
+	now := time.Now()
+	b.onFill(mock, builder, "987654321", "market", 0.10, 25, now)
+	if b.Pending() != 1 {
+		t.Fatalf("pending = %d after rejection, want 1", b.Pending())
+	}
+
+	// Not due yet
+	mock.Reject = ""
+	b.retryPending(mock, now.Add(bracketRetryDelay/2))
+	if len(mock.Orders()) != 1 || b.Pending() != 1 {
+		t.Fatalf("retried before the delay: orders=%d pending=%d", len(mock.Orders()), b.Pending())
+	}
+
+	b.retryPending(mock, now.Add(bracketRetryDelay))
+	orders := mock.Orders()
+	if len(orders) != 2 || b.Pending() != 0 {
+		t.Fatalf("orders=%d pending=%d after retry, want 2 and 0", len(orders), b.Pending())
+	}
+	sell := orders[1]
+	if sell.Order.Side != string(clob.OrderSideSell) || sell.OrderType != string(clob.OrderTypeGTC) {
+		t.Errorf("order = %s %s, want SELL GTC", sell.Order.Side, sell.OrderType)
+	}
+	// 25 shares at 20¢ is $5
+	if sell.Order.MakerAmount != "25000000" || sell.Order.TakerAmount != "5000000" {
+		t.Errorf("amounts = %s/%s, want 25000000/5000000", sell.Order.MakerAmount, sell.Order.TakerAmount)
+	}
+}
+
+func TestBracketSeller_DropsOtherRejections(t *testing.T) {
+	mock := clobmock.New()
+	mock.Reject = "market closed"
+
+	b := newBracketSeller("test", 2)
+	b.onFill(mock, testBracketBuilder(t), "987654321", "market", 0.10, 25, time.Now())
+	if b.Pending() != 0 {
+		t.Errorf("pending = %d, want rejection dropped", b.Pending())
+	}
+}
+
+func TestCheckPositions_PlacesTakeProfitOnFill(t *testing.T) {
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	mock := clobmock.New()
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		gamma:    gammamock.New(),
+		clob:     mock,
+		builder:  testBracketBuilder(t),
+		brackets: newBracketSeller("blackswan", 3),
+		tracker:  NewPositionTracker(),
+	}
+	h.tracker.Add(&OpenPosition{OrderID: "filled", TokenID: "987654321", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	h.tracker.Add(&OpenPosition{OrderID: "resting", TokenID: "123456789", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	mock.OpenOrders = []clob.Order{{ID: "resting"}}
+	mock.Statuses["filled"] = &clob.OrderStatus{ID: "filled", Status: "MATCHED", OriginalSize: "50", SizeMatched: "50"}
+
+	if err := h.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	orders := mock.Orders()
+	if len(orders) != 1 {
+		t.Fatalf("submitted %d orders, want 1 take-profit", len(orders))
+	}
+	if orders[0].Order.TokenID != "987654321" || orders[0].Order.Side != string(clob.OrderSideSell) {
+		t.Errorf("order = %s %s, want SELL of the filled token", orders[0].Order.Side, orders[0].Order.TokenID)
+	}
+	if h.tracker.Count() != 1 {
+		t.Errorf("tracker has %d positions, want only the resting order", h.tracker.Count())
+	}
+}
+
+func TestCheckPositions_UsesMatchedSizeOfClosedOrders(t *testing.T) {
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	mock := clobmock.New()
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		gamma:    gammamock.New(),
+		clob:     mock,
+		builder:  testBracketBuilder(t),
+		brackets: newBracketSeller("blackswan", 3),
+		tracker:  NewPositionTracker(),
+	}
+	h.tracker.Add(&OpenPosition{OrderID: "partial", TokenID: "987654321", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	h.tracker.Add(&OpenPosition{OrderID: "canceled", TokenID: "123456789", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	h.tracker.Add(&OpenPosition{OrderID: "unknown", TokenID: "555555555", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	mock.Statuses["partial"] = &clob.OrderStatus{ID: "partial", Status: "CANCELED", OriginalSize: "50", SizeMatched: "20"}
+	mock.Statuses["canceled"] = &clob.OrderStatus{ID: "canceled", Status: "CANCELED", OriginalSize: "50", SizeMatched: "0"}
+
+	if err := h.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	if len(h.held.positions) != 1 || h.held.positions[0].shares != 20 {
+		t.Fatalf("held = %+v, want only the 20 matched shares", h.held.positions)
+	}
+	orders := mock.Orders()
+	if len(orders) != 1 || orders[0].Order.TokenID != "987654321" {
+		t.Fatalf("submitted %d orders, want 1 take-profit on the part-filled token", len(orders))
+	}
+	if h.totalFilled != 1 || h.totalCanceled != 1 {
+		t.Errorf("filled=%d canceled=%d, want 1 and 1", h.totalFilled, h.totalCanceled)
+	}
+	// An order that can't be looked up stays tracked for the next cycle
+	if h.tracker.Count() != 1 || h.tracker.Get("unknown") == nil {
+		t.Errorf("tracker has %d positions, want only the unknown order", h.tracker.Count())
+	}
+}
+
+func TestCheckPositions_RealizesTakeProfitFills(t *testing.T) {
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	mock := clobmock.New()
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		gamma:    gammamock.New(),
+		clob:     mock,
+		builder:  testBracketBuilder(t),
+		brackets: newBracketSeller("blackswan", 3),
+		lossStop: newSessionLossStop("blackswan", 100),
+		tracker:  NewPositionTracker(),
+	}
+	h.tracker.Add(&OpenPosition{OrderID: "filled", TokenID: "987654321", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+	mock.Statuses["filled"] = &clob.OrderStatus{ID: "filled", Status: "MATCHED", OriginalSize: "50", SizeMatched: "50"}
+
+	// First cycle arms the take-profit at 6¢
+	if err := h.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+	if len(mock.Orders()) != 1 {
+		t.Fatalf("submitted %d orders, want 1 take-profit", len(mock.Orders()))
+	}
+
+	// Second cycle: 30 of the 50 shares sold before the sell was canceled
+	mock.Statuses["mock-1"] = &clob.OrderStatus{ID: "mock-1", Status: "CANCELED", OriginalSize: "50", SizeMatched: "30"}
+	if err := h.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	if got := h.lossStop.Realized(); absFloat(got-1.20) > 1e-9 {
+		t.Errorf("realized = $%.4f, want $1.20 from 30 shares sold 4¢ above entry", got)
+	}
+	if len(h.held.positions) != 1 || h.held.positions[0].shares != 20 {
+		t.Errorf("held = %+v, want the 20 unsold shares", h.held.positions)
+	}
+	if len(h.brackets.resting) != 0 {
+		t.Errorf("%d take-profits still followed after leaving the book", len(h.brackets.resting))
+	}
+}
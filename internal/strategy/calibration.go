@@ -0,0 +1,141 @@
+package strategy
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// calibrationHeader is the first row of a new calibration CSV.
+var calibrationHeader = []string{
+	"resolved_at", "market_slug", "location", "tier", "market_type", "days_ahead",
+	"side", "predicted_prob", "market_price", "confidence", "outcome",
+}
+
+// calibrationRecord is one weather forecast checked against how its market
+// resolved. Predicted and MarketPrice are for the side bought; Outcome is
+// that side's payout (1 = it happened, 0 = it didn't).
+type calibrationRecord struct {
+	MarketSlug  string
+	Location    string
+	Tier        string
+	MarketType  string
+	DaysAhead   int
+	Side        string
+	Predicted   float64
+	MarketPrice float64
+	Confidence  float64
+	Outcome     float64
+	ResolvedAt  time.Time
+}
+
+// calibrationLog appends a row per resolved weather trade to a CSV so the
+// model's predicted probabilities can be compared with what happened, e.g.
+// to refit the tier σ multipliers. Predictions are held in memory from order
+// placement until the position settles; orders canceled unfilled are
+// expired without being written.
+type calibrationLog struct {
+	path string
+
+	mu      sync.Mutex
+	pending map[string]calibrationRecord // By token ID
+}
+
+// newCalibrationLog returns nil when path is empty; the nil log records
+// nothing.
+func newCalibrationLog(path string) *calibrationLog {
+	if path == "" {
+		return nil
+	}
+	return &calibrationLog{path: path, pending: make(map[string]calibrationRecord)}
+}
+
+// track remembers the prediction behind an order for tokenID.
+func (c *calibrationLog) track(tokenID string, rec calibrationRecord) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.pending[tokenID] = rec
+	c.mu.Unlock()
+}
+
+// resolve writes the prediction for tokenID with its outcome. Tokens
+// without a tracked prediction are ignored.
+func (c *calibrationLog) resolve(tokenID string, outcome float64, at time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rec, ok := c.pending[tokenID]
+	if !ok {
+		return
+	}
+	delete(c.pending, tokenID)
+
+	rec.Outcome = outcome
+	rec.ResolvedAt = at
+	if err := c.append(rec); err != nil {
+		log.Printf("[weather] failed to record calibration for %s: %v", rec.MarketSlug, err)
+	}
+}
+
+// expire drops the prediction for tokenID without writing it, for an order
+// that was canceled before it filled.
+func (c *calibrationLog) expire(tokenID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.pending, tokenID)
+	c.mu.Unlock()
+}
+
+// append writes rec to the CSV, starting the file with a header.
+func (c *calibrationLog) append(rec calibrationRecord) error {
+	if dir := filepath.Dir(c.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create calibration directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open calibration log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat calibration log: %w", err)
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		w.Write(calibrationHeader)
+	}
+	w.Write([]string{
+		rec.ResolvedAt.UTC().Format(time.RFC3339),
+		rec.MarketSlug,
+		rec.Location,
+		rec.Tier,
+		rec.MarketType,
+		strconv.Itoa(rec.DaysAhead),
+		rec.Side,
+		strconv.FormatFloat(rec.Predicted, 'f', 4, 64),
+		strconv.FormatFloat(rec.MarketPrice, 'f', 4, 64),
+		strconv.FormatFloat(rec.Confidence, 'f', 4, 64),
+		strconv.FormatFloat(rec.Outcome, 'f', 2, 64),
+	})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write calibration log: %w", err)
+	}
+	return nil
+}
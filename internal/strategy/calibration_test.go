@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+)
+
+func readCalibrationCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open calibration log: %v", err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read calibration log: %v", err)
+	}
+	return rows
+}
+
+func TestCalibrationLog_RecordsResolvedPredictions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "calibration.csv")
+	c := newCalibrationLog(path)
+	at := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	c.track("111", calibrationRecord{MarketSlug: "london-12c", Location: "London", Tier: "A", MarketType: "temp_range", DaysAhead: 2, Side: "yes", Predicted: 0.42, MarketPrice: 0.30, Confidence: 0.8})
+	c.track("222", calibrationRecord{MarketSlug: "tokyo-20c", Location: "Tokyo", Tier: "B", MarketType: "temp_above", DaysAhead: 5, Side: "no", Predicted: 0.75, MarketPrice: 0.60, Confidence: 0.7})
+	c.resolve("111", 1, at)
+	c.resolve("333", 0, at) // Never tracked
+	c.resolve("222", 0, at)
+	c.resolve("111", 1, at) // Already written
+
+	rows := readCalibrationCSV(t, path)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header + 2: %v", len(rows), rows)
+	}
+	if rows[0][0] != "resolved_at" || rows[0][len(rows[0])-1] != "outcome" {
+		t.Errorf("header = %v", rows[0])
+	}
+	want := []string{"2026-03-04T12:00:00Z", "london-12c", "London", "A", "temp_range", "2", "yes", "0.4200", "0.3000", "0.8000", "1.00"}
+	for i, v := range want {
+		if rows[1][i] != v {
+			t.Errorf("row 1 column %s = %q, want %q", calibrationHeader[i], rows[1][i], v)
+		}
+	}
+	if rows[2][1] != "tokyo-20c" || rows[2][10] != "0.00" {
+		t.Errorf("row 2 = %v, want tokyo-20c with outcome 0.00", rows[2])
+	}
+
+	// A restarted session appends without repeating the header
+	c = newCalibrationLog(path)
+	c.track("444", calibrationRecord{MarketSlug: "seoul-5c"})
+	c.resolve("444", 0, at)
+	if rows := readCalibrationCSV(t, path); len(rows) != 4 || rows[3][1] != "seoul-5c" {
+		t.Errorf("after restart rows = %v, want seoul-5c appended", rows)
+	}
+}
+
+func TestCalibrationLog_Disabled(t *testing.T) {
+	c := newCalibrationLog("")
+	if c != nil {
+		t.Fatal("want nil log for an empty path")
+	}
+	c.track("111", calibrationRecord{})
+	c.resolve("111", 1, time.Now())
+}
+
+func TestRecordPaperSettlement_LogsCalibration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.csv")
+	ws := &WeatherSniper{
+		tracker:  NewWeatherPositionTracker(),
+		accuracy: newCalibrationLog(path),
+	}
+
+	wm := &gamma.WeatherMarket{
+		Market:         gamma.Market{Slug: "london-12c"},
+		Location:       "London",
+		MarketType:     gamma.WeatherTypeTempRange,
+		ResolutionDate: time.Now().Add(60 * time.Hour),
+	}
+	ws.trackCalibration(&WeatherOpportunity{WeatherMarket: wm, TokenID: "111", Side: "yes", OurProbForSide: 0.4, MarketPriceForSide: 0.3})
+
+	// 10 shares at 30¢ that paid out $1 each
+	ws.recordPaperSettlement(PaperPosition{ID: "dry-1", TokenID: "111", Shares: 10, Price: 0.3}, 7)
+
+	rows := readCalibrationCSV(t, path)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want header + 1", len(rows))
+	}
+	if rows[1][3] != "S" || rows[1][5] != "2" || rows[1][7] != "0.4000" || rows[1][10] != "1.00" {
+		t.Errorf("row = %v, want tier S, 2 days ahead, predicted 0.4000, outcome 1.00", rows[1])
+	}
+}
+
+func TestCheckPositions_ExpiresCalibrationOfCanceledOrders(t *testing.T) {
+	mock := clobmock.New()
+	ws := &WeatherSniper{
+		config:   &config.Config{},
+		gamma:    gammamock.New(),
+		clob:     mock,
+		tracker:  NewWeatherPositionTracker(),
+		accuracy: newCalibrationLog(filepath.Join(t.TempDir(), "calibration.csv")),
+	}
+	ws.tracker.Add(&WeatherPosition{OrderID: "unfilled", TokenID: "111", MarketQuestion: "London 12°C", PlacedAt: time.Now()})
+	ws.tracker.Add(&WeatherPosition{OrderID: "refill", TokenID: "222", MarketQuestion: "Tokyo 20°C", PlacedAt: time.Now()})
+	ws.accuracy.track("111", calibrationRecord{MarketSlug: "london-12c"})
+	ws.accuracy.track("222", calibrationRecord{MarketSlug: "tokyo-20c"})
+	// An earlier order on the Tokyo token filled and still needs its prediction
+	ws.held.add(heldPosition{tokenID: "222", marketSlug: "tokyo-20c", shares: 10, price: 0.3})
+	mock.Statuses["unfilled"] = &clob.OrderStatus{ID: "unfilled", Status: "CANCELED", OriginalSize: "10", SizeMatched: "0"}
+	mock.Statuses["refill"] = &clob.OrderStatus{ID: "refill", Status: "CANCELED", OriginalSize: "10", SizeMatched: "0"}
+
+	if err := ws.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	if _, ok := ws.accuracy.pending["111"]; ok {
+		t.Error("prediction for the canceled order is still pending")
+	}
+	if _, ok := ws.accuracy.pending["222"]; !ok {
+		t.Error("prediction for the held token was expired")
+	}
+	if ws.totalCanceled != 2 || ws.tracker.Count() != 0 {
+		t.Errorf("canceled=%d tracked=%d, want 2 and 0", ws.totalCanceled, ws.tracker.Count())
+	}
+}
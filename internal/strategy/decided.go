@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/sports"
+)
+
+// gameDecidedLeadNFL is the fourth-quarter lead, in points (three scores),
+// at which an NFL game is treated as decided.
+const gameDecidedLeadNFL = 21
+
+// decidedLeads is the lead at which an in-progress game is treated as
+// decided, by quarter (index 0 is Q1). Overtime uses the Q4 lead. 0 means a
+// game is never decided in that quarter.
+type decidedLeads [4]int
+
+// defaultDecidedLeads needs a bigger cushion the more time is left, and
+// never calls a game in the first quarter.
+var defaultDecidedLeads = decidedLeads{0, 35, 28, gameDecidedLeadNFL}
+
+// parseDecidedLeads parses per-quarter overrides of the form "2=35,3=28,4=21"
+// (quarter=lead) on top of the defaults. Empty means the defaults.
+func parseDecidedLeads(s string) (decidedLeads, error) {
+	leads := defaultDecidedLeads
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		quarterStr, leadStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return leads, fmt.Errorf("invalid entry %q: expected quarter=lead", entry)
+		}
+		quarter, err := strconv.Atoi(strings.TrimSpace(quarterStr))
+		if err != nil || quarter < 1 || quarter > len(leads) {
+			return leads, fmt.Errorf("invalid entry %q: quarter must be 1-%d", entry, len(leads))
+		}
+		lead, err := strconv.Atoi(strings.TrimSpace(leadStr))
+		if err != nil || lead < 0 {
+			return leads, fmt.Errorf("invalid entry %q: lead must be a non-negative number of points", entry)
+		}
+		leads[quarter-1] = lead
+	}
+	return leads, nil
+}
+
+// decided reports whether an in-progress game's lead has reached the
+// threshold for its quarter, a deterministic call made alongside the
+// win-probability model.
+func (d decidedLeads) decided(game *sports.Game) bool {
+	if game.Status != sports.StatusInProgress || game.Quarter < 1 {
+		return false
+	}
+	quarter := game.Quarter
+	if quarter > len(d) {
+		quarter = len(d)
+	}
+	lead := d[quarter-1]
+	return lead > 0 && game.PointDifferential() >= lead
+}
+
+// String formats the thresholds as parseDecidedLeads accepts them.
+func (d decidedLeads) String() string {
+	parts := make([]string, len(d))
+	for i, lead := range d {
+		parts[i] = fmt.Sprintf("%d=%d", i+1, lead)
+	}
+	return strings.Join(parts, ",")
+}
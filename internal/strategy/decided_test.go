@@ -0,0 +1,85 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/sports"
+)
+
+func TestParseDecidedLeads(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    decidedLeads
+		wantErr bool
+	}{
+		{"defaults", "", defaultDecidedLeads, false},
+		{"override Q4", "4=17", decidedLeads{0, 35, 28, 17}, false},
+		{"enable Q1, disable Q2", "1=42, 2=0", decidedLeads{42, 0, 28, gameDecidedLeadNFL}, false},
+		{"missing lead", "4", decidedLeads{}, true},
+		{"quarter out of range", "5=14", decidedLeads{}, true},
+		{"negative lead", "3=-7", decidedLeads{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDecidedLeads(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDecidedLeads(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseDecidedLeads(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSportsAnalyzeMarket_Blowouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		quarter   int
+		home      int
+		away      int
+		yesPrice  float64
+		wantTrade bool
+	}{
+		{"Q1 rout is never decided", 1, 35, 0, 0.80, false},
+		{"Q2 four-score lead", 2, 35, 0, 0.80, true},
+		{"Q2 three-score lead", 2, 28, 7, 0.80, false},
+		{"Q3 four-score lead", 3, 31, 3, 0.80, true},
+		{"Q3 two-score lead", 3, 17, 3, 0.80, false},
+		{"Q4 three-score lead", 4, 24, 3, 0.80, true},
+		{"Q4 one-score lead", 4, 10, 3, 0.80, false},
+		{"overtime uses Q4 lead", 5, 30, 3, 0.80, true},
+		{"decided but priced in", 4, 31, 3, 0.96, false},
+		{"trailing team's market", 4, 3, 31, 0.80, true}, // Buys NO on the Eagles
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SportsSniper{config: &config.Config{MaxPositionSize: 10}, decided: defaultDecidedLeads}
+			tracked := &TrackedSportsMarket{
+				YesTokenID: "1",
+				NoTokenID:  "2",
+				Game: &sports.Game{
+					Status:   sports.StatusInProgress,
+					Quarter:  tt.quarter,
+					HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: tt.home},
+					AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: tt.away},
+				},
+				TeamName: "Eagles",
+				YesPrice: tt.yesPrice,
+				NoPrice:  tt.yesPrice,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s), want %v", analysis.ShouldTrade, analysis.Reason, tt.wantTrade)
+			}
+			if tt.wantTrade && analysis.WinProbability != 1.0 {
+				t.Errorf("WinProbability = %v, want 1.0 for a decided game", analysis.WinProbability)
+			}
+		})
+	}
+}
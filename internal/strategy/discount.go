@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// discountBreakpoint is the bid discount to use for a market resolving in
+// days days.
+type discountBreakpoint struct {
+	days     float64
+	discount float64
+}
+
+// discountSchedule scales the blackswan bid discount with days to
+// resolution: near-term markets bid closer to the market to get filled
+// before they resolve, long-dated ones can wait for a deeper discount.
+// Breakpoints are sorted by days.
+type discountSchedule []discountBreakpoint
+
+// parseDiscountSchedule parses breakpoints of the form
+// "1=0.05,7=0.20,30=0.35" (days=discount). Discounts must be in [0, 1) and
+// each day may appear only once.
+func parseDiscountSchedule(s string) (discountSchedule, error) {
+	var schedule discountSchedule
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		daysStr, discountStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid breakpoint %q: expected days=discount", entry)
+		}
+		days, err := strconv.ParseFloat(strings.TrimSpace(daysStr), 64)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid breakpoint %q: days must be a non-negative number", entry)
+		}
+		discount, err := strconv.ParseFloat(strings.TrimSpace(discountStr), 64)
+		if err != nil || discount < 0 || discount >= 1 {
+			return nil, fmt.Errorf("invalid breakpoint %q: discount must be in [0, 1)", entry)
+		}
+
+		schedule = append(schedule, discountBreakpoint{days: days, discount: discount})
+	}
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].days < schedule[j].days })
+	for i := 1; i < len(schedule); i++ {
+		if schedule[i].days == schedule[i-1].days {
+			return nil, fmt.Errorf("duplicate breakpoint for %g days", schedule[i].days)
+		}
+	}
+	return schedule, nil
+}
+
+// at returns the discount for a market resolving in days days, interpolating
+// linearly between breakpoints and holding the end values flat beyond them.
+func (s discountSchedule) at(days float64) float64 {
+	if days <= s[0].days {
+		return s[0].discount
+	}
+	for i := 1; i < len(s); i++ {
+		if days <= s[i].days {
+			lo, hi := s[i-1], s[i]
+			frac := (days - lo.days) / (hi.days - lo.days)
+			return lo.discount + frac*(hi.discount-lo.discount)
+		}
+	}
+	return s[len(s)-1].discount
+}
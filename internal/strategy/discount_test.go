@@ -0,0 +1,84 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseDiscountSchedule(t *testing.T) {
+	schedule, err := parseDiscountSchedule(" 30=0.35, 1=0.05,7=0.20 ")
+	if err != nil {
+		t.Fatalf("parseDiscountSchedule: %v", err)
+	}
+	want := discountSchedule{{1, 0.05}, {7, 0.20}, {30, 0.35}}
+	if len(schedule) != len(want) {
+		t.Fatalf("schedule = %v, want %v", schedule, want)
+	}
+	for i := range want {
+		if schedule[i] != want[i] {
+			t.Errorf("breakpoint %d = %v, want %v", i, schedule[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"7", "x=0.2", "7=abc", "-1=0.2", "7=1", "7=-0.1", "7=0.2,7=0.3"} {
+		if _, err := parseDiscountSchedule(bad); err == nil {
+			t.Errorf("parseDiscountSchedule(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestDiscountSchedule_At(t *testing.T) {
+	schedule := discountSchedule{{1, 0.05}, {7, 0.20}, {30, 0.35}}
+
+	tests := []struct {
+		days float64
+		want float64
+	}{
+		{0, 0.05},     // Before the first breakpoint holds flat
+		{1, 0.05},     // On a breakpoint
+		{4, 0.125},    // Halfway between 1 and 7
+		{7, 0.20},     // On a breakpoint
+		{18.5, 0.275}, // Halfway between 7 and 30
+		{45, 0.35},    // Past the last breakpoint holds flat
+	}
+	for _, tt := range tests {
+		if got := schedule.at(tt.days); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("at(%g) = %.4f, want %.4f", tt.days, got, tt.want)
+		}
+	}
+
+	single := discountSchedule{{5, 0.10}}
+	if got := single.at(20); got != 0.10 {
+		t.Errorf("single breakpoint at(20) = %.4f, want 0.10", got)
+	}
+}
+
+func TestBuildCandidate_UsesDiscountSchedule(t *testing.T) {
+	cfg := testBlackSwanConfig()
+	h := &BlackSwanHunter{config: cfg}
+	h.discounts, _ = parseDiscountSchedule("1=0.05,30=0.35")
+
+	bid := func(end time.Time) float64 {
+		market := testBlackSwanMarket("m", 0.08, 0.92, 5000, end)
+		c := h.buildCandidate(market, &market.Tokens[0], &market.Tokens[1])
+		return c.BidPrice
+	}
+
+	near := bid(time.Now().Add(12 * time.Hour))
+	far := bid(time.Now().Add(40 * 24 * time.Hour))
+	if math.Abs(near-0.08*0.95) > 1e-9 {
+		t.Errorf("near-term bid = %.4f, want %.4f", near, 0.08*0.95)
+	}
+	if math.Abs(far-0.08*0.65) > 1e-9 {
+		t.Errorf("long-dated bid = %.4f, want %.4f", far, 0.08*0.65)
+	}
+
+	// Without a schedule the flat discount applies
+	h.discounts = nil
+	market := testBlackSwanMarket("m", 0.08, 0.92, 5000, time.Now().Add(12*time.Hour))
+	c := h.buildCandidateNo(market, &market.Tokens[0], &market.Tokens[1])
+	if want := 0.08 * (1 - cfg.BlackSwanBidDiscount); math.Abs(c.BidPrice-want) > 1e-9 {
+		t.Errorf("flat bid = %.4f, want %.4f", c.BidPrice, want)
+	}
+}
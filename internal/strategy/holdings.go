@@ -0,0 +1,82 @@
+package strategy
+
+import (
+	"log"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/dataapi"
+)
+
+// holdingsInterval is how often status reports re-read the wallet's
+// positions from the Data API.
+const holdingsInterval = 15 * time.Minute
+
+// holdingsReport adds the wallets' actual positions and P&L, as the Data API
+// sees them, to a live strategy's status log, and checks the positions the
+// strategy believes it holds against them. A nil report does nothing.
+type holdingsReport struct {
+	prefix    string
+	data      *dataapi.Client
+	addresses []string // Every wallet the strategy trades from, primary first
+	last      time.Time
+}
+
+// newHoldingsReport returns nil in dry run, where there's nothing on-chain
+// to reconcile against.
+func newHoldingsReport(prefix string, dryRun bool, addresses ...string) *holdingsReport {
+	if dryRun || len(addresses) == 0 || addresses[0] == "" {
+		return nil
+	}
+	return &holdingsReport{prefix: prefix, data: dataapi.NewClient(), addresses: addresses}
+}
+
+// log fetches each wallet's positions, at most once per holdingsInterval,
+// and logs their value and P&L. Held positions no wallet shows any more
+// (sold, redeemed or never settled on-chain) are logged by label.
+func (r *holdingsReport) log(now time.Time, held *heldPositions) {
+	if r == nil || (!r.last.IsZero() && now.Sub(r.last) < holdingsInterval) {
+		return
+	}
+	r.last = now
+
+	byAsset := make(map[string]dataapi.Position)
+	complete := true
+	for _, address := range r.addresses {
+		name := "wallet"
+		if len(r.addresses) > 1 {
+			name = "wallet " + address
+		}
+		positions, err := r.data.GetPositions(address)
+		if err != nil {
+			log.Printf("[%s] %s holdings unavailable: %v", r.prefix, name, err)
+			complete = false
+			continue
+		}
+		s := dataapi.Summarize(positions)
+		log.Printf("[%s] %s: %d positions worth $%.2f (cost $%.2f), unrealized $%+.2f, realized $%+.2f",
+			r.prefix, name, len(positions), s.Value, s.Cost, s.UnrealizedPnL, s.RealizedPnL)
+		for _, p := range positions {
+			if prev, ok := byAsset[p.Asset]; ok {
+				p.CashPnl += prev.CashPnl
+			}
+			byAsset[p.Asset] = p
+		}
+	}
+	// A wallet that couldn't be read would make its positions look gone
+	if !complete {
+		return
+	}
+
+	var ours float64
+	for _, pos := range held.positions {
+		p, ok := byAsset[pos.tokenID]
+		if !ok {
+			log.Printf("[%s] held position %s not in wallet", r.prefix, pos.label)
+			continue
+		}
+		ours += p.CashPnl
+	}
+	if len(held.positions) > 0 {
+		log.Printf("[%s] unrealized P&L on %d held positions: $%+.2f", r.prefix, len(held.positions), ours)
+	}
+}
@@ -0,0 +1,88 @@
+package strategy
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/dataapi"
+)
+
+func TestHoldingsReport_RefreshInterval(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`[{"asset":"101","size":10,"avgPrice":0.3,"initialValue":3,"currentValue":5,"cashPnl":2}]`))
+	}))
+	defer srv.Close()
+
+	r := newHoldingsReport("weather", false, "0xabc")
+	r.data = dataapi.NewClient().WithBaseURL(srv.URL)
+	held := &heldPositions{}
+	held.add(heldPosition{tokenID: "101", label: "in wallet"})
+	held.add(heldPosition{tokenID: "202", label: "gone"})
+
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	r.log(now, held)
+	r.log(now.Add(time.Minute), held)
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("fetched %d times within the interval, want 1", got)
+	}
+	r.log(now.Add(holdingsInterval), held)
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("fetched %d times after the interval, want 2", got)
+	}
+}
+
+func TestHoldingsReport_EveryWallet(t *testing.T) {
+	var mu sync.Mutex
+	var users []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		users = append(users, r.URL.Query().Get("user"))
+		mu.Unlock()
+		switch r.URL.Query().Get("user") {
+		case "0xabc":
+			w.Write([]byte(`[{"asset":"101","size":10,"avgPrice":0.3,"initialValue":3,"currentValue":5,"cashPnl":2}]`))
+		default:
+			w.Write([]byte(`[{"asset":"202","size":10,"avgPrice":0.3,"initialValue":3,"currentValue":4,"cashPnl":1}]`))
+		}
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	r := newHoldingsReport("blackswan", false, "0xabc", "0xdef")
+	r.data = dataapi.NewClient().WithBaseURL(srv.URL)
+	held := &heldPositions{}
+	held.add(heldPosition{tokenID: "101", label: "primary fill"})
+	held.add(heldPosition{tokenID: "202", label: "extra wallet fill"})
+	r.log(time.Now(), held)
+
+	if len(users) != 2 || users[0] != "0xabc" || users[1] != "0xdef" {
+		t.Errorf("queried %v, want both wallets", users)
+	}
+	if strings.Contains(out.String(), "not in wallet") {
+		t.Errorf("held position reported missing:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "$+3.00") {
+		t.Errorf("log lacks the $+3.00 P&L over both wallets:\n%s", out.String())
+	}
+}
+
+func TestNewHoldingsReport_DryRun(t *testing.T) {
+	r := newHoldingsReport("weather", true, "0xabc")
+	if r != nil {
+		t.Fatalf("newHoldingsReport in dry run = %v, want nil", r)
+	}
+	r.log(time.Now(), &heldPositions{})
+}
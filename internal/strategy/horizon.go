@@ -0,0 +1,79 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// horizonBonus multiplies the score of a weather market resolving within
+// days days.
+type horizonBonus struct {
+	days  int
+	bonus float64
+}
+
+// horizonBonuses scales opportunity scores by days to resolution, so
+// rankings can favor fast turnover or longer-dated markets. Entries are
+// sorted by days; markets beyond the last one score unscaled.
+type horizonBonuses []horizonBonus
+
+// defaultHorizonBonuses favors markets resolving tomorrow, then within
+// three days.
+var defaultHorizonBonuses = horizonBonuses{{1, 2.0}, {3, 1.5}}
+
+// parseHorizonBonuses parses entries of the form "1=2.0,3=1.5"
+// (days=bonus). Bonuses must be positive and each day may appear only once.
+func parseHorizonBonuses(s string) (horizonBonuses, error) {
+	var bonuses horizonBonuses
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		daysStr, bonusStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid horizon bonus %q: expected days=bonus", entry)
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(daysStr))
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("invalid horizon bonus %q: days must be a non-negative integer", entry)
+		}
+		bonus, err := strconv.ParseFloat(strings.TrimSpace(bonusStr), 64)
+		if err != nil || bonus <= 0 {
+			return nil, fmt.Errorf("invalid horizon bonus %q: bonus must be positive", entry)
+		}
+
+		bonuses = append(bonuses, horizonBonus{days: days, bonus: bonus})
+	}
+
+	sort.Slice(bonuses, func(i, j int) bool { return bonuses[i].days < bonuses[j].days })
+	for i := 1; i < len(bonuses); i++ {
+		if bonuses[i].days == bonuses[i-1].days {
+			return nil, fmt.Errorf("duplicate horizon bonus for %d days", bonuses[i].days)
+		}
+	}
+	return bonuses, nil
+}
+
+// at returns the bonus of the first entry covering daysAhead, or 1 when
+// none does.
+func (h horizonBonuses) at(daysAhead int) float64 {
+	for _, b := range h {
+		if daysAhead <= b.days {
+			return b.bonus
+		}
+	}
+	return 1.0
+}
+
+// String formats the bonuses as parseHorizonBonuses reads them.
+func (h horizonBonuses) String() string {
+	parts := make([]string, len(h))
+	for i, b := range h {
+		parts[i] = fmt.Sprintf("%d=%g", b.days, b.bonus)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,87 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+func TestParseHorizonBonuses(t *testing.T) {
+	bonuses, err := parseHorizonBonuses(" 7=2.5, 1=0.8,3=1.2 ")
+	if err != nil {
+		t.Fatalf("parseHorizonBonuses: %v", err)
+	}
+	if got, want := bonuses.String(), "1=0.8,3=1.2,7=2.5"; got != want {
+		t.Errorf("bonuses = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		days int
+		want float64
+	}{
+		{0, 0.8},
+		{1, 0.8},
+		{2, 1.2},
+		{7, 2.5},
+		{8, 1.0}, // Beyond the table scores unscaled
+	}
+	for _, tt := range tests {
+		if got := bonuses.at(tt.days); got != tt.want {
+			t.Errorf("at(%d) = %v, want %v", tt.days, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"3", "x=1.5", "3=abc", "-1=1.5", "1.5=2", "3=0", "3=-1", "3=1.5,3=2"} {
+		if _, err := parseHorizonBonuses(bad); err == nil {
+			t.Errorf("parseHorizonBonuses(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestEvaluateOpportunity_HorizonBonusOrdering(t *testing.T) {
+	wm := &gamma.WeatherMarket{
+		Location:       "London",
+		MarketType:     gamma.WeatherTypeTempAbove,
+		Threshold:      10,
+		ThresholdUnits: "C",
+		YesTokenID:     "1",
+		NoTokenID:      "2",
+		YesPrice:       0.50,
+		NoPrice:        0.50,
+	}
+	forecast := &weather.Forecast{TempHigh: 14, TempLow: 6, TempMean: 10}
+
+	// Scores of the same market resolving tomorrow and in six days
+	scores := func(horizon horizonBonuses) (soon, later float64) {
+		ws := &WeatherSniper{
+			config: &config.Config{
+				WeatherMinConfidence: 0.01,
+				WeatherMinEdge:       0.01,
+				WeatherMaxDivergence: 1,
+			},
+			horizon: horizon,
+		}
+		for _, days := range []int{1, 6} {
+			opp := ws.evaluateOpportunity(wm, forecast, days, 1, 0)
+			if opp == nil {
+				t.Fatalf("no opportunity %d days ahead", days)
+			}
+			if days == 1 {
+				soon = opp.Score
+			} else {
+				later = opp.Score
+			}
+		}
+		return soon, later
+	}
+
+	if soon, later := scores(nil); soon <= later {
+		t.Errorf("default bonuses: tomorrow scores %.2f, six days %.2f; want tomorrow first", soon, later)
+	}
+	inverted := horizonBonuses{{1, 1.0}, {3, 1.5}, {7, 2.0}}
+	if soon, later := scores(inverted); later <= soon {
+		t.Errorf("inverted bonuses: tomorrow scores %.2f, six days %.2f; want six days first", soon, later)
+	}
+}
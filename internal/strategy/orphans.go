@@ -0,0 +1,68 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// cancelOrphanOrders cancels, with CANCEL_ORPHANS_ON_START set in live mode,
+// open buy orders left by a previous run: those on a market the strategy
+// trades whose order ID isn't in tracked. Trackers start empty, so without
+// this such orders can fill unnoticed. Sells only close positions and are
+// left alone, as are orders on other markets, which may be manual. Returns
+// the number canceled.
+func cancelOrphanOrders(prefix string, cfg *config.Config, clobClient clob.CLOBClient, tracked map[string]bool, markets func() ([]gamma.Market, error)) (int, error) {
+	if cfg.DryRun || !cfg.CancelOrphansOnStart {
+		return 0, nil
+	}
+
+	managedMarkets, err := markets()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get markets: %w", err)
+	}
+	managed := marketTokenIDs(managedMarkets)
+
+	openOrders, err := clobClient.GetOpenOrders()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get open orders: %w", err)
+	}
+
+	canceled := 0
+	for _, order := range openOrders {
+		orderID := order.GetID()
+		if orderID == "" || tracked[orderID] || !managed[order.TokenID] {
+			continue
+		}
+		if !strings.EqualFold(order.Side, string(clob.OrderSideBuy)) {
+			continue
+		}
+		log.Printf("[%s] canceling orphaned order %s on token %s", prefix, orderID, order.TokenID)
+		if err := clobClient.CancelOrder(orderID); err != nil {
+			log.Printf("[%s] failed to cancel orphaned order %s: %v", prefix, orderID, err)
+			continue
+		}
+		canceled++
+	}
+	if canceled > 0 {
+		log.Printf("[%s] canceled %d orphaned orders from a previous run", prefix, canceled)
+	}
+	return canceled, nil
+}
+
+// marketTokenIDs returns the set of both outcome tokens of each market.
+func marketTokenIDs(markets []gamma.Market) map[string]bool {
+	ids := make(map[string]bool)
+	for i := range markets {
+		for _, tok := range markets[i].OutcomeTokens() {
+			if tok.TokenID != "" {
+				ids[tok.TokenID] = true
+			}
+		}
+	}
+	return ids
+}
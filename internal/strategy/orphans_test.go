@@ -0,0 +1,55 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+)
+
+func TestCancelOrphanOrders(t *testing.T) {
+	soon := time.Now().Add(5 * 24 * time.Hour)
+	source := gammamock.New()
+	source.Search = []gamma.Market{testBlackSwanMarket("longshot", 0.03, 0.97, 5000, soon)}
+
+	openOrders := []clob.Order{
+		{ID: "orphan", TokenID: "longshot-yes", Side: "BUY"},
+		{ID: "tracked", TokenID: "longshot-no", Side: "BUY"},
+		{ID: "take-profit", TokenID: "longshot-yes", Side: "SELL"},
+		{ID: "manual", TokenID: "other-market-yes", Side: "BUY"},
+	}
+
+	tests := []struct {
+		name   string
+		dryRun bool
+		enable bool
+		want   []string
+	}{
+		{"disabled", false, false, nil},
+		{"dry run", true, true, nil},
+		{"enabled", false, true, []string{"orphan"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testBlackSwanConfig()
+			cfg.DryRun = tt.dryRun
+			cfg.CancelOrphansOnStart = tt.enable
+			mock := clobmock.New()
+			mock.OpenOrders = openOrders
+			h := &BlackSwanHunter{config: cfg, clob: mock, gamma: source, tracker: NewPositionTracker()}
+			h.tracker.Add(&OpenPosition{OrderID: "tracked", TokenID: "longshot-no"})
+
+			n, err := cancelOrphanOrders("blackswan", h.config, h.clob, h.trackedOrders(), h.searchMarkets)
+			if err != nil {
+				t.Fatalf("cancelOrphanOrders: %v", err)
+			}
+			if got := mock.Canceled(); !reflect.DeepEqual(got, tt.want) || n != len(tt.want) {
+				t.Errorf("canceled %v (n=%d), want %v", got, n, tt.want)
+			}
+		})
+	}
+}
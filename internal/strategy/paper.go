@@ -0,0 +1,270 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// PaperPosition is a simulated holding in a PaperAccount.
+type PaperPosition struct {
+	ID         string
+	TokenID    string
+	MarketSlug string
+	Label      string // Human-readable description for logs
+	Shares     float64
+	Price      float64
+	WinProb    float64 // Strategy's latest probability the position pays out, 0 if it has none
+	OpenedAt   time.Time
+}
+
+// Cost returns what the position debited from the paper balance.
+func (p PaperPosition) Cost() float64 {
+	return p.Shares * p.Price
+}
+
+// PaperAccount simulates a cash balance during dry-run sessions. Entries are
+// assumed to fill at their limit price and are held to resolution, so the
+// account acts as a lightweight forward test of a strategy.
+type PaperAccount struct {
+	startBalance float64
+	balance      float64
+	realizedPnL  float64
+	wins         int
+	losses       int
+	positions    map[string]*PaperPosition // ID -> position
+	mu           sync.Mutex
+}
+
+// NewPaperAccount creates a paper account holding startBalance in cash.
+func NewPaperAccount(startBalance float64) *PaperAccount {
+	return &PaperAccount{
+		startBalance: startBalance,
+		balance:      startBalance,
+		positions:    make(map[string]*PaperPosition),
+	}
+}
+
+// Open debits the cost of a simulated entry.
+func (pa *PaperAccount) Open(pos PaperPosition) error {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
+	cost := pos.Cost()
+	if cost > pa.balance {
+		return fmt.Errorf("insufficient paper balance $%.2f for $%.2f entry", pa.balance, cost)
+	}
+	if pos.OpenedAt.IsZero() {
+		pos.OpenedAt = time.Now()
+	}
+
+	pa.balance -= cost
+	pa.positions[pos.ID] = &pos
+	return nil
+}
+
+// Settle closes a position at payoutPerShare (1 for a win, 0 for a loss) and
+// returns its realized P&L.
+func (pa *PaperAccount) Settle(id string, payoutPerShare float64) (float64, bool) {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
+	pos, ok := pa.positions[id]
+	if !ok {
+		return 0, false
+	}
+	delete(pa.positions, id)
+
+	payout := pos.Shares * payoutPerShare
+	pnl := payout - pos.Cost()
+	pa.balance += payout
+	pa.realizedPnL += pnl
+	if pnl > 0 {
+		pa.wins++
+	} else {
+		pa.losses++
+	}
+	return pnl, true
+}
+
+// SetWinProb updates an open position's estimated win probability.
+func (pa *PaperAccount) SetWinProb(id string, prob float64) {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	if pos, ok := pa.positions[id]; ok {
+		pos.WinProb = prob
+	}
+}
+
+// OpenPositions returns copies of the unsettled positions, oldest first.
+func (pa *PaperAccount) OpenPositions() []PaperPosition {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
+	result := make([]PaperPosition, 0, len(pa.positions))
+	for _, pos := range pa.positions {
+		result = append(result, *pos)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].OpenedAt.Before(result[j].OpenedAt)
+	})
+	return result
+}
+
+// Balance returns the cash not tied up in open positions.
+func (pa *PaperAccount) Balance() float64 {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	return pa.balance
+}
+
+// RealizedPnL returns the P&L of settled positions.
+func (pa *PaperAccount) RealizedPnL() float64 {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	return pa.realizedPnL
+}
+
+// Stats returns the account figures for inclusion in a strategy's GetStats.
+func (pa *PaperAccount) Stats() map[string]interface{} {
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+
+	var exposure float64
+	for _, pos := range pa.positions {
+		exposure += pos.Cost()
+	}
+	return map[string]interface{}{
+		"paper_start_balance": pa.startBalance,
+		"paper_balance":       pa.balance,
+		"paper_exposure":      exposure,
+		"paper_realized_pnl":  pa.realizedPnL,
+		"paper_open":          len(pa.positions),
+		"paper_wins":          pa.wins,
+		"paper_losses":        pa.losses,
+	}
+}
+
+// LogSummary logs the session's simulated performance.
+func (pa *PaperAccount) LogSummary(prefix string) {
+	stats := pa.Stats()
+	log.Printf("[%s] PAPER: start=$%.2f, cash=$%.2f, open=%d ($%.2f at cost), realized_pnl=$%+.2f, wins=%d, losses=%d",
+		prefix,
+		stats["paper_start_balance"], stats["paper_balance"],
+		stats["paper_open"], stats["paper_exposure"],
+		stats["paper_realized_pnl"], stats["paper_wins"], stats["paper_losses"])
+}
+
+// OutcomeEstimate is a guess at how an open dry-run position resolves, so
+// a session can report P&L without waiting for markets to settle.
+type OutcomeEstimate struct {
+	Shares  float64
+	Cost    float64
+	WinProb float64 // Chance the position pays $1 a share
+}
+
+// Expected returns the probability-weighted P&L.
+func (e OutcomeEstimate) Expected() float64 {
+	return e.Shares*e.WinProb - e.Cost
+}
+
+// PnLEstimate sums the outcome estimates of a session's open positions.
+type PnLEstimate struct {
+	Positions int
+	Expected  float64
+	Low       float64 // Every position loses
+	High      float64 // Every position wins
+}
+
+// estimatePnL totals estimates on top of realized P&L.
+func estimatePnL(realized float64, estimates []OutcomeEstimate) PnLEstimate {
+	est := PnLEstimate{Expected: realized, Low: realized, High: realized}
+	for _, e := range estimates {
+		est.Positions++
+		est.Expected += e.Expected()
+		est.Low -= e.Cost
+		est.High += e.Shares - e.Cost
+	}
+	return est
+}
+
+// String formats the estimate as a session summary note.
+func (e PnLEstimate) String() string {
+	return fmt.Sprintf("Est. P&L at resolution: $%+.2f (range $%+.2f to $%+.2f, %d open)",
+		e.Expected, e.Low, e.High, e.Positions)
+}
+
+// estimatePaperPnL estimates the account's P&L once its open positions
+// resolve, with estimate giving each position's outcome.
+func estimatePaperPnL(pa *PaperAccount, estimate func(PaperPosition) OutcomeEstimate) PnLEstimate {
+	var estimates []OutcomeEstimate
+	for _, pos := range pa.OpenPositions() {
+		estimates = append(estimates, estimate(pos))
+	}
+	return estimatePnL(pa.RealizedPnL(), estimates)
+}
+
+// resolvedTokenPayout returns the per-share payout of tokenID once its market
+// has closed, taken from the final outcome prices.
+func resolvedTokenPayout(market *gamma.Market, tokenID string) (float64, bool) {
+	if !market.Closed {
+		return 0, false
+	}
+
+	ids := market.ParseClobTokenIDs()
+	prices := market.ParseOutcomePrices()
+	for i, id := range ids {
+		if id != tokenID || i >= len(prices) {
+			continue
+		}
+		// Final prices sit at 0 or 1; round away any dust
+		switch {
+		case prices[i] >= 0.99:
+			return 1, true
+		case prices[i] <= 0.01:
+			return 0, true
+		default:
+			return prices[i], true
+		}
+	}
+	return 0, false
+}
+
+// settlePaperPositions settles every paper position whose market has closed,
+// calling onSettle for each one.
+func settlePaperPositions(pa *PaperAccount, gammaClient gamma.MarketSource, prefix string, onSettle func(pos PaperPosition, pnl float64)) {
+	for _, pos := range pa.OpenPositions() {
+		market, err := gammaClient.GetMarketBySlug(pos.MarketSlug)
+		if err != nil {
+			if gamma.IsNotFound(err) {
+				continue
+			}
+			log.Printf("[%s] paper: failed to check %s: %v", prefix, pos.MarketSlug, err)
+			continue
+		}
+
+		payout, ok := resolvedTokenPayout(market, pos.TokenID)
+		if !ok {
+			continue
+		}
+
+		pnl, ok := pa.Settle(pos.ID, payout)
+		if !ok {
+			continue
+		}
+		result := "LOST"
+		if pnl > 0 {
+			result = "WON"
+		}
+		log.Printf("[%s] PAPER %s: %s pnl=$%+.2f (balance $%.2f)",
+			prefix, result, strings.TrimSpace(pos.Label), pnl, pa.Balance())
+		if onSettle != nil {
+			onSettle(pos, pnl)
+		}
+	}
+}
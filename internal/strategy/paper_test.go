@@ -0,0 +1,180 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+func TestPaperAccount_OpenAndSettle(t *testing.T) {
+	pa := NewPaperAccount(20)
+
+	if err := pa.Open(PaperPosition{ID: "win", Shares: 10, Price: 0.40}); err != nil {
+		t.Fatalf("Open(win): %v", err)
+	}
+	if err := pa.Open(PaperPosition{ID: "lose", Shares: 20, Price: 0.25}); err != nil {
+		t.Fatalf("Open(lose): %v", err)
+	}
+	if got := pa.Balance(); math.Abs(got-11) > 1e-9 {
+		t.Fatalf("balance after entries = %.2f, want 11.00", got)
+	}
+
+	if err := pa.Open(PaperPosition{ID: "too-big", Shares: 100, Price: 0.50}); err == nil {
+		t.Error("expected error opening a position larger than the balance")
+	}
+
+	tests := []struct {
+		id      string
+		payout  float64
+		wantPnL float64
+	}{
+		{"win", 1, 6},
+		{"lose", 0, -5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			pnl, ok := pa.Settle(tt.id, tt.payout)
+			if !ok {
+				t.Fatal("position not found")
+			}
+			if math.Abs(pnl-tt.wantPnL) > 1e-9 {
+				t.Errorf("pnl = %.2f, want %.2f", pnl, tt.wantPnL)
+			}
+		})
+	}
+
+	if _, ok := pa.Settle("win", 1); ok {
+		t.Error("settling twice should fail")
+	}
+
+	stats := pa.Stats()
+	if got := stats["paper_balance"].(float64); math.Abs(got-21) > 1e-9 {
+		t.Errorf("final balance = %.2f, want 21.00", got)
+	}
+	if got := stats["paper_realized_pnl"].(float64); math.Abs(got-1) > 1e-9 {
+		t.Errorf("realized pnl = %.2f, want 1.00", got)
+	}
+	if stats["paper_wins"] != 1 || stats["paper_losses"] != 1 || stats["paper_open"] != 0 {
+		t.Errorf("unexpected counts: %v", stats)
+	}
+}
+
+func TestResolvedTokenPayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		market     gamma.Market
+		tokenID    string
+		wantPayout float64
+		wantOK     bool
+	}{
+		{
+			"open market",
+			gamma.Market{ClobTokenIDs: `["1","2"]`, OutcomePrices: `["0.6","0.4"]`},
+			"1", 0, false,
+		},
+		{
+			"winning token",
+			gamma.Market{Closed: true, ClobTokenIDs: `["1","2"]`, OutcomePrices: `["0.9995","0.0005"]`},
+			"1", 1, true,
+		},
+		{
+			"losing token",
+			gamma.Market{Closed: true, ClobTokenIDs: `["1","2"]`, OutcomePrices: `["0.9995","0.0005"]`},
+			"2", 0, true,
+		},
+		{
+			"unknown token",
+			gamma.Market{Closed: true, ClobTokenIDs: `["1","2"]`, OutcomePrices: `["1","0"]`},
+			"3", 0, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payout, ok := resolvedTokenPayout(&tt.market, tt.tokenID)
+			if ok != tt.wantOK || payout != tt.wantPayout {
+				t.Errorf("resolvedTokenPayout() = (%v, %v), want (%v, %v)", payout, ok, tt.wantPayout, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSettlePaperPositions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		closed := r.URL.Query().Get("slug") == "resolved"
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"slug":%q,"closed":%t,"clobTokenIds":"[\"yes\",\"no\"]","outcomePrices":"[\"1\",\"0\"]"}]`,
+			r.URL.Query().Get("slug"), closed)
+	}))
+	defer srv.Close()
+
+	pa := NewPaperAccount(10)
+	pa.Open(PaperPosition{ID: "a", TokenID: "yes", MarketSlug: "resolved", Shares: 5, Price: 0.5})
+	pa.Open(PaperPosition{ID: "b", TokenID: "no", MarketSlug: "pending", Shares: 5, Price: 0.5})
+
+	var settled []string
+	settlePaperPositions(pa, gamma.NewClient().WithBaseURL(srv.URL), "test", func(pos PaperPosition, pnl float64) {
+		settled = append(settled, pos.ID)
+	})
+
+	if len(settled) != 1 || settled[0] != "a" {
+		t.Fatalf("settled %v, want [a]", settled)
+	}
+	if open := pa.OpenPositions(); len(open) != 1 || open[0].ID != "b" {
+		t.Errorf("open positions %v, want only b", open)
+	}
+	if got := pa.Balance(); math.Abs(got-10) > 1e-9 {
+		t.Errorf("balance = %.2f, want 10.00", got)
+	}
+}
+
+func TestEstimateOutcome(t *testing.T) {
+	pos := PaperPosition{ID: "p1", Shares: 10, Price: 0.40, WinProb: 0.70}
+
+	tests := []struct {
+		name         string
+		estimate     OutcomeEstimate
+		wantExpected float64
+	}{
+		// 10 shares at 40¢ with a 70% forecast: 7.00 - 4.00
+		{"weather uses forecast probability", (&WeatherSniper{}).EstimateOutcome(pos), 3.00},
+		{"weather clamps probability", (&WeatherSniper{}).EstimateOutcome(PaperPosition{Shares: 10, Price: 0.40, WinProb: 1.3}), 6.00},
+		{"blackswan assumes the long shot loses", (&BlackSwanHunter{}).EstimateOutcome(PaperPosition{Shares: 100, Price: 0.02, WinProb: 0.5}), -2.00},
+		{"sniper assumes the favorite wins", (&Sniper{}).EstimateOutcome(SnipePosition{Shares: 10, EntryPrice: 0.95}), 0.50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.estimate.Expected(); math.Abs(got-tt.wantExpected) > 1e-9 {
+				t.Errorf("Expected() = %.4f, want %.2f", got, tt.wantExpected)
+			}
+		})
+	}
+}
+
+func TestEstimatePaperPnL(t *testing.T) {
+	pa := NewPaperAccount(100)
+	for _, pos := range []PaperPosition{
+		{ID: "a", Shares: 10, Price: 0.40, WinProb: 0.70},
+		{ID: "b", Shares: 100, Price: 0.02, WinProb: 0.10},
+		{ID: "settled", Shares: 5, Price: 0.50},
+	} {
+		if err := pa.Open(pos); err != nil {
+			t.Fatalf("Open %s: %v", pos.ID, err)
+		}
+	}
+	pa.Settle("settled", 1) // +2.50 realized
+	pa.SetWinProb("a", 0.80)
+
+	ws := &WeatherSniper{}
+	got := estimatePaperPnL(pa, ws.EstimateOutcome)
+	// a: 8.00 - 4.00; b: 10.00 - 2.00
+	want := PnLEstimate{Positions: 2, Expected: 2.50 + 4.00 + 8.00, Low: 2.50 - 6.00, High: 2.50 + 6.00 + 98.00}
+	if got.Positions != want.Positions || math.Abs(got.Expected-want.Expected) > 1e-9 ||
+		math.Abs(got.Low-want.Low) > 1e-9 || math.Abs(got.High-want.High) > 1e-9 {
+		t.Errorf("estimatePaperPnL = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// hourWindow is a range of UTC hours, start inclusive and end exclusive,
+// that may wrap past midnight ("22-4" is 22:00 to 04:00).
+type hourWindow struct {
+	start int
+	end   int
+}
+
+// parseHourWindow parses "start-end" with hours in 0-24.
+func parseHourWindow(s string) (*hourWindow, error) {
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid hour window %q: expected start-end", s)
+	}
+	start, err := strconv.Atoi(strings.TrimSpace(startStr))
+	if err != nil || start < 0 || start > 24 {
+		return nil, fmt.Errorf("invalid hour window %q: start must be an hour in 0-24", s)
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(endStr))
+	if err != nil || end < 0 || end > 24 {
+		return nil, fmt.Errorf("invalid hour window %q: end must be an hour in 0-24", s)
+	}
+	if start%24 == end%24 {
+		return nil, fmt.Errorf("invalid hour window %q: start and end must differ", s)
+	}
+	return &hourWindow{start: start % 24, end: end % 24}, nil
+}
+
+// contains reports whether t falls inside the window, in UTC.
+func (w *hourWindow) contains(t time.Time) bool {
+	hour := t.UTC().Hour()
+	if w.start < w.end {
+		return hour >= w.start && hour < w.end
+	}
+	return hour >= w.start || hour < w.end
+}
+
+func (w *hourWindow) String() string {
+	return fmt.Sprintf("%02d:00-%02d:00 UTC", w.start, w.end)
+}
@@ -0,0 +1,46 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHourWindow(t *testing.T) {
+	tests := []struct {
+		in      string
+		wantErr bool
+		inside  []int
+		outside []int
+	}{
+		{in: "2-8", inside: []int{2, 5, 7}, outside: []int{1, 8, 20}},
+		{in: "22-6", inside: []int{22, 23, 0, 5}, outside: []int{6, 12, 21}},
+		{in: " 20 - 24 ", inside: []int{20, 23}, outside: []int{0, 19}},
+		{in: "8", wantErr: true},
+		{in: "3-3", wantErr: true},
+		{in: "0-24", wantErr: true},
+		{in: "5-25", wantErr: true},
+		{in: "a-4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			w, err := parseHourWindow(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseHourWindow(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			day := time.Date(2026, 5, 1, 0, 30, 0, 0, time.UTC)
+			for _, h := range tt.inside {
+				if !w.contains(day.Add(time.Duration(h) * time.Hour)) {
+					t.Errorf("%s: hour %d not inside", w, h)
+				}
+			}
+			for _, h := range tt.outside {
+				if w.contains(day.Add(time.Duration(h) * time.Hour)) {
+					t.Errorf("%s: hour %d inside", w, h)
+				}
+			}
+		})
+	}
+}
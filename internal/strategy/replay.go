@@ -0,0 +1,241 @@
+package strategy
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// ReplayRecord is one line of a sniper snapshot recording: a tracked
+// market's prices at the moment a snapshot was taken. Recordings are JSON
+// lines, appended in the order snapshots happen across all markets.
+type ReplayRecord struct {
+	Slug      string    `json:"slug"`
+	Question  string    `json:"question"`
+	EndTime   time.Time `json:"end_time"`
+	Timestamp time.Time `json:"ts"`
+	YesBid    float64   `json:"yes_bid"`
+	YesAsk    float64   `json:"yes_ask"`
+	NoBid     float64   `json:"no_bid"`
+	NoAsk     float64   `json:"no_ask"`
+	YesSize   float64   `json:"yes_size"` // Size at the best ask
+	NoSize    float64   `json:"no_size"`
+	GammaYes  float64   `json:"gamma_yes"`
+	GammaNo   float64   `json:"gamma_no"`
+}
+
+// snapshotRecorder appends every tracked market's price snapshots to a
+// JSON-lines file for offline replay. A nil recorder records nothing.
+type snapshotRecorder struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// newSnapshotRecorder opens path for appending; it returns nil when path is
+// empty.
+func newSnapshotRecorder(path string) (*snapshotRecorder, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create snapshot recording directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot recording: %w", err)
+	}
+	return &snapshotRecorder{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// record writes a snapshot of tm. The caller holds tm's lock.
+func (r *snapshotRecorder) record(tm *TrackedMarket, snap PriceSnapshot) {
+	if r == nil {
+		return
+	}
+	rec := ReplayRecord{
+		Slug:      tm.Market.Slug,
+		Question:  tm.Market.Question,
+		EndTime:   tm.EndTime,
+		Timestamp: snap.Timestamp,
+		YesBid:    snap.YesBid,
+		YesAsk:    snap.YesAsk,
+		NoBid:     snap.NoBid,
+		NoAsk:     snap.NoAsk,
+		YesSize:   tm.YesSize,
+		NoSize:    tm.NoSize,
+		GammaYes:  tm.GammaYesPrice,
+		GammaNo:   tm.GammaNoPrice,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// A failed write loses one snapshot; trading must not stop for it
+	_ = r.enc.Encode(rec)
+}
+
+// close closes the recording file.
+func (r *snapshotRecorder) close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
+
+// ReadReplayRecords parses a snapshot recording. Blank lines are skipped.
+func ReadReplayRecords(r io.Reader) ([]ReplayRecord, error) {
+	var records []ReplayRecord
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec ReplayRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot on line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	return records, nil
+}
+
+// ReplayResult is what the sniper would have done with one recorded market.
+type ReplayResult struct {
+	Slug     string
+	Question string
+	Decided  bool          // The market reached the trigger window warmed up
+	Analysis TradeAnalysis // The decision, when Decided
+	Winner   string        // "UP" or "DOWN" from the last snapshot's Gamma prices, "" if unknown
+}
+
+// Traded reports whether the sniper would have bought.
+func (r ReplayResult) Traded() bool {
+	return r.Decided && r.Analysis.ShouldTrade
+}
+
+// ReplayReport summarizes a replay.
+type ReplayReport struct {
+	Results    []ReplayResult
+	Markets    int
+	Trades     int
+	Wins       int
+	Losses     int
+	Unresolved int // Trades whose winner couldn't be told from the recording
+	Skips      map[SkipReason]int
+	PnL        float64 // Expected profit on wins less max loss on losses
+}
+
+// Replay feeds recorded snapshots through analyzeMarket with the strategy
+// parameters in cfg (MIN_CONFIDENCE, MAX_UNCERTAINTY, TRIGGER_SECONDS,
+// SNIPE_PRICE, liquidity minimums and warmup), making the same single
+// trade-or-skip decision per market that CheckAndSnipe would.
+//
+// Winners are read from Gamma prices, as recordings have no Binance feed,
+// and the outcome is taken from each market's last snapshot, so recordings
+// should run until markets close. The daily loss limit isn't simulated.
+func Replay(cfg *config.Config, records []ReplayRecord) ReplayReport {
+	s := newSniperCore(cfg)
+
+	bySlug := make(map[string][]ReplayRecord)
+	var slugs []string
+	for _, rec := range records {
+		if _, ok := bySlug[rec.Slug]; !ok {
+			slugs = append(slugs, rec.Slug)
+		}
+		bySlug[rec.Slug] = append(bySlug[rec.Slug], rec)
+	}
+
+	report := ReplayReport{Skips: make(map[SkipReason]int)}
+	for _, slug := range slugs {
+		result := s.replayMarket(bySlug[slug])
+		report.Results = append(report.Results, result)
+		report.Markets++
+
+		switch {
+		case !result.Decided:
+		case !result.Analysis.ShouldTrade:
+			report.Skips[result.Analysis.SkipReason]++
+		case result.Winner == "":
+			report.Trades++
+			report.Unresolved++
+		case result.Winner == result.Analysis.Side:
+			report.Trades++
+			report.Wins++
+			report.PnL += result.Analysis.ExpectedProfit
+		default:
+			report.Trades++
+			report.Losses++
+			report.PnL -= result.Analysis.MaxLoss
+		}
+	}
+	return report
+}
+
+// replayMarket replays one market's snapshots in time order until the first
+// warmed-up snapshot inside the trigger window.
+func (s *Sniper) replayMarket(records []ReplayRecord) ReplayResult {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	first, last := records[0], records[len(records)-1]
+
+	result := ReplayResult{Slug: first.Slug, Question: first.Question}
+	switch {
+	case last.GammaYes > last.GammaNo:
+		result.Winner = "UP"
+	case last.GammaNo > last.GammaYes:
+		result.Winner = "DOWN"
+	}
+
+	tracked := &TrackedMarket{
+		Market:       gamma.Market{Slug: first.Slug, Question: first.Question},
+		YesTokenID:   "yes",
+		NoTokenID:    "no",
+		EndTime:      first.EndTime,
+		priceHistory: make([]PriceSnapshot, 0, maxPriceSnapshots),
+		trackedAt:    first.Timestamp,
+	}
+	trigger := time.Duration(s.config.TriggerSeconds) * time.Second
+	minAge := time.Duration(s.config.SnipeWarmupSeconds) * time.Second
+
+	for _, rec := range records {
+		tracked.mu.Lock()
+		tracked.BestYesBid, tracked.BestYesAsk = rec.YesBid, rec.YesAsk
+		tracked.BestNoBid, tracked.BestNoAsk = rec.NoBid, rec.NoAsk
+		tracked.YesSize, tracked.NoSize = rec.YesSize, rec.NoSize
+		tracked.GammaYesPrice, tracked.GammaNoPrice = rec.GammaYes, rec.GammaNo
+		tracked.recordSnapshotAt(rec.Timestamp)
+		tracked.mu.Unlock()
+
+		remaining := tracked.EndTime.Sub(rec.Timestamp)
+		if remaining > trigger || remaining < 0 {
+			continue
+		}
+		if !tracked.IsWarmedUp(s.config.SnipeWarmupSnapshots, minAge, rec.Timestamp) {
+			continue
+		}
+		result.Decided = true
+		result.Analysis = s.analyzeMarket(tracked)
+		break
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// recordMarket writes snapshots one second apart for a market ending at end,
+// with the Gamma YES price at each step.
+func recordMarket(t *testing.T, r *snapshotRecorder, slug string, end time.Time, gammaYes []float64) {
+	t.Helper()
+	tm := &TrackedMarket{
+		Market:  gamma.Market{Slug: slug, Question: slug + "?"},
+		EndTime: end,
+		YesSize: 100,
+		NoSize:  100,
+	}
+	start := end.Add(-time.Duration(len(gammaYes)-1) * time.Second)
+	for i, p := range gammaYes {
+		tm.GammaYesPrice, tm.GammaNoPrice = p, 1-p
+		tm.BestYesAsk, tm.BestNoAsk = p+0.01, 1-p+0.01
+		r.record(tm, PriceSnapshot{YesAsk: tm.BestYesAsk, NoAsk: tm.BestNoAsk, Timestamp: start.Add(time.Duration(i) * time.Second)})
+	}
+}
+
+func TestReplay_RecordedSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "snapshots.jsonl")
+	r, err := newSnapshotRecorder(path)
+	if err != nil {
+		t.Fatalf("newSnapshotRecorder: %v", err)
+	}
+	end := time.Date(2026, 3, 4, 12, 15, 0, 0, time.UTC)
+	recordMarket(t, r, "btc-up", end, []float64{0.60, 0.70, 0.80, 0.90, 0.95})       // Clear UP, resolves UP
+	recordMarket(t, r, "eth-reverses", end, []float64{0.60, 0.62, 0.63, 0.64, 0.40}) // Marginal UP, resolves DOWN
+	recordMarket(t, r, "sol-flat", end, []float64{0.50, 0.52, 0.51, 0.52, 0.52})     // Too close to call
+	if err := r.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open recording: %v", err)
+	}
+	defer f.Close()
+	records, err := ReadReplayRecords(f)
+	if err != nil {
+		t.Fatalf("ReadReplayRecords: %v", err)
+	}
+	if len(records) != 15 {
+		t.Fatalf("read %d records, want 15", len(records))
+	}
+
+	tests := []struct {
+		name       string
+		minConf    float64
+		wantTrades int
+		wantWins   int
+		wantLosses int
+	}{
+		{"loose", 0.55, 2, 1, 1},
+		{"strict", 0.70, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				SnipePrice:           0.99,
+				TriggerSeconds:       2,
+				MaxPositionSize:      10,
+				MinConfidence:        tt.minConf,
+				MaxUncertainty:       0.10,
+				SnipeWarmupSnapshots: 2,
+			}
+			report := Replay(cfg, records)
+			if report.Markets != 3 {
+				t.Errorf("Markets = %d, want 3", report.Markets)
+			}
+			if report.Trades != tt.wantTrades || report.Wins != tt.wantWins || report.Losses != tt.wantLosses {
+				t.Errorf("trades/wins/losses = %d/%d/%d, want %d/%d/%d",
+					report.Trades, report.Wins, report.Losses, tt.wantTrades, tt.wantWins, tt.wantLosses)
+			}
+			if report.Skips[SkipReasonTooUncertain]+report.Skips[SkipReasonNoWinner] != 3-tt.wantTrades {
+				t.Errorf("skips = %v, want %d confidence skips", report.Skips, 3-tt.wantTrades)
+			}
+		})
+	}
+}
+
+func TestNewSnapshotRecorder_Disabled(t *testing.T) {
+	r, err := newSnapshotRecorder("")
+	if err != nil || r != nil {
+		t.Fatalf("newSnapshotRecorder(\"\") = %v, %v; want nil, nil", r, err)
+	}
+	r.record(&TrackedMarket{}, PriceSnapshot{})
+	if err := r.close(); err != nil {
+		t.Errorf("nil close = %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package strategy
+
+import (
+	"log"
+	"math"
+	"strings"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+// sessionLossStop is a kill switch across the whole session: once realized
+// P&L falls to -limit the strategy stops opening positions for good, unlike
+// the daily limits which reset at midnight.
+type sessionLossStop struct {
+	prefix string
+	limit  float64 // Dollars of net realized loss that halt trading
+
+	realized float64 // Net realized P&L this session
+	halted   bool
+}
+
+// newSessionLossStop returns nil when limit is not positive; the nil stop
+// never halts.
+func newSessionLossStop(prefix string, limit float64) *sessionLossStop {
+	if limit <= 0 {
+		return nil
+	}
+	return &sessionLossStop{prefix: prefix, limit: limit}
+}
+
+// record adds realized P&L and reports whether it just tripped the stop.
+func (s *sessionLossStop) record(pnl float64) bool {
+	if s == nil {
+		return false
+	}
+	s.realized += pnl
+	if s.halted || -s.realized < s.limit {
+		return false
+	}
+	s.halted = true
+	log.Printf("[%s] SESSION LOSS LIMIT: realized $%+.2f hit the -$%.2f limit, halting new trades", s.prefix, s.realized, s.limit)
+	return true
+}
+
+// Halted reports whether the stop has tripped.
+func (s *sessionLossStop) Halted() bool {
+	return s != nil && s.halted
+}
+
+// Realized returns the session's net realized P&L.
+func (s *sessionLossStop) Realized() float64 {
+	if s == nil {
+		return 0
+	}
+	return s.realized
+}
+
+// heldPosition is a filled live buy held until its market resolves or a
+// take-profit sells it, so its P&L can be realized.
+type heldPosition struct {
+	tokenID    string
+	marketSlug string
+	label      string
+	shares     float64
+	price      float64
+	groupKey   string // Weather bucket group (bucketGroupKey), empty elsewhere
+}
+
+// heldPositions tracks filled live positions until their markets resolve,
+// the live counterpart of a PaperAccount's open positions.
+type heldPositions struct {
+	positions []heldPosition
+}
+
+func (h *heldPositions) add(pos heldPosition) {
+	h.positions = append(h.positions, pos)
+}
+
+// sell removes shares of a token sold before resolution, oldest position
+// first.
+func (h *heldPositions) sell(tokenID string, shares float64) {
+	remaining := h.positions[:0]
+	for _, pos := range h.positions {
+		if pos.tokenID == tokenID && shares > 0 {
+			sold := math.Min(shares, pos.shares)
+			pos.shares -= sold
+			shares -= sold
+			if pos.shares <= 0 {
+				continue
+			}
+		}
+		remaining = append(remaining, pos)
+	}
+	h.positions = remaining
+}
+
+// holds reports whether any held position is in tokenID.
+func (h *heldPositions) holds(tokenID string) bool {
+	for _, pos := range h.positions {
+		if pos.tokenID == tokenID {
+			return true
+		}
+	}
+	return false
+}
+
+// groupCount returns how many held positions are in a bucket group.
+func (h *heldPositions) groupCount(key string) int {
+	count := 0
+	for _, pos := range h.positions {
+		if pos.groupKey == key {
+			count++
+		}
+	}
+	return count
+}
+
+// groupExposure returns the combined cost of held positions in a bucket
+// group.
+func (h *heldPositions) groupExposure(key string) float64 {
+	total := 0.0
+	for _, pos := range h.positions {
+		if pos.groupKey == key {
+			total += pos.shares * pos.price
+		}
+	}
+	return total
+}
+
+// settle removes positions whose markets have closed and calls onSettle with
+// each one's realized P&L.
+func (h *heldPositions) settle(src gamma.MarketSource, prefix string, onSettle func(pos heldPosition, pnl float64)) {
+	remaining := h.positions[:0]
+	for _, pos := range h.positions {
+		market, err := src.GetMarketBySlug(pos.marketSlug)
+		if err != nil {
+			if gamma.IsNotFound(err) {
+				// Not listed (yet or any more); nothing to report
+				remaining = append(remaining, pos)
+				continue
+			}
+			log.Printf("[%s] failed to check resolution of %s: %v", prefix, pos.marketSlug, err)
+			remaining = append(remaining, pos)
+			continue
+		}
+
+		payout, ok := resolvedTokenPayout(market, pos.tokenID)
+		if !ok {
+			remaining = append(remaining, pos)
+			continue
+		}
+
+		pnl := pos.shares * (payout - pos.price)
+		result := "LOST"
+		if pnl > 0 {
+			result = "WON"
+		}
+		log.Printf("[%s] RESOLVED %s: %s pnl=$%+.2f", prefix, result, strings.TrimSpace(pos.label), pnl)
+		onSettle(pos, pnl)
+	}
+	h.positions = remaining
+}
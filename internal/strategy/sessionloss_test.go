@@ -0,0 +1,93 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+)
+
+func TestSessionLossStop_Record(t *testing.T) {
+	s := newSessionLossStop("test", 5)
+	steps := []struct {
+		pnl        float64
+		wantTrip   bool
+		wantHalted bool
+	}{
+		{-3, false, false},
+		{+1, false, false}, // Wins offset losses
+		{-2.5, false, false},
+		{-0.5, true, true}, // -5.00 realized
+		{-1, false, true},  // Trips once
+		{+10, false, true}, // Stays halted
+	}
+	for i, step := range steps {
+		if got := s.record(step.pnl); got != step.wantTrip {
+			t.Errorf("step %d: record(%v) = %v, want %v", i, step.pnl, got, step.wantTrip)
+		}
+		if s.Halted() != step.wantHalted {
+			t.Errorf("step %d: Halted() = %v, want %v", i, s.Halted(), step.wantHalted)
+		}
+	}
+
+	var disabled *sessionLossStop
+	if newSessionLossStop("test", 0) != nil || disabled.record(-100) || disabled.Halted() {
+		t.Error("disabled stop should never halt")
+	}
+}
+
+func TestBlackSwan_SessionLossHaltsTrading(t *testing.T) {
+	cfg := testBlackSwanConfig()
+	cfg.DryRun = false
+	cfg.MaxSessionLoss = 1
+
+	source := gammamock.New()
+	source.Markets = []gamma.Market{{
+		Slug:          "lost",
+		Closed:        true,
+		ClobTokenIDs:  `["111","222"]`,
+		OutcomePrices: `["0","1"]`,
+	}}
+	source.Search = []gamma.Market{
+		testBlackSwanMarket("yes-longshot", 0.03, 0.97, 5000, time.Now().Add(24*time.Hour)),
+	}
+	mock := clobmock.New()
+	mock.OpenOrders = []clob.Order{{ID: "resting"}}
+
+	h := &BlackSwanHunter{
+		config:   cfg,
+		gamma:    source,
+		clob:     mock,
+		builder:  testBracketBuilder(t),
+		lossStop: newSessionLossStop("blackswan", cfg.MaxSessionLoss),
+		tracker:  NewPositionTracker(),
+		bankroll: 10,
+	}
+	// 60 shares at 2¢ filled on a market that resolved against us: -$1.20
+	h.held.add(heldPosition{tokenID: "111", marketSlug: "lost", shares: 60, price: 0.02})
+	h.tracker.Add(&OpenPosition{OrderID: "resting", TokenID: "333", MarketSlug: "open", BidPrice: 0.02, Size: 50, PlacedAt: time.Now()})
+
+	if err := h.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	if !h.lossStop.Halted() {
+		t.Fatalf("not halted after realized $%.2f", h.lossStop.Realized())
+	}
+	if canceled := mock.Canceled(); len(canceled) != 1 || canceled[0] != "resting" {
+		t.Errorf("canceled = %v, want the resting order", canceled)
+	}
+	if h.tracker.Count() != 0 {
+		t.Errorf("tracker has %d positions after halt, want 0", h.tracker.Count())
+	}
+
+	if err := h.ScanAndBet(); err != nil {
+		t.Fatalf("ScanAndBet: %v", err)
+	}
+	if len(source.Searches()) != 0 || len(mock.Orders()) != 0 {
+		t.Errorf("halted hunter still scanned (%d searches) or bet (%d orders)", len(source.Searches()), len(mock.Orders()))
+	}
+}
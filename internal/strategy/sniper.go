@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/dantezy/polymarket-sniper/internal/config"
 	"github.com/dantezy/polymarket-sniper/internal/gamma"
 	"github.com/dantezy/polymarket-sniper/internal/pricefeed"
+	"github.com/dantezy/polymarket-sniper/internal/pricing"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,11 +25,42 @@ const (
 	checkInterval   = 100 * time.Millisecond
 	cleanupInterval = 1 * time.Minute
 
+	// How often tracked markets are re-fetched to detect early closure
+	closedCheckInterval = 20 * time.Second
+
+	// Price history kept per market for momentum detection
+	maxPriceSnapshots = 10
+
+	// Maximum number of markets polled over REST at the same time
+	maxConcurrentPolls = 8
+
+	// Order books (for ask depth) are polled from this long before the
+	// trigger window; earlier polls only need batch bid/ask
+	depthLeadTime = 2 * time.Second
+
+	// Price polling tightens as expiry nears: every defaultPollIdle with more
+	// than pollNearWindow left, defaultPollNear inside it and
+	// defaultPollFinal inside pollFinalWindow (SNIPE_POLL_*_MS override)
+	pollNearWindow   = 60 * time.Second
+	pollFinalWindow  = 10 * time.Second
+	defaultPollIdle  = 5 * time.Second
+	defaultPollNear  = 500 * time.Millisecond
+	defaultPollFinal = checkInterval
+
 	// Winner detection thresholds
 	minWinnerConfidence = 0.50 // Minimum price to consider a clear winner (per strategy: >50%)
 	maxUncertaintyGap   = 0.10 // If YES and NO bids are within this range, too risky
-	defaultMinLiquidity = 5.0  // Default minimum size in USD at best ask
+	defaultMinLiquidity = 5.0  // Default minimum shares at best ask when no liquidity gate is configured
 	momentumThreshold   = 0.15 // Price jump threshold for momentum signal
+	emaMomentumAlpha    = 0.5  // Weight of each new snapshot in EMA momentum
+
+	// Order submission timeout when SNIPE_ORDER_TIMEOUT_MS is unset
+	defaultOrderTimeout = 2 * time.Second
+
+	// A failed stop-loss sell (often shares that haven't settled yet) is
+	// retried after stopLossRetryDelay, at most stopLossMaxAttempts times
+	stopLossRetryDelay  = 5 * time.Second
+	stopLossMaxAttempts = 5
 
 	// Risk management
 	defaultMaxLossPerTrade = 5.0  // Maximum loss per trade in USD
@@ -45,6 +79,8 @@ const (
 	SkipReasonMaxLossExceeds SkipReason = "max_loss_exceeded"
 	SkipReasonDailyLimit     SkipReason = "daily_loss_limit"
 	SkipReasonDownNoLiq      SkipReason = "down_no_liquidity"
+	SkipReasonStalePrices    SkipReason = "stale_prices"
+	SkipReasonLowProfit      SkipReason = "profit_below_minimum"
 )
 
 // PriceSnapshot holds price data at a point in time for momentum tracking.
@@ -73,6 +109,7 @@ type TrackedMarket struct {
 	GammaYesPrice float64
 	GammaNoPrice  float64
 	sniped        bool
+	closed        bool // Gamma reports the market closed for trading
 
 	// Binance price tracking (for faster winner detection)
 	BinanceSymbol     string  // e.g., "BTCUSDT"
@@ -80,7 +117,45 @@ type TrackedMarket struct {
 
 	// Price history for momentum detection (last 10 snapshots)
 	priceHistory []PriceSnapshot
+	trackedAt    time.Time // When tracking began, for the warmup gate
+	yesQuoted    time.Time // Last YES price update, for the staleness gate
+	noQuoted     time.Time // Last NO price update
+	nextPoll     time.Time // When CheckAndSnipe next polls this market's prices
 	mu           sync.RWMutex
+
+	// Position opened by a snipe, monitored for a stop-loss exit until expiry
+	position *SnipePosition
+
+	// Resting maker snipe bid, settled at expiry or when the market is dropped
+	makerBid *makerBid
+
+	// Receives every price snapshot when SNIPE_RECORD_FILE is set
+	recorder *snapshotRecorder
+}
+
+// SnipePosition records what a snipe bought so it can be exited early.
+type SnipePosition struct {
+	Side         string // "UP" or "DOWN"
+	TokenID      string
+	Shares       float64
+	EntryPrice   float64
+	OpenedAt     time.Time // Set by SetPosition; stop-loss momentum counts from here
+	Exited       bool
+	ExitAttempts int       // Stop-loss sells tried so far
+	LastExitAt   time.Time // When the last stop-loss sell was tried
+}
+
+// makerBid records a resting maker snipe bid until it is settled.
+type makerBid struct {
+	orderID        string
+	side           string
+	tokenID        string
+	price          float64
+	shares         float64 // Size submitted
+	filled         float64 // Shares the user channel reported matched
+	cost           float64 // Dollars spent if it fills
+	maxLoss        float64 // Daily loss charged if it fills, analysis.MaxLoss as on the other paths
+	expectedProfit float64
 }
 
 // UpdateYesPrice updates the YES token prices thread-safely.
@@ -90,6 +165,7 @@ func (tm *TrackedMarket) UpdateYesPrice(bid, ask, size float64) {
 	tm.BestYesBid = bid
 	tm.BestYesAsk = ask
 	tm.YesSize = size
+	tm.yesQuoted = time.Now()
 	tm.recordSnapshot()
 }
 
@@ -100,25 +176,47 @@ func (tm *TrackedMarket) UpdateNoPrice(bid, ask, size float64) {
 	tm.BestNoBid = bid
 	tm.BestNoAsk = ask
 	tm.NoSize = size
+	tm.noQuoted = time.Now()
+	tm.recordSnapshot()
+}
+
+// UpdateQuotes sets both tokens' best bid and ask from a batch price fetch,
+// keeping the ask sizes from the last order book or WebSocket update.
+func (tm *TrackedMarket) UpdateQuotes(yes, no clob.PriceSides) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.BestYesBid = yes.Bid
+	tm.BestYesAsk = yes.Ask
+	tm.BestNoBid = no.Bid
+	tm.BestNoAsk = no.Ask
+	tm.yesQuoted = time.Now()
+	tm.noQuoted = tm.yesQuoted
 	tm.recordSnapshot()
 }
 
 // recordSnapshot stores current prices for momentum tracking.
 // Must be called with lock held.
 func (tm *TrackedMarket) recordSnapshot() {
+	tm.recordSnapshotAt(time.Now())
+}
+
+// recordSnapshotAt stores current prices as of at. Must be called with lock
+// held.
+func (tm *TrackedMarket) recordSnapshotAt(at time.Time) {
 	snapshot := PriceSnapshot{
 		YesBid:    tm.BestYesBid,
 		YesAsk:    tm.BestYesAsk,
 		NoBid:     tm.BestNoBid,
 		NoAsk:     tm.BestNoAsk,
-		Timestamp: time.Now(),
+		Timestamp: at,
 	}
 	tm.priceHistory = append(tm.priceHistory, snapshot)
 
 	// Keep only last 10 snapshots
-	if len(tm.priceHistory) > 10 {
+	if len(tm.priceHistory) > maxPriceSnapshots {
 		tm.priceHistory = tm.priceHistory[1:]
 	}
+	tm.recorder.record(tm, snapshot)
 }
 
 // GetPrices returns current prices thread-safely.
@@ -128,6 +226,21 @@ func (tm *TrackedMarket) GetPrices() (yesBid, yesAsk, noBid, noAsk float64) {
 	return tm.BestYesBid, tm.BestYesAsk, tm.BestNoBid, tm.BestNoAsk
 }
 
+// PriceAge returns how long ago tokenID's price was last updated. A token
+// that has never been quoted is infinitely stale.
+func (tm *TrackedMarket) PriceAge(tokenID string, now time.Time) time.Duration {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	quoted := tm.yesQuoted
+	if tokenID == tm.NoTokenID {
+		quoted = tm.noQuoted
+	}
+	if quoted.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	return now.Sub(quoted)
+}
+
 // GetSizes returns available liquidity at best ask for each side.
 func (tm *TrackedMarket) GetSizes() (yesSize, noSize float64) {
 	tm.mu.RLock()
@@ -151,6 +264,91 @@ func (tm *TrackedMarket) GetMomentum() float64 {
 	return newest.YesBid - oldest.YesBid
 }
 
+// GetEMAMomentum returns the YES side's price change over recent history
+// like GetMomentum, but between exponentially smoothed ends: the newest
+// level is an EMA run forward through the snapshots and the oldest one an
+// EMA run backward, so a single outlier snapshot counts for less. It is
+// scaled so a steady trend reads the same as GetMomentum.
+func (tm *TrackedMarket) GetEMAMomentum() float64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	prices := make([]float64, len(tm.priceHistory))
+	for i, snap := range tm.priceHistory {
+		prices[i] = snap.YesBid
+	}
+	return emaMomentum(prices)
+}
+
+// MomentumSince returns the YES side's price change like GetMomentum, or
+// GetEMAMomentum when ema is set, over only the snapshots taken at or after
+// since. Moves from before then, such as the run-up a snipe bought into,
+// don't count.
+func (tm *TrackedMarket) MomentumSince(since time.Time, ema bool) float64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	var prices []float64
+	for _, snap := range tm.priceHistory {
+		if !snap.Timestamp.Before(since) {
+			prices = append(prices, snap.YesBid)
+		}
+	}
+	if ema {
+		return emaMomentum(prices)
+	}
+	if len(prices) < 2 {
+		return 0
+	}
+	return prices[len(prices)-1] - prices[0]
+}
+
+// emaMomentum compares prices' forward and backward EMAs, scaled by what
+// the same EMAs give for a straight line rising one per snapshot.
+func emaMomentum(prices []float64) float64 {
+	n := len(prices)
+	if n < 2 {
+		return 0
+	}
+	if n == 2 {
+		// Nothing to smooth
+		return prices[1] - prices[0]
+	}
+
+	newest, oldest := prices[0], prices[n-1]
+	lineNewest, lineOldest := 0.0, float64(n-1)
+	for i := 1; i < n; i++ {
+		newest += emaMomentumAlpha * (prices[i] - newest)
+		oldest += emaMomentumAlpha * (prices[n-1-i] - oldest)
+		lineNewest += emaMomentumAlpha * (float64(i) - lineNewest)
+		lineOldest += emaMomentumAlpha * (float64(n-1-i) - lineOldest)
+	}
+	return (newest - oldest) * float64(n-1) / (lineNewest - lineOldest)
+}
+
+// IsWarmedUp reports whether the market has enough price history and has
+// been tracked long enough for its analysis to be trusted.
+func (tm *TrackedMarket) IsWarmedUp(minSnapshots int, minAge time.Duration, now time.Time) bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	if minSnapshots > maxPriceSnapshots {
+		minSnapshots = maxPriceSnapshots
+	}
+	return len(tm.priceHistory) >= minSnapshots && now.Sub(tm.trackedAt) >= minAge
+}
+
+// pollDue reports whether the market's prices should be polled at now and,
+// if so, schedules the next poll interval later.
+func (tm *TrackedMarket) pollDue(now time.Time, interval time.Duration) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if now.Before(tm.nextPoll) {
+		return false
+	}
+	tm.nextPoll = now.Add(interval)
+	return true
+}
+
 // MarkSniped marks the market as already sniped to prevent duplicate trades.
 func (tm *TrackedMarket) MarkSniped() {
 	tm.mu.Lock()
@@ -165,6 +363,117 @@ func (tm *TrackedMarket) IsSniped() bool {
 	return tm.sniped
 }
 
+// SetPosition records the position opened by a snipe, stamping when it
+// was opened if the caller didn't.
+func (tm *TrackedMarket) SetPosition(pos *SnipePosition) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if pos.OpenedAt.IsZero() {
+		pos.OpenedAt = time.Now()
+	}
+	tm.position = pos
+}
+
+// OpenPosition returns a copy of the snipe position if one is still held.
+func (tm *TrackedMarket) OpenPosition() (SnipePosition, bool) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	if tm.position == nil || tm.position.Exited {
+		return SnipePosition{}, false
+	}
+	return *tm.position, true
+}
+
+// setMakerBid records a resting maker snipe bid.
+func (tm *TrackedMarket) setMakerBid(bid *makerBid) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.makerBid = bid
+}
+
+// takeMakerBid returns and forgets the resting maker bid, or nil if none.
+func (tm *TrackedMarket) takeMakerBid() *makerBid {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	bid := tm.makerBid
+	tm.makerBid = nil
+	return bid
+}
+
+// makerBidID returns the resting maker bid's order ID, or "" if none.
+func (tm *TrackedMarket) makerBidID() string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	if tm.makerBid == nil {
+		return ""
+	}
+	return tm.makerBid.orderID
+}
+
+// recordMakerFill adds shares matched on the resting maker bid orderID to
+// the snipe position, so the stop-loss watches them, and forgets the bid
+// once it has fully filled. ok is false when orderID isn't the bid.
+func (tm *TrackedMarket) recordMakerFill(orderID string, shares float64, now time.Time) (bid makerBid, ok bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.makerBid == nil || tm.makerBid.orderID != orderID {
+		return makerBid{}, false
+	}
+	tm.makerBid.filled += shares
+	if tm.position == nil || tm.position.Exited {
+		tm.position = &SnipePosition{
+			Side:       tm.makerBid.side,
+			TokenID:    tm.makerBid.tokenID,
+			Shares:     shares,
+			EntryPrice: tm.makerBid.price,
+			OpenedAt:   now,
+		}
+	} else if !tm.position.Exited {
+		tm.position.Shares += shares
+	}
+	bid = *tm.makerBid
+	if bid.filled >= bid.shares {
+		tm.makerBid = nil
+	}
+	return bid, true
+}
+
+// recordExitAttempt counts a stop-loss sell attempt at now and returns the
+// number made so far.
+func (tm *TrackedMarket) recordExitAttempt(now time.Time) int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.position == nil {
+		return 0
+	}
+	tm.position.ExitAttempts++
+	tm.position.LastExitAt = now
+	return tm.position.ExitAttempts
+}
+
+// markExited flags the snipe position as sold.
+func (tm *TrackedMarket) markExited() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.position != nil {
+		tm.position.Exited = true
+	}
+}
+
+// MarkClosed marks the market as closed for trading.
+func (tm *TrackedMarket) MarkClosed() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.closed = true
+}
+
+// IsClosed returns whether Gamma has reported the market as closed.
+func (tm *TrackedMarket) IsClosed() bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.closed
+}
+
 // TradeAnalysis contains the analysis results for a potential trade.
 type TradeAnalysis struct {
 	ShouldTrade     bool
@@ -218,26 +527,39 @@ func (ds *DailyStats) Reset() {
 
 // Sniper implements the sniping strategy for 15-minute up/down markets.
 type Sniper struct {
-	config   *config.Config
-	gamma    *gamma.Client
-	clob     *clob.Client
-	ws       *clob.WSClient
-	builder  *clob.OrderBuilder
-	telegram *telegram.Bot
-	binance  *pricefeed.BinanceClient // Real-time price feed
+	config     *config.Config
+	gamma      gamma.MarketSource
+	clob       clob.CLOBClient
+	ws         *clob.WSClient
+	userWS     *clob.WSClient // Our trades, for live maker snipe fills; nil otherwise
+	builder    *clob.OrderBuilder
+	telegram   *telegram.Bot
+	emptyScans *emptyScanWatchdog       // Alerts when scans keep finding no markets
+	gammaLimit *rateLimitBackoff        // Pauses scans after a Gamma rate limit
+	arming     *armGate                 // Holds live orders for LIVE_ARM_DELAY, nil when disabled
+	authStop   *authStop                // Halts Run once the CLOB rejects the API credentials
+	binance    *pricefeed.BinanceClient // Real-time price feed
+	recorder   *snapshotRecorder        // Writes price snapshots for sniper-replay, nil when disabled
 
 	activeMarkets map[string]*TrackedMarket
 	dailyStats    *DailyStats
 	mu            sync.RWMutex
 
+	// Session totals for the shutdown summary
+	startedAt time.Time
+	session   sessionCounter
+
 	// Configurable risk parameters
-	maxLossPerTrade float64
-	dailyLossLimit  float64
-	minLiquidity    float64
+	maxLossPerTrade    float64
+	dailyLossLimit     float64
+	minLiquidityShares float64 // Min shares at the ask, 0 = disabled
+	minLiquidityUSD    float64 // Min dollars (size * ask) at the ask, 0 = disabled
 
 	// Configurable strategy parameters
 	minConfidence  float64
 	maxUncertainty float64
+	makerEntry     bool // SNIPE_MODE=maker: rest a bid inside the spread instead of taking the ask
+	emaMomentum    bool // SNIPE_MOMENTUM_MODE=ema: smooth momentum instead of first-vs-last
 }
 
 // NewSniper creates a new Sniper instance.
@@ -250,8 +572,10 @@ func NewSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*Sniper,
 	}
 
 	gammaClient := gamma.NewClient()
-	clobClient := clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, w.AddressHex())
-	wsClient := clob.NewWSClient()
+	clobClient := clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, w.AddressHex()).
+		WithUTLS(cfg.CLOBUTLS).
+		WithOrderRetries(cfg.CLOBOrderRetries)
+	wsClient := clob.NewWSClient().WithURL(cfg.CLOBWSURL)
 	binanceClient := pricefeed.NewBinanceClient()
 
 	// Create order builder - use proxy wallet if configured
@@ -261,10 +585,61 @@ func NewSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*Sniper,
 	} else {
 		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
 	}
+	rounding, err := clob.ParseRoundingMode(cfg.OrderSizeRounding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_SIZE_ROUNDING: %w", err)
+	}
+	builder.WithTickSizes(clobClient).WithMinOrderSizes(clobClient).WithRoundingMode(rounding)
+	withSignatureCheck(cfg, clobClient, builder)
+	authStop := newAuthStop("sniper", tg)
+	authStop.watch(cfg, clobClient, w, builder)
+
+	makerEntry, err := parseSnipeMode(cfg.SnipeMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SNIPE_MODE: %w", err)
+	}
+	if _, err := parseMomentumMode(cfg.SnipeMomentumMode); err != nil {
+		return nil, fmt.Errorf("invalid SNIPE_MOMENTUM_MODE: %w", err)
+	}
+
+	recorder, err := newSnapshotRecorder(cfg.SnipeRecordFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sniper := newSniperCore(cfg)
+	sniper.gamma = gammaClient
+	sniper.clob = clobClient
+	sniper.ws = wsClient
+	sniper.builder = builder
+	sniper.telegram = tg
+	sniper.emptyScans = newEmptyScanWatchdog("sniper", cfg.EmptyScanAlertAfter, tg)
+	sniper.gammaLimit = newRateLimitBackoff("sniper")
+	sniper.arming = newArmGate("sniper", cfg)
+	sniper.authStop = authStop
+	sniper.binance = binanceClient
+	sniper.recorder = recorder
+	sniper.makerEntry = makerEntry
 
-	minLiq := cfg.MinLiquidity
-	if minLiq <= 0 {
-		minLiq = defaultMinLiquidity
+	// Register global WebSocket handler for price updates
+	wsClient.OnUpdate(sniper.handleMarketUpdate)
+
+	// Live maker bids learn of their fills from the user channel
+	if makerEntry && !cfg.DryRun {
+		sniper.userWS = clob.NewUserWSClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase).WithURL(cfg.CLOBWSURL)
+		sniper.userWS.OnTrade(sniper.handleUserTrade)
+	}
+
+	return sniper, nil
+}
+
+// newSniperCore builds a Sniper with the analysis and risk parameters
+// resolved from cfg but no API clients, enough to run analyzeMarket. Replay
+// uses it directly.
+func newSniperCore(cfg *config.Config) *Sniper {
+	minLiqShares, minLiqUSD := cfg.MinLiquidityShares, cfg.MinLiquidityUSD
+	if minLiqShares <= 0 && minLiqUSD <= 0 {
+		minLiqShares = defaultMinLiquidity
 	}
 
 	minConf := cfg.MinConfidence
@@ -277,27 +652,21 @@ func NewSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*Sniper,
 		maxUncert = maxUncertaintyGap
 	}
 
-	sniper := &Sniper{
-		config:          cfg,
-		gamma:           gammaClient,
-		clob:            clobClient,
-		ws:              wsClient,
-		builder:         builder,
-		telegram:        tg,
-		binance:         binanceClient,
-		activeMarkets:   make(map[string]*TrackedMarket),
-		dailyStats:      &DailyStats{Date: time.Now().Truncate(24 * time.Hour)},
-		maxLossPerTrade: defaultMaxLossPerTrade,
-		dailyLossLimit:  defaultDailyLossLimit,
-		minLiquidity:    minLiq,
-		minConfidence:   minConf,
-		maxUncertainty:  maxUncert,
-	}
+	// NewSniper rejects an invalid mode; replays read it as simple
+	emaMomentum, _ := parseMomentumMode(cfg.SnipeMomentumMode)
 
-	// Register global WebSocket handler for price updates
-	wsClient.OnUpdate(sniper.handleMarketUpdate)
-
-	return sniper, nil
+	return &Sniper{
+		config:             cfg,
+		activeMarkets:      make(map[string]*TrackedMarket),
+		dailyStats:         &DailyStats{Date: time.Now().Truncate(24 * time.Hour)},
+		maxLossPerTrade:    defaultMaxLossPerTrade,
+		dailyLossLimit:     defaultDailyLossLimit,
+		minLiquidityShares: minLiqShares,
+		minLiquidityUSD:    minLiqUSD,
+		minConfidence:      minConf,
+		maxUncertainty:     maxUncert,
+		emaMomentum:        emaMomentum,
+	}
 }
 
 // SetRiskLimits configures risk management parameters.
@@ -320,15 +689,36 @@ func (s *Sniper) handleMarketUpdate(update clob.MarketUpdate) {
 	}
 }
 
+// WithMarketSource replaces the Gamma client markets are fetched from
+// (useful for testing).
+func (s *Sniper) WithMarketSource(src gamma.MarketSource) *Sniper {
+	s.gamma = src
+	return s
+}
+
 // Run starts the sniper and blocks until the context is cancelled.
 func (s *Sniper) Run(ctx context.Context) error {
+	s.startedAt = time.Now()
+	ctx = s.authStop.bind(ctx)
 	log.Printf("[sniper] starting in %s mode", s.modeString())
 	log.Printf("[sniper] config: snipe_price=%.4f, trigger_seconds=%d, max_position=$%.2f",
-		s.config.SnipePrice, s.config.TriggerSeconds, s.config.MaxPositionSize)
+		s.config.SnipePrice, s.config.TriggerSeconds, s.config.SnipePositionSize())
 	log.Printf("[sniper] strategy: min_confidence=%.0f%%, max_uncertainty=%.0f%%",
 		s.minConfidence*100, s.maxUncertainty*100)
 	log.Printf("[sniper] risk: max_loss_per_trade=$%.2f, daily_limit=$%.2f",
 		s.maxLossPerTrade, s.dailyLossLimit)
+	if s.config.SnipeStopLossMomentum > 0 {
+		log.Printf("[sniper] exit: stop_loss_momentum=%.4f", s.config.SnipeStopLossMomentum)
+	}
+	if s.config.MaxSessionLoss > 0 {
+		log.Printf("[sniper] warning: MAX_SESSION_LOSS applies to weather and blackswan only, the sniper ignores it")
+	}
+
+	if err := checkLiveAllowance(ctx, "sniper", s.config, s.clob, s.builder, false); err != nil {
+		return err
+	}
+	syncLiveNonce("sniper", s.config, s.builder)
+	s.arming.start(ctx)
 
 	// Connect to WebSocket for real-time price updates
 	if err := s.ws.Connect(); err != nil {
@@ -341,6 +731,13 @@ func (s *Sniper) Run(ctx context.Context) error {
 			}
 		}()
 	}
+	if s.userWS != nil {
+		go func() {
+			if err := s.userWS.Run(ctx); err != nil {
+				log.Printf("[sniper] user WebSocket run error: %v", err)
+			}
+		}()
+	}
 
 	// Initial market scan
 	if err := s.ScanForMarkets(); err != nil {
@@ -350,11 +747,13 @@ func (s *Sniper) Run(ctx context.Context) error {
 	scanTicker := time.NewTicker(scanInterval)
 	checkTicker := time.NewTicker(checkInterval)
 	cleanupTicker := time.NewTicker(cleanupInterval)
+	closedTicker := time.NewTicker(closedCheckInterval)
 	statusTicker := time.NewTicker(60 * time.Second) // Log status every minute
 
 	defer scanTicker.Stop()
 	defer checkTicker.Stop()
 	defer cleanupTicker.Stop()
+	defer closedTicker.Stop()
 	defer statusTicker.Stop()
 
 	for {
@@ -364,7 +763,16 @@ func (s *Sniper) Run(ctx context.Context) error {
 			if err := s.ws.Close(); err != nil {
 				log.Printf("[sniper] ws close error: %v", err)
 			}
-			return ctx.Err()
+			if s.userWS != nil {
+				if err := s.userWS.Close(); err != nil {
+					log.Printf("[sniper] user ws close error: %v", err)
+				}
+			}
+			if err := s.recorder.close(); err != nil {
+				log.Printf("[sniper] snapshot recording close error: %v", err)
+			}
+			s.logSessionSummary()
+			return context.Cause(ctx)
 
 		case <-scanTicker.C:
 			if err := s.ScanForMarkets(); err != nil {
@@ -380,6 +788,9 @@ func (s *Sniper) Run(ctx context.Context) error {
 		case <-cleanupTicker.C:
 			s.cleanupExpiredMarkets()
 
+		case <-closedTicker.C:
+			s.removeClosedMarkets()
+
 		case <-statusTicker.C:
 			s.refreshAllGammaPrices()
 			s.logStatus()
@@ -399,13 +810,20 @@ func (s *Sniper) resetDailyStatsIfNeeded() {
 
 // ScanForMarkets discovers new 15-minute markets to track.
 func (s *Sniper) ScanForMarkets() error {
+	if s.gammaLimit.paused(time.Now()) {
+		return nil
+	}
 	markets, err := s.gamma.GetActiveUpDownMarkets()
+	s.emptyScans.observe(len(markets))
 	if err != nil {
+		s.gammaLimit.observe(err, time.Now())
 		return fmt.Errorf("failed to fetch markets: %w", err)
 	}
 
 	log.Printf("[sniper] found %d active up/down markets", len(markets))
 
+	now := time.Now()
+	tooNew := 0
 	for _, market := range markets {
 		s.mu.RLock()
 		_, exists := s.activeMarkets[market.Slug]
@@ -415,6 +833,13 @@ func (s *Sniper) ScanForMarkets() error {
 			continue
 		}
 
+		// A market that just opened may only have placeholder prices and no
+		// book; leave it for a later scan
+		if s.isTooNew(market, now) {
+			tooNew++
+			continue
+		}
+
 		tracked, err := s.trackMarket(market)
 		if err != nil {
 			log.Printf("[sniper] failed to track market %s: %v", market.Slug, err)
@@ -434,9 +859,24 @@ func (s *Sniper) ScanForMarkets() error {
 		}
 	}
 
+	if tooNew > 0 {
+		log.Printf("[sniper] skipped %d markets opened less than %ds ago", tooNew, s.config.SnipeMinMarketAge)
+	}
 	return nil
 }
 
+// isTooNew reports whether market opened less than SNIPE_MIN_MARKET_AGE_SECONDS
+// before now, going by the start time in its slug. Markets without one are
+// never too new.
+func (s *Sniper) isTooNew(market gamma.Market, now time.Time) bool {
+	minAge := time.Duration(s.config.SnipeMinMarketAge) * time.Second
+	if minAge <= 0 {
+		return false
+	}
+	start, ok := market.StartTimeFromSlug()
+	return ok && now.Sub(start) < minAge
+}
+
 // trackMarket creates a TrackedMarket and subscribes to price updates.
 func (s *Sniper) trackMarket(market gamma.Market) (*TrackedMarket, error) {
 	endTime, err := market.EndTime()
@@ -449,20 +889,15 @@ func (s *Sniper) trackMarket(market gamma.Market) (*TrackedMarket, error) {
 		return nil, fmt.Errorf("market already ended at %s", endTime.Format(time.RFC3339))
 	}
 
+	// Orders for empty or malformed token IDs would only fail at signing
+	if !market.HasValidTokens() {
+		return nil, fmt.Errorf("market missing valid YES/NO token IDs")
+	}
 	yesToken := market.GetYesToken()
 	noToken := market.GetNoToken()
 
-	if yesToken == nil || noToken == nil {
-		return nil, fmt.Errorf("market missing YES or NO token")
-	}
-
 	// Store Gamma's indicative prices (used for winner determination)
-	gammaPrices := market.ParseOutcomePrices()
-	gammaYes, gammaNo := 0.0, 0.0
-	if len(gammaPrices) >= 2 {
-		gammaYes = gammaPrices[0]
-		gammaNo = gammaPrices[1]
-	}
+	gammaYes, gammaNo, _ := market.YesNoPrices()
 
 	// Get Binance symbol and start price for real-time winner detection
 	binanceSymbol := pricefeed.SymbolFromMarketQuestion(market.Question)
@@ -474,20 +909,29 @@ func (s *Sniper) trackMarket(market gamma.Market) (*TrackedMarket, error) {
 		}
 	}
 
+	// Warm the tick size cache so the snipe itself needs no extra round trip
+	for _, tokenID := range []string{yesToken.TokenID, noToken.TokenID} {
+		if _, err := s.clob.GetTickSize(tokenID); err != nil {
+			log.Printf("[sniper] %s: tick size lookup failed, using default: %v", market.Slug, err)
+		}
+	}
+
 	tracked := &TrackedMarket{
 		Market:            market,
 		YesTokenID:        yesToken.TokenID,
 		NoTokenID:         noToken.TokenID,
 		EndTime:           endTime,
-		BestYesBid:        yesToken.Price,
-		BestYesAsk:        yesToken.Price,
-		BestNoBid:         noToken.Price,
-		BestNoAsk:         noToken.Price,
+		BestYesBid:        yesToken.Probability(),
+		BestYesAsk:        yesToken.Probability(),
+		BestNoBid:         noToken.Probability(),
+		BestNoAsk:         noToken.Probability(),
 		GammaYesPrice:     gammaYes,
 		GammaNoPrice:      gammaNo,
 		BinanceSymbol:     binanceSymbol,
 		BinanceStartPrice: binanceStartPrice,
-		priceHistory:      make([]PriceSnapshot, 0, 10),
+		priceHistory:      make([]PriceSnapshot, 0, maxPriceSnapshots),
+		trackedAt:         time.Now(),
+		recorder:          s.recorder,
 	}
 
 	// Subscribe to WebSocket price updates for both tokens
@@ -514,11 +958,14 @@ func (s *Sniper) refreshGammaPrices(tracked *TrackedMarket) {
 		return
 	}
 
-	prices := market.ParseOutcomePrices()
-	if len(prices) >= 2 {
+	if market.Closed {
+		tracked.MarkClosed()
+	}
+
+	if yes, no, ok := market.YesNoPrices(); ok {
 		tracked.mu.Lock()
-		tracked.GammaYesPrice = prices[0]
-		tracked.GammaNoPrice = prices[1]
+		tracked.GammaYesPrice = yes
+		tracked.GammaNoPrice = no
 		tracked.mu.Unlock()
 	}
 }
@@ -538,18 +985,112 @@ func (s *Sniper) refreshAllGammaPrices() {
 }
 
 // updateOrderBookPrices fetches current order book prices from REST API.
+// YES and NO books are fetched concurrently; updates go through the
+// TrackedMarket mutex.
 func (s *Sniper) updateOrderBookPrices(tracked *TrackedMarket) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+
 	// Fetch YES token order book
-	if yesBook, err := s.clob.GetOrderBook(tracked.YesTokenID); err == nil {
-		bid, ask, size := extractBestPricesWithSize(yesBook)
-		tracked.UpdateYesPrice(bid, ask, size)
-	}
+	go func() {
+		defer wg.Done()
+		if yesBook, err := s.clob.GetOrderBook(tracked.YesTokenID); err == nil {
+			bid, ask, size := extractBestPricesWithSize(yesBook)
+			tracked.UpdateYesPrice(bid, ask, size)
+		}
+	}()
 
 	// Fetch NO token order book
-	if noBook, err := s.clob.GetOrderBook(tracked.NoTokenID); err == nil {
-		bid, ask, size := extractBestPricesWithSize(noBook)
-		tracked.UpdateNoPrice(bid, ask, size)
+	go func() {
+		defer wg.Done()
+		if noBook, err := s.clob.GetOrderBook(tracked.NoTokenID); err == nil {
+			bid, ask, size := extractBestPricesWithSize(noBook)
+			tracked.UpdateNoPrice(bid, ask, size)
+		}
+	}()
+
+	wg.Wait()
+}
+
+// pollMarkets refreshes CLOB and Gamma prices for the given markets. Bid and
+// ask for every token come from one batch request; order books, which carry
+// the ask depth the liquidity gate needs, are only fetched for markets about
+// to enter the trigger window or missing from the batch response.
+func (s *Sniper) pollMarkets(markets []*TrackedMarket) {
+	if len(markets) == 0 {
+		return
 	}
+
+	quoted := s.refreshBatchPrices(markets)
+	depthWindow := time.Duration(s.config.TriggerSeconds)*time.Second + depthLeadTime
+
+	forEachMarket(markets, maxConcurrentPolls, func(tracked *TrackedMarket) {
+		if !quoted[tracked] || time.Until(tracked.EndTime) <= depthWindow {
+			s.updateOrderBookPrices(tracked)
+		}
+		s.refreshGammaPrices(tracked)
+	})
+}
+
+// refreshBatchPrices updates bid and ask for all markets with a single CLOB
+// request and reports which markets were updated. On failure none are.
+func (s *Sniper) refreshBatchPrices(markets []*TrackedMarket) map[*TrackedMarket]bool {
+	tokenIDs := make([]string, 0, 2*len(markets))
+	for _, tracked := range markets {
+		tokenIDs = append(tokenIDs, tracked.YesTokenID, tracked.NoTokenID)
+	}
+
+	prices, err := s.clob.GetPrices(tokenIDs)
+	if err != nil {
+		log.Printf("[sniper] batch price fetch failed, polling order books: %v", err)
+		return nil
+	}
+
+	quoted := make(map[*TrackedMarket]bool, len(markets))
+	for _, tracked := range markets {
+		yes, okYes := prices[tracked.YesTokenID]
+		no, okNo := prices[tracked.NoTokenID]
+		if okYes && okNo {
+			tracked.UpdateQuotes(yes, no)
+			quoted[tracked] = true
+		}
+	}
+	return quoted
+}
+
+// forEachMarket runs fn for every market using at most workers goroutines
+// and blocks until all calls have returned.
+func forEachMarket(markets []*TrackedMarket, workers int, fn func(*TrackedMarket)) {
+	forEachBounded(len(markets), workers, func(i int) { fn(markets[i]) })
+}
+
+// forEachBounded calls fn for every index in [0, n) on at most limit
+// goroutines and returns when all calls are done. fn should write its result
+// to its own slot so no further locking is needed.
+func forEachBounded(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > n {
+		limit = n
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
 }
 
 // extractBestPricesWithSize gets the best bid, ask, and ask size from an order book.
@@ -581,18 +1122,44 @@ func (s *Sniper) CheckAndSnipe() error {
 	}
 	s.mu.RUnlock()
 
+	// Poll prices via REST (since WebSocket may not be connected), more
+	// often the closer each market is to expiry
+	// Sniped markets with an open position keep being polled for stop-loss
+	toPoll := make([]*TrackedMarket, 0, len(markets))
+	for _, tracked := range markets {
+		if tracked.IsClosed() {
+			continue
+		}
+		if tracked.IsSniped() && !s.monitorsExit(tracked) {
+			continue
+		}
+		timeRemaining := tracked.EndTime.Sub(now)
+		if timeRemaining > 0 && tracked.pollDue(now, s.pollInterval(timeRemaining)) {
+			toPoll = append(toPoll, tracked)
+		}
+	}
+	s.pollMarkets(toPoll)
+
 	for _, tracked := range markets {
+		s.cancelExpiredMakerBid(tracked, now)
+
+		if tracked.IsClosed() {
+			continue
+		}
+
 		if tracked.IsSniped() {
+			if s.monitorsExit(tracked) && tracked.EndTime.After(now) {
+				s.checkStopLoss(tracked)
+			}
 			continue
 		}
 
 		timeRemaining := tracked.EndTime.Sub(now)
 
-		// Poll prices via REST (since WebSocket may not be connected)
-		// Only poll when getting close to snipe window (within 30s)
-		if timeRemaining <= 30*time.Second && timeRemaining > 0 {
-			s.updateOrderBookPrices(tracked)
-			s.refreshGammaPrices(tracked)
+		// A market still at a coin flip this late won't pass analysis;
+		// stop polling and evaluating it now
+		if s.givesUpCoinFlip(tracked, timeRemaining) {
+			continue
 		}
 
 		// Skip if not within snipe window yet
@@ -605,8 +1172,22 @@ func (s *Sniper) CheckAndSnipe() error {
 			continue
 		}
 
+		// Too close to expiry for an order to land before the market closes
+		if s.pastCutoff(tracked, timeRemaining) {
+			continue
+		}
+
+		// Freshly discovered markets have no momentum history yet; wait
+		// rather than trade on a single stale snapshot
+		if !s.isWarmedUp(tracked, now) {
+			continue
+		}
+
 		// Analyze and execute snipe
 		analysis := s.analyzeMarket(tracked)
+		if analysis.ShouldTrade {
+			analysis = s.ensureFreshPrices(tracked, analysis, time.Now())
+		}
 		s.logAnalysis(tracked, analysis, timeRemaining)
 
 		if !analysis.ShouldTrade {
@@ -614,6 +1195,12 @@ func (s *Sniper) CheckAndSnipe() error {
 			continue
 		}
 
+		// Analysis and a price refresh take time; check the cutoff again
+		timeRemaining = time.Until(tracked.EndTime)
+		if s.pastCutoff(tracked, timeRemaining) {
+			continue
+		}
+
 		if err := s.executeSnipe(tracked, analysis, timeRemaining); err != nil {
 			log.Printf("[sniper] snipe error for %s: %v", tracked.Market.Question, err)
 		}
@@ -626,7 +1213,7 @@ func (s *Sniper) CheckAndSnipe() error {
 func (s *Sniper) analyzeMarket(tracked *TrackedMarket) TradeAnalysis {
 	yesBid, yesAsk, noBid, noAsk := tracked.GetPrices()
 	yesSize, noSize := tracked.GetSizes()
-	momentum := tracked.GetMomentum()
+	momentum := s.momentum(tracked)
 
 	// Get Gamma's indicative prices
 	tracked.mu.RLock()
@@ -735,9 +1322,9 @@ func (s *Sniper) analyzeMarket(tracked *TrackedMarket) TradeAnalysis {
 
 	// Check 4: Sufficient liquidity at ask
 	analysis.AvailableSize = winnerSize
-	if winnerSize < s.minLiquidity {
+	if reason := liquidityShortfall(winnerSize, winnerAsk, s.minLiquidityShares, s.minLiquidityUSD); reason != "" {
 		analysis.SkipReason = SkipReasonNoLiquidity
-		analysis.SkipDescription = fmt.Sprintf("size $%.2f < min $%.2f", winnerSize, s.minLiquidity)
+		analysis.SkipDescription = reason
 		return analysis
 	}
 
@@ -780,14 +1367,34 @@ func (s *Sniper) analyzeMarket(tracked *TrackedMarket) TradeAnalysis {
 		}
 	}
 
-	// Expected profit if we win: ($1.00 - entry) * shares
-	sharesCount := positionSize / analysis.EntryPrice
-	analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * sharesCount
+	// Expected profit if we win: ($1.00 - entry) * shares, for the share
+	// count the order is submitted with (MaxLoss, see executeSnipe)
+	analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * analysis.MaxLoss
+
+	// Check 8: Worth the fees and risk in absolute terms
+	if analysis.ExpectedProfit < s.config.MinExpectedProfit {
+		analysis.SkipReason = SkipReasonLowProfit
+		analysis.SkipDescription = fmt.Sprintf("expected profit $%.2f < min $%.2f", analysis.ExpectedProfit, s.config.MinExpectedProfit)
+		return analysis
+	}
 
 	analysis.ShouldTrade = true
 	return analysis
 }
 
+// liquidityShortfall checks the size available at the ask against the share
+// and dollar minimums (0 disables either) and describes the first one missed.
+// It returns "" when there is enough liquidity.
+func liquidityShortfall(size, ask, minShares, minUSD float64) string {
+	if minShares > 0 && size < minShares {
+		return fmt.Sprintf("size %.2f shares < min %.2f shares", size, minShares)
+	}
+	if minUSD > 0 && size*ask < minUSD {
+		return fmt.Sprintf("size $%.2f (%.2f @ %.4f) < min $%.2f", size*ask, size, ask, minUSD)
+	}
+	return ""
+}
+
 // calculateConfidence computes a 0-1 confidence score based on multiple factors.
 func calculateConfidence(winnerBid, bidGap, spreadPercent, momentum float64, isYes bool) float64 {
 	// Base confidence from bid price (0.65 bid = 0.65 confidence)
@@ -835,7 +1442,7 @@ func (s *Sniper) calculatePositionSize(confidence float64, availableSize float64
 		confidenceScale = 1.0
 	}
 
-	targetSize := s.config.MaxPositionSize * confidenceScale
+	targetSize := s.config.SnipePositionSize() * confidenceScale
 
 	// Don't exceed available liquidity
 	if targetSize > availableSize*0.8 { // Take max 80% of book
@@ -869,6 +1476,18 @@ func (s *Sniper) logAnalysis(tracked *TrackedMarket, analysis TradeAnalysis, tim
 
 // executeSnipe executes the trade based on analysis.
 func (s *Sniper) executeSnipe(tracked *TrackedMarket, analysis TradeAnalysis, _ time.Duration) error {
+	if err := s.arming.check(); err != nil {
+		log.Printf("[sniper] NOT ARMED: would buy %s at %.4f", analysis.Side, analysis.EntryPrice)
+		return err
+	}
+
+	s.session.recordPlaced()
+
+	// Maker bids count toward the daily loss only once they fill
+	if s.makerEntry {
+		return s.placeMakerSnipe(tracked, analysis)
+	}
+
 	// Record potential loss for daily tracking
 	s.dailyStats.AddLoss(analysis.MaxLoss)
 
@@ -889,7 +1508,14 @@ func (s *Sniper) executeSnipe(tracked *TrackedMarket, analysis TradeAnalysis, _
 			}
 		}
 
+		tracked.SetPosition(&SnipePosition{
+			Side:       analysis.Side,
+			TokenID:    analysis.TokenID,
+			Shares:     analysis.MaxLoss, // Same size the live path submits
+			EntryPrice: analysis.EntryPrice,
+		})
 		tracked.MarkSniped()
+		s.session.recordFill(analysis.MaxLoss*analysis.EntryPrice, analysis.ExpectedProfit)
 		return nil
 	}
 
@@ -902,7 +1528,10 @@ func (s *Sniper) executeSnipe(tracked *TrackedMarket, analysis TradeAnalysis, _
 		return fmt.Errorf("failed to build order: %w", err)
 	}
 
-	resp, err := s.clob.CreateOrder(orderReq)
+	ctx, cancel := s.orderContext(tracked)
+	defer cancel()
+
+	resp, err := s.clob.CreateOrderCtx(ctx, orderReq)
 	if err != nil {
 		return fmt.Errorf("failed to submit order: %w", err)
 	}
@@ -913,6 +1542,7 @@ func (s *Sniper) executeSnipe(tracked *TrackedMarket, analysis TradeAnalysis, _
 
 	log.Printf("[sniper] ORDER FILLED: %s at %.4f (order ID: %s)", analysis.Side, analysis.EntryPrice, resp.OrderID)
 	log.Printf("[sniper]   actual_cost:$%.2f expected_profit:$%.2f", analysis.MaxLoss, analysis.ExpectedProfit)
+	s.session.recordFill(analysis.MaxLoss*analysis.EntryPrice, analysis.ExpectedProfit)
 
 	if s.telegram != nil {
 		if err := s.telegram.NotifyOrderExecuted(analysis.Side, analysis.EntryPrice, size, analysis.ExpectedProfit); err != nil {
@@ -920,32 +1550,531 @@ func (s *Sniper) executeSnipe(tracked *TrackedMarket, analysis TradeAnalysis, _
 		}
 	}
 
+	// BuildOrder treats size as the share count
+	tracked.SetPosition(&SnipePosition{
+		Side:       analysis.Side,
+		TokenID:    analysis.TokenID,
+		Shares:     size,
+		EntryPrice: analysis.EntryPrice,
+	})
 	tracked.MarkSniped()
 	return nil
 }
 
+// placeMakerSnipe rests a GTC bid one tick inside the ask instead of taking
+// it, trading fill certainty for a better price and no taker fee. The bid
+// stays up until the market ends or is dropped, and is then settled by
+// settleMakerBid. Live fills reported on the user channel open the
+// stop-loss position as they happen (handleUserTrade); a dry run assumes
+// the paper fill.
+func (s *Sniper) placeMakerSnipe(tracked *TrackedMarket, analysis TradeAnalysis) error {
+	price, err := s.makerBidPrice(analysis.TokenID, analysis.EntryPrice)
+	if err != nil {
+		return err
+	}
+	size := analysis.MaxLoss // Share count, as in the taker path
+
+	if s.config.DryRun {
+		log.Printf("[sniper] DRY_RUN: WOULD BID %s at %.4f inside ask %.4f (confidence: %.2f%%)",
+			analysis.Side, price, analysis.EntryPrice, analysis.Confidence*100)
+
+		tracked.SetPosition(&SnipePosition{
+			Side:       analysis.Side,
+			TokenID:    analysis.TokenID,
+			Shares:     size,
+			EntryPrice: price,
+		})
+		tracked.MarkSniped()
+		s.dailyStats.AddLoss(analysis.MaxLoss)
+		s.session.recordFill(size*price, analysis.ExpectedProfit)
+		return nil
+	}
+
+	negRisk, err := s.clob.GetNegRisk(analysis.TokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get neg risk: %w", err)
+	}
+
+	orderReq, err := s.builder.BuildGTCBuyOrder(analysis.TokenID, price, size, negRisk)
+	if err != nil {
+		return fmt.Errorf("failed to build order: %w", err)
+	}
+
+	ctx, cancel := s.orderContext(tracked)
+	defer cancel()
+
+	resp, err := s.clob.CreateOrderCtx(ctx, orderReq)
+	if err != nil {
+		return fmt.Errorf("failed to submit order: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("order rejected: %s", resp.Error)
+	}
+
+	log.Printf("[sniper] MAKER BID RESTING: %s %.2f shares at %.4f, ask %.4f (order ID: %s)",
+		analysis.Side, size, price, analysis.EntryPrice, resp.OrderID)
+
+	tracked.setMakerBid(&makerBid{
+		orderID:        resp.OrderID,
+		side:           analysis.Side,
+		tokenID:        analysis.TokenID,
+		price:          price,
+		shares:         size,
+		cost:           size * price,
+		maxLoss:        analysis.MaxLoss,
+		expectedProfit: analysis.ExpectedProfit,
+	})
+	tracked.MarkSniped()
+	return nil
+}
+
+// makerBidPrice returns the price one tick below ask. The best bid is at
+// most that, so the order improves or joins the bid without crossing.
+func (s *Sniper) makerBidPrice(tokenID string, ask float64) (float64, error) {
+	tick, err := s.clob.GetTickSize(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tick size: %w", err)
+	}
+	price := pricing.RoundToTick(ask-tick, tick)
+	if price < tick {
+		return 0, fmt.Errorf("ask %.4f leaves no room for a maker bid", ask)
+	}
+	return price, nil
+}
+
+// cancelExpiredMakerBid settles a resting maker snipe once its market ends.
+func (s *Sniper) cancelExpiredMakerBid(tracked *TrackedMarket, now time.Time) {
+	if now.Before(tracked.EndTime) {
+		return
+	}
+	s.settleMakerBid(tracked)
+}
+
+// handleUserTrade records user channel fills of resting maker bids. A
+// trade is resent as its status advances, so only the MATCHED event counts.
+func (s *Sniper) handleUserTrade(trade clob.TradeUpdate) {
+	if trade.Status != "MATCHED" {
+		return
+	}
+	for _, tracked := range s.GetActiveMarkets() {
+		orderID := tracked.makerBidID()
+		shares := trade.MatchedShares(orderID)
+		if shares <= 0 {
+			continue
+		}
+		bid, ok := tracked.recordMakerFill(orderID, shares, time.Now())
+		if !ok {
+			continue
+		}
+		log.Printf("[sniper] MAKER BID FILL: %s matched %.2f shares (%.2f of %.2f) for %s",
+			orderID, shares, bid.filled, bid.shares, tracked.Market.Question)
+		if bid.filled >= bid.shares {
+			s.chargeMakerFill(tracked, bid)
+		}
+	}
+}
+
+// chargeMakerFill charges the filled part of a maker bid's max loss to the
+// daily loss, and its cost to the session totals.
+func (s *Sniper) chargeMakerFill(tracked *TrackedMarket, bid makerBid) {
+	fraction := math.Min(bid.filled/bid.shares, 1)
+	log.Printf("[sniper] MAKER BID FILLED: %s for %s (%.2f shares, cost $%.2f)",
+		bid.orderID, tracked.Market.Question, bid.filled, bid.cost*fraction)
+	s.dailyStats.AddLoss(bid.maxLoss * fraction)
+	s.session.recordFill(bid.cost*fraction, bid.expectedProfit*fraction)
+}
+
+// settleMakerBid cancels a maker snipe bid still resting on the book. One
+// gone from the open orders filled, and only then is its max loss charged to
+// the daily loss. When the open orders can't be fetched the bid is canceled
+// anyway, and a failed cancel is only logged, since the fill is unconfirmed.
+// A bid the user channel saw part-fill has the rest canceled and the filled
+// part charged.
+func (s *Sniper) settleMakerBid(tracked *TrackedMarket) {
+	bid := tracked.takeMakerBid()
+	if bid == nil {
+		return
+	}
+
+	if bid.filled > 0 {
+		if err := s.clob.CancelOrder(bid.orderID); err != nil {
+			log.Printf("[sniper] failed to cancel rest of maker bid %s for %s: %v",
+				bid.orderID, tracked.Market.Question, err)
+		}
+		s.chargeMakerFill(tracked, *bid)
+		return
+	}
+
+	open, err := s.isOrderOpen(bid.orderID)
+	if err == nil && !open {
+		log.Printf("[sniper] MAKER BID FILLED: %s for %s (cost $%.2f)",
+			bid.orderID, tracked.Market.Question, bid.cost)
+		s.dailyStats.AddLoss(bid.maxLoss)
+		s.session.recordFill(bid.cost, bid.expectedProfit)
+		return
+	}
+	if err != nil {
+		log.Printf("[sniper] failed to check maker bid %s for %s: %v", bid.orderID, tracked.Market.Question, err)
+	}
+
+	if err := s.clob.CancelOrder(bid.orderID); err != nil {
+		log.Printf("[sniper] failed to cancel maker bid %s for %s (may have filled): %v",
+			bid.orderID, tracked.Market.Question, err)
+		return
+	}
+	log.Printf("[sniper] canceled unfilled maker bid %s for %s", bid.orderID, tracked.Market.Question)
+}
+
+// isOrderOpen reports whether orderID is still among the open orders.
+func (s *Sniper) isOrderOpen(orderID string) (bool, error) {
+	orders, err := s.clob.GetOpenOrders()
+	if err != nil {
+		return false, err
+	}
+	for i := range orders {
+		if orders[i].GetID() == orderID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseSnipeMode parses SNIPE_MODE, reporting whether it selects maker
+// entries. Empty means taker.
+func parseSnipeMode(mode string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "taker":
+		return false, nil
+	case "maker":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid snipe mode %q: must be taker or maker", mode)
+	}
+}
+
+// parseMomentumMode parses SNIPE_MOMENTUM_MODE, reporting whether it
+// selects EMA momentum. Empty means simple.
+func parseMomentumMode(mode string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "simple":
+		return false, nil
+	case "ema":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid momentum mode %q: must be simple or ema", mode)
+	}
+}
+
+// momentum returns tracked's YES momentum as SNIPE_MOMENTUM_MODE measures it.
+func (s *Sniper) momentum(tracked *TrackedMarket) float64 {
+	if s.emaMomentum {
+		return tracked.GetEMAMomentum()
+	}
+	return tracked.GetMomentum()
+}
+
+// pollInterval returns how long to wait between price polls for a market
+// with remaining time left.
+func (s *Sniper) pollInterval(remaining time.Duration) time.Duration {
+	switch {
+	case remaining <= pollFinalWindow:
+		return msOrDefault(s.config.SnipePollFinalMs, defaultPollFinal)
+	case remaining <= pollNearWindow:
+		return msOrDefault(s.config.SnipePollNearMs, defaultPollNear)
+	default:
+		return msOrDefault(s.config.SnipePollIdleMs, defaultPollIdle)
+	}
+}
+
+// msOrDefault converts a millisecond setting, falling back to def when it's
+// unset.
+func msOrDefault(ms int, def time.Duration) time.Duration {
+	if ms <= 0 {
+		return def
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// isWarmedUp applies the configured warmup gate to a tracked market.
+func (s *Sniper) isWarmedUp(tracked *TrackedMarket, now time.Time) bool {
+	minAge := time.Duration(s.config.SnipeWarmupSeconds) * time.Second
+	if tracked.IsWarmedUp(s.config.SnipeWarmupSnapshots, minAge, now) {
+		return true
+	}
+	log.Printf("[sniper] %s: still warming up, skipping", tracked.Market.Question)
+	return false
+}
+
+// givesUpCoinFlip marks a market sniped, logging it once, when its Gamma
+// prices are within the uncertainty gap with SNIPE_COIN_FLIP_SECONDS (or
+// the trigger window) left. analyzeMarket would skip it as too uncertain
+// anyway; catching it here spares the warmup wait and every poll after.
+// Markets without Gamma prices yet are left to analysis.
+func (s *Sniper) givesUpCoinFlip(tracked *TrackedMarket, timeRemaining time.Duration) bool {
+	window := time.Duration(s.config.SnipeCoinFlipSeconds * float64(time.Second))
+	if window <= 0 {
+		window = time.Duration(s.config.TriggerSeconds) * time.Second
+	}
+	if timeRemaining < 0 || timeRemaining > window {
+		return false
+	}
+
+	tracked.mu.RLock()
+	gammaYes, gammaNo := tracked.GammaYesPrice, tracked.GammaNoPrice
+	tracked.mu.RUnlock()
+	if gammaYes <= 0 || gammaNo <= 0 || math.Abs(gammaYes-gammaNo) >= s.maxUncertainty {
+		return false
+	}
+
+	log.Printf("[sniper] SKIP %s: %s - coin flip with %v left (UP:%.4f DOWN:%.4f, gap < %.4f), giving up",
+		tracked.Market.Question, SkipReasonTooUncertain, timeRemaining.Truncate(time.Millisecond),
+		gammaYes, gammaNo, s.maxUncertainty)
+	tracked.MarkSniped()
+	return true
+}
+
+// pastCutoff reports whether timeRemaining is inside SNIPE_CUTOFF_SECONDS,
+// marking the market sniped so it isn't tried again.
+func (s *Sniper) pastCutoff(tracked *TrackedMarket, timeRemaining time.Duration) bool {
+	cutoff := time.Duration(s.config.SnipeCutoffSeconds * float64(time.Second))
+	if cutoff <= 0 || timeRemaining >= cutoff {
+		return false
+	}
+	log.Printf("[sniper] %s: %v to expiry is inside the %v cutoff, skipping",
+		tracked.Market.Question, timeRemaining.Truncate(time.Millisecond), cutoff)
+	tracked.MarkSniped()
+	return true
+}
+
+// ensureFreshPrices checks the winning token's price against
+// SNIPE_MAX_PRICE_AGE_MS before a snipe executes. A stale price is refreshed
+// from the order book and the market analyzed again; if the refresh fails the
+// trade is skipped rather than sent as an FOK at a price that may have moved.
+func (s *Sniper) ensureFreshPrices(tracked *TrackedMarket, analysis TradeAnalysis, now time.Time) TradeAnalysis {
+	maxAge := time.Duration(s.config.SnipeMaxPriceAgeMs) * time.Millisecond
+	if maxAge <= 0 {
+		return analysis
+	}
+	age := tracked.PriceAge(analysis.TokenID, now)
+	if age <= maxAge {
+		return analysis
+	}
+
+	log.Printf("[sniper] %s: %s price is stale (%v old), refreshing order books",
+		tracked.Market.Question, analysis.Side, age.Truncate(time.Millisecond))
+	s.updateOrderBookPrices(tracked)
+	if tracked.PriceAge(analysis.TokenID, time.Now()) > maxAge {
+		return TradeAnalysis{
+			Side:            analysis.Side,
+			TokenID:         analysis.TokenID,
+			Momentum:        analysis.Momentum,
+			SkipReason:      SkipReasonStalePrices,
+			SkipDescription: fmt.Sprintf("%s order book refresh failed, price older than %v", analysis.Side, maxAge),
+		}
+	}
+	return s.analyzeMarket(tracked)
+}
+
+// orderContext bounds an order submission by the configured timeout and by
+// the market end, so a hung request never blocks past expiry.
+func (s *Sniper) orderContext(tracked *TrackedMarket) (context.Context, context.CancelFunc) {
+	timeout := defaultOrderTimeout
+	if s.config.SnipeOrderTimeoutMs > 0 {
+		timeout = time.Duration(s.config.SnipeOrderTimeoutMs) * time.Millisecond
+	}
+
+	deadline := time.Now().Add(timeout)
+	if tracked.EndTime.Before(deadline) {
+		deadline = tracked.EndTime
+	}
+
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+// monitorsExit reports whether a sniped market still needs stop-loss monitoring.
+func (s *Sniper) monitorsExit(tracked *TrackedMarket) bool {
+	if s.config.SnipeStopLossMomentum <= 0 {
+		return false
+	}
+	_, ok := tracked.OpenPosition()
+	return ok
+}
+
+// checkStopLoss exits a snipe when momentum since its entry reverses against
+// the held side by more than SNIPE_STOP_LOSS_MOMENTUM. A rejected sell is retried after
+// stopLossRetryDelay, up to stopLossMaxAttempts times.
+func (s *Sniper) checkStopLoss(tracked *TrackedMarket) {
+	pos, ok := tracked.OpenPosition()
+	if !ok {
+		return
+	}
+
+	// Only moves since the fill count, not the ones that triggered the snipe
+	momentum := tracked.MomentumSince(pos.OpenedAt, s.emaMomentum)
+	threshold := s.config.SnipeStopLossMomentum
+
+	reversed := (pos.Side == "UP" && momentum <= -threshold) ||
+		(pos.Side == "DOWN" && momentum >= threshold)
+	if !reversed {
+		return
+	}
+
+	// Failed sells back off instead of resending on every poll tick
+	now := time.Now()
+	if pos.ExitAttempts >= stopLossMaxAttempts || now.Before(pos.LastExitAt.Add(stopLossRetryDelay)) {
+		return
+	}
+
+	log.Printf("[sniper] STOP LOSS %s: %s momentum %.4f reversed past %.4f",
+		tracked.Market.Question, pos.Side, momentum, threshold)
+
+	attempts := tracked.recordExitAttempt(now)
+	if err := s.exitSnipe(tracked, pos.Side); err != nil {
+		if attempts >= stopLossMaxAttempts {
+			log.Printf("[sniper] giving up on stop loss for %s after %d attempts: %v", tracked.Market.Question, attempts, err)
+			return
+		}
+		log.Printf("[sniper] exit error for %s (attempt %d, retrying in %v): %v",
+			tracked.Market.Question, attempts, stopLossRetryDelay, err)
+	}
+}
+
+// exitSnipe dumps a sniped position with an FOK sell at the current best bid.
+func (s *Sniper) exitSnipe(tracked *TrackedMarket, side string) error {
+	pos, ok := tracked.OpenPosition()
+	if !ok || pos.Side != side {
+		return fmt.Errorf("no open %s position", side)
+	}
+
+	yesBid, _, noBid, _ := tracked.GetPrices()
+	bid := yesBid
+	if side == "DOWN" {
+		bid = noBid
+	}
+	if bid <= 0 {
+		return fmt.Errorf("no bid to sell %s into", side)
+	}
+
+	loss := (pos.EntryPrice - bid) * pos.Shares
+
+	if s.config.DryRun {
+		log.Printf("[sniper] DRY_RUN: WOULD SELL %.2f %s at %.4f (entry %.4f, est. loss $%.2f)",
+			pos.Shares, side, bid, pos.EntryPrice, loss)
+		tracked.markExited()
+		return nil
+	}
+
+	orderReq, err := s.builder.BuildFOKSellOrder(pos.TokenID, bid, pos.Shares)
+	if err != nil {
+		return fmt.Errorf("failed to build sell order: %w", err)
+	}
+
+	ctx, cancel := s.orderContext(tracked)
+	defer cancel()
+
+	resp, err := s.clob.CreateOrderCtx(ctx, orderReq)
+	if err != nil {
+		return fmt.Errorf("failed to submit sell order: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("sell order rejected: %s", resp.Error)
+	}
+
+	tracked.markExited()
+
+	log.Printf("[sniper] EXITED %s: sold %.2f at %.4f (order ID: %s, est. loss $%.2f)",
+		side, pos.Shares, bid, resp.OrderID, loss)
+
+	if s.telegram != nil {
+		msg := fmt.Sprintf("Stop loss: sold %s at %.4f\n"+
+			"Market: %s\n"+
+			"Entry: %.4f\n"+
+			"Est. Loss: $%.2f",
+			side, bid, tracked.Market.Question, pos.EntryPrice, loss)
+		if err := s.telegram.SendCritical(msg); err != nil {
+			log.Printf("[sniper] telegram error: %v", err)
+		}
+	}
+
+	return nil
+}
+
 // cleanupExpiredMarkets removes markets that have ended from tracking.
 func (s *Sniper) cleanupExpiredMarkets() {
 	now := time.Now()
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
+	var removed []*TrackedMarket
 	for slug, tracked := range s.activeMarkets {
 		// Remove markets that ended more than 1 minute ago
 		if now.Sub(tracked.EndTime) > 1*time.Minute {
-			// Unsubscribe from WebSocket
-			if err := s.ws.Unsubscribe(tracked.YesTokenID); err != nil {
-				log.Printf("[sniper] unsubscribe error: %v", err)
-			}
-			if err := s.ws.Unsubscribe(tracked.NoTokenID); err != nil {
-				log.Printf("[sniper] unsubscribe error: %v", err)
-			}
-
-			delete(s.activeMarkets, slug)
+			s.untrackMarket(slug, tracked)
+			removed = append(removed, tracked)
 			log.Printf("[sniper] cleaned up expired market: %s", tracked.Market.Question)
 		}
 	}
+	s.mu.Unlock()
+
+	s.settleMakerBids(removed)
+}
+
+// removeClosedMarkets re-fetches every tracked market from Gamma and drops
+// the ones reporting Closed, regardless of their nominal end time.
+func (s *Sniper) removeClosedMarkets() {
+	s.mu.RLock()
+	markets := make([]*TrackedMarket, 0, len(s.activeMarkets))
+	for _, m := range s.activeMarkets {
+		markets = append(markets, m)
+	}
+	s.mu.RUnlock()
+
+	forEachMarket(markets, maxConcurrentPolls, func(tracked *TrackedMarket) {
+		if tracked.IsClosed() {
+			return
+		}
+		market, err := s.gamma.GetMarketBySlug(tracked.Market.Slug)
+		if err != nil {
+			return
+		}
+		if market.Closed {
+			tracked.MarkClosed()
+		}
+	})
+
+	s.mu.Lock()
+	var removed []*TrackedMarket
+	for slug, tracked := range s.activeMarkets {
+		if tracked.IsClosed() {
+			s.untrackMarket(slug, tracked)
+			removed = append(removed, tracked)
+			log.Printf("[sniper] removed closed market: %s", tracked.Market.Question)
+		}
+	}
+	s.mu.Unlock()
+
+	s.settleMakerBids(removed)
+}
+
+// settleMakerBids settles the resting maker bids of markets no longer
+// tracked, which CheckAndSnipe would otherwise never revisit.
+func (s *Sniper) settleMakerBids(markets []*TrackedMarket) {
+	for _, tracked := range markets {
+		s.settleMakerBid(tracked)
+	}
+}
+
+// untrackMarket unsubscribes a market's tokens and removes it from tracking.
+// Must be called with s.mu held.
+func (s *Sniper) untrackMarket(slug string, tracked *TrackedMarket) {
+	if err := s.ws.Unsubscribe(tracked.YesTokenID); err != nil {
+		log.Printf("[sniper] unsubscribe error: %v", err)
+	}
+	if err := s.ws.Unsubscribe(tracked.NoTokenID); err != nil {
+		log.Printf("[sniper] unsubscribe error: %v", err)
+	}
+
+	delete(s.activeMarkets, slug)
 }
 
 // modeString returns "LIVE" or "DRY_RUN" based on config.
@@ -993,6 +2122,41 @@ func (s *Sniper) GetStats() Stats {
 	}
 }
 
+// logSessionSummary reports the session's trading recap on shutdown.
+func (s *Sniper) logSessionSummary() {
+	summary := s.session.summary("Sniper", s.modeString(), s.startedAt)
+
+	// Positions in markets still tracked are the ones awaiting resolution
+	s.mu.RLock()
+	var exposure float64
+	var estimates []OutcomeEstimate
+	for _, tracked := range s.activeMarkets {
+		if pos, ok := tracked.OpenPosition(); ok {
+			exposure += pos.Shares * pos.EntryPrice
+			estimates = append(estimates, s.EstimateOutcome(pos))
+		}
+	}
+	s.mu.RUnlock()
+	summary.Exposure = exposure
+	if s.config.DryRun {
+		summary.Notes = append(summary.Notes, estimatePnL(0, estimates).String())
+	}
+
+	s.dailyStats.mu.RLock()
+	summary.Notes = append(summary.Notes,
+		fmt.Sprintf("Daily loss: $%.2f", s.dailyStats.TotalLoss),
+		fmt.Sprintf("Daily trades: %d", s.dailyStats.TradeCount))
+	s.dailyStats.mu.RUnlock()
+
+	reportSessionSummary("sniper", s.telegram, summary)
+}
+
+// EstimateOutcome assumes an open snipe's favorite wins, as the sniper only
+// buys sides it expects to.
+func (s *Sniper) EstimateOutcome(pos SnipePosition) OutcomeEstimate {
+	return OutcomeEstimate{Shares: pos.Shares, Cost: pos.Shares * pos.EntryPrice, WinProb: 1}
+}
+
 // logStatus logs the current status of tracked markets.
 func (s *Sniper) logStatus() {
 	s.mu.RLock()
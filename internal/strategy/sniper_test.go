@@ -0,0 +1,1184 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/wallet"
+)
+
+const bookDelay = 50 * time.Millisecond
+
+// newSlowBookServer returns a CLOB stub whose /book endpoint sleeps before
+// answering with a fixed one-level book.
+func newSlowBookServer(t testing.TB) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(bookDelay)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"bids":[{"price":"0.60","size":"100"}],"asks":[{"price":"0.62","size":"50"}]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestMarkets(n int) []*TrackedMarket {
+	markets := make([]*TrackedMarket, n)
+	for i := range markets {
+		markets[i] = &TrackedMarket{
+			YesTokenID: fmt.Sprintf("yes-%d", i),
+			NoTokenID:  fmt.Sprintf("no-%d", i),
+		}
+	}
+	return markets
+}
+
+func TestUpdateOrderBookPrices_Concurrent(t *testing.T) {
+	srv := newSlowBookServer(t)
+	s := &Sniper{clob: clob.NewClient("key", "secret", "pass", "0x0").WithBaseURL(srv.URL)}
+
+	const n = 16
+	markets := newTestMarkets(n)
+
+	start := time.Now()
+	forEachMarket(markets, maxConcurrentPolls, s.updateOrderBookPrices)
+	elapsed := time.Since(start)
+
+	// Sequential polling would take 2*n*bookDelay.
+	sequential := 2 * n * bookDelay
+	if elapsed >= sequential/2 {
+		t.Errorf("polling %d markets took %v, want well under sequential %v", n, elapsed, sequential)
+	}
+
+	for _, m := range markets {
+		yesBid, yesAsk, noBid, noAsk := m.GetPrices()
+		if yesBid != 0.60 || yesAsk != 0.62 || noBid != 0.60 || noAsk != 0.62 {
+			t.Errorf("%s: unexpected prices %.2f/%.2f %.2f/%.2f", m.YesTokenID, yesBid, yesAsk, noBid, noAsk)
+		}
+		yesSize, noSize := m.GetSizes()
+		if yesSize != 50 || noSize != 50 {
+			t.Errorf("%s: unexpected sizes %.0f/%.0f", m.YesTokenID, yesSize, noSize)
+		}
+	}
+}
+
+func TestPollMarkets_BatchPrices(t *testing.T) {
+	var priceCalls, bookCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/prices":
+			priceCalls.Add(1)
+			fmt.Fprint(w, `{"yes-0":{"BUY":"0.70","SELL":"0.72"},"no-0":{"BUY":"0.27","SELL":"0.29"},`+
+				`"yes-1":{"BUY":"0.40","SELL":"0.42"},"no-1":{"BUY":"0.57","SELL":"0.59"}}`)
+		case "/book":
+			bookCalls.Add(1)
+			fmt.Fprint(w, `{"bids":[{"price":"0.60","size":"100"}],"asks":[{"price":"0.62","size":"50"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	s := &Sniper{
+		config: &config.Config{TriggerSeconds: 1},
+		clob:   clob.NewClient("key", "secret", "pass", "0x0").WithBaseURL(srv.URL),
+		gamma:  gamma.NewClient().WithBaseURL(srv.URL),
+	}
+
+	markets := newTestMarkets(3) // market 2 is missing from the batch response
+	for _, m := range markets {
+		m.EndTime = time.Now().Add(20 * time.Second)
+	}
+	s.pollMarkets(markets)
+
+	if got := priceCalls.Load(); got != 1 {
+		t.Errorf("batch price calls = %d, want 1", got)
+	}
+	if got := bookCalls.Load(); got != 2 {
+		t.Errorf("order book calls = %d, want 2 (only the unquoted market)", got)
+	}
+
+	yesBid, yesAsk, noBid, noAsk := markets[0].GetPrices()
+	if yesBid != 0.70 || yesAsk != 0.72 || noBid != 0.27 || noAsk != 0.29 {
+		t.Errorf("market 0 prices = %.2f/%.2f %.2f/%.2f, want batch quotes", yesBid, yesAsk, noBid, noAsk)
+	}
+	if _, yesAsk, _, _ := markets[2].GetPrices(); yesAsk != 0.62 {
+		t.Errorf("market 2 yes ask = %.2f, want order book fallback 0.62", yesAsk)
+	}
+
+	// Inside the depth window books are fetched even for quoted markets
+	bookCalls.Store(0)
+	markets[0].EndTime = time.Now().Add(2 * time.Second)
+	s.pollMarkets(markets[:1])
+	if got := bookCalls.Load(); got != 2 {
+		t.Errorf("order book calls near trigger = %d, want 2", got)
+	}
+}
+
+func TestForEachMarket_BoundedWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		markets int
+		workers int
+	}{
+		{"more markets than workers", 20, 4},
+		{"fewer markets than workers", 2, 8},
+		{"zero workers", 3, 0},
+		{"no markets", 0, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markets := newTestMarkets(tt.markets)
+			seen := make(chan string, tt.markets)
+			forEachMarket(markets, tt.workers, func(m *TrackedMarket) {
+				seen <- m.YesTokenID
+			})
+			close(seen)
+
+			count := 0
+			for range seen {
+				count++
+			}
+			if count != tt.markets {
+				t.Errorf("visited %d markets, want %d", count, tt.markets)
+			}
+		})
+	}
+}
+
+func BenchmarkUpdateOrderBookPrices(b *testing.B) {
+	srv := newSlowBookServer(b)
+	s := &Sniper{clob: clob.NewClient("key", "secret", "pass", "0x0").WithBaseURL(srv.URL)}
+	markets := newTestMarkets(16)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			forEachMarket(markets, 1, s.updateOrderBookPrices)
+		}
+	})
+	b.Run("concurrent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			forEachMarket(markets, maxConcurrentPolls, s.updateOrderBookPrices)
+		}
+	})
+}
+
+func TestRemoveClosedMarkets(t *testing.T) {
+	var closed atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slug := r.URL.Query().Get("slug")
+		isClosed := slug == "btc-updown-15m-1" && closed.Load()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"slug":%q,"closed":%t}]`, slug, isClosed)
+	}))
+	defer srv.Close()
+
+	s := &Sniper{
+		gamma: gamma.NewClient().WithBaseURL(srv.URL),
+		ws:    clob.NewWSClient(),
+		activeMarkets: map[string]*TrackedMarket{
+			"btc-updown-15m-1": {Market: gamma.Market{Slug: "btc-updown-15m-1"}, EndTime: time.Now().Add(10 * time.Minute)},
+			"eth-updown-15m-1": {Market: gamma.Market{Slug: "eth-updown-15m-1"}, EndTime: time.Now().Add(10 * time.Minute)},
+		},
+	}
+
+	s.removeClosedMarkets()
+	if got := len(s.GetActiveMarkets()); got != 2 {
+		t.Fatalf("open markets: tracked %d, want 2", got)
+	}
+
+	closed.Store(true)
+	s.removeClosedMarkets()
+
+	if got := len(s.GetActiveMarkets()); got != 1 {
+		t.Fatalf("after close: tracked %d, want 1", got)
+	}
+	if _, ok := s.activeMarkets["btc-updown-15m-1"]; ok {
+		t.Error("closed market should no longer be tracked")
+	}
+	if _, ok := s.activeMarkets["eth-updown-15m-1"]; !ok {
+		t.Error("open market should still be tracked")
+	}
+}
+
+func TestCheckStopLoss(t *testing.T) {
+	tests := []struct {
+		name       string
+		side       string
+		yesBids    []float64
+		wantExited bool
+	}{
+		{"UP holds on rising price", "UP", []float64{0.80, 0.85, 0.90}, false},
+		{"UP exits on reversal", "UP", []float64{0.90, 0.80, 0.70}, true},
+		{"DOWN exits when YES surges", "DOWN", []float64{0.10, 0.20, 0.35}, true},
+		{"DOWN holds on small move", "DOWN", []float64{0.10, 0.12, 0.15}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sniper{config: &config.Config{DryRun: true, SnipeStopLossMomentum: 0.15}}
+			tracked := &TrackedMarket{Market: gamma.Market{Question: "test"}}
+			tracked.SetPosition(&SnipePosition{Side: tt.side, TokenID: "1", Shares: 5, EntryPrice: 0.9})
+			tracked.MarkSniped()
+
+			for _, bid := range tt.yesBids {
+				tracked.UpdateYesPrice(bid, bid+0.01, 10)
+				tracked.UpdateNoPrice(1-bid-0.01, 1-bid, 10)
+			}
+
+			if !s.monitorsExit(tracked) {
+				t.Fatal("expected sniped market with open position to be monitored")
+			}
+			s.checkStopLoss(tracked)
+
+			_, open := tracked.OpenPosition()
+			if open == tt.wantExited {
+				t.Errorf("exited = %v, want %v", !open, tt.wantExited)
+			}
+		})
+	}
+}
+
+func TestCheckStopLoss_IgnoresMovesBeforeEntry(t *testing.T) {
+	s := &Sniper{config: &config.Config{DryRun: true, SnipeStopLossMomentum: 0.15}}
+	tracked := &TrackedMarket{Market: gamma.Market{Question: "test"}}
+
+	// A sharp drop before the snipe, then a flat price after it
+	start := time.Now().Add(-time.Minute)
+	tracked.mu.Lock()
+	for i, bid := range []float64{0.90, 0.80, 0.70} {
+		tracked.BestYesBid, tracked.BestYesAsk = bid, bid+0.01
+		tracked.recordSnapshotAt(start.Add(time.Duration(i) * time.Second))
+	}
+	tracked.mu.Unlock()
+
+	tracked.SetPosition(&SnipePosition{Side: "UP", TokenID: "1", Shares: 5, EntryPrice: 0.71})
+	tracked.MarkSniped()
+	for i := 0; i < 2; i++ {
+		tracked.UpdateYesPrice(0.70, 0.71, 10)
+	}
+
+	s.checkStopLoss(tracked)
+	if _, open := tracked.OpenPosition(); !open {
+		t.Error("position was exited for a move that happened before entry")
+	}
+}
+
+func TestCheckStopLoss_BacksOffFailedSells(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeStopLossMomentum: 0.15})
+	mock := clobmock.New()
+	mock.Reject = "not enough balance / allowance"
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{Market: gamma.Market{Question: "test"}, EndTime: time.Now().Add(time.Minute)}
+	tracked.SetPosition(&SnipePosition{Side: "UP", TokenID: "1", Shares: 5, EntryPrice: 0.9})
+	tracked.MarkSniped()
+	for _, bid := range []float64{0.90, 0.80, 0.70} {
+		tracked.UpdateYesPrice(bid, bid+0.01, 10)
+		tracked.UpdateNoPrice(1-bid-0.01, 1-bid, 10)
+	}
+
+	// Every poll tick inside the retry delay reuses the first attempt
+	for i := 0; i < 5; i++ {
+		s.checkStopLoss(tracked)
+	}
+	if got := len(mock.Orders()); got != 1 {
+		t.Fatalf("sent %d sells within the retry delay, want 1", got)
+	}
+
+	// Once the delay passes the sell is retried, until attempts run out
+	for i := 0; i < stopLossMaxAttempts+2; i++ {
+		tracked.mu.Lock()
+		tracked.position.LastExitAt = tracked.position.LastExitAt.Add(-stopLossRetryDelay)
+		tracked.mu.Unlock()
+		s.checkStopLoss(tracked)
+	}
+	if got := len(mock.Orders()); got != stopLossMaxAttempts {
+		t.Errorf("sent %d sells in total, want %d", got, stopLossMaxAttempts)
+	}
+	if _, open := tracked.OpenPosition(); !open {
+		t.Error("rejected sells should leave the position open")
+	}
+}
+
+func TestIsWarmedUp(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		snapshots    int
+		trackedFor   time.Duration
+		minSnapshots int
+		minAge       time.Duration
+		want         bool
+	}{
+		{"fresh market with no history", 0, 0, 4, 10 * time.Second, false},
+		{"enough snapshots but too young", 6, 3 * time.Second, 4, 10 * time.Second, false},
+		{"old enough but too few snapshots", 2, time.Minute, 4, 10 * time.Second, false},
+		{"warmed up", 4, 15 * time.Second, 4, 10 * time.Second, true},
+		{"requirement above history cap", 10, time.Minute, 50, 0, true},
+		{"gate disabled", 0, 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracked := &TrackedMarket{trackedAt: now.Add(-tt.trackedFor)}
+			for i := 0; i < tt.snapshots; i++ {
+				tracked.UpdateYesPrice(0.6, 0.62, 10)
+			}
+
+			s := &Sniper{config: &config.Config{
+				SnipeWarmupSnapshots: tt.minSnapshots,
+				SnipeWarmupSeconds:   int(tt.minAge / time.Second),
+			}}
+			if got := s.isWarmedUp(tracked, now); got != tt.want {
+				t.Errorf("isWarmedUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiquidityShortfall(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      float64
+		ask       float64
+		minShares float64
+		minUSD    float64
+		wantSkip  bool
+	}{
+		{"shares gate met", 10, 0.50, 5, 0, false},
+		{"shares gate missed", 4, 0.95, 5, 0, true},
+		{"usd gate met", 10, 0.60, 0, 5, false},
+		{"usd gate missed despite many shares", 100, 0.04, 0, 5, true},
+		{"usd gate counts dollars not shares", 6, 0.50, 0, 5, true},
+		{"both gates met", 20, 0.50, 5, 5, false},
+		{"shares met but usd missed", 8, 0.50, 5, 5, true},
+		{"no gates configured", 0, 0.50, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := liquidityShortfall(tt.size, tt.ask, tt.minShares, tt.minUSD)
+			if (got != "") != tt.wantSkip {
+				t.Errorf("liquidityShortfall(%v, %v, %v, %v) = %q, want skip=%v",
+					tt.size, tt.ask, tt.minShares, tt.minUSD, got, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func newTestSniper(t *testing.T, cfg *config.Config) *Sniper {
+	t.Helper()
+	w, err := wallet.NewWalletFromHex("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	s, err := NewSniper(cfg, w, nil)
+	if err != nil {
+		t.Fatalf("NewSniper: %v", err)
+	}
+	return s.WithMarketSource(gammamock.New())
+}
+
+func TestAnalyzeMarket_SkipReasons(t *testing.T) {
+	tests := []struct {
+		name      string
+		market    *TrackedMarket
+		dailyLoss float64
+		want      SkipReason
+		wantTrade bool
+	}{
+		{
+			name:   "no clear winner",
+			market: &TrackedMarket{GammaYesPrice: 0.45, GammaNoPrice: 0.40, BestYesAsk: 0.50, YesSize: 100},
+			want:   SkipReasonNoWinner,
+		},
+		{
+			name:   "prices too close",
+			market: &TrackedMarket{GammaYesPrice: 0.53, GammaNoPrice: 0.47, BestYesAsk: 0.55, YesSize: 100},
+			want:   SkipReasonTooUncertain,
+		},
+		{
+			name:   "down winning without liquidity",
+			market: &TrackedMarket{GammaYesPrice: 0.10, GammaNoPrice: 0.90, BestNoAsk: 0.995, NoSize: 100},
+			want:   SkipReasonDownNoLiq,
+		},
+		{
+			name:   "thin book",
+			market: &TrackedMarket{GammaYesPrice: 0.90, GammaNoPrice: 0.10, BestYesAsk: 0.95, YesSize: 2},
+			want:   SkipReasonNoLiquidity,
+		},
+		{
+			name:   "ask above snipe price",
+			market: &TrackedMarket{GammaYesPrice: 0.90, GammaNoPrice: 0.10, BestYesAsk: 0.985, YesSize: 100},
+			want:   SkipReasonPriceTooHigh,
+		},
+		{
+			name:      "daily loss limit hit",
+			market:    &TrackedMarket{GammaYesPrice: 0.90, GammaNoPrice: 0.10, BestYesAsk: 0.95, YesSize: 100},
+			dailyLoss: defaultDailyLossLimit,
+			want:      SkipReasonDailyLimit,
+		},
+		{
+			name:      "clear winner trades",
+			market:    &TrackedMarket{GammaYesPrice: 0.90, GammaNoPrice: 0.10, BestYesAsk: 0.95, YesSize: 100},
+			wantTrade: true,
+		},
+		{
+			name:      "down winner trades",
+			market:    &TrackedMarket{GammaYesPrice: 0.08, GammaNoPrice: 0.92, BestNoAsk: 0.94, NoSize: 100},
+			wantTrade: true,
+		},
+	}
+
+	cfg := &config.Config{SnipePrice: 0.98, MaxPositionSize: 10, MinConfidence: 0.50, MaxUncertainty: 0.10}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, cfg)
+			s.dailyStats.AddLoss(tt.dailyLoss)
+			tracked := tt.market
+			tracked.YesTokenID, tracked.NoTokenID = "1", "2"
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s: %s), want %v",
+					analysis.ShouldTrade, analysis.SkipReason, analysis.SkipDescription, tt.wantTrade)
+			}
+			if analysis.SkipReason != tt.want {
+				t.Errorf("SkipReason = %q (%s), want %q", analysis.SkipReason, analysis.SkipDescription, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMarket_MinExpectedProfit(t *testing.T) {
+	tests := []struct {
+		name      string
+		ask       float64
+		wantTrade bool
+	}{
+		{"pennies at 97c", 0.97, false},
+		{"worth it at 85c", 0.85, true},
+	}
+
+	cfg := &config.Config{SnipePrice: 0.98, MaxPositionSize: 10, MinConfidence: 0.50, MaxUncertainty: 0.10, MinExpectedProfit: 0.5}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, cfg)
+			tracked := &TrackedMarket{
+				YesTokenID:    "1",
+				NoTokenID:     "2",
+				GammaYesPrice: 0.90,
+				GammaNoPrice:  0.10,
+				BestYesAsk:    tt.ask,
+				YesSize:       100,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s: %s, profit $%.2f), want %v",
+					analysis.ShouldTrade, analysis.SkipReason, analysis.SkipDescription, analysis.ExpectedProfit, tt.wantTrade)
+			}
+			if !tt.wantTrade && analysis.SkipReason != SkipReasonLowProfit {
+				t.Errorf("SkipReason = %q, want %q", analysis.SkipReason, SkipReasonLowProfit)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMarket_ExpectedProfitOfSubmittedOrder(t *testing.T) {
+	cfg := &config.Config{SnipePrice: 0.98, MaxPositionSize: 10, MinConfidence: 0.50, MaxUncertainty: 0.10}
+	s := newTestSniper(t, cfg)
+	mock := clobmock.New()
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{
+		YesTokenID:    "101",
+		NoTokenID:     "102",
+		GammaYesPrice: 0.90,
+		GammaNoPrice:  0.10,
+		BestYesAsk:    0.85,
+		YesSize:       100,
+		EndTime:       time.Now().Add(time.Minute),
+	}
+	analysis := s.analyzeMarket(tracked)
+	if !analysis.ShouldTrade {
+		t.Fatalf("skipped: %s: %s", analysis.SkipReason, analysis.SkipDescription)
+	}
+	if err := s.executeSnipe(tracked, analysis, time.Minute); err != nil {
+		t.Fatalf("executeSnipe: %v", err)
+	}
+	orders := mock.Orders()
+	if len(orders) != 1 {
+		t.Fatalf("submitted %d orders, want 1", len(orders))
+	}
+	takerAmount, _ := strconv.ParseFloat(orders[0].Order.TakerAmount, 64)
+	shares := takerAmount / 1e6
+	if want := (1 - 0.85) * shares; math.Abs(analysis.ExpectedProfit-want) > 0.01 {
+		t.Errorf("expected profit $%.2f, want $%.2f for the %.2f shares submitted", analysis.ExpectedProfit, want, shares)
+	}
+
+	// A minimum just above what the submitted order can earn refuses it
+	cfg.MinExpectedProfit = analysis.ExpectedProfit + 0.01
+	s = newTestSniper(t, cfg)
+	if analysis := s.analyzeMarket(tracked); analysis.ShouldTrade || analysis.SkipReason != SkipReasonLowProfit {
+		t.Errorf("ShouldTrade = %v (%s), want %s", analysis.ShouldTrade, analysis.SkipReason, SkipReasonLowProfit)
+	}
+}
+
+func TestScanForMarkets_TracksSourceMarkets(t *testing.T) {
+	end := time.Now().Add(10 * time.Minute)
+	source := gammamock.New()
+	source.UpDown = []gamma.Market{
+		{
+			Slug:     "sol-updown-15m-1",
+			Question: "Solana Up or Down?",
+			EndDate:  end.Format(time.RFC3339),
+			Tokens:   []gamma.Token{{TokenID: "1", Outcome: "Up", Price: 0.5}, {TokenID: "2", Outcome: "Down", Price: 0.5}},
+		},
+		{
+			Slug:     "sol-updown-15m-0",
+			Question: "Ended market?",
+			EndDate:  time.Now().Add(-time.Minute).Format(time.RFC3339),
+			Tokens:   []gamma.Token{{TokenID: "3", Outcome: "Up", Price: 0.5}, {TokenID: "4", Outcome: "Down", Price: 0.5}},
+		},
+		{
+			Slug:     "sol-updown-15m-2",
+			Question: "Missing token ID?",
+			EndDate:  end.Format(time.RFC3339),
+			Tokens:   []gamma.Token{{TokenID: "5", Outcome: "Up", Price: 0.5}, {TokenID: "", Outcome: "Down", Price: 0.5}},
+		},
+	}
+
+	s := newTestSniper(t, &config.Config{SnipePrice: 0.98, MaxPositionSize: 10})
+	s.WithMarketSource(source)
+	s.clob = clobmock.New()
+
+	if err := s.ScanForMarkets(); err != nil {
+		t.Fatalf("ScanForMarkets: %v", err)
+	}
+
+	active := s.GetActiveMarkets()
+	if len(active) != 1 {
+		t.Fatalf("tracking %d markets, want 1", len(active))
+	}
+	if active[0].Market.Slug != "sol-updown-15m-1" || active[0].YesTokenID != "1" || active[0].NoTokenID != "2" {
+		t.Errorf("tracked %s (%s/%s), want sol-updown-15m-1 (1/2)", active[0].Market.Slug, active[0].YesTokenID, active[0].NoTokenID)
+	}
+}
+
+func TestMakerSnipe_RestsInsideSpreadAndCancelsAtExpiry(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeMode: "maker"})
+	mock := clobmock.New()
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{
+		Market:     gamma.Market{Question: "Solana Up or Down?"},
+		YesTokenID: "101",
+		NoTokenID:  "102",
+		EndTime:    time.Now().Add(time.Second),
+	}
+	s.activeMarkets["sol"] = tracked
+
+	analysis := TradeAnalysis{ShouldTrade: true, Side: "UP", TokenID: "101", EntryPrice: 0.97, MaxLoss: 10}
+	if err := s.executeSnipe(tracked, analysis, time.Second); err != nil {
+		t.Fatalf("executeSnipe: %v", err)
+	}
+
+	orders := mock.Orders()
+	if len(orders) != 1 {
+		t.Fatalf("submitted %d orders, want 1", len(orders))
+	}
+	bid := orders[0]
+	if bid.OrderType != string(clob.OrderTypeGTC) || bid.Order.Side != string(clob.OrderSideBuy) {
+		t.Errorf("order = %s %s, want BUY GTC", bid.Order.Side, bid.OrderType)
+	}
+	// 10 shares one tick inside the 97¢ ask is $9.60
+	if bid.Order.MakerAmount != "9600000" || bid.Order.TakerAmount != "10000000" {
+		t.Errorf("amounts = %s/%s, want 9600000/10000000", bid.Order.MakerAmount, bid.Order.TakerAmount)
+	}
+	if !tracked.IsSniped() {
+		t.Error("market not marked sniped after the maker bid")
+	}
+	if _, open := tracked.OpenPosition(); open {
+		t.Error("unconfirmed maker bid recorded as an open position")
+	}
+	if loss := s.dailyStats.GetTotalLoss(); loss != 0 {
+		t.Errorf("daily loss = %.2f for an unfilled bid, want 0", loss)
+	}
+	mock.OpenOrders = []clob.Order{{ID: "mock-1"}}
+
+	// Still resting before expiry
+	if err := s.CheckAndSnipe(); err != nil {
+		t.Fatalf("CheckAndSnipe: %v", err)
+	}
+	if len(mock.Canceled()) != 0 {
+		t.Fatalf("canceled %v before expiry", mock.Canceled())
+	}
+
+	tracked.EndTime = time.Now().Add(-time.Second)
+	for i := 0; i < 2; i++ {
+		if err := s.CheckAndSnipe(); err != nil {
+			t.Fatalf("CheckAndSnipe: %v", err)
+		}
+	}
+	if canceled := mock.Canceled(); len(canceled) != 1 || canceled[0] != "mock-1" {
+		t.Errorf("canceled = %v, want [mock-1] once", canceled)
+	}
+	if loss := s.dailyStats.GetTotalLoss(); loss != 0 {
+		t.Errorf("daily loss = %.2f after canceling the unfilled bid, want 0", loss)
+	}
+}
+
+func TestMakerSnipe_ChargesLossOnlyOnFill(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeMode: "maker"})
+	mock := clobmock.New()
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{
+		Market:     gamma.Market{Question: "Solana Up or Down?"},
+		YesTokenID: "101",
+		NoTokenID:  "102",
+		EndTime:    time.Now().Add(time.Second),
+	}
+	analysis := TradeAnalysis{ShouldTrade: true, Side: "UP", TokenID: "101", EntryPrice: 0.97, MaxLoss: 10}
+	if err := s.placeMakerSnipe(tracked, analysis); err != nil {
+		t.Fatalf("placeMakerSnipe: %v", err)
+	}
+
+	// Gone from the open orders at expiry, so it filled
+	s.cancelExpiredMakerBid(tracked, tracked.EndTime)
+	if len(mock.Canceled()) != 0 {
+		t.Errorf("canceled %v, want the filled bid left alone", mock.Canceled())
+	}
+	if loss := s.dailyStats.GetTotalLoss(); loss != 10 {
+		t.Errorf("daily loss = %.2f, want the trade's max loss of 10", loss)
+	}
+}
+
+func TestMakerSnipe_UserChannelFills(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeMode: "maker"})
+	mock := clobmock.New()
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{
+		Market:     gamma.Market{Question: "Solana Up or Down?"},
+		YesTokenID: "101",
+		NoTokenID:  "102",
+		EndTime:    time.Now().Add(time.Minute),
+	}
+	s.activeMarkets["sol"] = tracked
+	analysis := TradeAnalysis{ShouldTrade: true, Side: "UP", TokenID: "101", EntryPrice: 0.97, MaxLoss: 10}
+	if err := s.placeMakerSnipe(tracked, analysis); err != nil {
+		t.Fatalf("placeMakerSnipe: %v", err)
+	}
+	mock.OpenOrders = []clob.Order{{ID: "mock-1"}}
+
+	fill := func(status string, shares float64) {
+		s.handleUserTrade(clob.TradeUpdate{
+			ID:           "t-" + status,
+			Status:       status,
+			TakerOrderID: "someone-else",
+			MakerOrders:  []clob.MakerFill{{OrderID: "mock-1", Size: shares}},
+		})
+	}
+
+	// 4 of the 10 shares match; later statuses of the same trade don't count
+	fill("MATCHED", 4)
+	fill("CONFIRMED", 4)
+	pos, open := tracked.OpenPosition()
+	if !open || pos.Shares != 4 || pos.EntryPrice != 0.96 || pos.Side != "UP" {
+		t.Fatalf("position = %+v (open %v), want 4 UP shares at 0.96", pos, open)
+	}
+	if loss := s.dailyStats.GetTotalLoss(); loss != 0 {
+		t.Errorf("daily loss = %.2f while the bid still rests, want 0", loss)
+	}
+
+	// The rest matches: the bid is settled without waiting for expiry
+	fill("MATCHED", 6)
+	if pos, _ := tracked.OpenPosition(); pos.Shares != 10 {
+		t.Errorf("position holds %.2f shares, want 10", pos.Shares)
+	}
+	if loss := s.dailyStats.GetTotalLoss(); loss != 10 {
+		t.Errorf("daily loss = %.2f, want the trade's max loss of 10", loss)
+	}
+	if tracked.makerBidID() != "" {
+		t.Error("filled bid still tracked")
+	}
+	s.cancelExpiredMakerBid(tracked, tracked.EndTime)
+	if len(mock.Canceled()) != 0 {
+		t.Errorf("canceled %v, want the filled bid left alone", mock.Canceled())
+	}
+}
+
+func TestExecuteSnipe_ChargesMaxLossOnEveryPath(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{"taker", &config.Config{}},
+		{"dry-run maker", &config.Config{SnipeMode: "maker", DryRun: true}},
+		{"live maker", &config.Config{SnipeMode: "maker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, tt.cfg)
+			mock := clobmock.New()
+			s.clob = mock
+			s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+			tracked := &TrackedMarket{
+				Market:     gamma.Market{Question: "Solana Up or Down?"},
+				YesTokenID: "101",
+				NoTokenID:  "102",
+				EndTime:    time.Now().Add(time.Minute),
+			}
+			analysis := TradeAnalysis{ShouldTrade: true, Side: "UP", TokenID: "101", EntryPrice: 0.97, MaxLoss: 10}
+			if err := s.executeSnipe(tracked, analysis, time.Minute); err != nil {
+				t.Fatalf("executeSnipe: %v", err)
+			}
+			// The live maker bid fills, gone from the open orders at expiry
+			s.cancelExpiredMakerBid(tracked, tracked.EndTime)
+
+			if loss := s.dailyStats.GetTotalLoss(); loss != analysis.MaxLoss {
+				t.Errorf("daily loss = %.2f, want the max loss %.2f", loss, analysis.MaxLoss)
+			}
+		})
+	}
+}
+
+func TestMakerSnipe_CanceledWhenMarketUntracked(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeMode: "maker"})
+	mock := clobmock.New()
+	s.clob = mock
+	s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+	tracked := &TrackedMarket{
+		Market:     gamma.Market{Question: "Solana Up or Down?"},
+		YesTokenID: "101",
+		NoTokenID:  "102",
+		EndTime:    time.Now().Add(-2 * time.Minute),
+	}
+	s.activeMarkets["sol"] = tracked
+	tracked.setMakerBid(&makerBid{orderID: "bid-1", cost: 9.6})
+	mock.OpenOrders = []clob.Order{{ID: "bid-1"}}
+
+	s.cleanupExpiredMarkets()
+	if canceled := mock.Canceled(); len(canceled) != 1 || canceled[0] != "bid-1" {
+		t.Errorf("canceled = %v, want [bid-1]", canceled)
+	}
+}
+
+func TestMakerSnipe_DryRunRecordsPaperPosition(t *testing.T) {
+	s := newTestSniper(t, &config.Config{SnipeMode: "maker", DryRun: true})
+	mock := clobmock.New()
+	s.clob = mock
+
+	tracked := &TrackedMarket{Market: gamma.Market{Question: "Solana Up or Down?"}, EndTime: time.Now().Add(time.Minute)}
+	analysis := TradeAnalysis{ShouldTrade: true, Side: "UP", TokenID: "101", EntryPrice: 0.97, MaxLoss: 10}
+	if err := s.placeMakerSnipe(tracked, analysis); err != nil {
+		t.Fatalf("placeMakerSnipe: %v", err)
+	}
+
+	pos, open := tracked.OpenPosition()
+	if !open || pos.Shares != 10 || pos.EntryPrice != 0.96 {
+		t.Errorf("position = %+v (open %v), want 10 shares at 0.96", pos, open)
+	}
+	if len(mock.Orders()) != 0 {
+		t.Errorf("dry run submitted %d orders", len(mock.Orders()))
+	}
+}
+
+func TestScanForMarkets_PausesAfterRateLimit(t *testing.T) {
+	opened := time.Now().Add(-2 * time.Minute)
+	source := gammamock.New()
+	source.Err = &gamma.GammaError{StatusCode: http.StatusTooManyRequests}
+
+	s := newTestSniper(t, &config.Config{SnipePrice: 0.98, MaxPositionSize: 10})
+	s.WithMarketSource(source)
+	s.clob = clobmock.New()
+
+	if err := s.ScanForMarkets(); !gamma.IsRateLimited(err) {
+		t.Fatalf("first scan err = %v, want the rate limit", err)
+	}
+
+	// Until the pause runs out, scans don't touch Gamma
+	source.Err = nil
+	source.UpDown = []gamma.Market{{
+		Slug:     fmt.Sprintf("btc-updown-15m-%d", opened.Unix()),
+		Question: "btc Up or Down?",
+		EndDate:  opened.Add(15 * time.Minute).Format(time.RFC3339),
+		Tokens:   []gamma.Token{{TokenID: "1", Outcome: "Up", Price: 0.5}, {TokenID: "2", Outcome: "Down", Price: 0.5}},
+	}}
+	if err := s.ScanForMarkets(); err != nil {
+		t.Fatalf("paused scan: %v", err)
+	}
+	if n := len(s.GetActiveMarkets()); n != 0 {
+		t.Errorf("paused scan tracked %d markets, want 0", n)
+	}
+}
+
+func TestScanForMarkets_SkipsNewMarkets(t *testing.T) {
+	now := time.Now()
+	market := func(slug string, opened time.Time, tokens ...string) gamma.Market {
+		return gamma.Market{
+			Slug:     fmt.Sprintf("%s-updown-15m-%d", slug, opened.Unix()),
+			Question: slug + " Up or Down?",
+			EndDate:  opened.Add(15 * time.Minute).Format(time.RFC3339),
+			Tokens:   []gamma.Token{{TokenID: tokens[0], Outcome: "Up", Price: 0.5}, {TokenID: tokens[1], Outcome: "Down", Price: 0.5}},
+		}
+	}
+	source := gammamock.New()
+	source.UpDown = []gamma.Market{
+		market("sol", now.Add(-5*time.Second), "1", "2"),
+		market("btc", now.Add(-2*time.Minute), "3", "4"),
+	}
+
+	s := newTestSniper(t, &config.Config{SnipePrice: 0.98, MaxPositionSize: 10, SnipeMinMarketAge: 30})
+	s.WithMarketSource(source)
+	s.clob = clobmock.New()
+
+	if err := s.ScanForMarkets(); err != nil {
+		t.Fatalf("ScanForMarkets: %v", err)
+	}
+
+	active := s.GetActiveMarkets()
+	if len(active) != 1 || active[0].YesTokenID != "3" {
+		var slugs []string
+		for _, m := range active {
+			slugs = append(slugs, m.Market.Slug)
+		}
+		t.Fatalf("tracking %v, want only the market opened 2 minutes ago", slugs)
+	}
+}
+
+func TestCheckAndSnipe_GivesUpCoinFlip(t *testing.T) {
+	tests := []struct {
+		name       string
+		remaining  time.Duration
+		yes, no    float64
+		wantSniped bool
+	}{
+		{"coin flip in window", 3 * time.Second, 0.51, 0.49, true},
+		{"coin flip before window", 30 * time.Second, 0.51, 0.49, false},
+		{"clear winner in window", 3 * time.Second, 0.90, 0.10, false},
+		{"no gamma prices yet", 3 * time.Second, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Still warming up, so without the early check nothing would
+			// happen until the warmup finished
+			s := newTestSniper(t, &config.Config{TriggerSeconds: 5, SnipeWarmupSnapshots: 4, MaxUncertainty: 0.10})
+			s.clob = clobmock.New()
+
+			tracked := &TrackedMarket{
+				Market:        gamma.Market{Question: "Solana Up or Down?"},
+				YesTokenID:    "101",
+				NoTokenID:     "102",
+				EndTime:       time.Now().Add(tt.remaining),
+				GammaYesPrice: tt.yes,
+				GammaNoPrice:  tt.no,
+			}
+			s.activeMarkets["sol"] = tracked
+
+			if err := s.CheckAndSnipe(); err != nil {
+				t.Fatalf("CheckAndSnipe: %v", err)
+			}
+			if tracked.IsSniped() != tt.wantSniped {
+				t.Errorf("sniped = %v, want %v", tracked.IsSniped(), tt.wantSniped)
+			}
+		})
+	}
+}
+
+func TestCheckAndSnipe_Cutoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		cutoff     float64
+		wantSniped bool
+	}{
+		{"inside cutoff", 1, true},
+		{"cutoff disabled", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, &config.Config{TriggerSeconds: 5, SnipeWarmupSnapshots: 4, SnipeCutoffSeconds: tt.cutoff})
+			mock := clobmock.New()
+			s.clob = mock
+			s.builder.WithTickSizes(mock).WithMinOrderSizes(mock)
+
+			tracked := &TrackedMarket{
+				Market:        gamma.Market{Question: "Solana Up or Down?"},
+				YesTokenID:    "101",
+				NoTokenID:     "102",
+				EndTime:       time.Now().Add(500 * time.Millisecond),
+				GammaYesPrice: 0.95,
+				GammaNoPrice:  0.05,
+				BestYesAsk:    0.97,
+				YesSize:       100,
+			}
+			s.activeMarkets["sol"] = tracked
+
+			if err := s.CheckAndSnipe(); err != nil {
+				t.Fatalf("CheckAndSnipe: %v", err)
+			}
+			if len(mock.Orders()) != 0 {
+				t.Errorf("submitted %d orders with 0.5s remaining", len(mock.Orders()))
+			}
+			// Skipped for good inside the cutoff; otherwise still waiting on warmup
+			if tracked.IsSniped() != tt.wantSniped {
+				t.Errorf("sniped = %v, want %v", tracked.IsSniped(), tt.wantSniped)
+			}
+		})
+	}
+}
+
+func TestCalculatePositionSize_SnipeMaxPosition(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxPosition float64
+		want        float64
+	}{
+		{"SNIPE_MAX_POSITION", 4, 4},
+		{"falls back to MAX_POSITION_SIZE", 0, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, &config.Config{MaxPositionSize: 15, SnipeMaxPosition: tt.maxPosition})
+			if got := s.calculatePositionSize(1.0, 1000); got != tt.want {
+				t.Errorf("full-confidence size = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSnipeMode(t *testing.T) {
+	tests := []struct {
+		mode      string
+		wantMaker bool
+		wantErr   bool
+	}{
+		{"", false, false},
+		{"taker", false, false},
+		{" Maker ", true, false},
+		{"limit", false, true},
+	}
+	for _, tt := range tests {
+		maker, err := parseSnipeMode(tt.mode)
+		if maker != tt.wantMaker || (err != nil) != tt.wantErr {
+			t.Errorf("parseSnipeMode(%q) = %v, %v; want %v, err %v", tt.mode, maker, err, tt.wantMaker, tt.wantErr)
+		}
+	}
+}
+
+func TestMomentum_SimpleVsEMA(t *testing.T) {
+	// A steady 1¢ per snapshot rise, 9¢ over the 10 snapshots kept
+	const trend = 0.09
+	line := func() []float64 {
+		prices := make([]float64, maxPriceSnapshots)
+		for i := range prices {
+			prices[i] = 0.50 + 0.01*float64(i)
+		}
+		return prices
+	}
+	tracked := func(prices []float64) *TrackedMarket {
+		tm := &TrackedMarket{}
+		for i, p := range prices {
+			tm.BestYesBid = p
+			tm.recordSnapshotAt(time.Unix(int64(i), 0))
+		}
+		return tm
+	}
+
+	steady := tracked(line())
+	if simple, ema := steady.GetMomentum(), steady.GetEMAMomentum(); math.Abs(simple-trend) > 1e-9 || math.Abs(ema-trend) > 1e-9 {
+		t.Errorf("steady rise: simple %.4f, ema %.4f, want both %.2f", simple, ema, trend)
+	}
+
+	tests := []struct {
+		name  string
+		noisy func(prices []float64)
+	}{
+		{"spike on the newest snapshot", func(p []float64) { p[len(p)-1] += 0.20 }},
+		{"dip on the oldest snapshot", func(p []float64) { p[0] -= 0.20 }},
+		{"alternating noise", func(p []float64) {
+			for i := range p {
+				if i%2 == 1 {
+					p[i] += 0.04
+				}
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prices := line()
+			tt.noisy(prices)
+			tm := tracked(prices)
+
+			simple, ema := tm.GetMomentum(), tm.GetEMAMomentum()
+			if math.Abs(ema-trend) >= math.Abs(simple-trend) {
+				t.Errorf("ema %.4f strays further from the %.2f trend than simple %.4f", ema, trend, simple)
+			}
+		})
+	}
+
+	// SNIPE_MOMENTUM_MODE picks which one the sniper trades on
+	noisy := line()
+	noisy[len(noisy)-1] += 0.20
+	tm := tracked(noisy)
+	if got := newSniperCore(&config.Config{}).momentum(tm); got != tm.GetMomentum() {
+		t.Errorf("default momentum = %.4f, want simple %.4f", got, tm.GetMomentum())
+	}
+	if got := newSniperCore(&config.Config{SnipeMomentumMode: "ema"}).momentum(tm); got != tm.GetEMAMomentum() {
+		t.Errorf("ema mode momentum = %.4f, want %.4f", got, tm.GetEMAMomentum())
+	}
+}
+
+func TestParseMomentumMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantEMA bool
+		wantErr bool
+	}{
+		{"", false, false},
+		{"simple", false, false},
+		{" EMA ", true, false},
+		{"sma", false, true},
+	}
+	for _, tt := range tests {
+		ema, err := parseMomentumMode(tt.mode)
+		if ema != tt.wantEMA || (err != nil) != tt.wantErr {
+			t.Errorf("parseMomentumMode(%q) = %v, %v; want %v, err %v", tt.mode, ema, err, tt.wantEMA, tt.wantErr)
+		}
+	}
+}
+
+func TestEnsureFreshPrices(t *testing.T) {
+	freshBook := &clob.OrderBook{
+		Bids: []clob.PriceLevel{{Price: "0.95", Size: "100"}},
+		Asks: []clob.PriceLevel{{Price: "0.96", Size: "100"}},
+	}
+	tests := []struct {
+		name      string
+		maxAgeMs  int
+		age       time.Duration
+		bookErr   error
+		wantTrade bool
+		wantSkip  SkipReason
+		wantAsk   float64
+	}{
+		{name: "fresh", maxAgeMs: 500, age: 100 * time.Millisecond, wantTrade: true, wantAsk: 0.97},
+		{name: "disabled", maxAgeMs: 0, age: time.Minute, wantTrade: true, wantAsk: 0.97},
+		{name: "stale refreshed", maxAgeMs: 500, age: 2 * time.Second, wantTrade: true, wantAsk: 0.96},
+		{name: "stale refresh failed", maxAgeMs: 500, age: 2 * time.Second, bookErr: errors.New("timeout"), wantSkip: SkipReasonStalePrices},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSniper(t, &config.Config{SnipePrice: 0.99, MaxPositionSize: 10, SnipeMaxPriceAgeMs: tt.maxAgeMs})
+			mock := clobmock.New()
+			mock.OrderBooks["101"] = freshBook
+			mock.OrderBooks["102"] = &clob.OrderBook{Asks: []clob.PriceLevel{{Price: "0.99", Size: "100"}}}
+			mock.Err = tt.bookErr
+			s.clob = mock
+
+			tracked := &TrackedMarket{
+				Market:        gamma.Market{Question: "Bitcoin Up or Down?"},
+				YesTokenID:    "101",
+				NoTokenID:     "102",
+				GammaYesPrice: 0.95,
+				GammaNoPrice:  0.05,
+			}
+			now := time.Now()
+			tracked.UpdateYesPrice(0.96, 0.97, 100)
+			tracked.UpdateNoPrice(0.02, 0.99, 100)
+			tracked.yesQuoted = now.Add(-tt.age)
+
+			analysis := s.analyzeMarket(tracked)
+			if !analysis.ShouldTrade {
+				t.Fatalf("setup: analysis skipped: %s", analysis.SkipDescription)
+			}
+			got := s.ensureFreshPrices(tracked, analysis, now)
+			if got.ShouldTrade != tt.wantTrade || got.SkipReason != tt.wantSkip {
+				t.Fatalf("ensureFreshPrices = trade %v skip %q, want trade %v skip %q",
+					got.ShouldTrade, got.SkipReason, tt.wantTrade, tt.wantSkip)
+			}
+			if tt.wantTrade && got.EntryPrice != tt.wantAsk {
+				t.Errorf("EntryPrice = %.2f, want %.2f", got.EntryPrice, tt.wantAsk)
+			}
+		})
+	}
+}
+
+func TestTrackedMarket_PriceAge(t *testing.T) {
+	tracked := &TrackedMarket{YesTokenID: "101", NoTokenID: "102"}
+	now := time.Now()
+	if age := tracked.PriceAge("101", now); age != time.Duration(math.MaxInt64) {
+		t.Errorf("unquoted PriceAge = %v, want max", age)
+	}
+	tracked.UpdateQuotes(clob.PriceSides{Bid: 0.5, Ask: 0.51}, clob.PriceSides{Bid: 0.48, Ask: 0.49})
+	if age := tracked.PriceAge("102", time.Now()); age > time.Second {
+		t.Errorf("PriceAge after UpdateQuotes = %v, want ~0", age)
+	}
+}
+
+func TestPollCadence(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.Config
+		remaining time.Duration
+		want      time.Duration
+	}{
+		{"idle default", config.Config{}, 5 * time.Minute, defaultPollIdle},
+		{"near default", config.Config{}, 45 * time.Second, defaultPollNear},
+		{"final default", config.Config{}, 5 * time.Second, defaultPollFinal},
+		{"at the near boundary", config.Config{}, pollNearWindow, defaultPollNear},
+		{"idle configured", config.Config{SnipePollIdleMs: 2000}, 2 * time.Minute, 2 * time.Second},
+		{"near configured", config.Config{SnipePollNearMs: 250}, 30 * time.Second, 250 * time.Millisecond},
+		{"final configured", config.Config{SnipePollFinalMs: 50}, time.Second, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Sniper{config: &tt.cfg}
+			if got := s.pollInterval(tt.remaining); got != tt.want {
+				t.Errorf("pollInterval(%v) = %v, want %v", tt.remaining, got, tt.want)
+			}
+		})
+	}
+
+	// A market two minutes out is polled once per idle interval
+	s := &Sniper{config: &config.Config{}}
+	tracked := &TrackedMarket{}
+	now := time.Now()
+	interval := s.pollInterval(2 * time.Minute)
+	polls := 0
+	for at := now; at.Before(now.Add(20 * time.Second)); at = at.Add(100 * time.Millisecond) {
+		if tracked.pollDue(at, interval) {
+			polls++
+		}
+	}
+	if polls != 4 {
+		t.Errorf("polled %d times in 20s with %v left, want 4", polls, 2*time.Minute)
+	}
+}
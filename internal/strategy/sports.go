@@ -33,7 +33,8 @@ type TrackedSportsMarket struct {
 
 	// Matched ESPN game
 	Game     *sports.Game
-	TeamName string // Team this market is betting on (e.g., "Rams")
+	GameAt   time.Time // When Game was fetched from ESPN
+	TeamName string    // Team this market is betting on (e.g., "Rams")
 
 	// Prices from Gamma
 	YesPrice float64
@@ -46,15 +47,25 @@ type TrackedSportsMarket struct {
 
 // SportsSniper implements the sniping strategy for sports markets.
 type SportsSniper struct {
-	config   *config.Config
-	gamma    *gamma.Client
-	espn     *sports.ESPNClient
-	clob     *clob.Client
-	builder  *clob.OrderBuilder
-	telegram *telegram.Bot
+	config     *config.Config
+	gamma      gamma.MarketSource
+	espn       *sports.ESPNClient
+	clob       clob.CLOBClient
+	builder    *clob.OrderBuilder
+	telegram   *telegram.Bot
+	emptyScans *emptyScanWatchdog // Alerts when scans keep finding no markets
+	gammaLimit *rateLimitBackoff  // Pauses scans after a Gamma rate limit
+	arming     *armGate           // Holds live orders for LIVE_ARM_DELAY, nil when disabled
+	authStop   *authStop          // Halts Run once the CLOB rejects the API credentials
+	decided    decidedLeads       // Per-quarter leads that call a game regardless of the model
+	mode       string             // SPORTS_MODE: which game states may trade, "" = both
 
 	activeMarkets map[string]*TrackedSportsMarket
 	mu            sync.RWMutex
+
+	// Session totals for the shutdown summary
+	startedAt time.Time
+	session   sessionCounter
 }
 
 // NewSportsSniper creates a new SportsSniper instance.
@@ -73,23 +84,68 @@ func NewSportsSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*S
 	} else {
 		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
 	}
+	clobClient := clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, w.AddressHex()).
+		WithUTLS(cfg.CLOBUTLS).
+		WithOrderRetries(cfg.CLOBOrderRetries)
+	rounding, err := clob.ParseRoundingMode(cfg.OrderSizeRounding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_SIZE_ROUNDING: %w", err)
+	}
+	builder.WithTickSizes(clobClient).WithMinOrderSizes(clobClient).WithRoundingMode(rounding)
+	withSignatureCheck(cfg, clobClient, builder)
+	authStop := newAuthStop("sports", tg)
+	authStop.watch(cfg, clobClient, w, builder)
+
+	decided, err := parseDecidedLeads(cfg.SportsDecidedLeads)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SPORTS_DECIDED_LEADS: %w", err)
+	}
+	mode, err := parseSportsMode(cfg.SportsMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SPORTS_MODE: %w", err)
+	}
 
 	return &SportsSniper{
 		config:        cfg,
 		gamma:         gamma.NewClient(),
-		espn:          sports.NewESPNClient(),
-		clob:          clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, w.AddressHex()),
+		espn:          sports.NewESPNClient().WithTimeout(cfg.ESPNTimeout).WithRetries(cfg.ESPNRetries).WithCacheTTL(cfg.ESPNCacheTTL),
+		clob:          clobClient,
 		builder:       builder,
 		telegram:      tg,
+		emptyScans:    newEmptyScanWatchdog("sports", cfg.EmptyScanAlertAfter, tg),
+		gammaLimit:    newRateLimitBackoff("sports"),
+		arming:        newArmGate("sports", cfg),
+		authStop:      authStop,
+		decided:       decided,
+		mode:          mode,
 		activeMarkets: make(map[string]*TrackedSportsMarket),
 	}, nil
 }
 
+// WithMarketSource replaces the Gamma client markets are fetched from
+// (useful for testing).
+func (s *SportsSniper) WithMarketSource(src gamma.MarketSource) *SportsSniper {
+	s.gamma = src
+	return s
+}
+
 // Run starts the sports sniper and blocks until context is cancelled.
 func (s *SportsSniper) Run(ctx context.Context) error {
+	s.startedAt = time.Now()
+	ctx = s.authStop.bind(ctx)
 	log.Printf("[sports] starting in %s mode", s.modeString())
-	log.Printf("[sports] config: max_position=$%.2f, min_win_prob=%.0f%%",
-		s.config.MaxPositionSize, minWinProbability*100)
+	log.Printf("[sports] config: shares_per_trade=%.2f, min_win_prob=%.0f%%",
+		s.config.SportsShares(), minWinProbability*100)
+	log.Printf("[sports] config: decided_leads=%s, sports_mode=%s", s.decided, s.mode)
+	if s.config.MaxSessionLoss > 0 {
+		log.Printf("[sports] warning: MAX_SESSION_LOSS applies to weather and blackswan only, sports ignores it")
+	}
+
+	if err := checkLiveAllowance(ctx, "sports", s.config, s.clob, s.builder, false); err != nil {
+		return err
+	}
+	syncLiveNonce("sports", s.config, s.builder)
+	s.arming.start(ctx)
 
 	// Initial scan for markets
 	if err := s.ScanForMarkets(); err != nil {
@@ -106,7 +162,8 @@ func (s *SportsSniper) Run(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			log.Printf("[sports] shutting down")
-			return ctx.Err()
+			s.logSessionSummary()
+			return context.Cause(ctx)
 
 		case <-scanTicker.C:
 			if err := s.ScanForMarkets(); err != nil {
@@ -123,18 +180,33 @@ func (s *SportsSniper) Run(ctx context.Context) error {
 
 // ScanForMarkets discovers NFL playoff markets and matches them to ESPN games.
 func (s *SportsSniper) ScanForMarkets() error {
-	// Get NFL playoff markets from Polymarket
+	if s.gammaLimit.paused(time.Now()) {
+		return nil
+	}
+
+	// ESPN games are fetched alongside the Polymarket markets rather than
+	// after them
+	var games []sports.Game
+	var espnErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		games, espnErr = s.espn.GetNFLGames()
+	}()
+
 	markets, err := s.gamma.GetNFLPlayoffMarkets()
+	wg.Wait()
+	s.emptyScans.observe(len(markets))
 	if err != nil {
+		s.gammaLimit.observe(err, time.Now())
 		return fmt.Errorf("failed to fetch sports markets: %w", err)
 	}
-
-	// Get live NFL games from ESPN
-	games, err := s.espn.GetNFLGames()
-	if err != nil {
-		log.Printf("[sports] warning: failed to fetch ESPN games: %v", err)
+	if espnErr != nil {
+		log.Printf("[sports] warning: failed to fetch ESPN games: %v", espnErr)
 		games = []sports.Game{}
 	}
+	_, fetchedAt := s.espn.LastNFLGames()
 
 	log.Printf("[sports] found %d playoff markets, %d live games", len(markets), len(games))
 
@@ -152,6 +224,7 @@ func (s *SportsSniper) ScanForMarkets() error {
 			log.Printf("[sports] failed to track market %s: %v", market.Slug, err)
 			continue
 		}
+		tracked.GameAt = fetchedAt
 
 		s.mu.Lock()
 		s.activeMarkets[market.Slug] = tracked
@@ -175,19 +248,17 @@ func (s *SportsSniper) trackMarket(market gamma.Market, games []sports.Game) (*T
 		return nil, fmt.Errorf("failed to parse end time: %w", err)
 	}
 
+	// Orders for empty or malformed token IDs would only fail at signing
+	if !market.HasValidTokens() {
+		return nil, fmt.Errorf("market missing valid YES/NO token IDs")
+	}
 	yesToken := market.GetYesToken()
 	noToken := market.GetNoToken()
 
-	if yesToken == nil || noToken == nil {
-		return nil, fmt.Errorf("market missing YES or NO token")
-	}
-
 	// Parse outcome prices
-	prices := market.ParseOutcomePrices()
-	yesPrice, noPrice := 0.5, 0.5
-	if len(prices) >= 2 {
-		yesPrice = prices[0]
-		noPrice = prices[1]
+	yesPrice, noPrice, ok := market.YesNoPrices()
+	if !ok {
+		yesPrice, noPrice = 0.5, 0.5
 	}
 
 	tracked := &TrackedSportsMarket{
@@ -262,11 +333,13 @@ func gameMatchesTeam(game *sports.Game, teamName string) bool {
 
 // CheckAndSnipe evaluates all tracked markets and executes snipes when conditions are met.
 func (s *SportsSniper) CheckAndSnipe() error {
-	// Refresh ESPN game data
-	games, err := s.espn.GetNFLGames()
-	if err != nil {
+	// Refresh ESPN game data, unless a scan just did. On failure the last
+	// successful fetch is used, and analyzeMarket refuses to trade once it's
+	// too old
+	if _, err := s.espn.GetNFLGames(); err != nil {
 		log.Printf("[sports] warning: failed to refresh games: %v", err)
 	}
+	games, fetchedAt := s.espn.LastNFLGames()
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -281,6 +354,7 @@ func (s *SportsSniper) CheckAndSnipe() error {
 			for i := range games {
 				if gameMatchesTeam(&games[i], tracked.TeamName) {
 					tracked.Game = &games[i]
+					tracked.GameAt = fetchedAt
 					break
 				}
 			}
@@ -323,6 +397,14 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 		return analysis
 	}
 
+	// Stale scores could show a lead or a final result that isn't real
+	if maxAge := s.config.SportsMaxStale; maxAge > 0 {
+		if age := time.Since(tracked.GameAt); age > maxAge {
+			analysis.Reason = fmt.Sprintf("ESPN data %v old > max %v", age.Truncate(time.Second), maxAge)
+			return analysis
+		}
+	}
+
 	game := tracked.Game
 
 	// Log game status
@@ -335,6 +417,11 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 
 	// Check if game is final
 	if game.Status == sports.StatusFinal {
+		if s.mode == sportsModeLive {
+			analysis.Reason = "game final, but SPORTS_MODE=live only trades games in progress"
+			return analysis
+		}
+
 		winner := game.Winner()
 		if winner == nil {
 			analysis.Reason = "game ended in tie (no winner)"
@@ -357,19 +444,28 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 		}
 
 		// Only trade if price is favorable (not already at 0.99)
-		if analysis.EntryPrice >= 0.99 {
+		if analysis.EntryPrice >= s.maxEntryPrice(0.99) {
 			analysis.Reason = fmt.Sprintf("game final but price too high (%.2f)", analysis.EntryPrice)
 			return analysis
 		}
+		if reason := s.marginShortfall(analysis.EntryPrice, analysis.WinProbability); reason != "" {
+			analysis.Reason = "game final but " + reason
+			return analysis
+		}
 
 		analysis.ShouldTrade = true
-		analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * s.config.MaxPositionSize
+		analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * s.config.SportsShares()
 		analysis.Reason = "game final"
 		return analysis
 	}
 
 	// Check if game is "decided" (big lead late)
 	if game.Status == sports.StatusInProgress {
+		if s.mode == sportsModeFinal {
+			analysis.Reason = "game in progress, but SPORTS_MODE=final only trades final games"
+			return analysis
+		}
+
 		winProb := game.WinProbability()
 		leader := game.Leader()
 
@@ -378,6 +474,12 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 			return analysis
 		}
 
+		// A big enough lead for the quarter settles it, whatever the model says
+		decided := s.decided.decided(game)
+		if decided {
+			winProb = 1.0
+		}
+
 		if winProb < minWinProbability {
 			analysis.Reason = fmt.Sprintf("win probability %.0f%% < %.0f%% threshold",
 				winProb*100, minWinProbability*100)
@@ -400,15 +502,22 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 		analysis.WinProbability = winProb
 
 		// Only trade if price is favorable
-		if analysis.EntryPrice >= 0.95 {
+		if analysis.EntryPrice >= s.maxEntryPrice(0.95) {
 			analysis.Reason = fmt.Sprintf("price too high (%.2f) for %.0f%% probability",
 				analysis.EntryPrice, winProb*100)
 			return analysis
 		}
+		if reason := s.marginShortfall(analysis.EntryPrice, winProb); reason != "" {
+			analysis.Reason = reason
+			return analysis
+		}
 
 		analysis.ShouldTrade = true
-		analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * s.config.MaxPositionSize * winProb
+		analysis.ExpectedProfit = (1.0 - analysis.EntryPrice) * s.config.SportsShares() * winProb
 		analysis.Reason = fmt.Sprintf("high win probability (%.0f%%)", winProb*100)
+		if decided {
+			analysis.Reason = fmt.Sprintf("game decided (%d-point lead in Q%d)", game.PointDifferential(), game.Quarter)
+		}
 		return analysis
 	}
 
@@ -416,6 +525,47 @@ func (s *SportsSniper) analyzeMarket(tracked *TrackedSportsMarket) SportsTradeAn
 	return analysis
 }
 
+// maxEntryPrice returns SPORTS_MAX_ENTRY_PRICE, or def when it isn't set.
+// Prices at or above it aren't bought.
+func (s *SportsSniper) maxEntryPrice(def float64) float64 {
+	if s.config.SportsMaxEntry > 0 {
+		return s.config.SportsMaxEntry
+	}
+	return def
+}
+
+// marginShortfall returns why buying at price for a winProb chance of $1
+// falls short of SPORTS_MIN_PROFIT_MARGIN, or "" when it doesn't.
+func (s *SportsSniper) marginShortfall(price, winProb float64) string {
+	if s.config.SportsMargin <= 0 || price <= 0 {
+		return ""
+	}
+	if margin := winProb/price - 1; margin < s.config.SportsMargin {
+		return fmt.Sprintf("expected return %.1f%% at %.2f < %.1f%% minimum margin",
+			margin*100, price, s.config.SportsMargin*100)
+	}
+	return ""
+}
+
+// Values of SPORTS_MODE.
+const (
+	sportsModeFinal = "final" // Only games ESPN reports final, a near risk-free edge
+	sportsModeLive  = "live"  // Only in-progress games with a high win probability
+	sportsModeBoth  = "both"
+)
+
+// parseSportsMode parses SPORTS_MODE. Empty means both.
+func parseSportsMode(mode string) (string, error) {
+	switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+	case "", sportsModeBoth:
+		return sportsModeBoth, nil
+	case sportsModeFinal, sportsModeLive:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid sports mode %q: must be final, live or both", mode)
+	}
+}
+
 // refreshMarketPrices updates market prices from Gamma API.
 func (s *SportsSniper) refreshMarketPrices(tracked *TrackedSportsMarket) {
 	market, err := s.gamma.GetMarketBySlug(tracked.Market.Slug)
@@ -423,11 +573,10 @@ func (s *SportsSniper) refreshMarketPrices(tracked *TrackedSportsMarket) {
 		return
 	}
 
-	prices := market.ParseOutcomePrices()
-	if len(prices) >= 2 {
+	if yes, no, ok := market.YesNoPrices(); ok {
 		tracked.mu.Lock()
-		tracked.YesPrice = prices[0]
-		tracked.NoPrice = prices[1]
+		tracked.YesPrice = yes
+		tracked.NoPrice = no
 		tracked.mu.Unlock()
 	}
 }
@@ -439,6 +588,13 @@ func (s *SportsSniper) executeSnipe(tracked *TrackedSportsMarket, analysis Sport
 		analysis.Side, analysis.EntryPrice, analysis.WinProbability*100, analysis.ExpectedProfit)
 	log.Printf("[sports]   reason: %s", analysis.Reason)
 
+	if err := s.arming.check(); err != nil {
+		log.Printf("[sports] NOT ARMED: would buy %s at %.4f", analysis.Side, analysis.EntryPrice)
+		return err
+	}
+
+	s.session.recordPlaced()
+
 	if s.config.DryRun {
 		log.Printf("[sports] DRY_RUN: WOULD BUY %s at %.4f", analysis.Side, analysis.EntryPrice)
 
@@ -456,6 +612,7 @@ func (s *SportsSniper) executeSnipe(tracked *TrackedSportsMarket, analysis Sport
 		}
 
 		tracked.Sniped = true
+		s.session.recordFill(s.config.SportsShares()*analysis.EntryPrice, analysis.ExpectedProfit)
 		return nil
 	}
 
@@ -477,7 +634,7 @@ func (s *SportsSniper) executeSnipe(tracked *TrackedSportsMarket, analysis Sport
 	}
 
 	// Build and submit order
-	size := s.config.MaxPositionSize
+	size := s.config.SportsShares()
 	orderReq, err := s.builder.BuildFOKBuyOrder(analysis.TokenID, actualAsk, size)
 	if err != nil {
 		return fmt.Errorf("failed to build order: %w", err)
@@ -494,6 +651,7 @@ func (s *SportsSniper) executeSnipe(tracked *TrackedSportsMarket, analysis Sport
 
 	log.Printf("[sports] ORDER FILLED: %s at %.4f (order ID: %s)",
 		analysis.Side, actualAsk, resp.OrderID)
+	s.session.recordFill(size*actualAsk, analysis.ExpectedProfit)
 
 	if s.telegram != nil {
 		if err := s.telegram.NotifyOrderExecuted(analysis.Side, actualAsk, size, analysis.ExpectedProfit); err != nil {
@@ -505,6 +663,11 @@ func (s *SportsSniper) executeSnipe(tracked *TrackedSportsMarket, analysis Sport
 	return nil
 }
 
+// logSessionSummary reports the session's trading recap on shutdown.
+func (s *SportsSniper) logSessionSummary() {
+	reportSessionSummary("sports", s.telegram, s.session.summary("Sports", s.modeString(), s.startedAt))
+}
+
 func (s *SportsSniper) modeString() string {
 	if s.config.DryRun {
 		return "DRY_RUN"
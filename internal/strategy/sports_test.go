@@ -0,0 +1,298 @@
+package strategy
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/sports"
+)
+
+func TestSportsAnalyzeMarket_StaleGameData(t *testing.T) {
+	final := &sports.Game{
+		Status:   sports.StatusFinal,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 28},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	tests := []struct {
+		name      string
+		age       time.Duration
+		maxStale  time.Duration
+		wantTrade bool
+	}{
+		{"fresh", 5 * time.Second, 30 * time.Second, true},
+		{"stale", 2 * time.Minute, 30 * time.Second, false},
+		{"no limit", 2 * time.Minute, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SportsSniper{config: &config.Config{MaxPositionSize: 10, SportsMaxStale: tt.maxStale}}
+			tracked := &TrackedSportsMarket{
+				YesTokenID: "1",
+				NoTokenID:  "2",
+				Game:       final,
+				GameAt:     time.Now().Add(-tt.age),
+				TeamName:   "Eagles",
+				YesPrice:   0.90,
+				NoPrice:    0.10,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s), want %v", analysis.ShouldTrade, analysis.Reason, tt.wantTrade)
+			}
+			if !tt.wantTrade && !strings.Contains(analysis.Reason, "ESPN data") {
+				t.Errorf("Reason = %q, want stale ESPN data", analysis.Reason)
+			}
+		})
+	}
+}
+
+// rendezvousSource blocks GetNFLPlayoffMarkets until ESPN has been called,
+// which only happens if the two are fetched concurrently.
+type rendezvousSource struct {
+	*gammamock.Client
+	espnCalled  <-chan struct{}
+	gammaCalled chan<- struct{}
+}
+
+func (r *rendezvousSource) GetNFLPlayoffMarkets() ([]gamma.Market, error) {
+	close(r.gammaCalled)
+	select {
+	case <-r.espnCalled:
+		return r.Client.GetNFLPlayoffMarkets()
+	case <-time.After(2 * time.Second):
+		return nil, errors.New("ESPN was not fetched while Gamma was in flight")
+	}
+}
+
+func TestSportsScanForMarkets_ConcurrentFetchAndCache(t *testing.T) {
+	espnCalled := make(chan struct{})
+	gammaCalled := make(chan struct{})
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			close(espnCalled)
+			select {
+			case <-gammaCalled:
+			case <-time.After(2 * time.Second):
+				t.Error("Gamma was not fetched while ESPN was in flight")
+			}
+		}
+		w.Write([]byte(`{"events": []}`))
+	}))
+	defer srv.Close()
+
+	s := &SportsSniper{
+		config:        &config.Config{MaxPositionSize: 10},
+		gamma:         &rendezvousSource{Client: gammamock.New(), espnCalled: espnCalled, gammaCalled: gammaCalled},
+		espn:          sports.NewESPNClient().WithBaseURL(srv.URL).WithRetries(0).WithCacheTTL(time.Minute),
+		activeMarkets: make(map[string]*TrackedSportsMarket),
+	}
+
+	if err := s.ScanForMarkets(); err != nil {
+		t.Fatalf("ScanForMarkets: %v", err)
+	}
+
+	// The check right after the scan reuses its games
+	if err := s.CheckAndSnipe(); err != nil {
+		t.Fatalf("CheckAndSnipe: %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("ESPN fetched %d times, want 1 within the cache TTL", got)
+	}
+}
+
+func TestSportsAnalyzeMarket_Mode(t *testing.T) {
+	final := &sports.Game{
+		Status:   sports.StatusFinal,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 28},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	// Two scores up in the fourth: a 95% favorite, not yet decided
+	live := &sports.Game{
+		Status:   sports.StatusInProgress,
+		Quarter:  4,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 21},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	tests := []struct {
+		mode      string
+		game      *sports.Game
+		wantTrade bool
+	}{
+		{"both", final, true},
+		{"both", live, true},
+		{"final", final, true},
+		{"final", live, false},
+		{"live", final, false},
+		{"live", live, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+"/"+string(tt.game.Status), func(t *testing.T) {
+			mode, err := parseSportsMode(tt.mode)
+			if err != nil {
+				t.Fatalf("parseSportsMode(%q): %v", tt.mode, err)
+			}
+			s := &SportsSniper{config: &config.Config{MaxPositionSize: 10}, mode: mode}
+			tracked := &TrackedSportsMarket{
+				YesTokenID: "1",
+				NoTokenID:  "2",
+				Game:       tt.game,
+				GameAt:     time.Now(),
+				TeamName:   "Eagles",
+				YesPrice:   0.90,
+				NoPrice:    0.10,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s), want %v", analysis.ShouldTrade, analysis.Reason, tt.wantTrade)
+			}
+			if !tt.wantTrade && !strings.Contains(analysis.Reason, "SPORTS_MODE") {
+				t.Errorf("Reason = %q, want the mode named", analysis.Reason)
+			}
+		})
+	}
+}
+
+func TestParseSportsMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "both", false},
+		{"both", "both", false},
+		{" Final ", "final", false},
+		{"LIVE", "live", false},
+		{"in-progress", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseSportsMode(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("parseSportsMode(%q) = %q, %v; want %q, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSportsAnalyzeMarket_EntryPriceAndMargin(t *testing.T) {
+	final := &sports.Game{
+		Status:   sports.StatusFinal,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 28},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	live := &sports.Game{
+		Status:   sports.StatusInProgress,
+		Quarter:  4,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 21},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	tests := []struct {
+		name      string
+		game      *sports.Game
+		price     float64
+		maxEntry  float64
+		margin    float64
+		wantTrade bool
+	}{
+		{"final at 0.98 without limits", final, 0.98, 0, 0, true},
+		{"final at 0.98 below 3% margin", final, 0.98, 0, 0.03, false},
+		{"final at 0.96 clears 3% margin", final, 0.96, 0, 0.03, true},
+		{"final at 0.96 above max entry", final, 0.96, 0.95, 0, false},
+		{"final at 0.94 under max entry", final, 0.94, 0.95, 0, true},
+		{"live 95% at 0.90 clears 5% margin", live, 0.90, 0, 0.05, true},
+		{"live 95% at 0.90 below 10% margin", live, 0.90, 0, 0.10, false},
+		{"live max entry tighter than default", live, 0.90, 0.85, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SportsSniper{config: &config.Config{MaxPositionSize: 10, SportsMaxEntry: tt.maxEntry, SportsMargin: tt.margin}}
+			tracked := &TrackedSportsMarket{
+				YesTokenID: "1",
+				NoTokenID:  "2",
+				Game:       tt.game,
+				GameAt:     time.Now(),
+				TeamName:   "Eagles",
+				YesPrice:   tt.price,
+				NoPrice:    1 - tt.price,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if analysis.ShouldTrade != tt.wantTrade {
+				t.Fatalf("ShouldTrade = %v (%s), want %v", analysis.ShouldTrade, analysis.Reason, tt.wantTrade)
+			}
+		})
+	}
+}
+
+func TestSportsTrackMarket_ReversedOutcomes(t *testing.T) {
+	s := &SportsSniper{config: &config.Config{MaxPositionSize: 10}}
+	for _, market := range []gamma.Market{
+		{Slug: "yes-first", ClobTokenIDs: `["111","222"]`, Outcomes: `["Yes","No"]`, OutcomePrices: `["0.90","0.10"]`},
+		{Slug: "no-first", ClobTokenIDs: `["222","111"]`, Outcomes: `["No","Yes"]`, OutcomePrices: `["0.10","0.90"]`},
+	} {
+		market.Question = "Will the Eagles win the Super Bowl?"
+		market.EndDate = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+
+		tracked, err := s.trackMarket(market, nil)
+		if err != nil {
+			t.Fatalf("%s: trackMarket: %v", market.Slug, err)
+		}
+		if tracked.YesTokenID != "111" || tracked.NoTokenID != "222" {
+			t.Errorf("%s: tokens YES=%s NO=%s, want 111/222", market.Slug, tracked.YesTokenID, tracked.NoTokenID)
+		}
+		if tracked.YesPrice != 0.90 || tracked.NoPrice != 0.10 {
+			t.Errorf("%s: prices YES=%v NO=%v, want 0.90/0.10", market.Slug, tracked.YesPrice, tracked.NoPrice)
+		}
+	}
+}
+
+func TestSportsAnalyzeMarket_SportsSharesPerTrade(t *testing.T) {
+	final := &sports.Game{
+		Status:   sports.StatusFinal,
+		HomeTeam: sports.Team{Name: "Philadelphia Eagles", Score: 28},
+		AwayTeam: sports.Team{Name: "Los Angeles Rams", Score: 7},
+	}
+	tests := []struct {
+		name       string
+		shares     float64
+		wantProfit float64
+	}{
+		{"SPORTS_SHARES_PER_TRADE", 4, 0.40},
+		{"falls back to MAX_POSITION_SIZE", 0, 1.00},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SportsSniper{config: &config.Config{MaxPositionSize: 10, SportsSharesPerTrade: tt.shares}}
+			tracked := &TrackedSportsMarket{
+				YesTokenID: "1",
+				NoTokenID:  "2",
+				Game:       final,
+				GameAt:     time.Now(),
+				TeamName:   "Eagles",
+				YesPrice:   0.90,
+				NoPrice:    0.10,
+			}
+
+			analysis := s.analyzeMarket(tracked)
+			if !analysis.ShouldTrade {
+				t.Fatalf("ShouldTrade = false (%s), want true", analysis.Reason)
+			}
+			if math.Abs(analysis.ExpectedProfit-tt.wantProfit) > 1e-9 {
+				t.Errorf("ExpectedProfit = %.4f, want %.2f", analysis.ExpectedProfit, tt.wantProfit)
+			}
+		})
+	}
+}
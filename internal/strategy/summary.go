@@ -0,0 +1,103 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/telegram"
+)
+
+// SessionSummary is the end-of-run recap a strategy reports on shutdown.
+type SessionSummary struct {
+	Strategy string
+	Mode     string
+	Runtime  time.Duration
+	Placed   int
+	Filled   int
+	Canceled int
+	Exposure float64  // Cost of positions still open
+	PnL      float64  // Estimated P&L, see PnLBasis
+	PnLBasis string   // How PnL was estimated; empty when P&L is not tracked
+	Notes    []string // Strategy-specific extra lines
+}
+
+// Lines renders the summary one figure per line.
+func (s SessionSummary) Lines() []string {
+	pnl := "n/a"
+	if s.PnLBasis != "" {
+		pnl = fmt.Sprintf("$%+.2f (%s)", s.PnL, s.PnLBasis)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Runtime: %v", s.Runtime.Round(time.Second)),
+		fmt.Sprintf("Trades: placed=%d, filled=%d, canceled=%d", s.Placed, s.Filled, s.Canceled),
+		fmt.Sprintf("Exposure: $%.2f", s.Exposure),
+		fmt.Sprintf("Est. P&L: %s", pnl),
+	}
+	return append(lines, s.Notes...)
+}
+
+// String formats the summary as a Telegram message.
+func (s SessionSummary) String() string {
+	return fmt.Sprintf("%s Session Summary [%s]\n\n%s", s.Strategy, s.Mode, strings.Join(s.Lines(), "\n"))
+}
+
+// reportSessionSummary logs the summary under prefix and sends it to Telegram.
+func reportSessionSummary(prefix string, tg *telegram.Bot, summary SessionSummary) {
+	log.Printf("[%s] SESSION SUMMARY (%s)", prefix, summary.Mode)
+	for _, line := range summary.Lines() {
+		log.Printf("[%s]   %s", prefix, line)
+	}
+
+	if tg != nil {
+		if err := tg.SendMessage(summary.String()); err != nil {
+			log.Printf("[%s] telegram error: %v", prefix, err)
+		}
+	}
+}
+
+// sessionCounter tallies orders for strategies that place fill-or-kill
+// orders and have no trade counters of their own.
+type sessionCounter struct {
+	mu             sync.Mutex
+	placed         int
+	filled         int
+	cost           float64
+	expectedProfit float64
+}
+
+// recordPlaced counts an order sent (or simulated in dry run).
+func (sc *sessionCounter) recordPlaced() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.placed++
+}
+
+// recordFill counts a filled order with its cost and expected profit.
+func (sc *sessionCounter) recordFill(cost, expectedProfit float64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.filled++
+	sc.cost += cost
+	sc.expectedProfit += expectedProfit
+}
+
+// summary fills in the trade figures of a SessionSummary. Exposure defaults
+// to the cost of all fills, since positions are held to resolution.
+func (sc *sessionCounter) summary(strategy, mode string, startedAt time.Time) SessionSummary {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return SessionSummary{
+		Strategy: strategy,
+		Mode:     mode,
+		Runtime:  time.Since(startedAt),
+		Placed:   sc.placed,
+		Filled:   sc.filled,
+		Exposure: sc.cost,
+		PnL:      sc.expectedProfit,
+		PnLBasis: "expected, if fills resolve as predicted",
+	}
+}
@@ -0,0 +1,81 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionSummary_Lines(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary SessionSummary
+		want    []string
+	}{
+		{
+			name: "tracked pnl",
+			summary: SessionSummary{
+				Runtime:  90*time.Minute + 400*time.Millisecond,
+				Placed:   5,
+				Filled:   3,
+				Canceled: 2,
+				Exposure: 4.5,
+				PnL:      1.25,
+				PnLBasis: "paper",
+				Notes:    []string{"Daily loss: $2.00"},
+			},
+			want: []string{
+				"Runtime: 1h30m0s",
+				"Trades: placed=5, filled=3, canceled=2",
+				"Exposure: $4.50",
+				"Est. P&L: $+1.25 (paper)",
+				"Daily loss: $2.00",
+			},
+		},
+		{
+			name:    "untracked pnl",
+			summary: SessionSummary{PnL: 3},
+			want: []string{
+				"Runtime: 0s",
+				"Trades: placed=0, filled=0, canceled=0",
+				"Exposure: $0.00",
+				"Est. P&L: n/a",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.summary.Lines()
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Lines() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestSessionCounter_Summary(t *testing.T) {
+	var sc sessionCounter
+	sc.recordPlaced()
+	sc.recordPlaced()
+	sc.recordPlaced()
+	sc.recordFill(9.8, 0.2)
+	sc.recordFill(4.9, 0.1)
+
+	summary := sc.summary("Sniper", "DRY_RUN", time.Now().Add(-time.Minute))
+	if summary.Placed != 3 || summary.Filled != 2 {
+		t.Errorf("placed/filled = %d/%d, want 3/2", summary.Placed, summary.Filled)
+	}
+	if diff := summary.Exposure - 14.7; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("exposure = %v, want 14.7", summary.Exposure)
+	}
+	if diff := summary.PnL - 0.3; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("pnl = %v, want 0.3", summary.PnL)
+	}
+	if summary.Runtime < time.Minute {
+		t.Errorf("runtime = %v, want at least 1m", summary.Runtime)
+	}
+	if !strings.HasPrefix(summary.String(), "Sniper Session Summary [DRY_RUN]") {
+		t.Errorf("String() = %q", summary.String())
+	}
+}
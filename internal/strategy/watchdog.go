@@ -0,0 +1,106 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/telegram"
+)
+
+// emptyScanWatchdog notices when market scans keep finding nothing, which
+// usually means Gamma changed its slug pattern or the account is blocked
+// rather than that there are genuinely no markets.
+type emptyScanWatchdog struct {
+	prefix    string
+	threshold int // Consecutive empty scans before alerting, 0 disables
+	telegram  *telegram.Bot
+
+	empty    int
+	lastSeen time.Time // Last non-empty scan, or when the watchdog started
+	alerted  bool
+}
+
+func newEmptyScanWatchdog(prefix string, threshold int, tg *telegram.Bot) *emptyScanWatchdog {
+	return &emptyScanWatchdog{
+		prefix:    prefix,
+		threshold: threshold,
+		telegram:  tg,
+		lastSeen:  time.Now(),
+	}
+}
+
+// observe records how many markets a scan found and alerts once when the
+// empty streak reaches the threshold. A failed fetch counts as empty.
+func (w *emptyScanWatchdog) observe(markets int) {
+	if w == nil {
+		return
+	}
+	if msg := w.record(markets, time.Now()); msg != "" {
+		log.Printf("[%s] WARNING: %s", w.prefix, msg)
+		if w.telegram != nil {
+			if err := w.telegram.SendAlert("No Markets Found", msg); err != nil {
+				log.Printf("[%s] telegram error: %v", w.prefix, err)
+			}
+		}
+	}
+}
+
+// record updates the streak and returns the alert text when one is due.
+func (w *emptyScanWatchdog) record(markets int, now time.Time) string {
+	if markets > 0 {
+		if w.alerted {
+			log.Printf("[%s] markets found again after %d empty scans", w.prefix, w.empty)
+		}
+		w.empty = 0
+		w.alerted = false
+		w.lastSeen = now
+		return ""
+	}
+
+	w.empty++
+	if w.threshold <= 0 || w.alerted || w.empty < w.threshold {
+		return ""
+	}
+
+	w.alerted = true
+	return fmt.Sprintf("no markets found for %v (%d consecutive scans) — check API/slug pattern",
+		now.Sub(w.lastSeen).Round(time.Minute), w.empty)
+}
+
+// defaultRateLimitPause is how long scans wait after a Gamma rate limit that
+// didn't say when to retry.
+const defaultRateLimitPause = time.Minute
+
+// rateLimitBackoff pauses market scans after Gamma rate limits us, so the
+// scan loop doesn't keep the limit tripped.
+type rateLimitBackoff struct {
+	prefix string
+	until  time.Time
+}
+
+func newRateLimitBackoff(prefix string) *rateLimitBackoff {
+	return &rateLimitBackoff{prefix: prefix}
+}
+
+// paused reports whether a scan at now should be skipped.
+func (b *rateLimitBackoff) paused(now time.Time) bool {
+	return b != nil && now.Before(b.until)
+}
+
+// observe starts a pause when err is a Gamma rate limit, for as long as the
+// response's Retry-After asked or defaultRateLimitPause otherwise.
+func (b *rateLimitBackoff) observe(err error, now time.Time) {
+	if b == nil || !gamma.IsRateLimited(err) {
+		return
+	}
+	pause := defaultRateLimitPause
+	var ge *gamma.GammaError
+	if errors.As(err, &ge) && ge.RetryAfter > 0 {
+		pause = ge.RetryAfter
+	}
+	b.until = now.Add(pause)
+	log.Printf("[%s] Gamma rate limit hit, pausing scans for %v", b.prefix, pause)
+}
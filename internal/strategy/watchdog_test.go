@@ -0,0 +1,97 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+)
+
+func TestEmptyScanWatchdog(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := newEmptyScanWatchdog("test", 3, nil)
+	w.lastSeen = start
+
+	tests := []struct {
+		name      string
+		markets   int
+		at        time.Duration
+		wantAlert bool
+	}{
+		{"first empty scan", 0, 1 * time.Minute, false},
+		{"second empty scan", 0, 2 * time.Minute, false},
+		{"threshold reached", 0, 3 * time.Minute, true},
+		{"alerts once per streak", 0, 4 * time.Minute, false},
+		{"markets found resets", 2, 5 * time.Minute, false},
+		{"new streak starts over", 0, 6 * time.Minute, false},
+		{"second empty of new streak", 0, 7 * time.Minute, false},
+		{"new streak alerts", 0, 8 * time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := w.record(tt.markets, start.Add(tt.at))
+			if (msg != "") != tt.wantAlert {
+				t.Fatalf("record() = %q, wantAlert %v", msg, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestEmptyScanWatchdog_Message(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := newEmptyScanWatchdog("test", 2, nil)
+	w.lastSeen = start
+
+	w.record(0, start.Add(15*time.Minute))
+	msg := w.record(0, start.Add(30*time.Minute))
+	for _, want := range []string{"30m0s", "2 consecutive scans", "check API/slug pattern"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message %q missing %q", msg, want)
+		}
+	}
+}
+
+func TestEmptyScanWatchdog_Disabled(t *testing.T) {
+	w := newEmptyScanWatchdog("test", 0, nil)
+	for i := 0; i < 100; i++ {
+		if msg := w.record(0, time.Now()); msg != "" {
+			t.Fatalf("disabled watchdog alerted: %q", msg)
+		}
+	}
+
+	var nilWatchdog *emptyScanWatchdog
+	nilWatchdog.observe(0) // Must not panic
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newRateLimitBackoff("test")
+
+	b.observe(errors.New("connection reset"), now)
+	if b.paused(now) {
+		t.Fatal("paused after an error that isn't a rate limit")
+	}
+
+	b.observe(fmt.Errorf("failed: %w", &gamma.GammaError{StatusCode: http.StatusTooManyRequests}), now)
+	if !b.paused(now.Add(defaultRateLimitPause - time.Second)) {
+		t.Error("not paused within the default pause")
+	}
+	if b.paused(now.Add(defaultRateLimitPause)) {
+		t.Error("still paused after the default pause")
+	}
+
+	b.observe(&gamma.GammaError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Minute}, now)
+	if !b.paused(now.Add(4 * time.Minute)) {
+		t.Error("Retry-After not honored")
+	}
+
+	var nilBackoff *rateLimitBackoff
+	nilBackoff.observe(&gamma.GammaError{StatusCode: http.StatusTooManyRequests}, now) // Must not panic
+	if nilBackoff.paused(now) {
+		t.Error("nil backoff paused")
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/dantezy/polymarket-sniper/internal/api"
 	"github.com/dantezy/polymarket-sniper/internal/clob"
 	"github.com/dantezy/polymarket-sniper/internal/config"
 	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/pricing"
 	"github.com/dantezy/polymarket-sniper/internal/telegram"
 	"github.com/dantezy/polymarket-sniper/internal/wallet"
 	"github.com/dantezy/polymarket-sniper/internal/weather"
@@ -22,15 +26,22 @@ const (
 	weatherCheckInterval  = 30 * time.Second // Check positions every 30 seconds
 	weatherStatusInterval = 5 * time.Minute  // Log status every 5 minutes
 	weatherMaxOrderAge    = 12 * time.Hour   // Cancel orders older than this
+	weatherReevalInterval = 1 * time.Hour    // Re-check resting orders against fresh forecasts
 )
 
+// coinFlipPenalty scales confidence when an above/below threshold sits
+// within WEATHER_COIN_FLIP_MARGIN of the forecast mean.
+const coinFlipPenalty = 0.7
+
 // WeatherOpportunity represents a trading opportunity in a weather market.
 type WeatherOpportunity struct {
 	WeatherMarket      *gamma.WeatherMarket
 	Forecast           *weather.Forecast
+	ForecastAt         time.Time
 	OurProbYes         float64 // Our calculated probability for YES
 	MarketPriceYes     float64 // Market's implied probability (YES price)
 	Edge               float64 // OurProb - MarketPrice
+	NetEdge            float64 // Edge after estimated fees and bid-discount cost
 	ExpectedValue      float64 // EV of the trade
 	Side               string  // "yes" or "no"
 	TokenID            string
@@ -51,8 +62,11 @@ type WeatherPosition struct {
 	BidPrice       float64
 	Shares         float64
 	PlacedAt       time.Time
+	ForecastAt     time.Time // When the forecast Edge was priced from was fetched
 	Edge           float64
+	NetEdge        float64
 	Status         string // "open", "filled", "cancelled"
+	GroupKey       string // Bucket city/date group (bucketGroupKey), empty for other markets
 }
 
 // WeatherPositionTracker manages open weather positions.
@@ -117,24 +131,66 @@ func (pt *WeatherPositionTracker) HasMarket(slug string) bool {
 	return false
 }
 
+// GroupCount returns how many positions are held in a bucket group.
+func (pt *WeatherPositionTracker) GroupCount(key string) int {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+	count := 0
+	for _, pos := range pt.positions {
+		if pos.GroupKey == key {
+			count++
+		}
+	}
+	return count
+}
+
+// GroupExposure returns the combined cost of positions in a bucket group.
+func (pt *WeatherPositionTracker) GroupExposure(key string) float64 {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+	total := 0.0
+	for _, pos := range pt.positions {
+		if pos.GroupKey == key {
+			total += pos.Shares * pos.BidPrice
+		}
+	}
+	return total
+}
+
 // WeatherSniper implements a weather market trading strategy.
 type WeatherSniper struct {
-	config   *config.Config
-	gamma    *gamma.Client
-	clob     *clob.Client
-	builder  *clob.OrderBuilder
-	weather  *weather.Client
-	telegram *telegram.Bot
-	tracker  *WeatherPositionTracker
-	edgeCalc *weather.EdgeCalculator
+	config     *config.Config
+	gamma      gamma.MarketSource
+	clob       clob.CLOBClient
+	builder    *clob.OrderBuilder
+	weather    *weather.Client
+	gistemp    *weather.GISTEMPClient // Global anomaly record for global_temp markets
+	telegram   *telegram.Bot
+	emptyScans *emptyScanWatchdog // Alerts when scans keep finding no markets
+	gammaLimit *rateLimitBackoff  // Pauses scans after a Gamma rate limit
+	brackets   *bracketSeller     // Take-profit sells placed on fill, nil when disabled
+	lossStop   *sessionLossStop   // Halts new trades past MAX_SESSION_LOSS, nil when disabled
+	held       heldPositions      // Filled live positions awaiting resolution
+	accuracy   *calibrationLog    // Predicted vs resolved outcomes, nil when disabled
+	arming     *armGate           // Holds live orders for LIVE_ARM_DELAY, nil when disabled
+	authStop   *authStop          // Halts Run once the CLOB rejects the API credentials
+	holdings   *holdingsReport    // Wallet P&L from the Data API, nil in dry run
+	tracker    *WeatherPositionTracker
+	edgeCalc   *weather.EdgeCalculator
+	tempBias   weather.BiasOffsets
+	horizon    horizonBonuses // Score bonus by days to resolution, nil for the defaults
+	board      *api.Board
+	paper      *PaperAccount // Simulated balance, dry run only
 
 	// Balance tracking
 	walletAddr   string // For on-chain balance queries
 	bankroll     float64
 	dailyLoss    float64
 	lastResetDay int
+	lastReeval   time.Time // Last WEATHER_REEVALUATE pass over resting orders
 
 	// Stats
+	startedAt     time.Time
 	totalTrades   int
 	totalFilled   int
 	totalCanceled int
@@ -178,6 +234,7 @@ func NewWeatherSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*
 	} else {
 		clobClient = clob.NewClient(cfg.CLOBApiKey, cfg.CLOBSecret, cfg.CLOBPassphrase, walletAddr)
 	}
+	clobClient.WithUTLS(cfg.CLOBUTLS).WithOrderRetries(cfg.CLOBOrderRetries)
 
 	// Create order builder
 	var builder *clob.OrderBuilder
@@ -186,6 +243,58 @@ func NewWeatherSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*
 	} else {
 		builder = clob.NewOrderBuilder(w, cfg.CLOBApiKey)
 	}
+	rounding, err := clob.ParseRoundingMode(cfg.OrderSizeRounding)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_SIZE_ROUNDING: %w", err)
+	}
+	builder.WithTickSizes(clobClient).WithMinOrderSizes(clobClient).WithRoundingMode(rounding)
+	withSignatureCheck(cfg, clobClient, builder)
+	authStop := newAuthStop("weather", tg)
+	authStop.watch(cfg, clobClient, w, builder)
+
+	// Per-city model overrides replace the built-in preferences
+	weatherClient := weather.NewClient().WithRetries(cfg.WeatherAPIRetries)
+	if cfg.WeatherModelOverrides != "" {
+		overrides, err := weather.ParseModelOverrides(cfg.WeatherModelOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse WEATHER_MODEL_OVERRIDES: %w", err)
+		}
+		for city, models := range overrides {
+			log.Printf("[weather] model override: %s -> %v", city, models)
+		}
+		weatherClient.WithModelOverrides(overrides)
+	}
+
+	agreement, err := weather.ParseAgreementFunc(cfg.WeatherAgreement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WEATHER_AGREEMENT_FORMULA: %w", err)
+	}
+	if cfg.WeatherAgreement != "" {
+		log.Printf("[weather] model agreement formula: %s", cfg.WeatherAgreement)
+	}
+	weatherClient.WithAgreementFunc(agreement)
+
+	tempBias, err := weather.ParseBiasOffsets(cfg.WeatherTempBias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WEATHER_TEMP_BIAS: %w", err)
+	}
+	for city, offset := range tempBias {
+		log.Printf("[weather] forecast bias: %s %+.1f°C", city, offset)
+	}
+	var horizon horizonBonuses
+	if cfg.WeatherHorizonBonus != "" {
+		horizon, err = parseHorizonBonuses(cfg.WeatherHorizonBonus)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse WEATHER_HORIZON_BONUS: %w", err)
+		}
+		log.Printf("[weather] horizon bonus: %s", horizon)
+	}
+	if dof := cfg.WeatherTempDoF; dof != 0 {
+		if dof <= 2 {
+			return nil, fmt.Errorf("WEATHER_TEMP_DOF must be above 2, got %v", dof)
+		}
+		log.Printf("[weather] temperature error: Student's t with %.0f degrees of freedom", dof)
+	}
 
 	// Use proxy wallet for balance queries if configured
 	balanceAddr := walletAddr
@@ -193,23 +302,57 @@ func NewWeatherSniper(cfg *config.Config, w *wallet.Wallet, tg *telegram.Bot) (*
 		balanceAddr = cfg.ProxyWalletAddress
 	}
 
+	// Dry run trades against a simulated balance
+	var paper *PaperAccount
+	if cfg.DryRun {
+		start := cfg.PaperBalance
+		if start <= 0 {
+			start = cfg.WeatherBankroll
+			if cfg.WeatherBalance > 0 {
+				start = cfg.WeatherBalance
+			}
+		}
+		paper = NewPaperAccount(start)
+	}
+
 	return &WeatherSniper{
 		config:       cfg,
 		gamma:        gammaClient,
 		clob:         clobClient,
 		builder:      builder,
-		weather:      weather.NewClient(),
+		weather:      weatherClient,
+		gistemp:      weather.NewGISTEMPClient(),
 		telegram:     tg,
+		emptyScans:   newEmptyScanWatchdog("weather", cfg.EmptyScanAlertAfter, tg),
+		gammaLimit:   newRateLimitBackoff("weather"),
+		arming:       newArmGate("weather", cfg),
+		authStop:     authStop,
+		holdings:     newHoldingsReport("weather", cfg.DryRun, balanceAddr),
+		brackets:     newBracketSeller("weather", cfg.WeatherSellTarget),
+		lossStop:     newSessionLossStop("weather", cfg.MaxSessionLoss),
+		accuracy:     newCalibrationLog(cfg.WeatherCalibration),
+		tempBias:     tempBias,
+		horizon:      horizon,
 		tracker:      NewWeatherPositionTracker(),
 		edgeCalc:     weather.NewEdgeCalculator(),
+		paper:        paper,
 		walletAddr:   balanceAddr,
 		bankroll:     cfg.WeatherBankroll,
 		lastResetDay: time.Now().YearDay(),
 	}, nil
 }
 
+// WithMarketSource replaces the Gamma client markets are fetched from
+// (useful for testing).
+func (ws *WeatherSniper) WithMarketSource(src gamma.MarketSource) *WeatherSniper {
+	ws.gamma = src
+	return ws
+}
+
 // Run starts the weather sniper and blocks until context is cancelled.
 func (ws *WeatherSniper) Run(ctx context.Context) error {
+	ws.startedAt = time.Now()
+	ctx = ws.authStop.bind(ctx)
 	log.Printf("[weather] starting in %s mode", ws.modeString())
 	log.Printf("[weather] config: min_edge=%.0f%%, min_confidence=%.0f%%",
 		ws.config.WeatherMinEdge*100, ws.config.WeatherMinConfidence*100)
@@ -219,10 +362,20 @@ func (ws *WeatherSniper) Run(ctx context.Context) error {
 		ws.config.WeatherMinVolume, ws.config.WeatherMaxSpread*100)
 	log.Printf("[weather] bankroll: $%.2f", ws.bankroll)
 
+	if err := checkLiveAllowance(ctx, "weather", ws.config, ws.clob, ws.builder, true); err != nil {
+		return err
+	}
+	syncLiveNonce("weather", ws.config, ws.builder)
+	if _, err := cancelOrphanOrders("weather", ws.config, ws.clob, ws.trackedOrders(), ws.gamma.GetWeatherMarkets); err != nil {
+		log.Printf("[weather] orphaned order check failed: %v", err)
+	}
+	ws.arming.start(ctx)
+
 	// Initial scan
 	if err := ws.ScanAndTrade(); err != nil {
 		log.Printf("[weather] initial scan error: %v", err)
 	}
+	ws.publishState()
 
 	scanTicker := time.NewTicker(weatherScanInterval)
 	checkTicker := time.NewTicker(weatherCheckInterval)
@@ -236,7 +389,11 @@ func (ws *WeatherSniper) Run(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			log.Printf("[weather] shutting down")
-			return ctx.Err()
+			if ws.paper != nil {
+				ws.paper.LogSummary("weather")
+			}
+			ws.logSessionSummary()
+			return context.Cause(ctx)
 
 		case <-scanTicker.C:
 			if err := ws.ScanAndTrade(); err != nil {
@@ -251,6 +408,7 @@ func (ws *WeatherSniper) Run(ctx context.Context) error {
 		case <-statusTicker.C:
 			ws.logStatus()
 		}
+		ws.publishState()
 	}
 }
 
@@ -272,10 +430,17 @@ func (ws *WeatherSniper) ScanAndTrade() error {
 		return nil
 	}
 
+	// Unlike the daily limit this never resets; CheckPositions still runs
+	if ws.lossStop.Halted() {
+		log.Printf("[weather] session loss limit reached ($%+.2f realized), skipping scan", ws.lossStop.Realized())
+		return nil
+	}
+
 	opportunities, err := ws.FindOpportunities()
 	if err != nil {
 		return fmt.Errorf("failed to find opportunities: %w", err)
 	}
+	ws.publishScan(opportunities)
 
 	if len(opportunities) == 0 {
 		log.Printf("[weather] no opportunities found matching criteria")
@@ -309,6 +474,12 @@ func (ws *WeatherSniper) ScanAndTrade() error {
 			continue
 		}
 
+		// Sibling buckets are capped per city/date; opportunities are
+		// sorted, so the group keeps its top-scoring buckets
+		if key := bucketGroupKey(opp.WeatherMarket); key != "" && ws.groupCount(key) >= ws.maxBuckets() {
+			continue
+		}
+
 		// Place the trade
 		if err := ws.PlaceTrade(opp); err != nil {
 			log.Printf("[weather] failed to place trade: %v", err)
@@ -328,16 +499,28 @@ func (ws *WeatherSniper) ScanAndTrade() error {
 // FindOpportunities searches for weather markets with edge.
 func (ws *WeatherSniper) FindOpportunities() ([]*WeatherOpportunity, error) {
 	// Fetch weather markets from Gamma
+	if ws.gammaLimit.paused(time.Now()) {
+		return nil, nil
+	}
 	markets, err := ws.gamma.GetWeatherMarkets()
+	ws.emptyScans.observe(len(markets))
 	if err != nil {
+		ws.gammaLimit.observe(err, time.Now())
 		return nil, fmt.Errorf("failed to get weather markets: %w", err)
 	}
 
 	log.Printf("[weather] found %d weather markets", len(markets))
 
-	var opportunities []*WeatherOpportunity
-
+	// Cheap filters run inline; only the survivors cost forecast calls
+	var eligible []*gamma.WeatherMarket
+	siblings := make(map[string][]*gamma.WeatherMarket)
 	for _, market := range markets {
+		// ParseWeatherMarket rejects these too, but say why
+		if !market.HasValidTokens() {
+			log.Printf("[weather] skipping %s: missing or invalid token IDs", market.Slug)
+			continue
+		}
+
 		// Parse as weather market
 		wm := gamma.ParseWeatherMarket(market)
 		if wm == nil {
@@ -349,6 +532,11 @@ func (ws *WeatherSniper) FindOpportunities() ([]*WeatherOpportunity, error) {
 			continue
 		}
 
+		// Bucket normalization needs every sibling, tradable or not
+		if key := bucketSiblingKey(wm); key != "" {
+			siblings[key] = append(siblings[key], wm)
+		}
+
 		// Check liquidity
 		if !wm.HasGoodLiquidity(ws.config.WeatherMinVolume) {
 			continue
@@ -360,107 +548,362 @@ func (ws *WeatherSniper) FindOpportunities() ([]*WeatherOpportunity, error) {
 			continue
 		}
 
-		// Get forecast for the location
-		location := weather.FindLocationByName(wm.Location)
-		if location == nil {
-			log.Printf("[weather] unknown location: %s", wm.Location)
-			continue
+		eligible = append(eligible, wm)
+	}
+
+	// Forecasts are fetched on a bounded pool, one city/date per task, so
+	// workers don't queue behind each other on a shared forecast
+	groups := make(map[string][]int)
+	var groupOrder []string
+	for i, wm := range eligible {
+		key := cityDateKey(wm)
+		if _, ok := groups[key]; !ok {
+			groupOrder = append(groupOrder, key)
 		}
+		groups[key] = append(groups[key], i)
+	}
 
-		// Hard block Tier D cities - unpredictable, poor model coverage
-		if location.Tier == weather.TierD {
-			log.Printf("[weather] skipping Tier D location: %s", wm.Location)
-			continue
+	forecastAt := time.Now()
+	cache := newForecastCache(ws.weather)
+	prepared := make([]*weatherCandidate, len(eligible))
+	forEachBounded(len(groupOrder), ws.config.WeatherScanWorkers, func(g int) {
+		for _, i := range groups[groupOrder[g]] {
+			prepared[i] = ws.prepareCandidate(eligible[i], cache)
+		}
+	})
+
+	var candidates []weatherCandidate
+	for _, c := range prepared {
+		if c != nil {
+			candidates = append(candidates, *c)
+		}
+	}
+
+	// Sibling buckets for one city/date are mutually exclusive, so rescale
+	// their probabilities to sum to 1 before computing edge
+	scales := ws.bucketScales(candidates, siblings)
+
+	results := make([]*WeatherOpportunity, len(candidates))
+	forEachBounded(len(candidates), ws.config.WeatherScanWorkers, func(i int) {
+		c := candidates[i]
+		results[i] = ws.evaluateOpportunity(c.wm, c.forecast, c.daysAhead, c.agreement, scales[bucketGroupKey(c.wm)])
+	})
+
+	var opportunities []*WeatherOpportunity
+	for _, opp := range results {
+		if opp != nil {
+			opp.ForecastAt = forecastAt
+			opportunities = append(opportunities, opp)
 		}
-		// Tier C allowed but penalized heavily in evaluateOpportunity via confidence
+	}
+
+	return opportunities, nil
+}
 
-		// Fetch forecast
+// prepareCandidate fetches the forecast a market will be priced from and
+// applies the location and model-agreement filters. It returns nil for
+// markets to skip and is safe to call concurrently.
+func (ws *WeatherSniper) prepareCandidate(wm *gamma.WeatherMarket, cache *forecastCache) *weatherCandidate {
+	// Global anomaly markets have no city forecast; evaluateOpportunity
+	// prices them from the GISTEMP record instead
+	if wm.MarketType == gamma.WeatherTypeGlobalTemp {
 		daysAhead := int(wm.DaysUntilResolution())
 		if daysAhead < 0 {
-			continue
+			return nil
 		}
-		if daysAhead > 7 {
-			daysAhead = 7 // Open-Meteo limit
+		return &weatherCandidate{wm, nil, daysAhead, 0}
+	}
+
+	// Get forecast for the location
+	location := weather.FindLocationByName(wm.Location)
+	if location == nil {
+		log.Printf("[weather] unknown location: %s", wm.Location)
+		return nil
+	}
+
+	// Hard block Tier D cities - unpredictable, poor model coverage
+	if location.Tier == weather.TierD {
+		log.Printf("[weather] skipping Tier D location: %s", wm.Location)
+		return nil
+	}
+	// Tier C allowed but penalized heavily in evaluateOpportunity via confidence
+
+	// Fetch forecast
+	daysAhead := int(wm.DaysUntilResolution())
+	if daysAhead < 0 {
+		return nil
+	}
+	if daysAhead > 7 {
+		daysAhead = 7 // Open-Meteo limit
+	}
+
+	// Use multi-model consensus forecast for better accuracy; the cache
+	// falls back to a single forecast if consensus fails
+	consensus, single, err := cache.get(location, wm.ResolutionDate)
+	if err != nil {
+		log.Printf("[weather] failed to get forecast for %s: %v", wm.Location, err)
+		return nil
+	}
+	if consensus == nil {
+		if ws.config.WeatherRequireConsensus {
+			log.Printf("[weather] %s: skipping, no model consensus and WEATHER_REQUIRE_CONSENSUS is set", wm.Location)
+			return nil
+		}
+		log.Printf("[weather] %s: no model consensus, falling back to a single forecast", wm.Location)
+		// Lower agreement = less confident
+		const singleModelAgreement = 0.5
+		if belowStrictAgreement(singleModelAgreement, ws.config.WeatherStrictAgreement) {
+			log.Printf("[weather] %s: strict mode skipping, no model consensus (need %.0f%% agreement)",
+				wm.Location, ws.config.WeatherStrictAgreement*100)
+			return nil
 		}
+		return &weatherCandidate{wm, single, daysAhead, singleModelAgreement}
+	}
 
-		// Use multi-model consensus forecast for better accuracy
-		consensus, err := ws.weather.GetConsensusForecast(location, wm.ResolutionDate)
-		if err != nil {
-			// Fallback to single forecast if consensus fails
-			forecast, err := ws.weather.GetForecast(location, wm.ResolutionDate)
-			if err != nil {
-				log.Printf("[weather] failed to get forecast for %s: %v", wm.Location, err)
-				continue
-			}
-			opp := ws.evaluateOpportunity(wm, forecast, daysAhead, 0.5) // Lower agreement = less confident
-			if opp != nil {
-				opportunities = append(opportunities, opp)
-			}
+	// A lone model has zero spread, so its agreement reads as perfect
+	if len(consensus.Models) < 2 {
+		if ws.config.WeatherRequireConsensus {
+			log.Printf("[weather] %s: skipping, only %d model returned data and WEATHER_REQUIRE_CONSENSUS is set",
+				wm.Location, len(consensus.Models))
+			return nil
+		}
+		log.Printf("[weather] %s: partial consensus, only %d model returned data (agreement is not meaningful)",
+			wm.Location, len(consensus.Models))
+	}
+
+	relevantAgreement, relevantSpread, tempType := relevantConsensusAgreement(wm.MarketType, consensus)
+
+	// Strict mode refuses disagreement outright instead of trading the
+	// best model at reduced confidence
+	if belowStrictAgreement(relevantAgreement, ws.config.WeatherStrictAgreement) {
+		log.Printf("[weather] %s: strict mode skipping, models agree %.0f%% on %s temp < %.0f%% required (spread=%.1f°C)",
+			wm.Location, relevantAgreement*100, tempType, ws.config.WeatherStrictAgreement*100, relevantSpread)
+		return nil
+	}
+
+	// When models disagree heavily, fall back to best single model with low agreement score.
+	// This lets the downstream confidence/edge filters decide instead of hard-blocking here.
+	if relevantAgreement < 0.30 {
+		// Very low agreement: use best model but pass low agreement so confidence gets slashed
+		log.Printf("[weather] %s: models disagree on %s temp (agreement=%.0f%%, spread=%.1f°C) - using best model",
+			wm.Location, tempType, relevantAgreement*100, relevantSpread)
+		return &weatherCandidate{wm, consensus.BestForecast(), daysAhead, relevantAgreement}
+	}
+
+	// Log model consensus
+	if len(consensus.Models) > 1 {
+		log.Printf("[weather] %s: %d models agree on %s temp (%.0f%%), %.1f°C±%.1f°C",
+			wm.Location, len(consensus.Models), tempType, relevantAgreement*100,
+			consensus.AvgTempHigh, relevantSpread/2)
+	}
+
+	// Calculate probability based on market type using consensus forecast
+	return &weatherCandidate{wm, consensus.BestForecast(), daysAhead, relevantAgreement}
+}
+
+// relevantConsensusAgreement returns the model agreement and spread that
+// matter for a market type. "Above X" and bucket markets care about the high
+// temp, "below X" markets about the low, and snow/rain about overall agreement.
+func relevantConsensusAgreement(marketType gamma.WeatherMarketType, consensus *weather.ConsensusForecast) (agreement, spread float64, tempType string) {
+	switch marketType {
+	case gamma.WeatherTypeTempAbove, gamma.WeatherTypeTempRange:
+		// TempRange (bucket markets) are typically about daily highs
+		return consensus.HighTempAgreement(), consensus.TempHighSpread, "high"
+	case gamma.WeatherTypeTempBelow:
+		return consensus.LowTempAgreement(), consensus.TempLowSpread, "low"
+	default:
+		return consensus.Agreement, consensus.TempHighSpread, "overall"
+	}
+}
+
+// belowStrictAgreement reports whether strict mode (threshold > 0) rejects a
+// market with this model agreement.
+func belowStrictAgreement(agreement, threshold float64) bool {
+	return threshold > 0 && agreement < threshold
+}
+
+// weatherCandidate is a market that passed the pre-filters in
+// FindOpportunities and is waiting to be evaluated.
+type weatherCandidate struct {
+	wm        *gamma.WeatherMarket
+	forecast  *weather.Forecast
+	daysAhead int
+	agreement float64
+}
+
+// bucketGroupKey identifies the set of sibling bucket markets a market
+// belongs to. It returns "" for non-bucket markets.
+func bucketGroupKey(wm *gamma.WeatherMarket) string {
+	if wm.MarketType != gamma.WeatherTypeTempRange {
+		return ""
+	}
+	return cityDateKey(wm)
+}
+
+// bucketSiblingKey is bucketGroupKey for every market of a daily-high
+// bucket event, including its "or below" and "or higher" tails.
+func bucketSiblingKey(wm *gamma.WeatherMarket) string {
+	switch wm.MarketType {
+	case gamma.WeatherTypeTempRange:
+		return cityDateKey(wm)
+	case gamma.WeatherTypeTempAbove, gamma.WeatherTypeTempBelow:
+		if strings.Contains(strings.ToLower(wm.Market.Question), "highest temperature") {
+			return cityDateKey(wm)
+		}
+	}
+	return ""
+}
+
+// cityDateKey identifies a market's city and resolution date.
+func cityDateKey(wm *gamma.WeatherMarket) string {
+	return strings.ToLower(wm.Location) + "|" + wm.ResolutionDate.Format("2006-01-02")
+}
+
+// groupCount returns how many buckets of a group are open orders or filled
+// positions.
+func (ws *WeatherSniper) groupCount(key string) int {
+	return ws.tracker.GroupCount(key) + ws.held.groupCount(key)
+}
+
+// groupExposure returns the combined cost of a group's open orders and
+// filled positions.
+func (ws *WeatherSniper) groupExposure(key string) float64 {
+	return ws.tracker.GroupExposure(key) + ws.held.groupExposure(key)
+}
+
+// maxBuckets is how many sibling buckets may be held per city/date.
+func (ws *WeatherSniper) maxBuckets() int {
+	if ws.config.WeatherMaxBuckets < 1 {
+		return 1
+	}
+	return ws.config.WeatherMaxBuckets
+}
+
+// Bucket scale factors outside this range mean the model and the sibling
+// set disagree too much to trust the rescaled probabilities.
+const (
+	minBucketScale = 0.5
+	maxBucketScale = 2.0
+)
+
+// bucketScales computes the normalization factor for each city/date group
+// of bucket markets and logs it. Groups are priced over all their siblings,
+// including ones filtered out of candidates, and only normalized when the
+// siblings cover every temperature.
+func (ws *WeatherSniper) bucketScales(candidates []weatherCandidate, siblings map[string][]*gamma.WeatherMarket) map[string]float64 {
+	scales := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		key := bucketGroupKey(c.wm)
+		if key == "" || seen[key] {
 			continue
 		}
+		seen[key] = true
 
-		// Use relevant agreement based on market type
-		// "Above X" and bucket markets care about high temp agreement
-		// "Below X" markets care about low temp agreement
-		var relevantAgreement float64
-		var relevantSpread float64
-		var tempType string
-		switch wm.MarketType {
-		case gamma.WeatherTypeTempAbove, gamma.WeatherTypeTempRange:
-			// TempRange (bucket markets) are typically about daily highs
-			relevantAgreement = consensus.HighTempAgreement()
-			relevantSpread = consensus.TempHighSpread
-			tempType = "high"
-		case gamma.WeatherTypeTempBelow:
-			relevantAgreement = consensus.LowTempAgreement()
-			relevantSpread = consensus.TempLowSpread
-			tempType = "low"
-		default:
-			// For snow/rain, use overall agreement
-			relevantAgreement = consensus.Agreement
-			relevantSpread = consensus.TempHighSpread
-			tempType = "overall"
-		}
-
-		// When models disagree heavily, fall back to best single model with low agreement score.
-		// This lets the downstream confidence/edge filters decide instead of hard-blocking here.
-		if relevantAgreement < 0.30 {
-			// Very low agreement: use best model but pass low agreement so confidence gets slashed
-			log.Printf("[weather] %s: models disagree on %s temp (agreement=%.0f%%, spread=%.1f°C) - using best model",
-				wm.Location, tempType, relevantAgreement*100, relevantSpread)
-			forecast := consensus.BestForecast()
-			opp := ws.evaluateOpportunity(wm, forecast, daysAhead, relevantAgreement)
-			if opp != nil {
-				opportunities = append(opportunities, opp)
-			}
+		set := siblings[key]
+		if !bucketSetComplete(set) {
+			log.Printf("[weather] not normalizing %s: its %d sibling markets don't cover every temperature", key, len(set))
 			continue
 		}
 
-		// Log model consensus
-		if len(consensus.Models) > 1 {
-			log.Printf("[weather] %s: %d models agree on %s temp (%.0f%%), %.1f°C±%.1f°C",
-				wm.Location, len(consensus.Models), tempType, relevantAgreement*100,
-				consensus.AvgTempHigh, relevantSpread/2)
+		// Siblings share the city/date forecast, so any candidate's will do
+		forecast := ws.biasCorrected(c.wm.Location, c.forecast)
+		probs := make([]float64, len(set))
+		for i, wm := range set {
+			probs[i] = bucketProbYes(wm, forecast, c.daysAhead, ws.config.WeatherTempDoF)
 		}
 
-		// Calculate probability based on market type using consensus forecast
-		forecast := consensus.BestForecast()
-		opp := ws.evaluateOpportunity(wm, forecast, daysAhead, relevantAgreement)
-		if opp != nil {
-			opportunities = append(opportunities, opp)
+		scale, sum, ok := bucketScaleFactor(probs)
+		if !ok {
+			continue
 		}
+		scales[key] = scale
+		log.Printf("[weather] normalizing %d buckets for %s: raw sum %.2f, factor %.3f",
+			len(set), key, sum, scale)
 	}
+	return scales
+}
 
-	return opportunities, nil
+// bucketSetComplete reports whether a set of sibling markets partitions the
+// temperature line: a "below" tail, contiguous buckets, and a "higher" tail.
+// A partial set can't be normalized, as its probabilities needn't sum to 1.
+func bucketSetComplete(set []*gamma.WeatherMarket) bool {
+	if len(set) < 2 {
+		return false
+	}
+	type bounds struct{ low, high float64 }
+	ranges := make([]bounds, len(set))
+	for i, wm := range set {
+		ranges[i].low, ranges[i].high = wm.GetRangeBoundsCelsius()
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].low < ranges[j].low })
+
+	const tolerance = 0.01
+	if ranges[0].low > -100 || ranges[len(ranges)-1].high < 100 {
+		return false
+	}
+	for i := 1; i < len(ranges); i++ {
+		if math.Abs(ranges[i].low-ranges[i-1].high) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// bucketScaleFactor returns the factor that makes a complete sibling set's
+// probabilities sum to 1, clamped to [minBucketScale, maxBucketScale], and
+// their raw sum. ok is false when there is nothing to scale.
+func bucketScaleFactor(probs []float64) (scale, sum float64, ok bool) {
+	if len(probs) < 2 {
+		return 0, 0, false
+	}
+	for _, p := range probs {
+		sum += p
+	}
+	if sum <= 0 {
+		return 0, 0, false
+	}
+	return math.Max(minBucketScale, math.Min(1/sum, maxBucketScale)), sum, true
+}
+
+// bucketProbYes is our raw probability that the daily high lands in a
+// bucket market's range, with Student's t tails when dof is set.
+func bucketProbYes(wm *gamma.WeatherMarket, forecast *weather.Forecast, daysAhead int, dof float64) float64 {
+	locTier := weather.TierA
+	if location := weather.FindLocationByName(wm.Location); location != nil {
+		locTier = location.Tier
+	}
+	lowC, highC := wm.GetRangeBoundsCelsius()
+	dist := weather.NewHighTempDistributionT(forecast, daysAhead, dof)
+	dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
+	return dist.ProbBetween(lowC, highC)
+}
+
+// biasCorrected returns forecast shifted by the location's configured bias.
+// The shift goes on a copy because sibling markets share one forecast.
+func (ws *WeatherSniper) biasCorrected(location string, forecast *weather.Forecast) *weather.Forecast {
+	offset := ws.tempBias.For(location)
+	if offset == 0 {
+		return forecast
+	}
+	corrected := *forecast
+	weather.ApplyBias(&corrected, offset)
+	return &corrected
 }
 
 // evaluateOpportunity calculates edge for a weather market opportunity.
 // modelAgreement is 0-1 indicating how much weather models agree (1 = perfect agreement).
-func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *weather.Forecast, daysAhead int, modelAgreement float64) *WeatherOpportunity {
-	// Skip markets that appear already resolved (prices at extremes)
-	// YES < 0.01 or YES > 0.99 indicates the market outcome is effectively decided
+// bucketScale rescales bucket market probabilities (0 = no normalization).
+func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *weather.Forecast, daysAhead int, modelAgreement, bucketScale float64) *WeatherOpportunity {
+	// Skip markets whose outcome is already decided but not yet closed
+	if wm.Market.LikelyResolved(time.Now()) {
+		log.Printf("[weather] skipping %s: likely resolved (YES=%.4f, quiet near end)",
+			wm.Location, wm.YesPrice)
+		return nil
+	}
+	// Even an undecided market at the extremes leaves no room for forecast edge
 	if wm.YesPrice < 0.01 || wm.YesPrice > 0.99 {
-		log.Printf("[weather] skipping %s: price at extreme (%.4f) - likely resolved",
+		log.Printf("[weather] skipping %s: price at extreme (%.4f)",
 			wm.Location, wm.YesPrice)
 		return nil
 	}
@@ -474,8 +917,7 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 		return nil
 	}
 
-	var ourProbYes float64
-	var confidence float64
+	forecast = ws.biasCorrected(wm.Location, forecast)
 
 	// Get location tier for σ adjustment
 	location := weather.FindLocationByName(wm.Location)
@@ -486,46 +928,11 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 		locTier = weather.TierA // Default baseline
 	}
 
-	switch wm.MarketType {
-	case gamma.WeatherTypeTempAbove:
-		// "Will temperature be above X?"
-		thresholdC := wm.GetThresholdCelsius()
-		dist := weather.NewHighTempDistribution(forecast, daysAhead)
-		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
-		ourProbYes = dist.ProbAbove(thresholdC)
-		confidence = ws.calculateConfidence(dist, thresholdC, daysAhead)
-
-	case gamma.WeatherTypeTempBelow:
-		// "Will temperature be below X?"
-		thresholdC := wm.GetThresholdCelsius()
-		dist := weather.NewLowTempDistribution(forecast, daysAhead)
-		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
-		ourProbYes = dist.ProbBelow(thresholdC)
-		confidence = ws.calculateConfidence(dist, thresholdC, daysAhead)
-
-	case gamma.WeatherTypeTempRange:
-		// Bucket market: "8°C" means temperature falls within that specific range
-		lowC, highC := wm.GetRangeBoundsCelsius()
-		dist := weather.NewHighTempDistribution(forecast, daysAhead)
-		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
-		ourProbYes = dist.ProbBetween(lowC, highC)
-		confidence = ws.calculateConfidence(dist, (lowC+highC)/2, daysAhead)
-
-	case gamma.WeatherTypeSnow:
-		// "Will it snow?"
-		ourProbYes = weather.SnowProbability(forecast)
-		confidence = 0.6 // Snow predictions are less reliable
-
-	case gamma.WeatherTypeRain:
-		// "Will it rain?"
-		ourProbYes = weather.RainProbability(forecast)
-		confidence = 0.7 // Rain predictions are moderately reliable
-
-	default:
-		// Unknown market type - skip
-		log.Printf("[weather] skipping unknown market type: %s for %s", wm.MarketType, wm.Location)
+	ourProbYes, confidence, ok := ws.estimateProbYes(wm, forecast, daysAhead, locTier, bucketScale)
+	if !ok {
 		return nil
 	}
+	ourProbYes = ws.clampProb(ourProbYes)
 
 	// Factor in model agreement: when models agree, boost confidence
 	// modelAgreement=1.0 → no change, modelAgreement=0.5 → 25% reduction
@@ -575,12 +982,9 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 		ev = evYes
 		tokenID = wm.YesTokenID
 		if wm.YesPrice < minLimitOrderPrice {
-			bidPrice = roundToTick(wm.YesPrice, minTickSize)
+			bidPrice = pricing.RoundToTick(wm.YesPrice, minTickSize)
 		} else {
-			bidPrice = roundToTick(wm.YesPrice*(1-ws.config.WeatherBidDiscount), minTickSize)
-			if bidPrice < minTickSize {
-				bidPrice = minTickSize
-			}
+			bidPrice = ws.discountedBid(wm.YesPrice, minTickSize)
 		}
 	} else if noEligible {
 		side = "no"
@@ -588,18 +992,31 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 		ev = evNo
 		tokenID = wm.NoTokenID
 		if wm.NoPrice < minLimitOrderPrice {
-			bidPrice = roundToTick(wm.NoPrice, minTickSize)
+			bidPrice = pricing.RoundToTick(wm.NoPrice, minTickSize)
 		} else {
-			bidPrice = roundToTick(wm.NoPrice*(1-ws.config.WeatherBidDiscount), minTickSize)
-			if bidPrice < minTickSize {
-				bidPrice = minTickSize
-			}
+			bidPrice = ws.discountedBid(wm.NoPrice, minTickSize)
 		}
 	} else {
 		// No eligible side with sufficient edge and price
 		return nil
 	}
 
+	// Net edge gate: raw edge overstates what we keep once fees and the
+	// adverse selection of resting below market are paid for
+	var marketPriceForSide, ourProbForSide float64
+	if side == "yes" {
+		ourProbForSide, marketPriceForSide = ourProbYes, wm.YesPrice
+	} else {
+		ourProbForSide, marketPriceForSide = ourProbNo, wm.NoPrice
+	}
+	feeRateBps := ws.feeRateBps(tokenID)
+	netEdge := calculateNetEdge(ourProbForSide, marketPriceForSide, bidPrice, feeRateBps)
+	if netEdge < ws.config.WeatherMinEdge {
+		log.Printf("[weather] skipping %s: edge raw %.1f%% / net %.1f%% < min %.1f%% (fee=%dbps)",
+			wm.Location, edge*100, netEdge*100, ws.config.WeatherMinEdge*100, feeRateBps)
+		return nil
+	}
+
 	// Divergence cap: if our model disagrees with market by >30%, apply heavy skepticism.
 	// Markets aggregate many participants - large divergence likely means model error.
 	maxDivergence := ws.config.WeatherMaxDivergence
@@ -616,12 +1033,11 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 
 	// Score the opportunity
 	// Higher edge + higher confidence + sooner resolution + better location tier = better
-	timeBonus := 1.0
-	if daysAhead <= 1 {
-		timeBonus = 2.0 // Tomorrow - high bonus
-	} else if daysAhead <= 3 {
-		timeBonus = 1.5
+	horizon := ws.horizon
+	if horizon == nil {
+		horizon = defaultHorizonBonuses
 	}
+	timeBonus := horizon.at(daysAhead)
 
 	volumeBonus := 1.0
 	vol := wm.Market.GetVolume24hr()
@@ -676,18 +1092,8 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 
 	score := edge * confidence * 100 * timeBonus * volumeBonus * tierBonus * proximityMultiplier
 
-	// Determine our prob and market price for the chosen side (for Kelly sizing)
-	var ourProbForSide, marketPriceForSide float64
-	if side == "yes" {
-		ourProbForSide = ourProbYes
-		marketPriceForSide = wm.YesPrice
-	} else {
-		ourProbForSide = 1 - ourProbYes
-		marketPriceForSide = wm.NoPrice
-	}
-
-	log.Printf("[weather] opportunity: %s - %s side, edge=%.1f%%, confidence=%.0f%%, tier=%s, models=%.0f%%, zScore=%.1f, score=%.1f",
-		wm.Market.Question[:minInt(50, len(wm.Market.Question))], side, edge*100, confidence*100, tierStr, modelAgreement*100, zScoreForScoring, score)
+	log.Printf("[weather] opportunity: %s - %s side, edge=raw %.1f%% / net %.1f%%, confidence=%.0f%%, tier=%s, models=%.0f%%, zScore=%.1f, score=%.1f",
+		wm.Market.Question[:minInt(50, len(wm.Market.Question))], side, edge*100, netEdge*100, confidence*100, tierStr, modelAgreement*100, zScoreForScoring, score)
 
 	return &WeatherOpportunity{
 		WeatherMarket:      wm,
@@ -695,6 +1101,7 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 		OurProbYes:         ourProbYes,
 		MarketPriceYes:     wm.YesPrice,
 		Edge:               edge,
+		NetEdge:            netEdge,
 		ExpectedValue:      ev,
 		Side:               side,
 		TokenID:            tokenID,
@@ -706,8 +1113,225 @@ func (ws *WeatherSniper) evaluateOpportunity(wm *gamma.WeatherMarket, forecast *
 	}
 }
 
+// forecastSummary describes the data behind an opportunity for notifications.
+func forecastSummary(opp *WeatherOpportunity) string {
+	if opp.Forecast == nil {
+		return "Source: NASA GISTEMP (projected)"
+	}
+	return fmt.Sprintf("Forecast: High %.0f°F / Low %.0f°F", opp.Forecast.TempHighF(), opp.Forecast.TempLowF())
+}
+
+// estimateProbYes is our probability that a weather market resolves YES,
+// with the confidence we have in it before agreement and tier penalties.
+// forecast should already be bias-corrected. It returns false for markets
+// it can't price.
+func (ws *WeatherSniper) estimateProbYes(wm *gamma.WeatherMarket, forecast *weather.Forecast, daysAhead int, locTier weather.PredictabilityTier, bucketScale float64) (ourProbYes, confidence float64, ok bool) {
+	switch wm.MarketType {
+	case gamma.WeatherTypeTempAbove:
+		// "Will temperature be above X?"
+		thresholdC := wm.GetThresholdCelsius()
+		dist := weather.NewHighTempDistributionT(forecast, daysAhead, ws.config.WeatherTempDoF)
+		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
+		ourProbYes = dist.ProbAbove(thresholdC)
+		confidence = ws.calculateConfidence(dist, thresholdC, daysAhead, true)
+
+	case gamma.WeatherTypeTempBelow:
+		// "Will temperature be below X?"
+		thresholdC := wm.GetThresholdCelsius()
+		dist := weather.NewLowTempDistributionT(forecast, daysAhead, ws.config.WeatherTempDoF)
+		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
+		ourProbYes = dist.ProbBelow(thresholdC)
+		confidence = ws.calculateConfidence(dist, thresholdC, daysAhead, true)
+
+	case gamma.WeatherTypeTempRange:
+		// Bucket market: "8°C" means temperature falls within that specific range
+		lowC, highC := wm.GetRangeBoundsCelsius()
+		dist := weather.NewHighTempDistributionT(forecast, daysAhead, ws.config.WeatherTempDoF)
+		dist.StdDev = weather.TierAdjustedStdDev(dist.StdDev, locTier)
+		ourProbYes = dist.ProbBetween(lowC, highC)
+		if bucketScale > 0 {
+			ourProbYes = math.Min(ourProbYes*bucketScale, 1)
+		}
+		confidence = ws.calculateConfidence(dist, (lowC+highC)/2, daysAhead, false)
+
+	case gamma.WeatherTypeSnow:
+		// "Will it snow?"
+		ourProbYes = weather.SnowProbability(forecast)
+		confidence = 0.6 // Snow predictions are less reliable
+
+	case gamma.WeatherTypeRain:
+		// "Will it rain?"
+		ourProbYes = weather.RainProbability(forecast)
+		confidence = 0.7 // Rain predictions are moderately reliable
+
+	case gamma.WeatherTypeWind:
+		// "Will wind gusts exceed X mph?"
+		thresholdKmh := wm.GetThresholdKmh()
+		if thresholdKmh <= 0 {
+			log.Printf("[weather] skipping wind market with no threshold: %s",
+				wm.Market.Question[:minInt(50, len(wm.Market.Question))])
+			return 0, 0, false
+		}
+		if wm.AsksGusts() {
+			if forecast.WindGust <= 0 {
+				log.Printf("[weather] skipping gust market with no gust forecast: %s",
+					wm.Market.Question[:minInt(50, len(wm.Market.Question))])
+				return 0, 0, false
+			}
+			ourProbYes = weather.GustProbability(forecast, thresholdKmh)
+		} else {
+			ourProbYes = weather.WindProbability(forecast, thresholdKmh)
+		}
+		if wm.AsksBelow() {
+			ourProbYes = 1 - ourProbYes
+		}
+		confidence = 0.6 // Peak wind is harder to forecast than temperature
+
+	case gamma.WeatherTypeUV:
+		// "Will the UV index reach X?"
+		if wm.Threshold <= 0 {
+			log.Printf("[weather] skipping UV market with no threshold: %s",
+				wm.Market.Question[:minInt(50, len(wm.Market.Question))])
+			return 0, 0, false
+		}
+		ourProbYes = weather.UVProbability(forecast, wm.Threshold)
+		if wm.AsksBelow() {
+			ourProbYes = 1 - ourProbYes
+		}
+		confidence = 0.65 // UV tracks cloud cover, which models miss
+
+	case gamma.WeatherTypeGlobalTemp:
+		// "Will global temperature increase by more than X?" - NASA GISTEMP
+		var err error
+		ourProbYes, confidence, err = ws.globalTempProbYes(wm)
+		if err != nil {
+			log.Printf("[weather] skipping global temp market %s: %v",
+				wm.Market.Question[:minInt(50, len(wm.Market.Question))], err)
+			return 0, 0, false
+		}
+
+	default:
+		// Unknown market type - skip
+		log.Printf("[weather] skipping unknown market type: %s for %s", wm.MarketType, wm.Location)
+		return 0, 0, false
+	}
+
+	return ourProbYes, confidence, true
+}
+
+// globalTempProbYes prices a global anomaly market from the GISTEMP record
+// and returns a confidence that falls off quickly with months of lead time,
+// since the projection is a simple persistence model.
+func (ws *WeatherSniper) globalTempProbYes(wm *gamma.WeatherMarket) (float64, float64, error) {
+	low, high, ok := wm.GlobalTempBounds()
+	if !ok {
+		return 0, 0, fmt.Errorf("no anomaly threshold in question")
+	}
+
+	target := wm.TargetMonth()
+	proj, err := ws.gistemp.Projection(target)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	probYes, err := globalTempRangeProb(ws.gistemp, low, high, target)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	log.Printf("[weather] global temp %s: projected %.2f°C±%.2f (lead %d months), P(yes)=%.0f%%",
+		target.Format("Jan 2006"), proj.Mean, proj.StdDev, proj.LeadMonths, probYes*100)
+
+	return probYes, globalTempConfidence(proj.LeadMonths), nil
+}
+
+// globalTempRangeProb returns the probability that the anomaly falls in
+// (low, high); either bound may be infinite.
+func globalTempRangeProb(src *weather.GISTEMPClient, low, high float64, target time.Time) (float64, error) {
+	pAboveLow := 1.0
+	if !math.IsInf(low, -1) {
+		p, err := src.GlobalTempAnomalyProbability(low, target)
+		if err != nil {
+			return 0, err
+		}
+		pAboveLow = p
+	}
+
+	pAboveHigh := 0.0
+	if !math.IsInf(high, 1) {
+		p, err := src.GlobalTempAnomalyProbability(high, target)
+		if err != nil {
+			return 0, err
+		}
+		pAboveHigh = p
+	}
+
+	return math.Max(pAboveLow-pAboveHigh, 0), nil
+}
+
+// globalTempConfidence maps projection lead time to a deliberately
+// conservative confidence.
+func globalTempConfidence(leadMonths int) float64 {
+	switch {
+	case leadMonths <= 1:
+		return 0.75
+	case leadMonths == 2:
+		return 0.6
+	default:
+		return 0.45
+	}
+}
+
+// clampProb limits a model probability to [WEATHER_PROB_MIN,
+// WEATHER_PROB_MAX]. A forecast days out is never certain, and a deep-tail
+// probability near 0 or 1 would make the edge against the market look far
+// bigger than it is. An unset range leaves p alone.
+func (ws *WeatherSniper) clampProb(p float64) float64 {
+	lo, hi := ws.config.WeatherProbMin, ws.config.WeatherProbMax
+	if hi <= lo {
+		return p
+	}
+	return math.Max(lo, math.Min(hi, p))
+}
+
+// feeRateBps looks up the taker fee for a token, assuming zero when the
+// lookup is unavailable.
+func (ws *WeatherSniper) feeRateBps(tokenID string) int {
+	if ws.clob == nil {
+		return 0
+	}
+	bps, err := ws.clob.GetFeeRateBps(tokenID)
+	if err != nil {
+		return 0
+	}
+	return bps
+}
+
+// calculateNetEdge returns the edge left after estimated round-trip fees and
+// the implicit cost of bidding below market.
+//
+// Fees follow Polymarket's curve (rate * min(p, 1-p) per side), charged on
+// entry and again on an early exit. Resting below market improves the fill
+// price by (market - bid), but orders that fill are disproportionately the
+// ones the market moved against, so that improvement is treated as fully
+// given back rather than counted as extra edge.
+func calculateNetEdge(ourProb, marketPrice, bidPrice float64, feeRateBps int) float64 {
+	edgeAtBid := ourProb - bidPrice
+	discountCost := marketPrice - bidPrice
+	if discountCost < 0 {
+		discountCost = 0
+	}
+
+	feeRate := float64(feeRateBps) / 10000
+	roundTripFees := 2 * feeRate * math.Min(marketPrice, 1-marketPrice)
+
+	return edgeAtBid - discountCost - roundTripFees
+}
+
 // calculateConfidence estimates our confidence in the probability calculation.
-func (ws *WeatherSniper) calculateConfidence(dist *weather.TempDistribution, threshold float64, daysAhead int) float64 {
+// boundary marks threshold as the point where the outcome flips, as in
+// above/below markets, rather than the center of a bucket.
+func (ws *WeatherSniper) calculateConfidence(dist *weather.TempDistribution, threshold float64, daysAhead int, boundary bool) float64 {
 	// Base confidence decreases with forecast horizon
 	baseConfidence := 0.9
 	switch {
@@ -733,9 +1357,14 @@ func (ws *WeatherSniper) calculateConfidence(dist *weather.TempDistribution, thr
 		tailPenalty = 0.8
 	}
 
-	// Near-mean bets get slight boost - model is well-calibrated here
+	// Near-mean bets get slight boost - model is well-calibrated here.
+	// Except when a boundary sits within the coin-flip margin of the mean:
+	// then a fraction of a degree of forecast error decides the outcome.
 	proximityBonus := 1.0
-	if zScore < 0.5 {
+	switch {
+	case boundary && absFloat(threshold-dist.Mean) < ws.config.WeatherCoinFlipMargin:
+		proximityBonus = coinFlipPenalty
+	case zScore < 0.5:
 		proximityBonus = 1.15
 	}
 
@@ -752,22 +1381,25 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 	// Polymarket minimums
 	const minMarketableOrderSize = 1.0 // $1 minimum for marketable orders
 	const minLimitOrderPrice = 0.02    // 2 cents minimum for limit orders
-	const minSharesPerOrder = 5.0      // Polymarket requires minimum 5 shares
 
 	isMarketable := opp.BidPrice < minLimitOrderPrice
 
-	// Calculate minimum bet amount to meet 5 share requirement
-	minBetForShares := minSharesPerOrder * opp.BidPrice
+	// Calculate minimum bet amount to meet the market's share minimum
+	minShares := clob.MinOrderSize(ws.clob, opp.TokenID)
+	minBetForShares := minShares * opp.BidPrice
 
 	// Get balance for position sizing
 	// Priority: WEATHER_BALANCE env > on-chain query > CLOB API > bankroll fallback
 	var availableBalance float64
-	if ws.config.WeatherBalance > 0 {
+	if ws.paper != nil {
+		availableBalance = ws.paper.Balance()
+		log.Printf("[weather] using paper balance: $%.2f", availableBalance)
+	} else if ws.config.WeatherBalance > 0 {
 		availableBalance = ws.config.WeatherBalance
 		log.Printf("[weather] using configured balance: $%.2f", availableBalance)
 	} else if !ws.config.DryRun {
 		// Try on-chain balance (reads Polygon directly, no API key needed)
-		balance, err := clob.GetOnChainUSDCBalance(ws.walletAddr)
+		balance, err := clob.GetOnChainUSDCBalance(ws.walletAddr, ws.config.PolygonRPCURLs...)
 		if err != nil {
 			log.Printf("[weather] on-chain balance failed: %v", err)
 			// Fallback to CLOB API
@@ -793,19 +1425,18 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 	if betAmount > ws.config.WeatherMaxPosition {
 		betAmount = ws.config.WeatherMaxPosition
 	}
-	// Ensure minimum viable bet (must cover 5 shares at bid price)
-	minViableBet := minSharesPerOrder * opp.BidPrice
-	if betAmount < minViableBet && availableBalance >= minViableBet {
-		betAmount = minViableBet
+	// Ensure minimum viable bet (must cover the minimum shares at bid price)
+	if betAmount < minBetForShares && availableBalance >= minBetForShares {
+		betAmount = minBetForShares
 	}
 	log.Printf("[weather] Kelly sizing: prob=%.2f, price=%.2f, kelly=%.3f, half=%.3f, bet=$%.2f",
 		opp.OurProbForSide, opp.MarketPriceForSide, kellyFraction, kellyFraction*0.50, betAmount)
 
-	// Check if we can meet minimum 5 shares requirement
+	// Check if we can meet the minimum shares requirement
 	// If not, skip trade gracefully instead of forcing
 	if betAmount < minBetForShares {
-		return fmt.Errorf("skipping: bet amount $%.2f too small for 5 shares (need $%.2f at $%.2f/share)",
-			betAmount, minBetForShares, opp.BidPrice)
+		return fmt.Errorf("skipping: bet amount $%.2f too small for %g shares (need $%.2f at $%.2f/share)",
+			betAmount, minShares, minBetForShares, opp.BidPrice)
 	}
 
 	// Enforce $1 minimum for marketable orders
@@ -819,7 +1450,7 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 		betAmount = ws.config.WeatherMaxExposure - currentExposure
 		// After adjusting for exposure, check if we can still meet minimums
 		if betAmount < minBetForShares {
-			return fmt.Errorf("skipping: exposure limit leaves $%.2f, need $%.2f for 5 shares", betAmount, minBetForShares)
+			return fmt.Errorf("skipping: exposure limit leaves $%.2f, need $%.2f for %g shares", betAmount, minBetForShares, minShares)
 		}
 		if isMarketable && betAmount < minMarketableOrderSize {
 			return fmt.Errorf("skipping: exposure limit leaves $%.2f, marketable requires $1.00", betAmount)
@@ -827,23 +1458,56 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 		log.Printf("[weather] adjusted bet to $%.2f due to exposure limit", betAmount)
 	}
 
+	// Sibling buckets hedge one another, so together they get one
+	// position's budget
+	if key := bucketGroupKey(opp.WeatherMarket); key != "" {
+		groupExposure := ws.groupExposure(key)
+		if groupExposure+betAmount > ws.config.WeatherMaxPosition {
+			betAmount = ws.config.WeatherMaxPosition - groupExposure
+			if betAmount < minBetForShares {
+				return fmt.Errorf("skipping: %s buckets already hold $%.2f of $%.2f max position", key, groupExposure, ws.config.WeatherMaxPosition)
+			}
+			if isMarketable && betAmount < minMarketableOrderSize {
+				return fmt.Errorf("skipping: bucket group leaves $%.2f, marketable requires $1.00", betAmount)
+			}
+			log.Printf("[weather] adjusted bet to $%.2f, %s buckets already hold $%.2f", betAmount, key, groupExposure)
+		}
+	}
+
 	// Final balance check to ensure we have enough
 	if !ws.config.DryRun && betAmount > availableBalance {
 		return fmt.Errorf("skipping: insufficient balance $%.2f for $%.2f bet", availableBalance, betAmount)
 	}
 
-	// Calculate shares (round to 4 decimal places for Polymarket precision)
-	shares := roundShares(betAmount / opp.BidPrice)
+	// Round shares the way the builder will and cost the order from them
+	shares := ws.builder.RoundSize(betAmount / opp.BidPrice)
+	betAmount = shares * opp.BidPrice
 
-	log.Printf("[weather] placing %s trade: %s @ $%.2f, shares=%.4f, cost=$%.2f, edge=%.1f%%",
+	log.Printf("[weather] placing %s trade: %s @ $%.2f, shares=%.2f, cost=$%.2f, edge=raw %.1f%% / net %.1f%%",
 		opp.Side, opp.WeatherMarket.Market.Question[:minInt(40, len(opp.WeatherMarket.Market.Question))],
-		opp.BidPrice, shares, betAmount, opp.Edge*100)
+		opp.BidPrice, shares, betAmount, opp.Edge*100, opp.NetEdge*100)
 
 	if ws.config.DryRun {
 		log.Printf("[weather] DRY_RUN: would place GTC limit order")
 
+		orderID := fmt.Sprintf("dry-%d", time.Now().UnixNano())
+		if ws.paper != nil {
+			err := ws.paper.Open(PaperPosition{
+				ID:         orderID,
+				TokenID:    opp.TokenID,
+				MarketSlug: opp.WeatherMarket.Market.Slug,
+				Label:      fmt.Sprintf("%s %s", opp.WeatherMarket.Market.Question[:minInt(40, len(opp.WeatherMarket.Market.Question))], opp.Side),
+				Shares:     shares,
+				Price:      opp.BidPrice,
+				WinProb:    opp.OurProbForSide,
+			})
+			if err != nil {
+				return fmt.Errorf("skipping: %w", err)
+			}
+		}
+
 		position := &WeatherPosition{
-			OrderID:        fmt.Sprintf("dry-%d", time.Now().UnixNano()),
+			OrderID:        orderID,
 			TokenID:        opp.TokenID,
 			MarketSlug:     opp.WeatherMarket.Market.Slug,
 			MarketQuestion: opp.WeatherMarket.Market.Question,
@@ -852,9 +1516,13 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 			Shares:         shares,
 			PlacedAt:       time.Now(),
 			Edge:           opp.Edge,
+			NetEdge:        opp.NetEdge,
 			Status:         "open",
+			GroupKey:       bucketGroupKey(opp.WeatherMarket),
+			ForecastAt:     opp.ForecastAt,
 		}
 		ws.tracker.Add(position)
+		ws.trackCalibration(opp)
 		ws.totalTrades++
 
 		if ws.telegram != nil {
@@ -862,19 +1530,23 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 				"%s\n\n"+
 				"Side: %s @ $%.4f\n"+
 				"Size: %.0f shares ($%.2f)\n"+
-				"Edge: %.1f%%\n"+
-				"Forecast: High %.0f°F / Low %.0f°F",
+				"Edge: raw %.1f%% / net %.1f%%\n"+
+				"%s",
 				opp.WeatherMarket.Market.Question,
 				opp.Side, opp.BidPrice,
 				shares, betAmount,
-				opp.Edge*100,
-				opp.Forecast.TempHighF(), opp.Forecast.TempLowF())
+				opp.Edge*100, opp.NetEdge*100,
+				forecastSummary(opp))
 			ws.telegram.SendMessage(msg)
 		}
 
 		return nil
 	}
 
+	if err := ws.arming.check(); err != nil {
+		return err
+	}
+
 	// Check neg risk
 	negRisk, err := ws.clob.GetNegRisk(opp.TokenID)
 	if err != nil {
@@ -909,9 +1581,13 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 		Shares:         shares,
 		PlacedAt:       time.Now(),
 		Edge:           opp.Edge,
+		NetEdge:        opp.NetEdge,
 		Status:         "open",
+		GroupKey:       bucketGroupKey(opp.WeatherMarket),
+		ForecastAt:     opp.ForecastAt,
 	}
 	ws.tracker.Add(position)
+	ws.trackCalibration(opp)
 	ws.totalTrades++
 
 	log.Printf("[weather] ORDER PLACED: %s (order ID: %s)", opp.WeatherMarket.Market.Question[:minInt(40, len(opp.WeatherMarket.Market.Question))], resp.OrderID)
@@ -921,25 +1597,41 @@ func (ws *WeatherSniper) PlaceTrade(opp *WeatherOpportunity) error {
 			"%s\n\n"+
 			"Side: %s @ $%.4f\n"+
 			"Size: %.0f shares ($%.2f)\n"+
-			"Edge: %.1f%%\n"+
-			"Forecast: High %.0f°F / Low %.0f°F",
+			"Edge: raw %.1f%% / net %.1f%%\n"+
+			"%s",
 			opp.WeatherMarket.Market.Question,
 			opp.Side, opp.BidPrice,
 			shares, betAmount,
-			opp.Edge*100,
-			opp.Forecast.TempHighF(), opp.Forecast.TempLowF())
+			opp.Edge*100, opp.NetEdge*100,
+			forecastSummary(opp))
 		ws.telegram.SendMessage(msg)
 	}
 
 	return nil
 }
 
+// trackedOrders returns the set of order IDs the tracker holds.
+func (ws *WeatherSniper) trackedOrders() map[string]bool {
+	tracked := make(map[string]bool)
+	for _, pos := range ws.tracker.GetAll() {
+		tracked[pos.OrderID] = true
+	}
+	return tracked
+}
+
 // CheckPositions checks the status of open positions.
 func (ws *WeatherSniper) CheckPositions() error {
 	if ws.config.DryRun {
+		if ws.paper != nil {
+			settlePaperPositions(ws.paper, ws.gamma, "weather", ws.recordPaperSettlement)
+			ws.refreshPaperWinProbs(time.Now())
+		}
 		return nil
 	}
 
+	// Sells refused while earlier fills were settling
+	ws.brackets.retryPending(ws.clob, time.Now())
+
 	openOrders, err := ws.clob.GetOpenOrders()
 	if err != nil {
 		return fmt.Errorf("failed to get open orders: %w", err)
@@ -953,27 +1645,56 @@ func (ws *WeatherSniper) CheckPositions() error {
 		}
 	}
 
+	// Take-profits that sold realize their P&L now, not at resolution
+	ws.brackets.checkFills(ws.clob, openOrderMap, func(tokenID string, shares, pnl float64) {
+		ws.held.sell(tokenID, shares)
+		ws.recordRealized(pnl)
+	})
+
 	for _, pos := range ws.tracker.GetAll() {
 		if !openOrderMap[pos.OrderID] {
 			// Order was filled or cancelled
 			log.Printf("[weather] order %s no longer open (was: %s %s)",
 				pos.OrderID, pos.MarketQuestion[:minInt(30, len(pos.MarketQuestion))], pos.Side)
 
+			shares, ok, err := closedOrderFill(ws.clob, pos.OrderID)
+			if err != nil {
+				log.Printf("[weather] failed to look up order %s: %v", pos.OrderID, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if shares <= 0 {
+				log.Printf("[weather] order %s canceled unfilled", pos.OrderID)
+				ws.dropCanceled(pos)
+				continue
+			}
+			ws.tracker.Remove(pos.OrderID)
+
 			if ws.telegram != nil {
-				potentialPayout := pos.Shares
+				potentialPayout := shares
 				msg := fmt.Sprintf("Weather Order Filled!\n\n"+
 					"%s\n\n"+
 					"You own: %.0f %s shares\n"+
 					"Cost: $%.2f\n"+
 					"Payout if wins: $%.2f",
 					pos.MarketQuestion,
-					pos.Shares, pos.Side,
-					pos.Shares*pos.BidPrice,
+					shares, pos.Side,
+					shares*pos.BidPrice,
 					potentialPayout)
-				ws.telegram.SendMessage(msg)
+				ws.telegram.SendCritical(msg)
 			}
 
-			ws.tracker.Remove(pos.OrderID)
+			ws.brackets.onFill(ws.clob, ws.builder, pos.TokenID, pos.MarketQuestion[:minInt(30, len(pos.MarketQuestion))], pos.BidPrice, shares, time.Now())
+			ws.held.add(heldPosition{
+				tokenID:    pos.TokenID,
+				marketSlug: pos.MarketSlug,
+				label:      fmt.Sprintf("%s %s", pos.MarketQuestion[:minInt(40, len(pos.MarketQuestion))], pos.Side),
+				shares:     shares,
+				price:      pos.BidPrice,
+				groupKey:   pos.GroupKey,
+			})
 			ws.totalFilled++
 			continue
 		}
@@ -984,15 +1705,98 @@ func (ws *WeatherSniper) CheckPositions() error {
 			if err := ws.clob.CancelOrder(pos.OrderID); err != nil {
 				log.Printf("[weather] failed to cancel order %s: %v", pos.OrderID, err)
 			} else {
-				ws.tracker.Remove(pos.OrderID)
-				ws.totalCanceled++
+				ws.dropCanceled(pos)
 			}
 		}
 	}
 
+	ws.cancelDecayedOrders(time.Now())
+	ws.reevaluateOrders(time.Now())
+
+	ws.held.settle(ws.gamma, "weather", func(pos heldPosition, pnl float64) {
+		ws.accuracy.resolve(pos.tokenID, pos.price+pnl/pos.shares, time.Now())
+		ws.recordRealized(pnl)
+	})
+
 	return nil
 }
 
+// recordPaperSettlement updates stats when a paper position resolves.
+func (ws *WeatherSniper) recordPaperSettlement(pos PaperPosition, pnl float64) {
+	ws.tracker.Remove(pos.ID)
+	ws.accuracy.resolve(pos.TokenID, pos.Price+pnl/pos.Shares, time.Now())
+	ws.recordRealized(pnl)
+}
+
+// dropCanceled stops tracking a canceled order and expires its calibration
+// prediction, unless a filled position on the same token still needs it.
+func (ws *WeatherSniper) dropCanceled(pos *WeatherPosition) {
+	ws.tracker.Remove(pos.OrderID)
+	ws.totalCanceled++
+	if !ws.held.holds(pos.TokenID) {
+		ws.accuracy.expire(pos.TokenID)
+	}
+}
+
+// trackCalibration remembers the forecast behind a placed order so it can
+// be logged against the market's outcome once it resolves.
+func (ws *WeatherSniper) trackCalibration(opp *WeatherOpportunity) {
+	wm := opp.WeatherMarket
+	tier := "-"
+	if location := weather.FindLocationByName(wm.Location); location != nil {
+		tier = string(location.Tier)
+	}
+	ws.accuracy.track(opp.TokenID, calibrationRecord{
+		MarketSlug:  wm.Market.Slug,
+		Location:    wm.Location,
+		Tier:        tier,
+		MarketType:  string(wm.MarketType),
+		DaysAhead:   int(wm.DaysUntilResolution()),
+		Side:        opp.Side,
+		Predicted:   opp.OurProbForSide,
+		MarketPrice: opp.MarketPriceForSide,
+		Confidence:  opp.Confidence,
+	})
+}
+
+// recordRealized books the P&L of a resolved position and trips the session
+// loss stop when it's due.
+func (ws *WeatherSniper) recordRealized(pnl float64) {
+	ws.totalProfit += pnl
+	if pnl < 0 {
+		ws.dailyLoss -= pnl
+	}
+	if ws.lossStop.record(pnl) {
+		ws.haltTrading()
+	}
+}
+
+// haltTrading cancels every resting order after the session loss stop trips.
+// Filled positions are left to resolve; in dry run every entry is already a
+// filled paper position.
+func (ws *WeatherSniper) haltTrading() {
+	canceled := 0
+	for _, pos := range ws.tracker.GetAll() {
+		if ws.config.DryRun {
+			break
+		}
+		if err := ws.clob.CancelOrder(pos.OrderID); err != nil {
+			log.Printf("[weather] failed to cancel order %s: %v", pos.OrderID, err)
+			continue
+		}
+		ws.dropCanceled(pos)
+		canceled++
+	}
+
+	if ws.telegram != nil {
+		msg := fmt.Sprintf("Weather Trading Halted\n\n"+
+			"Session loss limit reached: $%+.2f realized (limit -$%.2f)\n"+
+			"Canceled %d open orders. Existing positions are still managed.",
+			ws.lossStop.Realized(), ws.config.MaxSessionLoss, canceled)
+		ws.telegram.SendCritical(msg)
+	}
+}
+
 // logStatus logs current status.
 func (ws *WeatherSniper) logStatus() {
 	positions := ws.tracker.GetAll()
@@ -1005,10 +1809,46 @@ func (ws *WeatherSniper) logStatus() {
 		log.Printf("[weather] open positions:")
 		for _, pos := range positions {
 			age := time.Since(pos.PlacedAt).Truncate(time.Minute)
-			log.Printf("[weather]   - %s %s @ $%.4f, edge=%.1f%% [%v old]",
-				pos.MarketQuestion[:minInt(35, len(pos.MarketQuestion))], pos.Side, pos.BidPrice, pos.Edge*100, age)
+			log.Printf("[weather]   - %s %s @ $%.4f, edge=raw %.1f%% / net %.1f%% [%v old]",
+				pos.MarketQuestion[:minInt(35, len(pos.MarketQuestion))], pos.Side, pos.BidPrice, pos.Edge*100, pos.NetEdge*100, age)
 		}
 	}
+
+	ws.holdings.log(time.Now(), &ws.held)
+
+	if ws.paper != nil {
+		ws.paper.LogSummary("weather")
+	}
+}
+
+// logSessionSummary reports the session's trading recap on shutdown.
+func (ws *WeatherSniper) logSessionSummary() {
+	summary := SessionSummary{
+		Strategy: "Weather",
+		Mode:     ws.modeString(),
+		Runtime:  time.Since(ws.startedAt),
+		Placed:   ws.totalTrades,
+		Filled:   ws.totalFilled,
+		Canceled: ws.totalCanceled,
+		Exposure: ws.tracker.TotalExposure(),
+		Notes:    []string{fmt.Sprintf("Daily loss: $%.2f", ws.dailyLoss)},
+	}
+	if ws.paper != nil {
+		summary.PnL = ws.paper.RealizedPnL()
+		summary.PnLBasis = "paper, settled positions"
+		summary.Notes = append(summary.Notes, estimatePaperPnL(ws.paper, ws.EstimateOutcome).String())
+	}
+	reportSessionSummary("weather", ws.telegram, summary)
+}
+
+// EstimateOutcome estimates an open paper position from the latest forecast
+// probability for its side.
+func (ws *WeatherSniper) EstimateOutcome(pos PaperPosition) OutcomeEstimate {
+	return OutcomeEstimate{
+		Shares:  pos.Shares,
+		Cost:    pos.Cost(),
+		WinProb: math.Max(0, math.Min(1, pos.WinProb)),
+	}
 }
 
 func (ws *WeatherSniper) modeString() string {
@@ -1020,7 +1860,7 @@ func (ws *WeatherSniper) modeString() string {
 
 // GetStats returns current strategy statistics.
 func (ws *WeatherSniper) GetStats() map[string]interface{} {
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"mode":           ws.modeString(),
 		"positions":      ws.tracker.Count(),
 		"exposure":       ws.tracker.TotalExposure(),
@@ -1030,6 +1870,12 @@ func (ws *WeatherSniper) GetStats() map[string]interface{} {
 		"daily_loss":     ws.dailyLoss,
 		"bankroll":       ws.bankroll,
 	}
+	if ws.paper != nil {
+		for k, v := range ws.paper.Stats() {
+			stats[k] = v
+		}
+	}
+	return stats
 }
 
 // Helper functions
@@ -1047,12 +1893,17 @@ func minInt(a, b int) int {
 	return b
 }
 
-// roundToTick rounds a price to the nearest tick size (e.g., 0.01 for cents).
-func roundToTick(price, tickSize float64) float64 {
-	return float64(int(price/tickSize+0.5)) * tickSize
-}
-
-// roundShares rounds shares to 4 decimal places (Polymarket maker amount precision).
-func roundShares(shares float64) float64 {
-	return float64(int(shares*10000+0.5)) / 10000
+// discountedBid bids WEATHER_BID_DISCOUNT below price, but never more than
+// WEATHER_MAX_BID_DISCOUNT_ABS below it when that's set, so higher-priced
+// markets still fill. The bid is at least one tick.
+func (ws *WeatherSniper) discountedBid(price, tickSize float64) float64 {
+	discount := price * ws.config.WeatherBidDiscount
+	if maxAbs := ws.config.WeatherMaxDiscountAbs; maxAbs > 0 && discount > maxAbs {
+		discount = maxAbs
+	}
+	bid := pricing.RoundToTick(price-discount, tickSize)
+	if bid < tickSize {
+		bid = tickSize
+	}
+	return bid
 }
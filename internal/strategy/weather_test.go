@@ -0,0 +1,474 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+func TestCalculateNetEdge(t *testing.T) {
+	tests := []struct {
+		name        string
+		ourProb     float64
+		marketPrice float64
+		bidPrice    float64
+		feeRateBps  int
+		want        float64
+	}{
+		{"no fees no discount", 0.62, 0.50, 0.50, 0, 0.12},
+		{"no fees with discount", 0.62, 0.50, 0.44, 0, 0.12},
+		{"fees at midpoint", 0.62, 0.50, 0.44, 300, 0.12 - 2*0.03*0.50},
+		{"fees on cheap side", 0.30, 0.20, 0.18, 100, 0.10 - 2*0.01*0.20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateNetEdge(tt.ourProb, tt.marketPrice, tt.bidPrice, tt.feeRateBps)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("calculateNetEdge() = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketScaleFactor(t *testing.T) {
+	tests := []struct {
+		name      string
+		probs     []float64
+		wantScale float64
+		wantOK    bool
+	}{
+		{"sums above 1", []float64{0.10, 0.35, 0.45, 0.30, 0.10}, 1 / 1.3, true},
+		{"sums below 1", []float64{0.20, 0.30, 0.25}, 1 / 0.75, true},
+		{"clamped up", []float64{0.10, 0.10, 0.10}, maxBucketScale, true},
+		{"clamped down", []float64{0.90, 0.80, 0.70}, minBucketScale, true},
+		{"lone bucket", []float64{0.40}, 0, false},
+		{"zero sum", []float64{0, 0}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale, _, ok := bucketScaleFactor(tt.probs)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(scale-tt.wantScale) > 1e-9 {
+				t.Errorf("scale = %.4f, want %.4f", scale, tt.wantScale)
+			}
+		})
+	}
+}
+
+func TestBucketSetComplete(t *testing.T) {
+	market := func(marketType gamma.WeatherMarketType, threshold float64) *gamma.WeatherMarket {
+		return &gamma.WeatherMarket{MarketType: marketType, Threshold: threshold, ThresholdUnits: "C"}
+	}
+	below := market(gamma.WeatherTypeTempBelow, 6)
+	above := market(gamma.WeatherTypeTempAbove, 10)
+	buckets := []*gamma.WeatherMarket{
+		market(gamma.WeatherTypeTempRange, 7),
+		market(gamma.WeatherTypeTempRange, 8),
+		market(gamma.WeatherTypeTempRange, 9),
+	}
+
+	tests := []struct {
+		name string
+		set  []*gamma.WeatherMarket
+		want bool
+	}{
+		{"full set, any order", []*gamma.WeatherMarket{buckets[1], above, buckets[0], below, buckets[2]}, true},
+		{"missing bucket", []*gamma.WeatherMarket{below, buckets[0], buckets[2], above}, false},
+		{"missing low tail", append(append([]*gamma.WeatherMarket{}, buckets...), above), false},
+		{"missing high tail", append([]*gamma.WeatherMarket{below}, buckets...), false},
+		{"buckets only", buckets, false},
+		{"empty", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bucketSetComplete(tt.set); got != tt.want {
+				t.Errorf("bucketSetComplete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalTempRangeProb(t *testing.T) {
+	// Latest published month is 2025-03 at 1.35, so April is projected
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `Land-Ocean: Global Means
+Year,Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec,J-D,D-N,DJF,MAM,JJA,SON
+2024,1.24,1.44,1.39,1.32,1.17,1.23,1.20,1.30,1.23,1.33,1.30,1.26,1.28,1.28,1.32,1.29,1.24,1.29
+2025,1.36,1.26,1.35,***,***,***,***,***,***,***,***,***,****,****,1.30,****,****,****
+`)
+	}))
+	defer srv.Close()
+
+	src := weather.NewGISTEMPClient().WithURL(srv.URL)
+	april := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	prob := func(low, high float64, target time.Time) float64 {
+		t.Helper()
+		p, err := globalTempRangeProb(src, low, high, target)
+		if err != nil {
+			t.Fatalf("globalTempRangeProb: %v", err)
+		}
+		return p
+	}
+
+	above := prob(1.305, math.Inf(1), april)
+	below := prob(math.Inf(-1), 1.305, april)
+	if math.Abs(above+below-1) > 1e-9 {
+		t.Errorf("above + below = %.4f, want 1", above+below)
+	}
+	if above <= below {
+		t.Errorf("P(>1.30) = %.2f should beat P(<1.30) = %.2f with a 1.33 projection", above, below)
+	}
+
+	// Adjacent buckets (less than 1.15, 1.15-1.19, ..., more than 1.44)
+	// cover the whole line and sum to 1
+	var total float64
+	edges := []float64{math.Inf(-1), 1.145, 1.195, 1.245, 1.295, 1.345, 1.395, 1.445, math.Inf(1)}
+	for i := 0; i+1 < len(edges); i++ {
+		total += prob(edges[i], edges[i+1], april)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("bucket probabilities sum to %.4f, want 1", total)
+	}
+
+	// A published value inside a bucket resolves it
+	if p := prob(1.345, 1.395, march); p < 0.99 {
+		t.Errorf("P(1.35-1.39) for published 1.35 = %.4f, want ~1", p)
+	}
+}
+
+func TestGlobalTempConfidence(t *testing.T) {
+	prev := 1.0
+	for lead := 0; lead <= 4; lead++ {
+		c := globalTempConfidence(lead)
+		if c > prev {
+			t.Errorf("confidence rose from %.2f to %.2f at lead %d", prev, c, lead)
+		}
+		if c > 0.75 {
+			t.Errorf("confidence %.2f at lead %d is not conservative", c, lead)
+		}
+		prev = c
+	}
+}
+
+func TestCalculateConfidence_CoinFlipMargin(t *testing.T) {
+	// Same-day forecast with σ = 2°C: z of 0, 0.3 and 1.0 put the threshold
+	// 0°C, 0.6°C and 2°C from the mean
+	dist := &weather.TempDistribution{Mean: 20, StdDev: 2}
+	tests := []struct {
+		name     string
+		zScore   float64
+		margin   float64
+		boundary bool
+		want     float64
+	}{
+		{"at threshold, penalized", 0, 0.5, true, 0.95 * coinFlipPenalty},
+		{"at threshold, margin disabled", 0, 0, true, 0.95},
+		{"at bucket center", 0, 0.5, false, 0.95},
+		{"z 0.3, outside margin", 0.3, 0.5, true, 0.95},
+		{"z 0.3, inside wider margin", 0.3, 1.0, true, 0.95 * coinFlipPenalty},
+		{"z 1.0", 1.0, 0.5, true, 0.95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &WeatherSniper{config: &config.Config{WeatherCoinFlipMargin: tt.margin}}
+			threshold := dist.Mean + tt.zScore*dist.StdDev
+			got := ws.calculateConfidence(dist, threshold, 0, tt.boundary)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("confidence = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+
+	// The curve now peaks away from the threshold instead of on it
+	ws := &WeatherSniper{config: &config.Config{WeatherCoinFlipMargin: 0.5}}
+	atZero := ws.calculateConfidence(dist, 20, 2, true)
+	atPoint3 := ws.calculateConfidence(dist, 20.6, 2, true)
+	atOne := ws.calculateConfidence(dist, 22, 2, true)
+	if !(atZero < atOne && atOne < atPoint3) {
+		t.Errorf("confidence at z 0/0.3/1.0 = %.4f/%.4f/%.4f, want z=0 lowest and z=0.3 highest", atZero, atPoint3, atOne)
+	}
+}
+
+func TestEvaluateOpportunity_ClampsProbability(t *testing.T) {
+	// A high forecast 15°C over the threshold is a deep tail for NO
+	wm := &gamma.WeatherMarket{
+		Location:       "London",
+		MarketType:     gamma.WeatherTypeTempAbove,
+		Threshold:      10,
+		ThresholdUnits: "C",
+		YesTokenID:     "1",
+		NoTokenID:      "2",
+		YesPrice:       0.85,
+		NoPrice:        0.15,
+	}
+	forecast := &weather.Forecast{TempHigh: 25, TempLow: 15, TempMean: 20}
+
+	evaluate := func(probMin, probMax float64) *WeatherOpportunity {
+		ws := &WeatherSniper{config: &config.Config{
+			WeatherMinConfidence: 0.01,
+			WeatherMinEdge:       0.01,
+			WeatherMaxDivergence: 1,
+			WeatherProbMin:       probMin,
+			WeatherProbMax:       probMax,
+		}}
+		opp := ws.evaluateOpportunity(wm, forecast, 1, 1, 0)
+		if opp == nil {
+			t.Fatalf("no opportunity with probabilities clamped to [%.2f, %.2f]", probMin, probMax)
+		}
+		return opp
+	}
+
+	raw := evaluate(0, 0)
+	if raw.OurProbYes < 0.999 {
+		t.Fatalf("unclamped P(YES) = %.4f, want a deep-tail 0.999+", raw.OurProbYes)
+	}
+
+	clamped := evaluate(0.02, 0.98)
+	if clamped.OurProbYes != 0.98 {
+		t.Errorf("clamped P(YES) = %.4f, want 0.98", clamped.OurProbYes)
+	}
+	if clamped.Edge >= raw.Edge || math.Abs(clamped.Edge-0.13) > 1e-9 {
+		t.Errorf("clamped edge = %.4f (raw %.4f), want 0.13", clamped.Edge, raw.Edge)
+	}
+}
+
+func TestStrictAgreement(t *testing.T) {
+	// Highs 6°C apart (40% agreement), lows 1°C apart (90%)
+	consensus := &weather.ConsensusForecast{TempHighSpread: 6, TempLowSpread: 1, Agreement: 0.65}
+
+	tests := []struct {
+		name       string
+		marketType gamma.WeatherMarketType
+		strict     float64
+		wantSkip   bool
+	}{
+		{"disabled keeps low agreement", gamma.WeatherTypeTempAbove, 0, false},
+		{"low high-temp agreement skipped", gamma.WeatherTypeTempAbove, 0.7, true},
+		{"bucket uses high-temp agreement", gamma.WeatherTypeTempRange, 0.7, true},
+		{"below market uses low-temp agreement", gamma.WeatherTypeTempBelow, 0.7, false},
+		{"rain uses overall agreement", gamma.WeatherTypeRain, 0.7, true},
+		{"threshold at agreement passes", gamma.WeatherTypeTempAbove, 0.4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agreement, _, _ := relevantConsensusAgreement(tt.marketType, consensus)
+			if got := belowStrictAgreement(agreement, tt.strict); got != tt.wantSkip {
+				t.Errorf("skip = %v (agreement %.2f, strict %.2f), want %v", got, agreement, tt.strict, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestDiscountedBid(t *testing.T) {
+	tests := []struct {
+		name     string
+		price    float64
+		discount float64
+		maxAbs   float64
+		want     float64
+	}{
+		{"percentage without cap", 0.60, 0.12, 0, 0.53},
+		{"percentage under cap", 0.20, 0.12, 0.03, 0.18},
+		{"absolute cap on higher price", 0.60, 0.12, 0.03, 0.57},
+		{"absolute cap on expensive side", 0.90, 0.12, 0.03, 0.87},
+		{"clamped to one tick", 0.02, 0.90, 0, 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &WeatherSniper{config: &config.Config{WeatherBidDiscount: tt.discount, WeatherMaxDiscountAbs: tt.maxAbs}}
+			if got := ws.discountedBid(tt.price, 0.01); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("discountedBid(%.2f) = %.4f, want %.2f", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBiasCorrected_CopiesSharedForecast(t *testing.T) {
+	ws := &WeatherSniper{tempBias: weather.BiasOffsets{"London": -1.5}}
+	shared := &weather.Forecast{TempHigh: 12, TempLow: 4, TempMean: 8}
+
+	got := ws.biasCorrected("London", shared)
+	if got.TempHigh != 10.5 || got.TempLow != 2.5 {
+		t.Errorf("corrected temps = %.1f/%.1f, want 10.5/2.5", got.TempHigh, got.TempLow)
+	}
+	if shared.TempHigh != 12 || shared.TempLow != 4 {
+		t.Errorf("shared forecast changed to %.1f/%.1f", shared.TempHigh, shared.TempLow)
+	}
+	if ws.biasCorrected("Tokyo", shared) != shared {
+		t.Error("city without an offset should reuse the forecast")
+	}
+
+	// A cooler forecast lowers the odds of the 12°C bucket
+	wm := &gamma.WeatherMarket{Location: "London", MarketType: gamma.WeatherTypeTempRange, Threshold: 12, ThresholdUnits: "C"}
+	raw := bucketProbYes(wm, shared, 1, 0)
+	corrected := bucketProbYes(wm, got, 1, 0)
+	if corrected >= raw {
+		t.Errorf("P(12°C bucket) = %.4f after cooling, want below %.4f", corrected, raw)
+	}
+}
+
+func TestScanAndTrade_CapsBucketsPerGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxBuckets  int
+		maxPosition float64
+		wantPerCity int
+	}{
+		{"default one bucket", 0, 10, 1},
+		{"two buckets", 2, 10, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int64
+			ws := testWeatherScan(t, openMeteoServer(t, 0, &hits), 1)
+			ws.config.DryRun = true
+			ws.config.WeatherMaxTrades = 100
+			ws.config.WeatherMaxExposure = 100
+			ws.config.WeatherDailyLossLimit = 100
+			ws.config.WeatherMaxPosition = tt.maxPosition
+			ws.config.WeatherMaxBuckets = tt.maxBuckets
+			ws.clob = clobmock.New()
+			ws.builder = testBracketBuilder(t)
+			ws.bankroll = 10
+
+			// Each scan places at most 3 trades; run enough to fill every group
+			for i := 0; i < 4; i++ {
+				if err := ws.ScanAndTrade(); err != nil {
+					t.Fatalf("ScanAndTrade: %v", err)
+				}
+			}
+
+			perGroup := make(map[string]int)
+			for _, pos := range ws.tracker.GetAll() {
+				if pos.GroupKey == "" {
+					t.Fatalf("position %s has no bucket group", pos.MarketSlug)
+				}
+				perGroup[pos.GroupKey]++
+			}
+			if len(perGroup) != 4 {
+				t.Errorf("positions span %d groups, want 4: %v", len(perGroup), perGroup)
+			}
+			for key, n := range perGroup {
+				if n != tt.wantPerCity {
+					t.Errorf("%s holds %d buckets, want %d", key, n, tt.wantPerCity)
+				}
+				if exposure := ws.tracker.GroupExposure(key); exposure > tt.maxPosition+1e-9 {
+					t.Errorf("%s exposure $%.2f > max position $%.2f", key, exposure, tt.maxPosition)
+				}
+			}
+		})
+	}
+}
+
+func TestScanAndTrade_CapsBucketsCountFilledPositions(t *testing.T) {
+	var hits int64
+	ws := testWeatherScan(t, openMeteoServer(t, 0, &hits), 1)
+	ws.config.WeatherMaxTrades = 100
+	ws.config.WeatherMaxExposure = 100
+	ws.config.WeatherDailyLossLimit = 100
+	ws.config.WeatherMaxPosition = 10
+	ws.config.WeatherBalance = 100
+	mock := clobmock.New()
+	ws.clob = mock
+	ws.builder = testBracketBuilder(t)
+	ws.bankroll = 100
+
+	if err := ws.ScanAndTrade(); err != nil {
+		t.Fatalf("ScanAndTrade: %v", err)
+	}
+	placed := ws.tracker.GetAll()
+	if len(placed) == 0 {
+		t.Fatal("first scan placed no orders")
+	}
+
+	// The first order fills, the rest keep resting
+	filled := placed[0]
+	for _, pos := range placed[1:] {
+		mock.OpenOrders = append(mock.OpenOrders, clob.Order{ID: pos.OrderID})
+	}
+	mock.Statuses[filled.OrderID] = &clob.OrderStatus{ID: filled.OrderID, Status: "MATCHED", SizeMatched: fmt.Sprint(filled.Shares)}
+	if err := ws.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+	if ws.groupCount(filled.GroupKey) != 1 {
+		t.Fatalf("%s counts %d buckets after the fill, want 1", filled.GroupKey, ws.groupCount(filled.GroupKey))
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := ws.ScanAndTrade(); err != nil {
+			t.Fatalf("ScanAndTrade: %v", err)
+		}
+	}
+	for _, pos := range ws.tracker.GetAll() {
+		if pos.GroupKey == filled.GroupKey {
+			t.Errorf("placed %s in %s, whose one bucket already filled", pos.MarketSlug, filled.GroupKey)
+		}
+	}
+}
+
+func TestBucketScales_UsesFullSiblingSet(t *testing.T) {
+	ws := &WeatherSniper{config: &config.Config{}}
+	date := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	market := func(marketType gamma.WeatherMarketType, threshold float64) *gamma.WeatherMarket {
+		return &gamma.WeatherMarket{
+			Market:         gamma.Market{Question: "Will the highest temperature in London be ...?"},
+			MarketType:     marketType,
+			Location:       "London",
+			Threshold:      threshold,
+			ThresholdUnits: "C",
+			ResolutionDate: date,
+		}
+	}
+
+	// A full event: "6°C or below", 7..13°C, "14°C or higher"
+	full := []*gamma.WeatherMarket{market(gamma.WeatherTypeTempBelow, 6)}
+	for c := 7.0; c <= 13; c++ {
+		full = append(full, market(gamma.WeatherTypeTempRange, c))
+	}
+	full = append(full, market(gamma.WeatherTypeTempAbove, 14))
+	key := bucketGroupKey(full[1])
+
+	// Only three middle buckets survived the filters
+	forecast := &weather.Forecast{TempHigh: 10, TempLow: 4}
+	var candidates []weatherCandidate
+	for _, wm := range full[3:6] {
+		candidates = append(candidates, weatherCandidate{wm, forecast, 1, 1})
+	}
+
+	t.Run("complete set", func(t *testing.T) {
+		scales := ws.bucketScales(candidates, map[string][]*gamma.WeatherMarket{key: full})
+		scale, ok := scales[key]
+		if !ok {
+			t.Fatal("complete set was not normalized")
+		}
+		// The full set already sums to ~1, so survivors must not be inflated
+		if math.Abs(scale-1) > 0.01 {
+			t.Errorf("scale = %.3f, want ~1", scale)
+		}
+	})
+
+	t.Run("incomplete set", func(t *testing.T) {
+		scales := ws.bucketScales(candidates, map[string][]*gamma.WeatherMarket{key: full[2:7]})
+		if scale, ok := scales[key]; ok {
+			t.Errorf("incomplete set normalized with factor %.3f", scale)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package strategy
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+// reevaluateOrders re-prices every resting order's market from a fresh
+// forecast and cancels the ones we no longer want: those whose net edge at
+// the bid, on the basis it was entered at, has turned negative, so a fill
+// would have negative expected value. It runs at most once per weatherReevalInterval
+// and only with WEATHER_REEVALUATE set.
+func (ws *WeatherSniper) reevaluateOrders(now time.Time) {
+	if !ws.config.WeatherReevaluate || now.Sub(ws.lastReeval) < weatherReevalInterval {
+		return
+	}
+	ws.lastReeval = now
+
+	// One forecast fetch per city/date, shared by sibling orders
+	cache := newForecastCache(ws.weather)
+	siblings := ws.weatherSiblings()
+	for _, pos := range ws.tracker.GetAll() {
+		label := pos.MarketQuestion[:minInt(40, len(pos.MarketQuestion))]
+		_, edge, err := ws.currentEdge(pos, cache, siblings)
+		if err != nil {
+			log.Printf("[weather] re-evaluate %s: %v, keeping order", label, err)
+			continue
+		}
+		if edge >= 0 {
+			continue
+		}
+
+		log.Printf("[weather] forecast flipped for %s %s: net edge at bid $%.2f now %.1f%% (entered at %.1f%%), canceling order %s",
+			label, pos.Side, pos.BidPrice, edge*100, pos.NetEdge*100, pos.OrderID)
+		if err := ws.clob.CancelOrder(pos.OrderID); err != nil {
+			log.Printf("[weather] failed to cancel order %s: %v", pos.OrderID, err)
+			continue
+		}
+		ws.dropCanceled(pos)
+	}
+}
+
+// refreshPaperWinProbs re-prices every paper position from a fresh
+// forecast, so the session's resolution estimate uses the latest probability
+// for each side rather than the one it was entered at. It runs in dry run
+// only, in place of reevaluateOrders, at most once per weatherReevalInterval.
+func (ws *WeatherSniper) refreshPaperWinProbs(now time.Time) {
+	if ws.paper == nil || now.Sub(ws.lastReeval) < weatherReevalInterval {
+		return
+	}
+	ws.lastReeval = now
+
+	cache := newForecastCache(ws.weather)
+	siblings := ws.weatherSiblings()
+	for _, pos := range ws.tracker.GetAll() {
+		prob, _, err := ws.currentEdge(pos, cache, siblings)
+		if err != nil {
+			log.Printf("[weather] paper: re-pricing %s: %v, keeping its estimate",
+				pos.MarketQuestion[:minInt(40, len(pos.MarketQuestion))], err)
+			continue
+		}
+		ws.paper.SetWinProb(pos.OrderID, prob)
+	}
+}
+
+// cancelDecayedOrders cancels resting orders whose edge, discounted by how
+// long ago their forecast was fetched, has fallen below WEATHER_EDGE_FLOOR.
+// Unlike reevaluateOrders it makes no forecast calls, so it runs on every
+// check. It does nothing unless WEATHER_EDGE_HALFLIFE is set.
+func (ws *WeatherSniper) cancelDecayedOrders(now time.Time) {
+	halflife := ws.config.WeatherEdgeHalflife
+	if halflife <= 0 {
+		return
+	}
+
+	for _, pos := range ws.tracker.GetAll() {
+		if pos.ForecastAt.IsZero() {
+			continue
+		}
+		age := now.Sub(pos.ForecastAt)
+		edge := decayedEdge(pos.NetEdge, age, halflife)
+		if edge >= ws.config.WeatherEdgeFloor {
+			continue
+		}
+
+		log.Printf("[weather] edge on %s %s decayed from %.1f%% to %.1f%% over %v, canceling order %s",
+			pos.MarketQuestion[:minInt(40, len(pos.MarketQuestion))], pos.Side,
+			pos.NetEdge*100, edge*100, age.Truncate(time.Minute), pos.OrderID)
+		if err := ws.clob.CancelOrder(pos.OrderID); err != nil {
+			log.Printf("[weather] failed to cancel order %s: %v", pos.OrderID, err)
+			continue
+		}
+		ws.dropCanceled(pos)
+	}
+}
+
+// decayedEdge discounts edge by half for every halflife of age, reflecting
+// how much of it a forecast that old can still be trusted for.
+func decayedEdge(edge float64, age, halflife time.Duration) float64 {
+	if halflife <= 0 || age <= 0 {
+		return edge
+	}
+	return edge * math.Pow(0.5, age.Hours()/halflife.Hours())
+}
+
+// weatherSiblings groups the current weather markets by bucketSiblingKey,
+// so re-priced buckets are normalized against their siblings as at entry.
+// When the markets can't be fetched buckets go unnormalized.
+func (ws *WeatherSniper) weatherSiblings() map[string][]*gamma.WeatherMarket {
+	markets, err := ws.gamma.GetWeatherMarkets()
+	if err != nil {
+		log.Printf("[weather] re-evaluate: failed to get weather markets, buckets won't be normalized: %v", err)
+		return nil
+	}
+	siblings := make(map[string][]*gamma.WeatherMarket)
+	for _, market := range markets {
+		wm := gamma.ParseWeatherMarket(market)
+		if wm == nil {
+			continue
+		}
+		if key := bucketSiblingKey(wm); key != "" {
+			siblings[key] = append(siblings[key], wm)
+		}
+	}
+	return siblings
+}
+
+// currentEdge recomputes our probability for a position's side from the
+// latest forecast, the way evaluateOpportunity priced it at entry, and
+// returns it with the net edge at the position's bid (see
+// calculateNetEdge), comparable to pos.NetEdge.
+func (ws *WeatherSniper) currentEdge(pos *WeatherPosition, cache *forecastCache, siblings map[string][]*gamma.WeatherMarket) (prob, netEdge float64, err error) {
+	market, err := ws.gamma.GetMarketBySlug(pos.MarketSlug)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch market: %w", err)
+	}
+	wm := gamma.ParseWeatherMarket(*market)
+	if wm == nil {
+		return 0, 0, fmt.Errorf("no longer parses as a weather market")
+	}
+	c := ws.prepareCandidate(wm, cache)
+	if c == nil {
+		return 0, 0, fmt.Errorf("no usable forecast")
+	}
+
+	var scale float64
+	if key := bucketGroupKey(wm); key != "" {
+		scale = ws.bucketScales([]weatherCandidate{*c}, siblings)[key]
+	}
+
+	locTier := weather.TierA
+	if location := weather.FindLocationByName(wm.Location); location != nil {
+		locTier = location.Tier
+	}
+	forecast := ws.biasCorrected(wm.Location, c.forecast)
+	probYes, _, ok := ws.estimateProbYes(wm, forecast, c.daysAhead, locTier, scale)
+	if !ok {
+		return 0, 0, fmt.Errorf("market can't be priced")
+	}
+	probYes = ws.clampProb(probYes)
+
+	prob, marketPrice := probYes, wm.YesPrice
+	if pos.Side == "no" {
+		prob, marketPrice = 1-probYes, wm.NoPrice
+	}
+	return prob, calculateNetEdge(prob, marketPrice, pos.BidPrice, ws.feeRateBps(pos.TokenID)), nil
+}
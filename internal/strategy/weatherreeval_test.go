@@ -0,0 +1,270 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/clob"
+	"github.com/dantezy/polymarket-sniper/internal/clob/clobmock"
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+func TestReevaluateOrders_CancelsFlippedForecast(t *testing.T) {
+	var hits int64
+	srv := openMeteoServer(t, 0, &hits) // Highs of 10°C every day
+
+	end := time.Now().UTC().Add(36 * time.Hour).Format(time.RFC3339)
+	source := gammamock.New()
+	for temp, yes := range map[int]float64{4: 0.30, 10: 0.10} {
+		source.Weather = append(source.Weather, gamma.Market{
+			Slug:     fmt.Sprintf("london-%d", temp),
+			Question: fmt.Sprintf("Will the highest temperature in London be %d°C tomorrow?", temp),
+			Active:   true,
+			EndDate:  end,
+			Tokens: []gamma.Token{
+				{TokenID: "1", Outcome: "Yes", Price: yes},
+				{TokenID: "2", Outcome: "No", Price: 1 - yes},
+			},
+		})
+	}
+
+	mock := clobmock.New()
+	positions := []*WeatherPosition{
+		{OrderID: "still-good", MarketSlug: "london-10", MarketQuestion: "London 10°C", Side: "yes", BidPrice: 0.08},
+		{OrderID: "flipped", MarketSlug: "london-4", MarketQuestion: "London 4°C", Side: "yes", BidPrice: 0.20},
+		{OrderID: "no-side", MarketSlug: "london-4", MarketQuestion: "London 4°C", Side: "no", BidPrice: 0.60},
+	}
+	for _, pos := range positions {
+		mock.OpenOrders = append(mock.OpenOrders, clob.Order{ID: pos.OrderID})
+	}
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			ws := &WeatherSniper{
+				config:   &config.Config{WeatherReevaluate: enabled},
+				gamma:    source,
+				clob:     mock,
+				weather:  weather.NewClient().WithBaseURL(srv.URL),
+				tracker:  NewWeatherPositionTracker(),
+				edgeCalc: weather.NewEdgeCalculator(),
+			}
+			for _, pos := range positions {
+				p := *pos
+				p.PlacedAt = time.Now()
+				p.Status = "open"
+				ws.tracker.Add(&p)
+			}
+
+			if err := ws.CheckPositions(); err != nil {
+				t.Fatalf("CheckPositions: %v", err)
+			}
+
+			want := 3
+			if enabled {
+				want = 2
+				if got := mock.Canceled(); len(got) != 1 || got[0] != "flipped" {
+					t.Errorf("canceled %v, want [flipped]", got)
+				}
+				if ws.totalCanceled != 1 {
+					t.Errorf("totalCanceled = %d, want 1", ws.totalCanceled)
+				}
+
+				// Within the interval nothing is fetched again
+				before := hits
+				ws.reevaluateOrders(time.Now())
+				if hits != before {
+					t.Errorf("re-evaluated again within %s", weatherReevalInterval)
+				}
+			} else if len(mock.Canceled()) != 0 {
+				t.Errorf("canceled %v with WEATHER_REEVALUATE off", mock.Canceled())
+			}
+			if ws.tracker.Count() != want {
+				t.Errorf("tracked %d orders, want %d", ws.tracker.Count(), want)
+			}
+		})
+	}
+}
+
+func TestCurrentEdge_MatchesEntryBasis(t *testing.T) {
+	var hits int64
+	ws := testWeatherScan(t, openMeteoServer(t, 0, &hits), 1) // Highs of 10°C every day
+	mock := clobmock.New()
+	ws.clob = mock
+
+	// One complete London event: "6°C or below", 7..13°C, "14°C or higher"
+	end := time.Now().UTC().Add(36 * time.Hour).Format(time.RFC3339)
+	source := gammamock.New()
+	market := func(slug, bucket string, yes float64) gamma.Market {
+		id := 100 + 2*len(source.Weather)
+		mock.FeeRates[fmt.Sprint(id)], mock.FeeRates[fmt.Sprint(id+1)] = 100, 100
+		return gamma.Market{
+			Slug:     slug,
+			Question: fmt.Sprintf("Will the highest temperature in London be %s tomorrow?", bucket),
+			Active:   true,
+			EndDate:  end,
+			Tokens: []gamma.Token{
+				{TokenID: fmt.Sprint(id), Outcome: "Yes", Price: yes},
+				{TokenID: fmt.Sprint(id + 1), Outcome: "No", Price: 1 - yes},
+			},
+		}
+	}
+	source.Weather = append(source.Weather, market("london-6-below", "6°C or below", 0.05))
+	for temp := 7; temp <= 13; temp++ {
+		source.Weather = append(source.Weather, market(fmt.Sprintf("london-%d", temp), fmt.Sprintf("%d°C", temp), 0.10))
+	}
+	source.Weather = append(source.Weather, market("london-14-higher", "14°C or higher", 0.05))
+	ws.WithMarketSource(source)
+
+	opps, err := ws.FindOpportunities()
+	if err != nil {
+		t.Fatalf("FindOpportunities: %v", err)
+	}
+	if len(opps) == 0 {
+		t.Fatal("no opportunities to re-price")
+	}
+
+	cache := newForecastCache(ws.weather)
+	siblings := ws.weatherSiblings()
+	for _, opp := range opps {
+		pos := &WeatherPosition{
+			MarketSlug: opp.WeatherMarket.Market.Slug,
+			TokenID:    opp.TokenID,
+			Side:       opp.Side,
+			BidPrice:   opp.BidPrice,
+			NetEdge:    opp.NetEdge,
+		}
+		prob, netEdge, err := ws.currentEdge(pos, cache, siblings)
+		if err != nil {
+			t.Fatalf("currentEdge %s: %v", pos.MarketSlug, err)
+		}
+		// Nothing moved since entry, so re-pricing must land on the entry values
+		if math.Abs(prob-opp.OurProbForSide) > 1e-9 || math.Abs(netEdge-pos.NetEdge) > 1e-9 {
+			t.Errorf("%s %s: prob %.4f, net edge %.4f; entered at %.4f, %.4f",
+				pos.MarketSlug, pos.Side, prob, netEdge, opp.OurProbForSide, pos.NetEdge)
+		}
+	}
+}
+
+func TestDecayedEdge(t *testing.T) {
+	tests := []struct {
+		name     string
+		age      time.Duration
+		halflife time.Duration
+		want     float64
+	}{
+		{"fresh forecast", 0, 6 * time.Hour, 0.20},
+		{"one halflife", 6 * time.Hour, 6 * time.Hour, 0.10},
+		{"two halflives", 12 * time.Hour, 6 * time.Hour, 0.05},
+		{"half a halflife", 3 * time.Hour, 6 * time.Hour, 0.20 / math.Sqrt2},
+		{"decay disabled", 12 * time.Hour, 0, 0.20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decayedEdge(0.20, tt.age, tt.halflife); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("decayedEdge(0.20, %v, %v) = %v, want %v", tt.age, tt.halflife, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPositions_CancelsDecayedEdge(t *testing.T) {
+	now := time.Now()
+	positions := []*WeatherPosition{
+		// 20% halves to 10% in 6h, still above the 5% floor
+		{OrderID: "recent", MarketQuestion: "London 10°C", Side: "yes", NetEdge: 0.20, ForecastAt: now.Add(-6 * time.Hour)},
+		// 20% quarters to 5% in 12h, and a little more makes it fall below
+		{OrderID: "stale", MarketQuestion: "London 4°C", Side: "yes", NetEdge: 0.20, ForecastAt: now.Add(-13 * time.Hour)},
+		// Positions from before forecast times were recorded are left alone
+		{OrderID: "untimed", MarketQuestion: "London 8°C", Side: "no", NetEdge: 0.20},
+	}
+
+	for _, halflife := range []time.Duration{0, 6 * time.Hour} {
+		t.Run(fmt.Sprintf("halflife=%v", halflife), func(t *testing.T) {
+			mock := clobmock.New()
+			ws := &WeatherSniper{
+				config:  &config.Config{WeatherEdgeHalflife: halflife, WeatherEdgeFloor: 0.05},
+				gamma:   gammamock.New(),
+				clob:    mock,
+				tracker: NewWeatherPositionTracker(),
+			}
+			for _, pos := range positions {
+				p := *pos
+				p.PlacedAt = now
+				ws.tracker.Add(&p)
+				mock.OpenOrders = append(mock.OpenOrders, clob.Order{ID: pos.OrderID})
+			}
+
+			if err := ws.CheckPositions(); err != nil {
+				t.Fatalf("CheckPositions: %v", err)
+			}
+
+			if halflife == 0 {
+				if got := mock.Canceled(); len(got) != 0 {
+					t.Errorf("canceled %v with decay disabled", got)
+				}
+				return
+			}
+			if got := mock.Canceled(); len(got) != 1 || got[0] != "stale" {
+				t.Errorf("canceled %v, want [stale]", got)
+			}
+			if n := len(ws.tracker.GetAll()); n != 2 {
+				t.Errorf("%d positions still tracked, want 2", n)
+			}
+		})
+	}
+}
+
+func TestCheckPositions_DryRunRefreshesPaperEstimate(t *testing.T) {
+	var hits int64
+	srv := openMeteoServer(t, 0, &hits) // Highs of 10°C every day
+
+	end := time.Now().UTC().Add(36 * time.Hour).Format(time.RFC3339)
+	source := gammamock.New()
+	source.Weather = append(source.Weather, gamma.Market{
+		Slug:     "london-4",
+		Question: "Will the highest temperature in London be 4°C tomorrow?",
+		Active:   true,
+		EndDate:  end,
+		Tokens: []gamma.Token{
+			{TokenID: "1", Outcome: "Yes", Price: 0.30},
+			{TokenID: "2", Outcome: "No", Price: 0.70},
+		},
+	})
+
+	ws := &WeatherSniper{
+		config:   &config.Config{DryRun: true},
+		gamma:    source,
+		clob:     clobmock.New(),
+		weather:  weather.NewClient().WithBaseURL(srv.URL),
+		tracker:  NewWeatherPositionTracker(),
+		edgeCalc: weather.NewEdgeCalculator(),
+		paper:    NewPaperAccount(100),
+	}
+	// Entered when the forecast gave 4°C an even chance
+	ws.tracker.Add(&WeatherPosition{OrderID: "dry-1", MarketSlug: "london-4", MarketQuestion: "London 4°C",
+		Side: "yes", BidPrice: 0.20, Shares: 10, PlacedAt: time.Now(), Status: "open"})
+	if err := ws.paper.Open(PaperPosition{ID: "dry-1", TokenID: "1", MarketSlug: "london-4", Shares: 10, Price: 0.20, WinProb: 0.5}); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	before := estimatePaperPnL(ws.paper, ws.EstimateOutcome)
+
+	if err := ws.CheckPositions(); err != nil {
+		t.Fatalf("CheckPositions: %v", err)
+	}
+
+	// A 10°C forecast leaves 4°C almost no chance
+	pos := ws.paper.OpenPositions()[0]
+	if pos.WinProb > 0.05 {
+		t.Errorf("WinProb = %.3f after refresh, want near 0", pos.WinProb)
+	}
+	// 10 shares at $0.20 expected $3 profit at 50%, a $2 loss near 0%
+	after := estimatePaperPnL(ws.paper, ws.EstimateOutcome)
+	if before.Expected != 3 || after.Expected > -1.5 {
+		t.Errorf("expected P&L moved from $%.2f to $%.2f, want $3.00 to about -$2.00", before.Expected, after.Expected)
+	}
+}
@@ -0,0 +1,61 @@
+package strategy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+// forecastCache shares forecasts between markets for the same city and date
+// during one scan. Sibling bucket markets all ask for the same forecast, and
+// concurrent requests for a key wait for the first one instead of repeating
+// its Open-Meteo calls.
+type forecastCache struct {
+	client *weather.Client
+
+	mu      sync.Mutex
+	entries map[string]*forecastEntry
+}
+
+// forecastEntry holds a consensus forecast, or a single-model forecast when
+// consensus failed. done is closed once the fetch finishes.
+type forecastEntry struct {
+	done      chan struct{}
+	consensus *weather.ConsensusForecast
+	single    *weather.Forecast
+	err       error
+}
+
+func newForecastCache(client *weather.Client) *forecastCache {
+	return &forecastCache{
+		client:  client,
+		entries: make(map[string]*forecastEntry),
+	}
+}
+
+// get returns the consensus forecast for a location and date, falling back to
+// the default model when no consensus is available.
+func (fc *forecastCache) get(loc *weather.Location, date time.Time) (*weather.ConsensusForecast, *weather.Forecast, error) {
+	key := loc.Name + "|" + date.Format("2006-01-02")
+
+	fc.mu.Lock()
+	entry, ok := fc.entries[key]
+	if !ok {
+		entry = &forecastEntry{done: make(chan struct{})}
+		fc.entries[key] = entry
+	}
+	fc.mu.Unlock()
+
+	if ok {
+		<-entry.done
+		return entry.consensus, entry.single, entry.err
+	}
+
+	entry.consensus, entry.err = fc.client.GetConsensusForecast(loc, date)
+	if entry.err != nil {
+		entry.single, entry.err = fc.client.GetForecast(loc, date)
+	}
+	close(entry.done)
+	return entry.consensus, entry.single, entry.err
+}
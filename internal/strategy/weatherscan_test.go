@@ -0,0 +1,226 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/config"
+	"github.com/dantezy/polymarket-sniper/internal/gamma"
+	"github.com/dantezy/polymarket-sniper/internal/gamma/gammamock"
+	"github.com/dantezy/polymarket-sniper/internal/weather"
+)
+
+// openMeteoServer serves the same week of forecasts for every request after
+// delay, counting the requests it gets.
+func openMeteoServer(tb testing.TB, delay time.Duration, hits *int64) *httptest.Server {
+	tb.Helper()
+
+	start := time.Now().UTC().AddDate(0, 0, -1)
+	var days []string
+	var highs, lows []float64
+	for i := 0; i < 9; i++ {
+		days = append(days, start.AddDate(0, 0, i).Format("2006-01-02"))
+		highs = append(highs, 10)
+		lows = append(lows, 2)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"daily": map[string]interface{}{
+			"time":               days,
+			"temperature_2m_max": highs,
+			"temperature_2m_min": lows,
+		},
+	})
+	if err != nil {
+		tb.Fatalf("failed to marshal forecast: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(hits, 1)
+		time.Sleep(delay)
+		w.Write(body)
+	}))
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+// testWeatherScan builds a sniper over bucket markets for several cities,
+// each city with a ladder of sibling buckets on the same date.
+func testWeatherScan(tb testing.TB, srv *httptest.Server, workers int) *WeatherSniper {
+	tb.Helper()
+
+	end := time.Now().UTC().Add(36 * time.Hour).Format(time.RFC3339)
+	source := gammamock.New()
+	for _, city := range []string{"London", "Tokyo", "Seoul", "Toronto"} {
+		for temp := 4; temp <= 14; temp += 2 {
+			source.Weather = append(source.Weather, gamma.Market{
+				Slug:     fmt.Sprintf("%s-%d", city, temp),
+				Question: fmt.Sprintf("Will the highest temperature in %s be %d°C tomorrow?", city, temp),
+				Active:   true,
+				EndDate:  end,
+				Tokens: []gamma.Token{
+					{TokenID: "1", Outcome: "Yes", Price: 0.30},
+					{TokenID: "2", Outcome: "No", Price: 0.70},
+				},
+			})
+		}
+	}
+
+	ws := &WeatherSniper{
+		config: &config.Config{
+			WeatherMinConfidence: 0.1,
+			WeatherMinEdge:       0.01,
+			WeatherMaxSpread:     0.05,
+			WeatherMaxDivergence: 1,
+			WeatherScanWorkers:   workers,
+		},
+		weather:  weather.NewClient().WithBaseURL(srv.URL),
+		tracker:  NewWeatherPositionTracker(),
+		edgeCalc: weather.NewEdgeCalculator(),
+	}
+	return ws.WithMarketSource(source)
+}
+
+func TestForEachBounded(t *testing.T) {
+	for _, limit := range []int{0, 1, 3, 100} {
+		var running, peak int64
+		seen := make([]bool, 20)
+		forEachBounded(len(seen), limit, func(i int) {
+			n := atomic.AddInt64(&running, 1)
+			for {
+				p := atomic.LoadInt64(&peak)
+				if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			seen[i] = true
+			atomic.AddInt64(&running, -1)
+		})
+
+		for i, ok := range seen {
+			if !ok {
+				t.Errorf("limit %d: index %d not visited", limit, i)
+			}
+		}
+		want := int64(limit)
+		if want < 1 {
+			want = 1
+		}
+		if peak > want {
+			t.Errorf("limit %d: %d calls ran at once", limit, peak)
+		}
+	}
+}
+
+func TestForecastCache_SharesConcurrentFetches(t *testing.T) {
+	var hits int64
+	srv := openMeteoServer(t, 10*time.Millisecond, &hits)
+	cache := newForecastCache(weather.NewClient().WithBaseURL(srv.URL))
+	london := weather.FindLocationByName("London")
+	date := time.Now().UTC().Add(24 * time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if consensus, _, err := cache.get(london, date); err != nil || consensus == nil {
+				t.Errorf("get() = %v, %v", consensus, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	models := int64(len(london.GetPreferredModels()))
+	if hits != models {
+		t.Errorf("Open-Meteo hit %d times, want %d (one per model)", hits, models)
+	}
+}
+
+func TestFindOpportunities_ConcurrentMatchesSerial(t *testing.T) {
+	var hits int64
+	srv := openMeteoServer(t, 0, &hits)
+
+	serial, err := testWeatherScan(t, srv, 1).FindOpportunities()
+	if err != nil {
+		t.Fatalf("serial scan: %v", err)
+	}
+	concurrent, err := testWeatherScan(t, srv, 4).FindOpportunities()
+	if err != nil {
+		t.Fatalf("concurrent scan: %v", err)
+	}
+
+	if len(serial) == 0 || len(serial) != len(concurrent) {
+		t.Fatalf("serial found %d opportunities, concurrent %d", len(serial), len(concurrent))
+	}
+	for i := range serial {
+		if serial[i].WeatherMarket.Market.Slug != concurrent[i].WeatherMarket.Market.Slug || serial[i].Side != concurrent[i].Side {
+			t.Errorf("opportunity %d: serial %s %s, concurrent %s %s", i,
+				serial[i].WeatherMarket.Market.Slug, serial[i].Side,
+				concurrent[i].WeatherMarket.Market.Slug, concurrent[i].Side)
+		}
+	}
+}
+
+func BenchmarkFindOpportunities(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			var hits int64
+			srv := openMeteoServer(b, 5*time.Millisecond, &hits)
+			ws := testWeatherScan(b, srv, workers)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ws.FindOpportunities(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestFindOpportunities_RequireConsensusSkipsLoneModel(t *testing.T) {
+	var hits int64
+	srv := openMeteoServer(t, 0, &hits)
+
+	// Every city asks a single model, so consensus is a lone forecast
+	lone := weather.ModelOverrides{}
+	for _, city := range []string{"London", "Tokyo", "Seoul", "Toronto"} {
+		lone[city] = []weather.WeatherModel{weather.ModelECMWF}
+	}
+
+	tests := []struct {
+		name    string
+		require bool
+		want    bool
+	}{
+		{"flag off trades the lone model", false, true},
+		{"flag on skips it", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := testWeatherScan(t, srv, 1)
+			ws.weather = ws.weather.WithModelOverrides(lone)
+			ws.config.WeatherRequireConsensus = tt.require
+
+			opps, err := ws.FindOpportunities()
+			if err != nil {
+				t.Fatalf("FindOpportunities: %v", err)
+			}
+			if got := len(opps) > 0; got != tt.want {
+				t.Errorf("found %d opportunities, want any = %v", len(opps), tt.want)
+			}
+		})
+	}
+}
@@ -4,17 +4,43 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+// digestSeparator joins messages coalesced into a single digest.
+const digestSeparator = "\n\n———\n\n"
+
+// maxMessageLength is the most characters Telegram accepts in one message.
+const maxMessageLength = 4096
+
 // Bot handles Telegram notifications for the sniper bot.
 type Bot struct {
 	api      *tgbotapi.BotAPI
 	chatID   int64
 	dryRun   bool
 	disabled bool
+
+	// Throttling: messages arriving within minInterval of the last send are
+	// queued and delivered together as one digest.
+	mu          sync.Mutex
+	minInterval time.Duration
+	lastSent    time.Time
+	pending     []queuedMessage
+	flushTimer  *time.Timer
+
+	// deliver performs the actual send; nil means the Telegram API.
+	deliver func(text string, useMarkdown bool) error
+}
+
+// queuedMessage is a message waiting for the next digest.
+type queuedMessage struct {
+	text        string
+	useMarkdown bool
 }
 
 // NewBot creates a new Telegram bot instance.
@@ -48,15 +74,43 @@ func (b *Bot) SetDryRun(dryRun bool) {
 	b.dryRun = dryRun
 }
 
+// SetRateLimit caps non-critical sends to perMinute messages. Messages over
+// the limit are coalesced into a digest. Zero or less disables throttling.
+func (b *Bot) SetRateLimit(perMinute int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if perMinute <= 0 {
+		b.minInterval = 0
+		return
+	}
+	b.minInterval = time.Minute / time.Duration(perMinute)
+}
+
 // SendMessage sends a plain text message.
 func (b *Bot) SendMessage(text string) error {
 	return b.send(text, false)
 }
 
+// SendCritical sends a plain text message immediately, bypassing throttling.
+// Use it for fills, exits and errors that must not wait for a digest.
+func (b *Bot) SendCritical(text string) error {
+	return b.sendNow(text, false)
+}
+
 // SendAlert sends a formatted alert with bold title.
 func (b *Bot) SendAlert(title, message string) error {
-	formatted := fmt.Sprintf("*%s*\n\n%s", escapeMarkdown(title), message)
-	return b.send(formatted, true)
+	return b.send(formatAlert(title, message), true)
+}
+
+// sendCriticalAlert sends a formatted alert immediately, bypassing throttling.
+func (b *Bot) sendCriticalAlert(title, message string) error {
+	return b.sendNow(formatAlert(title, message), true)
+}
+
+// formatAlert renders an alert with a bold title.
+func formatAlert(title, message string) string {
+	return fmt.Sprintf("*%s*\n\n%s", escapeMarkdown(title), message)
 }
 
 // NotifyStarted sends a notification that the bot has started.
@@ -68,9 +122,11 @@ func (b *Bot) NotifyStarted() error {
 	return b.SendAlert("Bot Started", fmt.Sprintf("Polymarket Sniper is running in `%s` mode", mode))
 }
 
-// NotifyStopped sends a notification that the bot has stopped.
+// NotifyStopped flushes any queued digest and sends a notification that the
+// bot has stopped.
 func (b *Bot) NotifyStopped() error {
-	return b.SendAlert("Bot Stopped", "Polymarket Sniper has been shut down")
+	b.Flush()
+	return b.sendCriticalAlert("Bot Stopped", "Polymarket Sniper has been shut down")
 }
 
 // NotifyMarketFound sends a notification when a market is found.
@@ -87,7 +143,7 @@ func (b *Bot) NotifyMarketFound(market string, endTime time.Time) error {
 
 // NotifyOrderExecuted sends a notification when an order is executed.
 func (b *Bot) NotifyOrderExecuted(side string, price, size, profit float64) error {
-	return b.SendAlert("Order Executed",
+	return b.sendCriticalAlert("Order Executed",
 		fmt.Sprintf("Side: `%s`\nPrice: `%.4f`\nSize: `%.2f`\nExpected Profit: `$%.2f`",
 			side, price, size, profit,
 		),
@@ -96,15 +152,140 @@ func (b *Bot) NotifyOrderExecuted(side string, price, size, profit float64) erro
 
 // NotifyError sends an error notification.
 func (b *Bot) NotifyError(err error) error {
-	return b.SendAlert("Error", fmt.Sprintf("`%s`", err.Error()))
+	return b.sendCriticalAlert("Error", fmt.Sprintf("`%s`", err.Error()))
+}
+
+// Flush delivers any queued messages immediately.
+func (b *Bot) Flush() {
+	b.mu.Lock()
+	if b.flushTimer != nil {
+		b.flushTimer.Stop()
+		b.flushTimer = nil
+	}
+	b.mu.Unlock()
+	b.flush()
 }
 
-// send handles the actual message sending with graceful error handling.
+// send throttles a non-critical message. It is delivered immediately when the
+// rate limit allows, otherwise queued for the next digest.
 func (b *Bot) send(text string, useMarkdown bool) error {
+	b.mu.Lock()
+	if b.disabled || b.minInterval == 0 {
+		b.mu.Unlock()
+		return b.sendNow(text, useMarkdown)
+	}
+
+	now := time.Now()
+	next := b.lastSent.Add(b.minInterval)
+	if len(b.pending) == 0 && !now.Before(next) {
+		b.lastSent = now
+		b.mu.Unlock()
+		return b.deliverMessage(text, useMarkdown)
+	}
+
+	b.pending = append(b.pending, queuedMessage{text: text, useMarkdown: useMarkdown})
+	if b.flushTimer == nil {
+		b.flushTimer = time.AfterFunc(next.Sub(now), b.flush)
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+// flush sends all queued messages as a single digest.
+func (b *Bot) flush() {
+	b.mu.Lock()
+	queued := b.pending
+	b.pending = nil
+	b.flushTimer = nil
+	if len(queued) > 0 {
+		b.lastSent = time.Now()
+	}
+	b.mu.Unlock()
+
+	if len(queued) == 0 {
+		return
+	}
+
+	// Markdown only survives the digest if every part was written for it
+	texts := make([]string, len(queued))
+	useMarkdown := true
+	for i, m := range queued {
+		texts[i] = m.text
+		useMarkdown = useMarkdown && m.useMarkdown
+	}
+	chunks := digestChunks(texts, maxMessageLength)
+	if len(queued) > 1 {
+		log.Printf("[telegram] sending digest of %d messages in %d parts", len(queued), len(chunks))
+	}
+	for _, chunk := range chunks {
+		b.deliverMessage(chunk, useMarkdown)
+	}
+}
+
+// digestChunks joins texts with digestSeparator into as few messages of at
+// most limit characters as it can, splitting only between texts, so one
+// oversized digest doesn't lose everything queued. A text longer than limit
+// on its own is cut into limit-sized pieces.
+func digestChunks(texts []string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	sepLen := utf8.RuneCountInString(digestSeparator)
+
+	for _, text := range texts {
+		for _, part := range splitRunes(text, limit) {
+			partLen := utf8.RuneCountInString(part)
+			if currentLen > 0 && currentLen+sepLen+partLen > limit {
+				chunks = append(chunks, current.String())
+				current.Reset()
+				currentLen = 0
+			}
+			if currentLen > 0 {
+				current.WriteString(digestSeparator)
+				currentLen += sepLen
+			}
+			current.WriteString(part)
+			currentLen += partLen
+		}
+	}
+	if currentLen > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// splitRunes cuts text into pieces of at most limit characters.
+func splitRunes(text string, limit int) []string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return []string{text}
+	}
+	var pieces []string
+	for len(runes) > limit {
+		pieces = append(pieces, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(pieces, string(runes))
+}
+
+// sendNow delivers a message without waiting, still counting it against the
+// rate limit so queued digests keep their spacing.
+func (b *Bot) sendNow(text string, useMarkdown bool) error {
+	b.mu.Lock()
+	b.lastSent = time.Now()
+	b.mu.Unlock()
+	return b.deliverMessage(text, useMarkdown)
+}
+
+// deliverMessage handles the actual message sending with graceful error handling.
+func (b *Bot) deliverMessage(text string, useMarkdown bool) error {
 	if b.disabled {
 		log.Printf("[telegram] (disabled) %s", text)
 		return nil
 	}
+	if b.deliver != nil {
+		return b.deliver(text, useMarkdown)
+	}
 
 	msg := tgbotapi.NewMessage(b.chatID, text)
 	if useMarkdown {
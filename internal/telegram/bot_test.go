@@ -1,8 +1,12 @@
 package telegram
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 func TestNewBot_EmptyToken(t *testing.T) {
@@ -135,3 +139,153 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+// recordingBot returns a bot whose sends are captured instead of hitting the
+// Telegram API.
+func recordingBot() (*Bot, func() []string) {
+	var mu sync.Mutex
+	var sent []string
+	bot := &Bot{deliver: func(text string, useMarkdown bool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, text)
+		return nil
+	}}
+	return bot, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), sent...)
+	}
+}
+
+func TestBot_RateLimit_BatchesRapidMessages(t *testing.T) {
+	bot, sent := recordingBot()
+	bot.SetRateLimit(600) // one send per 100ms
+
+	for i := 0; i < 5; i++ {
+		if err := bot.SendMessage(fmt.Sprintf("msg %d", i)); err != nil {
+			t.Fatalf("SendMessage: %v", err)
+		}
+	}
+
+	if got := sent(); len(got) != 1 || got[0] != "msg 0" {
+		t.Fatalf("before interval: sent %q, want only first message", got)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	got := sent()
+	if len(got) != 2 {
+		t.Fatalf("after interval: sent %d messages, want 2 (first + digest)", len(got))
+	}
+	for i := 1; i < 5; i++ {
+		if !strings.Contains(got[1], fmt.Sprintf("msg %d", i)) {
+			t.Errorf("digest missing msg %d: %q", i, got[1])
+		}
+	}
+}
+
+func TestBot_Flush_SplitsOversizedDigest(t *testing.T) {
+	bot, sent := recordingBot()
+	bot.SetRateLimit(1)
+
+	// 60 queued messages of ~200 characters make a ~13k character digest
+	bot.SendMessage("first")
+	for i := 0; i < 60; i++ {
+		bot.SendMessage(fmt.Sprintf("msg %02d %s", i, strings.Repeat("x", 200)))
+	}
+	bot.Flush()
+
+	got := sent()
+	if len(got) < 4 {
+		t.Fatalf("sent %d messages, want the first plus a digest in at least 3 parts", len(got))
+	}
+	digest := strings.Join(got[1:], digestSeparator)
+	for i, part := range got[1:] {
+		if n := utf8.RuneCountInString(part); n > maxMessageLength {
+			t.Errorf("part %d is %d characters, over the %d limit", i, n, maxMessageLength)
+		}
+		if strings.HasPrefix(part, digestSeparator) || strings.HasSuffix(part, digestSeparator) {
+			t.Errorf("part %d was split inside a separator", i)
+		}
+	}
+	for i := 0; i < 60; i++ {
+		if !strings.Contains(digest, fmt.Sprintf("msg %02d %s", i, strings.Repeat("x", 200))) {
+			t.Errorf("digest lost msg %02d", i)
+		}
+	}
+}
+
+func TestDigestChunks(t *testing.T) {
+	sepLen := utf8.RuneCountInString(digestSeparator)
+	tests := []struct {
+		name  string
+		texts []string
+		limit int
+		want  []string
+	}{
+		{"fits in one", []string{"a", "b"}, 10, []string{"a" + digestSeparator + "b"}},
+		{"splits between texts", []string{"aaaa", "bbbb"}, 4 + sepLen + 3, []string{"aaaa", "bbbb"}},
+		{"exactly at limit", []string{"aaaa", "bbbb"}, 8 + sepLen, []string{"aaaa" + digestSeparator + "bbbb"}},
+		{"oversized text is cut", []string{"aaaaaaa", "b"}, 3, []string{"aaa", "aaa", "a", "b"}},
+		{"empty", nil, 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := digestChunks(tt.texts, tt.limit)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("digestChunks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBot_RateLimit_CriticalBypassesQueue(t *testing.T) {
+	bot, sent := recordingBot()
+	bot.SetRateLimit(1)
+
+	bot.SendMessage("first")
+	bot.SendMessage("queued")
+	if err := bot.SendCritical("filled"); err != nil {
+		t.Fatalf("SendCritical: %v", err)
+	}
+	if err := bot.NotifyError(errTest); err != nil {
+		t.Fatalf("NotifyError: %v", err)
+	}
+
+	got := sent()
+	if len(got) != 3 {
+		t.Fatalf("sent %d messages, want 3 (first + two critical): %q", len(got), got)
+	}
+	if got[1] != "filled" {
+		t.Errorf("critical message = %q, want %q", got[1], "filled")
+	}
+
+	bot.Flush()
+	if got := sent(); len(got) != 4 || got[3] != "queued" {
+		t.Errorf("after Flush: sent %q, want queued message last", got)
+	}
+}
+
+func TestBot_SetRateLimit(t *testing.T) {
+	tests := []struct {
+		perMinute int
+		want      time.Duration
+	}{
+		{0, 0},
+		{-5, 0},
+		{20, 3 * time.Second},
+		{60, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.perMinute), func(t *testing.T) {
+			bot := &Bot{}
+			bot.SetRateLimit(tt.perMinute)
+			if bot.minInterval != tt.want {
+				t.Errorf("minInterval = %v, want %v", bot.minInterval, tt.want)
+			}
+		})
+	}
+}
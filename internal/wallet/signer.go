@@ -62,7 +62,7 @@ var (
 	// Domain separator ABI structure
 	domainABITypes = []abi.Type{bytes32Type, bytes32Type, bytes32Type, uint256Type, addressType}
 
-	// Order ABI structure
+	// Order ABI structure, which hashOrder packs by hand
 	orderABITypes = []abi.Type{
 		bytes32Type, // type hash
 		uint256Type, // salt
@@ -189,6 +189,18 @@ func (s *Signer) Wallet() *Wallet {
 	return s.wallet
 }
 
+// TypeHashes returns the EIP712Domain and Order type hashes orders are
+// signed with.
+func TypeHashes() (domain, order common.Hash) {
+	return eip712DomainTypeHash, orderTypeHash
+}
+
+// DomainHashes returns the hashes of the domain name and version the domain
+// separator is built from.
+func DomainHashes() (name, version common.Hash) {
+	return protocolNameHash, protocolVersionHash
+}
+
 // computeDomainSeparator calculates the EIP-712 domain separator using ABI encoding.
 // Uses pre-computed name and version hashes per official Polymarket implementation.
 func computeDomainSeparator(name string, chainID *big.Int, verifyingContract common.Address) common.Hash {
@@ -216,46 +228,43 @@ func computeDomainSeparator(name string, chainID *big.Int, verifyingContract com
 	return crypto.Keccak256Hash(encoded)
 }
 
-// hashOrder computes the EIP-712 struct hash for an Order using ABI encoding.
+// hashOrder computes the EIP-712 struct hash for an Order. Every field is a
+// static ABI type, so the encoding is just one 32-byte word per field; it is
+// packed into a single buffer rather than through the reflective ABI encoder,
+// which costs a few dozen allocations per order.
 func hashOrder(order *Order) common.Hash {
-	// ABI encode the order values (matching official go-order-utils)
-	values := []interface{}{
-		orderTypeHash,
-		order.Salt,
-		order.Maker,
-		order.Signer,
-		order.Taker,
-		order.TokenID,
-		order.MakerAmount,
-		order.TakerAmount,
-		order.Expiration,
-		order.Nonce,
-		order.FeeRateBps,
-		order.Side,
-		order.SignatureType,
-	}
+	var buf [13 * 32]byte
+	word := func(i int) []byte { return buf[i*32 : (i+1)*32] }
+
+	copy(word(0), orderTypeHash.Bytes())
+	putUint256(word(1), order.Salt)
+	copy(word(2)[12:], order.Maker.Bytes())
+	copy(word(3)[12:], order.Signer.Bytes())
+	copy(word(4)[12:], order.Taker.Bytes())
+	putUint256(word(5), order.TokenID)
+	putUint256(word(6), order.MakerAmount)
+	putUint256(word(7), order.TakerAmount)
+	putUint256(word(8), order.Expiration)
+	putUint256(word(9), order.Nonce)
+	putUint256(word(10), order.FeeRateBps)
+	word(11)[31] = order.Side
+	word(12)[31] = order.SignatureType
+
+	return crypto.Keccak256Hash(buf[:])
+}
 
-	encoded, err := abiEncode(orderABITypes, values)
-	if err != nil {
-		// Fallback to manual encoding if ABI encoding fails
-		return crypto.Keccak256Hash(
-			orderTypeHash.Bytes(),
-			padTo32Bytes(order.Salt),
-			padAddress(order.Maker),
-			padAddress(order.Signer),
-			padAddress(order.Taker),
-			padTo32Bytes(order.TokenID),
-			padTo32Bytes(order.MakerAmount),
-			padTo32Bytes(order.TakerAmount),
-			padTo32Bytes(order.Expiration),
-			padTo32Bytes(order.Nonce),
-			padTo32Bytes(order.FeeRateBps),
-			padUint8(order.Side),
-			padUint8(order.SignatureType),
-		)
+// putUint256 writes value into a 32-byte word, big-endian and left-padded
+// with zeros. Like padTo32Bytes, it keeps the first 32 bytes of a value too
+// large for the word.
+func putUint256(word []byte, value *big.Int) {
+	if value == nil {
+		return
 	}
-
-	return crypto.Keccak256Hash(encoded)
+	if value.BitLen() > 256 {
+		copy(word, value.Bytes()[:32])
+		return
+	}
+	value.FillBytes(word)
 }
 
 // abiEncode encodes values using the Ethereum ABI encoder.
@@ -317,13 +326,6 @@ func padAddress(addr common.Address) []byte {
 	return padded
 }
 
-// padUint8 pads a uint8 to 32 bytes (left-padded with zeros).
-func padUint8(value uint8) []byte {
-	padded := make([]byte, 32)
-	padded[31] = value
-	return padded
-}
-
 // ParseSignature parses a hex signature string into its components (r, s, v).
 func ParseSignature(sigHex string) (r, s *big.Int, v uint8, err error) {
 	sigHex = strings.TrimPrefix(sigHex, "0x")
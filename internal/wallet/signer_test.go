@@ -300,3 +300,92 @@ func TestCustomChainConfig(t *testing.T) {
 		t.Error("testnet and mainnet domain separators should differ")
 	}
 }
+
+func TestHashOrder_MatchesABIEncoding(t *testing.T) {
+	w, err := NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	proxy := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	orders := []*Order{
+		benchmarkOrder(w),
+		{
+			Salt:          big.NewInt(12345),
+			Maker:         proxy,
+			Signer:        w.Address(),
+			Taker:         common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			TokenID:       new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+			MakerAmount:   big.NewInt(1000000),
+			TakerAmount:   big.NewInt(500000),
+			Expiration:    big.NewInt(1700000000),
+			Nonce:         big.NewInt(7),
+			FeeRateBps:    big.NewInt(100),
+			Side:          SideSell,
+			SignatureType: SignatureTypePolyGnosis,
+		},
+	}
+	for i, order := range orders {
+		encoded, err := abiEncode(orderABITypes, []interface{}{
+			orderTypeHash, order.Salt, order.Maker, order.Signer, order.Taker, order.TokenID,
+			order.MakerAmount, order.TakerAmount, order.Expiration, order.Nonce, order.FeeRateBps,
+			order.Side, order.SignatureType,
+		})
+		if err != nil {
+			t.Fatalf("order %d: abiEncode: %v", i, err)
+		}
+		if got, want := hashOrder(order), crypto.Keccak256Hash(encoded); got != want {
+			t.Errorf("order %d: hashOrder = %s, want %s", i, got.Hex(), want.Hex())
+		}
+	}
+}
+
+func benchmarkOrder(w *Wallet) *Order {
+	return &Order{
+		Salt:          big.NewInt(1700000000123),
+		Maker:         w.Address(),
+		Signer:        w.Address(),
+		TokenID:       new(big.Int).Lsh(big.NewInt(1), 250), // Real token IDs are ~77 digits
+		MakerAmount:   big.NewInt(5600000),
+		TakerAmount:   big.NewInt(10000000),
+		Expiration:    big.NewInt(0),
+		Nonce:         big.NewInt(0),
+		FeeRateBps:    big.NewInt(0),
+		Side:          SideBuy,
+		SignatureType: SignatureTypeEOA,
+	}
+}
+
+// BenchmarkSignOrder reports signing throughput; run with
+// go test -bench SignOrder -benchmem ./internal/wallet
+func BenchmarkSignOrder(b *testing.B) {
+	w, err := NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		b.Fatalf("failed to create wallet: %v", err)
+	}
+	signer := NewSigner(w)
+	order := benchmarkOrder(w)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signer.SignOrder(order); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "orders/s")
+}
+
+func BenchmarkHashOrder(b *testing.B) {
+	w, err := NewWalletFromHex(testPrivateKey)
+	if err != nil {
+		b.Fatalf("failed to create wallet: %v", err)
+	}
+	order := benchmarkOrder(w)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hashOrder(order)
+	}
+}
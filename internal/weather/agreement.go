@@ -0,0 +1,72 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// defaultAgreementDivisor is the spread, in °C, at which linear agreement
+// reaches zero.
+const defaultAgreementDivisor = 10.0
+
+// AgreementFunc maps a model spread in °C to an agreement score in 0-1.
+type AgreementFunc func(spread float64) float64
+
+// LinearAgreement falls from 1 at no spread to 0 at a spread of divisor °C.
+func LinearAgreement(divisor float64) AgreementFunc {
+	return func(spread float64) float64 {
+		return math.Max(0, 1-spread/divisor)
+	}
+}
+
+// ExponentialAgreement decays as exp(-spread/k): it never reaches zero but
+// drops off faster than linear for small spreads.
+func ExponentialAgreement(k float64) AgreementFunc {
+	return func(spread float64) float64 {
+		return math.Exp(-spread / k)
+	}
+}
+
+// ParseAgreementFunc parses a formula of the form "linear:10" or "exp:4".
+// An empty string is the default linear mapping with a 10°C divisor.
+func ParseAgreementFunc(s string) (AgreementFunc, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return LinearAgreement(defaultAgreementDivisor), nil
+	}
+
+	kind, param, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid agreement formula %q: expected linear:N or exp:N", s)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(param), 64)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid agreement formula %q: parameter must be a positive number", s)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "linear":
+		return LinearAgreement(n), nil
+	case "exp", "exponential":
+		return ExponentialAgreement(n), nil
+	default:
+		return nil, fmt.Errorf("invalid agreement formula %q: unknown kind %q", s, kind)
+	}
+}
+
+// WithAgreementFunc sets how model spread maps to consensus agreement.
+// A nil fn restores the default linear mapping.
+func (c *Client) WithAgreementFunc(fn AgreementFunc) *Client {
+	c.agreement = fn
+	return c
+}
+
+// agreementFunc returns fn, or the default linear mapping when it's nil.
+func agreementFunc(fn AgreementFunc) AgreementFunc {
+	if fn == nil {
+		return LinearAgreement(defaultAgreementDivisor)
+	}
+	return fn
+}
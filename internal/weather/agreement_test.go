@@ -0,0 +1,53 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseAgreementFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		spreads []float64
+		want    []float64
+		wantErr bool
+	}{
+		{"default is linear over 10", "", []float64{0, 5, 10, 15}, []float64{1, 0.5, 0, 0}, false},
+		{"linear with divisor", "linear:4", []float64{0, 1, 2, 4, 6}, []float64{1, 0.75, 0.5, 0, 0}, false},
+		{"exponential", "exp:5", []float64{0, 5, 10}, []float64{1, math.Exp(-1), math.Exp(-2)}, false},
+		{"exponential long form", " Exponential : 2 ", []float64{2}, []float64{math.Exp(-1)}, false},
+		{"unknown kind", "quadratic:3", nil, nil, true},
+		{"missing parameter", "linear", nil, nil, true},
+		{"zero parameter", "exp:0", nil, nil, true},
+		{"non-numeric parameter", "linear:x", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := ParseAgreementFunc(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAgreementFunc(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			for i, spread := range tt.spreads {
+				if got := fn(spread); math.Abs(got-tt.want[i]) > 1e-9 {
+					t.Errorf("agreement at %.1f°C spread = %.4f, want %.4f", spread, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConsensusForecast_AgreementFunc(t *testing.T) {
+	cf := &ConsensusForecast{TempHighSpread: 3, TempLowSpread: 6}
+	if got := cf.HighTempAgreement(); math.Abs(got-0.7) > 1e-9 {
+		t.Errorf("default HighTempAgreement = %.4f, want 0.7", got)
+	}
+
+	cf.agreement = ExponentialAgreement(3)
+	if got := cf.HighTempAgreement(); math.Abs(got-math.Exp(-1)) > 1e-9 {
+		t.Errorf("exponential HighTempAgreement = %.4f, want %.4f", got, math.Exp(-1))
+	}
+	if got := cf.LowTempAgreement(); math.Abs(got-math.Exp(-2)) > 1e-9 {
+		t.Errorf("exponential LowTempAgreement = %.4f, want %.4f", got, math.Exp(-2))
+	}
+}
@@ -0,0 +1,58 @@
+package weather
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BiasOffsets maps a location's canonical name to a °C correction added to
+// its forecast temperatures, for cities where Open-Meteo runs consistently
+// warm or cold. Empty by default; fill it from observed forecast errors.
+type BiasOffsets map[string]float64
+
+// ParseBiasOffsets parses offsets of the form "London=-1.2;Tokyo=0.5". City
+// names and aliases resolve to their canonical location.
+func ParseBiasOffsets(s string) (BiasOffsets, error) {
+	offsets := make(BiasOffsets)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		city, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid bias offset %q: expected City=degreesC", entry)
+		}
+
+		loc := FindLocationByName(strings.TrimSpace(city))
+		if loc == nil {
+			return nil, fmt.Errorf("invalid bias offset %q: unknown location %q", entry, strings.TrimSpace(city))
+		}
+
+		offset, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bias offset for %s: %w", loc.Name, err)
+		}
+
+		offsets[loc.Name] = offset
+	}
+	return offsets, nil
+}
+
+// For returns the offset for a location name or alias, 0 when none is set.
+func (b BiasOffsets) For(location string) float64 {
+	if loc := FindLocationByName(location); loc != nil {
+		return b[loc.Name]
+	}
+	return 0
+}
+
+// ApplyBias shifts the forecast's temperatures by offsetC. Precipitation,
+// wind and UV are left alone.
+func ApplyBias(forecast *Forecast, offsetC float64) {
+	forecast.TempHigh += offsetC
+	forecast.TempLow += offsetC
+	forecast.TempMean += offsetC
+}
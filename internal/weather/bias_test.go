@@ -0,0 +1,73 @@
+package weather
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseBiasOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    BiasOffsets
+		wantErr bool
+	}{
+		{"empty", "", BiasOffsets{}, false},
+		{"single city", "London=-1.2", BiasOffsets{"London": -1.2}, false},
+		{
+			"multiple cities with alias",
+			" London=-1.2 ; NYC=0.5 ;",
+			BiasOffsets{"London": -1.2, "New York": 0.5},
+			false,
+		},
+		{"unknown city", "Atlantis=1", nil, true},
+		{"missing separator", "London", nil, true},
+		{"bad number", "London=warm", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBiasOffsets(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBiasOffsets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBiasOffsets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	offsets := BiasOffsets{"New York": 0.5}
+	if got := offsets.For("NYC"); got != 0.5 {
+		t.Errorf("For(NYC) = %v, want 0.5", got)
+	}
+	if got := offsets.For("London"); got != 0 {
+		t.Errorf("For(London) = %v, want 0", got)
+	}
+}
+
+func TestApplyBias_ShiftsProbability(t *testing.T) {
+	forecast := &Forecast{TempHigh: 20, TempLow: 10, TempMean: 15}
+	before := NewHighTempDistribution(forecast, 1).ProbAbove(21)
+
+	ApplyBias(forecast, 1.5)
+	if forecast.TempHigh != 21.5 || forecast.TempLow != 11.5 || forecast.TempMean != 16.5 {
+		t.Fatalf("temps = %.1f/%.1f/%.1f, want 21.5/11.5/16.5", forecast.TempHigh, forecast.TempLow, forecast.TempMean)
+	}
+
+	// Warming the forecast by 1.5°C is the same as lowering the threshold by 1.5°C
+	after := NewHighTempDistribution(forecast, 1).ProbAbove(21)
+	want := NewHighTempDistribution(&Forecast{TempHigh: 20, TempLow: 10}, 1).ProbAbove(19.5)
+	if math.Abs(after-want) > 1e-9 {
+		t.Errorf("P(high > 21) after bias = %.4f, want %.4f", after, want)
+	}
+	if after <= before {
+		t.Errorf("P(high > 21) = %.4f after warming, want above %.4f", after, before)
+	}
+
+	// The mean now sits above the threshold
+	if after <= 0.5 {
+		t.Errorf("P(high > 21) = %.4f with a 21.5°C mean, want above 0.5", after)
+	}
+}
@@ -5,12 +5,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
 )
 
 const (
 	openMeteoBaseURL = "https://api.open-meteo.com/v1"
 	defaultTimeout   = 30 * time.Second
+
+	// Open-Meteo rate-limits the free tier with 429s; retry those and 5xx
+	defaultRetries  = 3
+	retryBaseDelay  = 500 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second // Also caps a server's Retry-After
 )
 
 // WeatherModel represents a specific weather prediction model.
@@ -45,22 +53,47 @@ type ConsensusForecast struct {
 	TempHighSpread float64 // Max - Min high temp (model disagreement)
 	TempLowSpread  float64 // Max - Min low temp (model disagreement)
 	Agreement      float64 // 0-1, how much models agree (1 = perfect agreement)
+
+	agreement AgreementFunc // Spread to agreement mapping; nil is linear over 10°C
 }
 
 // Client fetches weather data from Open-Meteo (free, no auth required).
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient     *http.Client
+	baseURL        string
+	modelOverrides ModelOverrides
+	retries        int           // Extra attempts after a 429, 5xx or network error
+	retryDelay     time.Duration // Backoff before the first retry, doubling after
+	agreement      AgreementFunc // Spread to agreement mapping; nil is linear over 10°C
 }
 
 // NewClient creates a new weather API client.
 func NewClient() *Client {
 	return &Client{
-		httpClient: &http.Client{Timeout: defaultTimeout},
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: httpx.Transport(nil)},
 		baseURL:    openMeteoBaseURL,
+		retries:    defaultRetries,
+		retryDelay: retryBaseDelay,
 	}
 }
 
+// WithRetries sets how many times a rate-limited or failed request is
+// retried. Negative values are treated as 0.
+func (c *Client) WithRetries(retries int) *Client {
+	if retries < 0 {
+		retries = 0
+	}
+	c.retries = retries
+	return c
+}
+
+// WithBaseURL points the client at a different Open-Meteo compatible
+// endpoint (useful for testing).
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	c.baseURL = baseURL
+	return c
+}
+
 // Forecast represents weather forecast data for a location.
 type Forecast struct {
 	Location   string
@@ -74,6 +107,7 @@ type Forecast struct {
 	SnowProb   float64 // 0-100 (percentage)
 	Snowfall   float64 // cm
 	WindSpeed  float64 // km/h max
+	WindGust   float64 // km/h max gust, 0 if the model has none
 	Humidity   int     // 0-100 (percentage)
 	CloudCover int     // 0-100 (percentage)
 	UVIndex    float64
@@ -99,20 +133,65 @@ func (f *Forecast) TempLowF() float64 {
 	return CelsiusToFahrenheit(f.TempLow)
 }
 
+// get fetches endpoint, retrying 429s, 5xx responses and network errors
+// with exponential backoff. A Retry-After header replaces the backoff. The
+// last response is returned for the caller to check, whatever its status.
+func (c *Client) get(endpoint string) (*http.Response, error) {
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Get(endpoint)
+		retryable := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= c.retries {
+			return resp, err
+		}
+
+		wait := delay
+		if err == nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = after
+			}
+			resp.Body.Close()
+		}
+		if wait > maxRetryBackoff {
+			wait = maxRetryBackoff
+		}
+		time.Sleep(wait)
+		delay *= 2
+	}
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
 // GetForecast fetches weather forecast for a location and date.
 func (c *Client) GetForecast(loc *Location, date time.Time) (*Forecast, error) {
 	// Open-Meteo forecast endpoint
 	params := url.Values{}
 	params.Set("latitude", fmt.Sprintf("%.4f", loc.Latitude))
 	params.Set("longitude", fmt.Sprintf("%.4f", loc.Longitude))
-	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
+	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,wind_gusts_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
 	params.Set("temperature_unit", "celsius")
 	params.Set("timezone", loc.TimezoneID)
 	params.Set("forecast_days", "7") // Get 7 days of forecasts
 
 	endpoint := fmt.Sprintf("%s/forecast?%s", c.baseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(endpoint)
+	resp, err := c.get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
 	}
@@ -150,14 +229,14 @@ func (c *Client) GetForecastRange(loc *Location, days int) ([]*Forecast, error)
 	params := url.Values{}
 	params.Set("latitude", fmt.Sprintf("%.4f", loc.Latitude))
 	params.Set("longitude", fmt.Sprintf("%.4f", loc.Longitude))
-	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
+	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,wind_gusts_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
 	params.Set("temperature_unit", "celsius")
 	params.Set("timezone", loc.TimezoneID)
 	params.Set("forecast_days", fmt.Sprintf("%d", days))
 
 	endpoint := fmt.Sprintf("%s/forecast?%s", c.baseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(endpoint)
+	resp, err := c.get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
 	}
@@ -219,6 +298,9 @@ func (c *Client) buildForecast(loc *Location, data openMeteoResponse, idx int, d
 	if idx < len(data.Daily.WindSpeedMax) {
 		forecast.WindSpeed = data.Daily.WindSpeedMax[idx]
 	}
+	if idx < len(data.Daily.WindGustMax) {
+		forecast.WindGust = data.Daily.WindGustMax[idx]
+	}
 	if idx < len(data.Daily.HumidityMean) {
 		forecast.Humidity = int(data.Daily.HumidityMean[idx])
 	}
@@ -244,6 +326,7 @@ type openMeteoResponse struct {
 		PrecipitationProbMax []float64 `json:"precipitation_probability_max"`
 		SnowfallSum          []float64 `json:"snowfall_sum"`
 		WindSpeedMax         []float64 `json:"wind_speed_10m_max"`
+		WindGustMax          []float64 `json:"wind_gusts_10m_max"`
 		HumidityMean         []float64 `json:"relative_humidity_2m_mean"`
 		CloudCoverMean       []float64 `json:"cloud_cover_mean"`
 		UVIndexMax           []float64 `json:"uv_index_max"`
@@ -255,7 +338,7 @@ func (c *Client) GetForecastWithModel(loc *Location, date time.Time, model Weath
 	params := url.Values{}
 	params.Set("latitude", fmt.Sprintf("%.4f", loc.Latitude))
 	params.Set("longitude", fmt.Sprintf("%.4f", loc.Longitude))
-	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
+	params.Set("daily", "temperature_2m_max,temperature_2m_min,precipitation_probability_max,snowfall_sum,wind_speed_10m_max,wind_gusts_10m_max,relative_humidity_2m_mean,cloud_cover_mean,uv_index_max")
 	params.Set("temperature_unit", "celsius")
 	params.Set("timezone", loc.TimezoneID)
 	params.Set("forecast_days", "7")
@@ -267,7 +350,7 @@ func (c *Client) GetForecastWithModel(loc *Location, date time.Time, model Weath
 
 	endpoint := fmt.Sprintf("%s/forecast?%s", c.baseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(endpoint)
+	resp, err := c.get(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
 	}
@@ -294,16 +377,17 @@ func (c *Client) GetForecastWithModel(loc *Location, date time.Time, model Weath
 
 // GetConsensusForecast fetches forecasts from multiple models and computes agreement.
 func (c *Client) GetConsensusForecast(loc *Location, date time.Time) (*ConsensusForecast, error) {
-	models := loc.GetPreferredModels()
+	models := c.modelsFor(loc)
 	if len(models) == 0 {
 		// Default to ECMWF + GFS if no specific models
 		models = []WeatherModel{ModelECMWF, ModelGFS}
 	}
 
 	consensus := &ConsensusForecast{
-		Location: loc.Name,
-		Date:     date,
-		Models:   make([]ModelForecast, 0, len(models)),
+		Location:  loc.Name,
+		Date:      date,
+		Models:    make([]ModelForecast, 0, len(models)),
+		agreement: c.agreement,
 	}
 
 	var tempHighSum, tempLowSum float64
@@ -353,7 +437,7 @@ func (c *Client) GetConsensusForecast(loc *Location, date time.Time) (*Consensus
 	consensus.TempLowSpread = tempLowMax - tempLowMin
 
 	// Calculate agreement score (1.0 = perfect agreement, 0.0 = high disagreement)
-	// Agreement decreases as spread increases
+	// Agreement decreases as spread increases; by default linearly:
 	// A spread of 0°C = 1.0 agreement
 	// A spread of 5°C = 0.5 agreement
 	// A spread of 10°C+ = 0.0 agreement
@@ -361,10 +445,7 @@ func (c *Client) GetConsensusForecast(loc *Location, date time.Time) (*Consensus
 	if consensus.TempLowSpread > maxSpread {
 		maxSpread = consensus.TempLowSpread
 	}
-	consensus.Agreement = 1.0 - (maxSpread / 10.0)
-	if consensus.Agreement < 0 {
-		consensus.Agreement = 0
-	}
+	consensus.Agreement = agreementFunc(c.agreement)(maxSpread)
 
 	return consensus, nil
 }
@@ -372,21 +453,13 @@ func (c *Client) GetConsensusForecast(loc *Location, date time.Time) (*Consensus
 // HighTempAgreement returns agreement based only on high temp spread.
 // Use this for "above X" temperature markets.
 func (cf *ConsensusForecast) HighTempAgreement() float64 {
-	agreement := 1.0 - (cf.TempHighSpread / 10.0)
-	if agreement < 0 {
-		return 0
-	}
-	return agreement
+	return agreementFunc(cf.agreement)(cf.TempHighSpread)
 }
 
 // LowTempAgreement returns agreement based only on low temp spread.
 // Use this for "below X" temperature markets.
 func (cf *ConsensusForecast) LowTempAgreement() float64 {
-	agreement := 1.0 - (cf.TempLowSpread / 10.0)
-	if agreement < 0 {
-		return 0
-	}
-	return agreement
+	return agreementFunc(cf.agreement)(cf.TempLowSpread)
 }
 
 // BestForecast returns the most reliable forecast from consensus.
@@ -411,7 +484,9 @@ func (cf *ConsensusForecast) BestForecast() *Forecast {
 			SnowProb:  f.SnowProb,
 			Snowfall:  f.Snowfall,
 			WindSpeed: f.WindSpeed,
+			WindGust:  f.WindGust,
 			Humidity:  f.Humidity,
+			UVIndex:   f.UVIndex,
 		}
 	}
 
@@ -0,0 +1,88 @@
+package weather
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetForecast_RetriesRateLimit(t *testing.T) {
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	body := fmt.Sprintf(`{"daily": {"time": [%q], "temperature_2m_max": [21.5], "temperature_2m_min": [12.0],
+		"precipitation_probability_max": [10], "snowfall_sum": [0], "wind_speed_10m_max": [15],
+		"relative_humidity_2m_mean": [60], "cloud_cover_mean": [40], "uv_index_max": [5]}}`, date.Format("2006-01-02"))
+
+	tests := []struct {
+		name      string
+		statuses  []int
+		retries   int
+		wantErr   bool
+		wantCalls int32
+	}{
+		{"429 then 200", []int{http.StatusTooManyRequests, http.StatusOK}, 3, false, 2},
+		{"5xx then 200", []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}, 3, false, 3},
+		{"gives up after retries", []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests}, 2, true, 3},
+		{"404 is not retried", []int{http.StatusNotFound, http.StatusOK}, 3, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&calls, 1))
+				status := tt.statuses[len(tt.statuses)-1]
+				if n <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+				if status != http.StatusOK {
+					w.Header().Set("Retry-After", "0")
+					http.Error(w, "slow down", status)
+					return
+				}
+				fmt.Fprint(w, body)
+			}))
+			defer srv.Close()
+
+			c := NewClient().WithBaseURL(srv.URL).WithRetries(tt.retries)
+			c.retryDelay = time.Millisecond
+
+			forecast, err := c.GetForecast(FindLocationByName("London"), date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetForecast error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && forecast.TempHigh != 21.5 {
+				t.Errorf("TempHigh = %v, want 21.5", forecast.TempHigh)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"0", 0, true},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+		{"-3", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok := retryAfter(tt.header, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 // TempDistribution represents a probability distribution for temperature forecasts.
 // Weather forecasts have inherent uncertainty - we model this as a normal distribution
 // centered on the forecast with standard deviation based on forecast horizon.
+// Setting DoF switches to a Student's t with the same standard deviation, which
+// puts more weight in the tails where big forecast misses live.
 type TempDistribution struct {
 	Mean   float64 // Forecast temperature (Celsius)
 	StdDev float64 // Standard deviation (uncertainty)
 	Low    float64 // Minimum (TempLow from forecast)
 	High   float64 // Maximum (TempHigh from forecast)
+	DoF    float64 // Student's t degrees of freedom, 0 = normal
 }
 
 // NewTempDistribution creates a distribution from a forecast.
@@ -65,6 +68,22 @@ func NewHighTempDistribution(forecast *Forecast, daysAhead int) *TempDistributio
 	}
 }
 
+// NewHighTempDistributionT is NewHighTempDistribution with Student's t
+// tails of dof degrees of freedom. dof 0 keeps the normal distribution.
+func NewHighTempDistributionT(forecast *Forecast, daysAhead int, dof float64) *TempDistribution {
+	d := NewHighTempDistribution(forecast, daysAhead)
+	d.DoF = dof
+	return d
+}
+
+// NewLowTempDistributionT is NewLowTempDistribution with Student's t tails
+// of dof degrees of freedom. dof 0 keeps the normal distribution.
+func NewLowTempDistributionT(forecast *Forecast, daysAhead int, dof float64) *TempDistribution {
+	d := NewLowTempDistribution(forecast, daysAhead)
+	d.DoF = dof
+	return d
+}
+
 // NewLowTempDistribution creates a distribution for low temperature.
 func NewLowTempDistribution(forecast *Forecast, daysAhead int) *TempDistribution {
 	var stdDev float64
@@ -107,18 +126,26 @@ func TierAdjustedStdDev(stdDev float64, tier PredictabilityTier) float64 {
 // Uses the cumulative distribution function (CDF) of the normal distribution.
 func (d *TempDistribution) ProbAbove(threshold float64) float64 {
 	// P(X > threshold) = 1 - CDF(threshold)
-	return 1 - normalCDF(threshold, d.Mean, d.StdDev)
+	return 1 - d.cdf(threshold)
 }
 
 // ProbBelow calculates the probability that the actual temperature will be below the threshold.
 func (d *TempDistribution) ProbBelow(threshold float64) float64 {
 	// P(X < threshold) = CDF(threshold)
-	return normalCDF(threshold, d.Mean, d.StdDev)
+	return d.cdf(threshold)
 }
 
 // ProbBetween calculates the probability that temperature is between low and high.
 func (d *TempDistribution) ProbBetween(low, high float64) float64 {
-	return normalCDF(high, d.Mean, d.StdDev) - normalCDF(low, d.Mean, d.StdDev)
+	return d.cdf(high) - d.cdf(low)
+}
+
+// cdf evaluates the distribution's CDF at x in its configured shape.
+func (d *TempDistribution) cdf(x float64) float64 {
+	if d.DoF > 0 {
+		return studentTCDF(x, d.Mean, d.StdDev, d.DoF)
+	}
+	return normalCDF(x, d.Mean, d.StdDev)
 }
 
 // normalCDF computes the cumulative distribution function of a normal distribution.
@@ -134,6 +161,106 @@ func normalCDF(x, mean, stdDev float64) float64 {
 	return 0.5 * (1 + erf(z))
 }
 
+// studentTCDF computes the CDF of a Student's t distribution with dof degrees
+// of freedom, located at mean and scaled so its standard deviation is stdDev.
+// The variance is only finite above 2 degrees of freedom; at or below that,
+// stdDev is used as the scale directly.
+func studentTCDF(x, mean, stdDev, dof float64) float64 {
+	if stdDev <= 0 {
+		return normalCDF(x, mean, stdDev)
+	}
+	scale := stdDev
+	if dof > 2 {
+		scale = stdDev * math.Sqrt((dof-2)/dof)
+	}
+	t := (x - mean) / scale
+
+	// P(|T| > |t|) = I_{dof/(dof+t²)}(dof/2, 1/2)
+	tail := 0.5 * regIncBeta(dof/2, 0.5, dof/(dof+t*t))
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// regIncBeta computes the regularized incomplete beta function I_x(a, b)
+// with the continued fraction from Numerical Recipes.
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges fastest below the mean; use the
+	// symmetry I_x(a, b) = 1 - I_{1-x}(b, a) above it
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+	}
+	return front * betaContinuedFraction(a, b, x) / a
+}
+
+// betaContinuedFraction evaluates the incomplete beta continued fraction
+// with the modified Lentz method.
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	qab, qap, qam := a+b, a+1, a-1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		// Even step
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
+
 // erf approximates the error function using Horner's method.
 // Approximation from Abramowitz and Stegun.
 func erf(x float64) float64 {
@@ -241,3 +368,30 @@ func SnowProbability(forecast *Forecast) float64 {
 
 	return 0
 }
+
+// WindProbability calculates the probability that the day's maximum wind
+// speed reaches thresholdKmh. The forecast max is treated as the mean of a
+// normal distribution; wind forecasts miss by roughly a quarter of the value,
+// and by at least a few km/h on calm days.
+func WindProbability(forecast *Forecast, thresholdKmh float64) float64 {
+	return peakWindProbability(forecast.WindSpeed, thresholdKmh)
+}
+
+// GustProbability calculates the probability that the day's strongest gust
+// reaches thresholdKmh, the same way WindProbability does for sustained wind.
+func GustProbability(forecast *Forecast, thresholdKmh float64) float64 {
+	return peakWindProbability(forecast.WindGust, thresholdKmh)
+}
+
+// peakWindProbability treats a forecast daily peak in km/h as the mean of a
+// normal distribution and returns the chance it reaches thresholdKmh.
+func peakWindProbability(peakKmh, thresholdKmh float64) float64 {
+	stdDev := math.Max(peakKmh*0.25, 5)
+	return 1 - normalCDF(thresholdKmh, peakKmh, stdDev)
+}
+
+// UVProbability calculates the probability that the day's maximum UV index
+// reaches threshold. UV forecasts are usually within about one index point.
+func UVProbability(forecast *Forecast, threshold float64) float64 {
+	return 1 - normalCDF(threshold, forecast.UVIndex, 1.0)
+}
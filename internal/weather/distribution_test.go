@@ -0,0 +1,116 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWindProbability(t *testing.T) {
+	tests := []struct {
+		name      string
+		windKmh   float64
+		threshold float64
+		wantLow   float64
+		wantHigh  float64
+	}{
+		{"at forecast", 40, 40, 0.5, 0.5},
+		{"well above forecast", 20, 60, 0, 0.01},
+		{"well below forecast", 60, 20, 0.99, 1},
+		{"one sigma above", 40, 50, 0.15, 0.17},    // σ = 10 km/h
+		{"calm day uses floor", 8, 13, 0.15, 0.17}, // σ = 5 km/h
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WindProbability(&Forecast{WindSpeed: tt.windKmh}, tt.threshold)
+			if got < tt.wantLow-1e-3 || got > tt.wantHigh+1e-3 {
+				t.Errorf("WindProbability(%v, %v) = %.4f, want in [%.2f, %.2f]",
+					tt.windKmh, tt.threshold, got, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestGustProbability_UsesGusts(t *testing.T) {
+	forecast := &Forecast{WindSpeed: 20, WindGust: 60}
+	if got := GustProbability(forecast, 60); math.Abs(got-0.5) > 1e-3 {
+		t.Errorf("GustProbability at the gust forecast = %.4f, want 0.5", got)
+	}
+	if got := WindProbability(forecast, 60); got > 0.01 {
+		t.Errorf("WindProbability 40 km/h above sustained wind = %.4f, want ~0", got)
+	}
+}
+
+func TestUVProbability(t *testing.T) {
+	if got := UVProbability(&Forecast{UVIndex: 8}, 8); math.Abs(got-0.5) > 1e-3 {
+		t.Errorf("UVProbability at forecast = %.4f, want 0.5", got)
+	}
+	if got := UVProbability(&Forecast{UVIndex: 5}, 9); got > 0.01 {
+		t.Errorf("UVProbability 4 points above forecast = %.4f, want ~0", got)
+	}
+}
+
+func TestStudentTDistribution_HeavierTails(t *testing.T) {
+	forecast := &Forecast{TempHigh: 20, TempLow: 10}
+	normal := NewHighTempDistribution(forecast, 1)
+	heavy := NewHighTempDistributionT(forecast, 1, 4)
+
+	if heavy.StdDev != normal.StdDev {
+		t.Fatalf("StdDev = %v, want %v (same σ for both shapes)", heavy.StdDev, normal.StdDev)
+	}
+	if got := heavy.ProbAbove(heavy.Mean); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("t ProbAbove(mean) = %.6f, want 0.5", got)
+	}
+
+	tests := []struct {
+		name   string
+		sigmas float64
+	}{
+		{"3σ", 3},
+		{"4σ", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold := normal.Mean + tt.sigmas*normal.StdDev
+			pn := normal.ProbAbove(threshold)
+			pt := heavy.ProbAbove(threshold)
+			if pt <= pn {
+				t.Errorf("t tail %.6f not heavier than normal tail %.6f", pt, pn)
+			}
+			if lo, lt := normal.ProbBelow(2*normal.Mean-threshold), heavy.ProbBelow(2*heavy.Mean-threshold); math.Abs(lt-pt) > 1e-9 || math.Abs(lo-pn) > 1e-9 {
+				t.Errorf("lower tails %.6f/%.6f, want symmetric with %.6f/%.6f", lo, lt, pn, pt)
+			}
+		})
+	}
+}
+
+func TestStudentTCDF(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		dof  float64
+		want float64
+	}{
+		// Standard t quantiles (unit scale, dof <= 2 keeps the scale as given)
+		{"dof 1 at 1", 1, 1, 0.75},
+		{"dof 2 at 2.92", 2.919986, 2, 0.95},
+		// Unit-variance t: raw quantile 2.015048 at dof 5 scaled by sqrt(3/5)
+		{"dof 5 at 95%", 2.015048 * math.Sqrt(3.0/5.0), 5, 0.95},
+		{"dof 5 below mean", -2.015048 * math.Sqrt(3.0/5.0), 5, 0.05},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := studentTCDF(tt.x, 0, 1, tt.dof); math.Abs(got-tt.want) > 1e-5 {
+				t.Errorf("studentTCDF(%v, dof %v) = %.6f, want %.6f", tt.x, tt.dof, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStudentTCDF_ApproachesNormal(t *testing.T) {
+	for _, x := range []float64{-2, -0.5, 0.5, 1.5, 3} {
+		if got, want := studentTCDF(x, 0, 1, 1e5), normalCDF(x, 0, 1); math.Abs(got-want) > 1e-4 {
+			t.Errorf("studentTCDF(%v, dof 1e5) = %.6f, want normal %.6f", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package weather
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+const (
+	// GISTEMPURL is NASA GISS's monthly global land-ocean temperature index,
+	// the series Polymarket's global temperature markets resolve on.
+	GISTEMPURL = "https://data.giss.nasa.gov/gistemp/tabledata_v4/GLB.Ts+dSST.csv"
+
+	// The series is republished monthly, so a few hours of caching is plenty
+	gistempCacheTTL = 6 * time.Hour
+
+	// Spread of a persistence forecast one month past the latest published
+	// value (°C), widening each further month out, up to a ceiling
+	globalAnomalyBaseStdDev     = 0.09
+	globalAnomalyStdDevPerMonth = 0.03
+	globalAnomalyMaxStdDev      = 0.25
+	// Published months rarely move between releases
+	globalAnomalyRevisionStdDev = 0.002
+	// Weight of the latest month vs the trailing 12-month mean
+	globalAnomalyPersistence = 0.7
+)
+
+// MonthlyAnomaly is one month of the global temperature anomaly record (°C).
+type MonthlyAnomaly struct {
+	Month   time.Time // First day of the month, UTC
+	Anomaly float64
+}
+
+// GlobalAnomalySeries is the published monthly record, oldest first.
+type GlobalAnomalySeries []MonthlyAnomaly
+
+// GlobalAnomalyProjection is a normal estimate of a month's anomaly.
+type GlobalAnomalyProjection struct {
+	Mean       float64
+	StdDev     float64
+	LeadMonths int // Months past the latest published value, 0 if published
+}
+
+// ProbAbove returns the probability that the anomaly exceeds threshold.
+func (p GlobalAnomalyProjection) ProbAbove(threshold float64) float64 {
+	return 1 - normalCDF(threshold, p.Mean, p.StdDev)
+}
+
+// GISTEMPClient fetches the NASA GISTEMP global anomaly record.
+type GISTEMPClient struct {
+	httpClient *http.Client
+	url        string
+
+	series    GlobalAnomalySeries
+	fetchedAt time.Time
+	mu        sync.Mutex
+}
+
+// NewGISTEMPClient creates a client for the GISTEMP CSV.
+func NewGISTEMPClient() *GISTEMPClient {
+	return &GISTEMPClient{
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: httpx.Transport(nil)},
+		url:        GISTEMPURL,
+	}
+}
+
+// WithURL sets a custom CSV URL (useful for testing).
+func (c *GISTEMPClient) WithURL(url string) *GISTEMPClient {
+	c.url = url
+	return c
+}
+
+// GetSeries returns the monthly record, fetching it when the cache is stale.
+func (c *GISTEMPClient) GetSeries() (GlobalAnomalySeries, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.series != nil && time.Since(c.fetchedAt) < gistempCacheTTL {
+		return c.series, nil
+	}
+
+	resp, err := c.httpClient.Get(c.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch GISTEMP: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GISTEMP returned status %d", resp.StatusCode)
+	}
+
+	series, err := ParseGISTEMPCSV(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.series = series
+	c.fetchedAt = time.Now()
+	return series, nil
+}
+
+// GlobalTempAnomalyProbability returns the probability that the global
+// anomaly for the month containing resolutionDate exceeds threshold (°C).
+// Months not yet published are projected from the latest values.
+func (c *GISTEMPClient) GlobalTempAnomalyProbability(threshold float64, resolutionDate time.Time) (float64, error) {
+	proj, err := c.Projection(resolutionDate)
+	if err != nil {
+		return 0, err
+	}
+	return proj.ProbAbove(threshold), nil
+}
+
+// Projection estimates the anomaly for the month containing target.
+func (c *GISTEMPClient) Projection(target time.Time) (GlobalAnomalyProjection, error) {
+	series, err := c.GetSeries()
+	if err != nil {
+		return GlobalAnomalyProjection{}, err
+	}
+	return series.Project(target)
+}
+
+// Project estimates the anomaly for the month containing target. A published
+// month is returned as-is; later months blend the latest value with the
+// trailing 12-month mean, with a spread that widens with lead time.
+func (s GlobalAnomalySeries) Project(target time.Time) (GlobalAnomalyProjection, error) {
+	if len(s) == 0 {
+		return GlobalAnomalyProjection{}, fmt.Errorf("no GISTEMP data")
+	}
+
+	month := monthStart(target)
+	latest := s[len(s)-1]
+
+	if !month.After(latest.Month) {
+		for _, m := range s {
+			if m.Month.Equal(month) {
+				return GlobalAnomalyProjection{Mean: m.Anomaly, StdDev: globalAnomalyRevisionStdDev}, nil
+			}
+		}
+		return GlobalAnomalyProjection{}, fmt.Errorf("no GISTEMP value for %s", month.Format("2006-01"))
+	}
+
+	lead := monthsBetween(latest.Month, month)
+
+	start := len(s) - 12
+	if start < 0 {
+		start = 0
+	}
+	var sum float64
+	for _, m := range s[start:] {
+		sum += m.Anomaly
+	}
+	trailing := sum / float64(len(s)-start)
+
+	return GlobalAnomalyProjection{
+		Mean:       globalAnomalyPersistence*latest.Anomaly + (1-globalAnomalyPersistence)*trailing,
+		StdDev:     math.Min(globalAnomalyBaseStdDev+globalAnomalyStdDevPerMonth*float64(lead-1), globalAnomalyMaxStdDev),
+		LeadMonths: lead,
+	}, nil
+}
+
+// ParseGISTEMPCSV parses the GISTEMP table: a title line, then rows of
+// Year,Jan..Dec,... with "***" for months not yet published.
+func ParseGISTEMPCSV(r io.Reader) (GlobalAnomalySeries, error) {
+	var series GlobalAnomalySeries
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ",")
+		if len(fields) < 13 {
+			continue
+		}
+		year, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue // Title or header row
+		}
+
+		for i := 1; i <= 12; i++ {
+			val, err := strconv.ParseFloat(strings.TrimSpace(fields[i]), 64)
+			if err != nil {
+				continue // "***" placeholder
+			}
+			series = append(series, MonthlyAnomaly{
+				Month:   time.Date(year, time.Month(i), 1, 0, 0, 0, 0, time.UTC),
+				Anomaly: val,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read GISTEMP: %w", err)
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no monthly values in GISTEMP data")
+	}
+
+	return series, nil
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
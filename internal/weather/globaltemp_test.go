@@ -0,0 +1,124 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testGISTEMPCSV = `Land-Ocean: Global Means
+Year,Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec,J-D,D-N,DJF,MAM,JJA,SON
+2024,1.24,1.44,1.39,1.32,1.17,1.23,1.20,1.30,1.23,1.33,1.30,1.26,1.28,1.28,1.32,1.29,1.24,1.29
+2025,1.36,1.26,1.35,***,***,***,***,***,***,***,***,***,****,****,1.30,****,****,****
+`
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseGISTEMPCSV(t *testing.T) {
+	series, err := ParseGISTEMPCSV(strings.NewReader(testGISTEMPCSV))
+	if err != nil {
+		t.Fatalf("ParseGISTEMPCSV: %v", err)
+	}
+	if len(series) != 15 {
+		t.Fatalf("len = %d, want 15 published months", len(series))
+	}
+	last := series[len(series)-1]
+	if !last.Month.Equal(month(2025, time.March)) || last.Anomaly != 1.35 {
+		t.Errorf("last = %v %.2f, want 2025-03 1.35", last.Month, last.Anomaly)
+	}
+
+	if _, err := ParseGISTEMPCSV(strings.NewReader("not,a,table\n")); err == nil {
+		t.Error("expected error for input without monthly values")
+	}
+}
+
+func TestGlobalAnomalySeries_Project(t *testing.T) {
+	series, err := ParseGISTEMPCSV(strings.NewReader(testGISTEMPCSV))
+	if err != nil {
+		t.Fatalf("ParseGISTEMPCSV: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		target     time.Time
+		wantMean   float64
+		wantStdDev float64
+		wantLead   int
+	}{
+		{"published month", time.Date(2025, time.February, 20, 0, 0, 0, 0, time.UTC), 1.26, globalAnomalyRevisionStdDev, 0},
+		// Trailing 12 months (Apr 2024 - Mar 2025) average 1.2758
+		{"next month", month(2025, time.April), 0.7*1.35 + 0.3*1.2758333, globalAnomalyBaseStdDev, 1},
+		{"three months out", month(2025, time.June), 0.7*1.35 + 0.3*1.2758333, globalAnomalyBaseStdDev + 2*globalAnomalyStdDevPerMonth, 3},
+		{"spread is capped", month(2026, time.June), 0.7*1.35 + 0.3*1.2758333, globalAnomalyMaxStdDev, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj, err := series.Project(tt.target)
+			if err != nil {
+				t.Fatalf("Project: %v", err)
+			}
+			if math.Abs(proj.Mean-tt.wantMean) > 1e-4 {
+				t.Errorf("mean = %.4f, want %.4f", proj.Mean, tt.wantMean)
+			}
+			if math.Abs(proj.StdDev-tt.wantStdDev) > 1e-9 {
+				t.Errorf("stddev = %.4f, want %.4f", proj.StdDev, tt.wantStdDev)
+			}
+			if proj.LeadMonths != tt.wantLead {
+				t.Errorf("lead = %d, want %d", proj.LeadMonths, tt.wantLead)
+			}
+		})
+	}
+
+	if _, err := series.Project(month(2020, time.January)); err == nil {
+		t.Error("expected error for a month before the record")
+	}
+}
+
+func TestGlobalTempAnomalyProbability(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprint(w, testGISTEMPCSV)
+	}))
+	defer srv.Close()
+
+	c := NewGISTEMPClient().WithURL(srv.URL)
+
+	tests := []struct {
+		name      string
+		threshold float64
+		target    time.Time
+		wantMin   float64
+		wantMax   float64
+	}{
+		{"published above threshold", 1.30, month(2025, time.March), 0.99, 1},
+		{"published below threshold", 1.40, month(2025, time.March), 0, 0.01},
+		{"projection near mean is a coin flip", 1.33, month(2025, time.April), 0.4, 0.6},
+		{"projection far below threshold", 1.60, month(2025, time.April), 0, 0.01},
+		{"projection far above threshold", 1.00, month(2025, time.April), 0.99, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := c.GlobalTempAnomalyProbability(tt.threshold, tt.target)
+			if err != nil {
+				t.Fatalf("GlobalTempAnomalyProbability: %v", err)
+			}
+			if p < tt.wantMin || p > tt.wantMax {
+				t.Errorf("P(>%.2f) = %.4f, want in [%.2f, %.2f]", tt.threshold, p, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("GISTEMP fetched %d times, want 1 (cached)", got)
+	}
+}
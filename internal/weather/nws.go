@@ -0,0 +1,197 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dantezy/polymarket-sniper/internal/httpx"
+)
+
+const (
+	nwsBaseURL = "https://api.weather.gov"
+	// NWS rejects requests without an identifying User-Agent
+	nwsUserAgent = "polymarket-sniper (weather forecasts)"
+)
+
+// usTimezones lists the IANA zones of locations covered by the NWS.
+var usTimezones = map[string]bool{
+	"America/New_York":    true,
+	"America/Detroit":     true,
+	"America/Chicago":     true,
+	"America/Denver":      true,
+	"America/Phoenix":     true,
+	"America/Los_Angeles": true,
+	"America/Anchorage":   true,
+	"Pacific/Honolulu":    true,
+}
+
+// NWSClient fetches point forecasts from the US National Weather Service
+// (weather.gov). Only US locations are supported. Only temperature fields
+// are populated.
+type NWSClient struct {
+	httpClient *http.Client
+	baseURL    string
+
+	// Forecast URL per location, resolved once via /points
+	gridURLs map[string]string
+	mu       sync.Mutex
+}
+
+// NewNWSClient creates a new weather.gov client.
+func NewNWSClient() *NWSClient {
+	return &NWSClient{
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: httpx.Transport(nil)},
+		baseURL:    nwsBaseURL,
+		gridURLs:   make(map[string]string),
+	}
+}
+
+// WithBaseURL sets a custom base URL (useful for testing).
+func (c *NWSClient) WithBaseURL(url string) *NWSClient {
+	c.baseURL = url
+	return c
+}
+
+// Name identifies the NWS client as a forecast provider.
+func (c *NWSClient) Name() string {
+	return "nws"
+}
+
+// Supports reports whether the NWS covers a location.
+func (c *NWSClient) Supports(loc *Location) bool {
+	return loc != nil && usTimezones[loc.TimezoneID]
+}
+
+// GetForecast returns the daytime high and overnight low for a date.
+func (c *NWSClient) GetForecast(loc *Location, date time.Time) (*Forecast, error) {
+	if !c.Supports(loc) {
+		return nil, fmt.Errorf("NWS does not cover %s", loc.Name)
+	}
+
+	forecastURL, err := c.gridForecastURL(loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var data nwsForecastResponse
+	if err := c.getJSON(forecastURL, &data); err != nil {
+		return nil, fmt.Errorf("failed to fetch NWS forecast: %w", err)
+	}
+
+	// The day's low is normally reached in the night that ends on its
+	// morning; the night starting that evening runs into the next day and
+	// is only used when the earlier one has already dropped off the forecast
+	targetDate := date.Format("2006-01-02")
+	var high, low, eveningLow *float64
+	for _, p := range data.Properties.Periods {
+		start, err := time.Parse(time.RFC3339, p.StartTime)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, p.EndTime)
+		if err != nil {
+			end = start
+		}
+
+		temp := p.Temperature
+		if strings.EqualFold(p.TemperatureUnit, "F") {
+			temp = FahrenheitToCelsius(temp)
+		}
+
+		switch {
+		case p.IsDaytime && start.Format("2006-01-02") == targetDate && high == nil:
+			high = &temp
+		case !p.IsDaytime && end.Format("2006-01-02") == targetDate && low == nil:
+			low = &temp
+		case !p.IsDaytime && start.Format("2006-01-02") == targetDate && eveningLow == nil:
+			eveningLow = &temp
+		}
+	}
+	if low == nil {
+		low = eveningLow
+	}
+
+	if high == nil || low == nil {
+		return nil, fmt.Errorf("NWS forecast missing high/low for %s", targetDate)
+	}
+
+	return &Forecast{
+		Location:  loc.Name,
+		Latitude:  loc.Latitude,
+		Longitude: loc.Longitude,
+		Date:      date,
+		TempHigh:  *high,
+		TempLow:   *low,
+		TempMean:  (*high + *low) / 2,
+	}, nil
+}
+
+// gridForecastURL resolves the gridpoint forecast URL for a location.
+func (c *NWSClient) gridForecastURL(loc *Location) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.gridURLs[loc.Name]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/points/%.4f,%.4f", c.baseURL, loc.Latitude, loc.Longitude)
+
+	var data nwsPointsResponse
+	if err := c.getJSON(endpoint, &data); err != nil {
+		return "", fmt.Errorf("failed to resolve NWS gridpoint: %w", err)
+	}
+	if data.Properties.Forecast == "" {
+		return "", fmt.Errorf("NWS returned no forecast URL for %s", loc.Name)
+	}
+
+	c.mu.Lock()
+	c.gridURLs[loc.Name] = data.Properties.Forecast
+	c.mu.Unlock()
+
+	return data.Properties.Forecast, nil
+}
+
+func (c *NWSClient) getJSON(endpoint string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", nwsUserAgent)
+	req.Header.Set("Accept", "application/geo+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("NWS API returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// NWS API response types
+type nwsPointsResponse struct {
+	Properties struct {
+		Forecast string `json:"forecast"`
+	} `json:"properties"`
+}
+
+type nwsForecastResponse struct {
+	Properties struct {
+		Periods []struct {
+			StartTime       string  `json:"startTime"`
+			EndTime         string  `json:"endTime"`
+			IsDaytime       bool    `json:"isDaytime"`
+			Temperature     float64 `json:"temperature"`
+			TemperatureUnit string  `json:"temperatureUnit"`
+		} `json:"periods"`
+	} `json:"properties"`
+}
@@ -0,0 +1,138 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNWSClient_GetForecast(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			http.Error(w, "missing user agent", http.StatusForbidden)
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/points/"):
+			fmt.Fprintf(w, `{"properties":{"forecast":"%s/gridpoints/OKX/33,35/forecast"}}`, srv.URL)
+		case strings.HasSuffix(r.URL.Path, "/forecast"):
+			fmt.Fprint(w, `{"properties":{"periods":[
+				{"startTime":"2025-01-14T18:00:00-05:00","endTime":"2025-01-15T06:00:00-05:00","isDaytime":false,"temperature":23,"temperatureUnit":"F"},
+				{"startTime":"2025-01-15T06:00:00-05:00","endTime":"2025-01-15T18:00:00-05:00","isDaytime":true,"temperature":41,"temperatureUnit":"F"},
+				{"startTime":"2025-01-15T18:00:00-05:00","endTime":"2025-01-16T06:00:00-05:00","isDaytime":false,"temperature":20,"temperatureUnit":"F"}
+			]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewNWSClient().WithBaseURL(srv.URL)
+	loc := FindLocationByName("NYC")
+	if loc == nil {
+		t.Fatal("expected NYC location")
+	}
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	f, err := c.GetForecast(loc, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if math.Abs(f.TempHigh-5) > 1e-9 {
+		t.Errorf("TempHigh = %.2f, want 5.00", f.TempHigh)
+	}
+	// The night ending on the 15th, not the one starting that evening
+	if math.Abs(f.TempLow-(-5)) > 1e-9 {
+		t.Errorf("TempLow = %.2f, want -5.00", f.TempLow)
+	}
+
+	if _, err := c.GetForecast(loc, date.AddDate(0, 0, 5)); err == nil {
+		t.Error("expected error for a date outside the forecast")
+	}
+}
+
+func TestNWSClient_GetForecast_PeriodSequence(t *testing.T) {
+	// A forecast fetched on a Wednesday afternoon: the night before has
+	// already dropped off, so today's first night is the one starting tonight
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/points/"):
+			fmt.Fprintf(w, `{"properties":{"forecast":"%s/gridpoints/OKX/33,35/forecast"}}`, srv.URL)
+		case strings.HasSuffix(r.URL.Path, "/forecast"):
+			fmt.Fprint(w, `{"properties":{"periods":[
+				{"number":1,"name":"This Afternoon","startTime":"2025-01-15T13:00:00-05:00","endTime":"2025-01-15T18:00:00-05:00","isDaytime":true,"temperature":41,"temperatureUnit":"F"},
+				{"number":2,"name":"Tonight","startTime":"2025-01-15T18:00:00-05:00","endTime":"2025-01-16T06:00:00-05:00","isDaytime":false,"temperature":23,"temperatureUnit":"F"},
+				{"number":3,"name":"Thursday","startTime":"2025-01-16T06:00:00-05:00","endTime":"2025-01-16T18:00:00-05:00","isDaytime":true,"temperature":38,"temperatureUnit":"F"},
+				{"number":4,"name":"Thursday Night","startTime":"2025-01-16T18:00:00-05:00","endTime":"2025-01-17T06:00:00-05:00","isDaytime":false,"temperature":32,"temperatureUnit":"F"},
+				{"number":5,"name":"Friday","startTime":"2025-01-17T06:00:00-05:00","endTime":"2025-01-17T18:00:00-05:00","isDaytime":true,"temperature":44,"temperatureUnit":"F"}
+			]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewNWSClient().WithBaseURL(srv.URL)
+	loc := FindLocationByName("NYC")
+	if loc == nil {
+		t.Fatal("expected NYC location")
+	}
+
+	tests := []struct {
+		name     string
+		day      int
+		wantHigh float64
+		wantLow  float64
+	}{
+		{"today falls back to tonight", 15, 41, 23},
+		{"tomorrow uses the night ending that morning", 16, 38, 23},
+		{"Friday uses Thursday night", 17, 44, 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := c.GetForecast(loc, time.Date(2025, 1, tt.day, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := FahrenheitToCelsius(tt.wantHigh); math.Abs(f.TempHigh-want) > 1e-9 {
+				t.Errorf("TempHigh = %.2f, want %.2f", f.TempHigh, want)
+			}
+			if want := FahrenheitToCelsius(tt.wantLow); math.Abs(f.TempLow-want) > 1e-9 {
+				t.Errorf("TempLow = %.2f, want %.2f", f.TempLow, want)
+			}
+		})
+	}
+}
+
+func TestNWSClient_Supports(t *testing.T) {
+	c := NewNWSClient()
+	tests := []struct {
+		city string
+		want bool
+	}{
+		{"NYC", true},
+		{"Dallas", true},
+		{"London", false},
+		{"Toronto", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.city, func(t *testing.T) {
+			loc := FindLocationByName(tt.city)
+			if loc == nil {
+				t.Fatalf("unknown city %s", tt.city)
+			}
+			if got := c.Supports(loc); got != tt.want {
+				t.Errorf("Supports(%s) = %v, want %v", tt.city, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KnownModels lists the models that may be named in a model override.
+var KnownModels = []WeatherModel{
+	ModelECMWF,
+	ModelGFS,
+	ModelHRRR,
+	ModelICON,
+	ModelICONEU,
+	ModelUKMO,
+	ModelGEM,
+	ModelKMA,
+	ModelAROME,
+}
+
+// ModelOverrides maps a location's canonical name to the models to use for it
+// in place of its built-in preferences.
+type ModelOverrides map[string][]WeatherModel
+
+// ParseWeatherModel validates a model name against KnownModels.
+func ParseWeatherModel(name string) (WeatherModel, error) {
+	for _, m := range KnownModels {
+		if string(m) == name {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown weather model %q", name)
+}
+
+// ParseModelOverrides parses overrides of the form
+// "London=ukmo_seamless;Tokyo=jma_seamless,ecmwf_ifs04". City names and
+// aliases resolve to their canonical location; models are validated.
+func ParseModelOverrides(s string) (ModelOverrides, error) {
+	overrides := make(ModelOverrides)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		city, list, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid model override %q: expected City=model[,model]", entry)
+		}
+
+		loc := FindLocationByName(strings.TrimSpace(city))
+		if loc == nil {
+			return nil, fmt.Errorf("invalid model override %q: unknown location %q", entry, strings.TrimSpace(city))
+		}
+
+		var models []WeatherModel
+		for _, name := range strings.Split(list, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			model, err := ParseWeatherModel(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid model override for %s: %w", loc.Name, err)
+			}
+			models = append(models, model)
+		}
+		if len(models) == 0 {
+			return nil, fmt.Errorf("invalid model override for %s: no models listed", loc.Name)
+		}
+
+		overrides[loc.Name] = models
+	}
+	return overrides, nil
+}
+
+// WithModelOverrides sets per-location model preferences that take priority
+// over the built-in ones.
+func (c *Client) WithModelOverrides(overrides ModelOverrides) *Client {
+	c.modelOverrides = overrides
+	return c
+}
+
+// modelsFor returns the models to query for a location, consulting the
+// overrides before the location's built-in preferences.
+func (c *Client) modelsFor(loc *Location) []WeatherModel {
+	if models, ok := c.modelOverrides[loc.Name]; ok {
+		return models
+	}
+	return loc.GetPreferredModels()
+}
@@ -0,0 +1,100 @@
+package weather
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseModelOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ModelOverrides
+		wantErr bool
+	}{
+		{"empty", "", ModelOverrides{}, false},
+		{
+			"single city",
+			"London=ukmo_seamless",
+			ModelOverrides{"London": {ModelUKMO}},
+			false,
+		},
+		{
+			"multiple cities and models",
+			" London=ukmo_seamless ; Tokyo=jma_seamless, ecmwf_ifs04 ;",
+			ModelOverrides{"London": {ModelUKMO}, "Tokyo": {ModelKMA, ModelECMWF}},
+			false,
+		},
+		{
+			"alias resolves to canonical name",
+			"NYC=gfs_hrrr",
+			ModelOverrides{"New York": {ModelHRRR}},
+			false,
+		},
+		{"unknown model", "London=made_up_model", nil, true},
+		{"unknown city", "Atlantis=ecmwf_ifs04", nil, true},
+		{"missing separator", "London", nil, true},
+		{"no models", "London=", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModelOverrides(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseModelOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseModelOverrides() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetConsensusForecast_UsesOverrides(t *testing.T) {
+	var mu sync.Mutex
+	var queried []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queried = append(queried, r.URL.Query().Get("models"))
+		mu.Unlock()
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		overrides ModelOverrides
+		city      string
+		want      []string
+	}{
+		{"override replaces built-in", ModelOverrides{"London": {ModelECMWF}}, "London", []string{"ecmwf_ifs04"}},
+		{"other cities keep built-in", ModelOverrides{"London": {ModelECMWF}}, "Tokyo", modelNames(FindLocationByName("Tokyo").GetPreferredModels())},
+		{"no overrides", nil, "London", modelNames(FindLocationByName("London").GetPreferredModels())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queried = nil
+			c := NewClient().WithModelOverrides(tt.overrides).WithRetries(0)
+			c.baseURL = srv.URL
+
+			c.GetConsensusForecast(FindLocationByName(tt.city), time.Now())
+
+			if !reflect.DeepEqual(queried, tt.want) {
+				t.Errorf("queried models %v, want %v", queried, tt.want)
+			}
+		})
+	}
+}
+
+func modelNames(models []WeatherModel) []string {
+	names := make([]string, len(models))
+	for i, m := range models {
+		names[i] = string(m)
+	}
+	return names
+}
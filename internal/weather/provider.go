@@ -0,0 +1,22 @@
+package weather
+
+import "time"
+
+// ForecastProvider is a source of daily point forecasts. Implementations must
+// return temperatures in Celsius so forecasts from different sources can be
+// compared directly.
+type ForecastProvider interface {
+	Name() string
+	GetForecast(loc *Location, date time.Time) (*Forecast, error)
+}
+
+// Compile-time checks that both sources satisfy ForecastProvider.
+var (
+	_ ForecastProvider = (*Client)(nil)
+	_ ForecastProvider = (*NWSClient)(nil)
+)
+
+// Name identifies the Open-Meteo client as a forecast provider.
+func (c *Client) Name() string {
+	return "open-meteo"
+}